@@ -0,0 +1,202 @@
+// Package attributes validates product attribute values against the typed
+// attribute schema of their category.
+package attributes
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidCode    = errors.New("attribute code must be lower case letters, digits and underscores, starting with a letter")
+	ErrInvalidType    = errors.New("data type must be text, integer, decimal, boolean, date or enum")
+	ErrOptionsMissing = errors.New("enum attributes need at least one allowed value")
+	ErrOptionsNotEnum = errors.New("only enum attributes take allowed values")
+)
+
+var codePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// Type is the data type of an attribute.
+type Type string
+
+const (
+	Text    Type = "text"
+	Integer Type = "integer"
+	Decimal Type = "decimal"
+	Boolean Type = "boolean"
+	Date    Type = "date"
+	Enum    Type = "enum"
+)
+
+func (t Type) Valid() bool {
+	switch t {
+	case Text, Integer, Decimal, Boolean, Date, Enum:
+		return true
+	}
+	return false
+}
+
+// Definition is one attribute of a category's schema.
+type Definition struct {
+	Code     string
+	Type     Type
+	Required bool
+	// Options are the values an enum attribute may take.
+	Options []string
+}
+
+// Check reports whether a definition is well formed.
+func (d Definition) Check() error {
+	if !codePattern.MatchString(d.Code) {
+		return ErrInvalidCode
+	}
+	if !d.Type.Valid() {
+		return ErrInvalidType
+	}
+	if d.Type == Enum && len(d.Options) == 0 {
+		return ErrOptionsMissing
+	}
+	if d.Type != Enum && len(d.Options) > 0 {
+		return ErrOptionsNotEnum
+	}
+	for _, o := range d.Options {
+		if strings.TrimSpace(o) == "" {
+			return errors.New("allowed values cannot be blank")
+		}
+	}
+	return nil
+}
+
+// FieldError is a problem with one attribute value.
+type FieldError struct {
+	Attribute string `json:"attribute"`
+	Message   string `json:"error"`
+}
+
+// Validate checks values against a schema and returns them normalised:
+// text, dates and enums as strings, integers as int64, decimals as
+// json.Number without trailing zeros and booleans as bool. Attributes the
+// schema does not define are errors; a null value is the same as leaving an
+// optional attribute out. Errors are sorted by attribute.
+func Validate(schema []Definition, values map[string]json.RawMessage) (map[string]interface{}, []FieldError) {
+	defs := make(map[string]Definition, len(schema))
+	for _, d := range schema {
+		defs[d.Code] = d
+	}
+
+	out := make(map[string]interface{}, len(values))
+	var errs []FieldError
+	for code, raw := range values {
+		def, ok := defs[code]
+		if !ok {
+			errs = append(errs, FieldError{code, "not an attribute of this category"})
+			continue
+		}
+		if isNull(raw) {
+			continue
+		}
+		v, err := convert(def, raw)
+		if err != nil {
+			errs = append(errs, FieldError{code, err.Error()})
+			continue
+		}
+		out[code] = v
+	}
+	for _, d := range schema {
+		if _, ok := out[d.Code]; d.Required && !ok && !failed(errs, d.Code) {
+			errs = append(errs, FieldError{d.Code, "is required"})
+		}
+	}
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Attribute < errs[j].Attribute })
+	return out, errs
+}
+
+func isNull(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) == 0 || string(raw) == "null"
+}
+
+func failed(errs []FieldError, code string) bool {
+	for _, e := range errs {
+		if e.Attribute == code {
+			return true
+		}
+	}
+	return false
+}
+
+func convert(def Definition, raw json.RawMessage) (interface{}, error) {
+	switch def.Type {
+	case Text:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, errors.New("must be a string")
+		}
+		return s, nil
+	case Integer:
+		var n json.Number
+		if err := json.Unmarshal(raw, &n); err != nil {
+			return nil, errors.New("must be a whole number")
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, errors.New("must be a whole number")
+		}
+		return i, nil
+	case Decimal:
+		d, err := decimalValue(raw)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		return json.Number(d.String()), nil
+	case Boolean:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, errors.New("must be true or false")
+		}
+		return b, nil
+	case Date:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, errors.New("must be a date as YYYY-MM-DD")
+		}
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			return nil, errors.New("must be a date as YYYY-MM-DD")
+		}
+		return s, nil
+	case Enum:
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			for _, o := range def.Options {
+				if s == o {
+					return s, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("must be one of %s", strings.Join(def.Options, ", "))
+	}
+	return nil, ErrInvalidType
+}
+
+// decimalValue accepts a JSON number or a string holding one, as decimals
+// often arrive quoted to keep their precision.
+func decimalValue(raw json.RawMessage) (decimal.Decimal, error) {
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return decimal.NewFromString(n.String())
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return decimal.Decimal{}, err
+	}
+	return decimal.NewFromString(strings.TrimSpace(s))
+}
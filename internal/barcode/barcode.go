@@ -0,0 +1,112 @@
+// Package barcode validates EAN/UPC barcodes and reads the GS1 application
+// identifiers carried by GS1-128 and GS1 DataMatrix symbols.
+package barcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNotDigits  = errors.New("barcode must be digits only")
+	ErrLength     = errors.New("barcode must have 8, 12, 13 or 14 digits (EAN-8, UPC-A, EAN-13 or GTIN-14)")
+	ErrCheckDigit = errors.New("barcode check digit is wrong")
+)
+
+// gtinLengths are the lengths of EAN-8, UPC-A, EAN-13 and GTIN-14.
+var gtinLengths = []int{8, 12, 13, 14}
+
+// normalizedLength is the length of a GTIN-14, which every GTIN pads to.
+const normalizedLength = 14
+
+func digitsOnly(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckDigit is the GS1 mod-10 check digit of the digits before it.
+func CheckDigit(body string) byte {
+	sum := 0
+	// Weights alternate 3, 1 starting from the digit next to the check digit.
+	for i := len(body) - 1; i >= 0; i-- {
+		d := int(body[i] - '0')
+		if (len(body)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// CheckGTIN reports why code is not a valid EAN-8, UPC-A, EAN-13 or GTIN-14,
+// or nil when it is.
+func CheckGTIN(code string) error {
+	if !digitsOnly(code) {
+		return ErrNotDigits
+	}
+	valid := false
+	for _, n := range gtinLengths {
+		valid = valid || len(code) == n
+	}
+	if !valid {
+		return ErrLength
+	}
+	if CheckDigit(code[:len(code)-1]) != code[len(code)-1] {
+		return ErrCheckDigit
+	}
+	return nil
+}
+
+// ValidGTIN reports whether code is a valid EAN-8, UPC-A, EAN-13 or
+// GTIN-14.
+func ValidGTIN(code string) bool {
+	return CheckGTIN(code) == nil
+}
+
+// Equivalents lists the ways a valid GTIN can be printed, longest first: a
+// UPC-A is the same item as the EAN-13 with a leading zero and the GTIN-14
+// with two. Stored barcodes can be matched against any of them.
+func Equivalents(code string) []string {
+	if !ValidGTIN(code) {
+		return nil
+	}
+	full := strings.Repeat("0", normalizedLength-len(code)) + code
+	var forms []string
+	for i := len(gtinLengths) - 1; i >= 0; i-- {
+		n := gtinLengths[i]
+		if strings.Trim(full[:normalizedLength-n], "0") == "" {
+			forms = append(forms, full[normalizedLength-n:])
+		}
+	}
+	return forms
+}
+
+// ValidationError names the barcode a check failed for.
+type ValidationError struct {
+	Code string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid barcode %q: %v", e.Code, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Validate is CheckGTIN with the code in the error.
+func Validate(code string) error {
+	if err := CheckGTIN(code); err != nil {
+		return &ValidationError{Code: code, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package barcode
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		body string
+		want byte
+	}{
+		{"400638133393", '1'},
+		{"03600029145", '2'},
+		{"9638507", '4'},
+		{"1061414100041", '5'},
+		{"10614141123456789", '7'},
+	}
+	for _, tt := range tests {
+		if got := CheckDigit(tt.body); got != tt.want {
+			t.Errorf("%s: CheckDigit = %c, want %c", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestCheckGTIN(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want error
+	}{
+		{"ean-8", "96385074", nil},
+		{"upc-a", "036000291452", nil},
+		{"ean-13", "4006381333931", nil},
+		{"gtin-14", "10614141000415", nil},
+		{"empty", "", ErrNotDigits},
+		{"letters", "40063813339A1", ErrNotDigits},
+		{"space", "4006381 333931", ErrNotDigits},
+		{"too short", "1234567", ErrLength},
+		{"eleven digits", "03600029145", ErrLength},
+		{"too long", "106141410004150", ErrLength},
+		{"wrong check digit", "4006381333932", ErrCheckDigit},
+	}
+	for _, tt := range tests {
+		if err := CheckGTIN(tt.code); !errors.Is(err, tt.want) {
+			t.Errorf("%s: CheckGTIN(%q) = %v, want %v", tt.name, tt.code, err, tt.want)
+		}
+	}
+}
+
+func TestEquivalents(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want []string
+	}{
+		{"upc-a", "036000291452", []string{"00036000291452", "0036000291452", "036000291452"}},
+		{"ean-8", "96385074", []string{"00000096385074", "0000096385074", "000096385074", "96385074"}},
+		{"ean-13", "4006381333931", []string{"04006381333931", "4006381333931"}},
+		{"gtin-14", "10614141000415", []string{"10614141000415"}},
+		{"invalid", "4006381333932", nil},
+	}
+	for _, tt := range tests {
+		if got := Equivalents(tt.code); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Equivalents = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	err := Validate("4006381333932")
+	var ve *ValidationError
+	if !errors.As(err, &ve) || ve.Code != "4006381333932" || !errors.Is(err, ErrCheckDigit) {
+		t.Errorf("Validate = %v, want a ValidationError wrapping ErrCheckDigit", err)
+	}
+	if err := Validate("4006381333931"); err != nil {
+		t.Errorf("Validate = %v, want nil", err)
+	}
+}
@@ -0,0 +1,240 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GroupSeparator is the ASCII GS character scanners send for FNC1 between a
+// variable-length element and the next one.
+const GroupSeparator = '\x1d'
+
+var (
+	ErrNotGS1    = errors.New("not GS1 element string data")
+	ErrUnknownAI = errors.New("unsupported GS1 application identifier")
+)
+
+// ai describes an application identifier: a fixed length value, or a
+// variable one of at most max characters ended by a group separator.
+type ai struct {
+	name  string
+	fixed int
+	max   int
+	date  bool
+}
+
+// ais are the application identifiers that identify stock.
+var ais = map[string]ai{
+	"00": {name: "sscc", fixed: 18},
+	"01": {name: "gtin", fixed: 14},
+	"02": {name: "content_gtin", fixed: 14},
+	"10": {name: "batch", max: 20},
+	"11": {name: "production_date", fixed: 6, date: true},
+	"13": {name: "packaging_date", fixed: 6, date: true},
+	"15": {name: "best_before", fixed: 6, date: true},
+	"17": {name: "expiry_date", fixed: 6, date: true},
+	"21": {name: "serial", max: 20},
+	"30": {name: "count", max: 8},
+	"37": {name: "count", max: 8},
+}
+
+// Element is one application identifier and its value.
+type Element struct {
+	AI    string `json:"ai"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// GS1 is GS1 element string data read into the fields stock is tracked by.
+type GS1 struct {
+	Elements       []Element  `json:"elements"`
+	SSCC           string     `json:"sscc,omitempty"`
+	GTIN           string     `json:"gtin,omitempty"`
+	Batch          string     `json:"batch,omitempty"`
+	Serial         string     `json:"serial,omitempty"`
+	ExpiryDate     *time.Time `json:"expiry_date,omitempty"`
+	BestBefore     *time.Time `json:"best_before,omitempty"`
+	ProductionDate *time.Time `json:"production_date,omitempty"`
+	Count          int        `json:"count,omitempty"`
+}
+
+// ParseGS1 reads GS1-128 or GS1 DataMatrix data, either as scanned (an
+// optional symbology identifier such as ]C1 or ]d2, then elements with
+// group separators after variable-length values) or as printed under the
+// symbol, with each identifier in parentheses.
+func ParseGS1(data string) (GS1, error) {
+	var (
+		elements []Element
+		err      error
+	)
+	if strings.HasPrefix(data, "(") {
+		elements, err = splitBracketed(data)
+	} else {
+		elements, err = splitRaw(data)
+	}
+	if err != nil {
+		return GS1{}, err
+	}
+	return build(elements)
+}
+
+func splitBracketed(data string) ([]Element, error) {
+	var elements []Element
+	rest := data
+	for rest != "" {
+		if rest[0] != '(' {
+			return nil, ErrNotGS1
+		}
+		end := strings.IndexByte(rest, ')')
+		if end < 0 {
+			return nil, ErrNotGS1
+		}
+		id := rest[1:end]
+		rest = rest[end+1:]
+		value := rest
+		if next := strings.IndexByte(rest, '('); next >= 0 {
+			value, rest = rest[:next], rest[next:]
+		} else {
+			rest = ""
+		}
+
+		def, ok := ais[id]
+		if !ok {
+			return nil, fmt.Errorf("%w (%s)", ErrUnknownAI, id)
+		}
+		if err := checkLength(id, def, value); err != nil {
+			return nil, err
+		}
+		elements = append(elements, Element{AI: id, Name: def.name, Value: value})
+	}
+	return elements, nil
+}
+
+func splitRaw(data string) ([]Element, error) {
+	rest := data
+	if strings.HasPrefix(rest, "]") {
+		if len(rest) < 3 {
+			return nil, ErrNotGS1
+		}
+		rest = rest[3:]
+	}
+	rest = strings.TrimLeft(rest, string(GroupSeparator))
+	if rest == "" {
+		return nil, ErrNotGS1
+	}
+
+	var elements []Element
+	for rest != "" {
+		if len(rest) < 2 || !digitsOnly(rest[:2]) {
+			return nil, ErrNotGS1
+		}
+		id := rest[:2]
+		def, ok := ais[id]
+		if !ok {
+			return nil, fmt.Errorf("%w (%s)", ErrUnknownAI, id)
+		}
+		rest = rest[2:]
+
+		var value string
+		if def.fixed > 0 {
+			if len(rest) < def.fixed {
+				return nil, fmt.Errorf("%w: (%s) needs %d characters", ErrNotGS1, id, def.fixed)
+			}
+			value, rest = rest[:def.fixed], rest[def.fixed:]
+		} else if end := strings.IndexByte(rest, GroupSeparator); end >= 0 {
+			value, rest = rest[:end], rest[end:]
+		} else {
+			value, rest = rest, ""
+		}
+		rest = strings.TrimLeft(rest, string(GroupSeparator))
+
+		if err := checkLength(id, def, value); err != nil {
+			return nil, err
+		}
+		elements = append(elements, Element{AI: id, Name: def.name, Value: value})
+	}
+	return elements, nil
+}
+
+func checkLength(id string, def ai, value string) error {
+	switch {
+	case def.fixed > 0 && len(value) != def.fixed:
+		return fmt.Errorf("%w: (%s) needs %d characters", ErrNotGS1, id, def.fixed)
+	case def.max > 0 && (value == "" || len(value) > def.max):
+		return fmt.Errorf("%w: (%s) takes 1 to %d characters", ErrNotGS1, id, def.max)
+	}
+	return nil
+}
+
+func build(elements []Element) (GS1, error) {
+	g := GS1{Elements: elements}
+	for _, e := range elements {
+		def := ais[e.AI]
+		if def.date {
+			t, err := parseDate(e.Value)
+			if err != nil {
+				return g, fmt.Errorf("%w: (%s) %v", ErrNotGS1, e.AI, err)
+			}
+			switch e.AI {
+			case "11":
+				g.ProductionDate = &t
+			case "15":
+				g.BestBefore = &t
+			case "17":
+				g.ExpiryDate = &t
+			}
+			continue
+		}
+		switch e.AI {
+		case "00":
+			if CheckDigit(e.Value[:17]) != e.Value[17] {
+				return g, fmt.Errorf("%w: (00) %v", ErrNotGS1, ErrCheckDigit)
+			}
+			g.SSCC = e.Value
+		case "01", "02":
+			if err := CheckGTIN(e.Value); err != nil {
+				return g, fmt.Errorf("%w: (%s) %v", ErrNotGS1, e.AI, err)
+			}
+			if e.AI == "01" || g.GTIN == "" {
+				g.GTIN = e.Value
+			}
+		case "10":
+			g.Batch = e.Value
+		case "21":
+			g.Serial = e.Value
+		case "30", "37":
+			n, err := strconv.Atoi(e.Value)
+			if err != nil {
+				return g, fmt.Errorf("%w: (%s) must be a number", ErrNotGS1, e.AI)
+			}
+			g.Count = n
+		}
+	}
+	return g, nil
+}
+
+// parseDate reads a YYMMDD date, taking years as 20YY; a day of 00 means
+// the last day of the month.
+func parseDate(v string) (time.Time, error) {
+	if !digitsOnly(v) {
+		return time.Time{}, errors.New("date must be YYMMDD")
+	}
+	year, _ := strconv.Atoi(v[:2])
+	month, _ := strconv.Atoi(v[2:4])
+	day, _ := strconv.Atoi(v[4:6])
+	if month < 1 || month > 12 {
+		return time.Time{}, errors.New("month must be 01 to 12")
+	}
+	first := time.Date(2000+year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	if day == 0 {
+		day = last
+	}
+	if day > last {
+		return time.Time{}, errors.New("day is past the end of the month")
+	}
+	return first.AddDate(0, 0, day-1), nil
+}
@@ -0,0 +1,131 @@
+package barcode
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+const gs = string(GroupSeparator)
+
+func date(y int, m time.Month, d int) *time.Time {
+	t := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func TestParseGS1(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want GS1
+	}{
+		{
+			"gs1-128 with separator after the batch",
+			"]C10110614141000415" + "10LOT-7" + gs + "17260131" + "21SN42",
+			GS1{GTIN: "10614141000415", Batch: "LOT-7", ExpiryDate: date(2026, time.January, 31), Serial: "SN42"},
+		},
+		{
+			"datamatrix with leading separator",
+			"]d2" + gs + "0110614141000415" + "21SN42" + gs + "10LOT-7",
+			GS1{GTIN: "10614141000415", Serial: "SN42", Batch: "LOT-7"},
+		},
+		{
+			"fixed lengths need no separator",
+			"0110614141000415" + "11250301" + "15250930" + "10B1",
+			GS1{GTIN: "10614141000415", ProductionDate: date(2025, time.March, 1), BestBefore: date(2025, time.September, 30), Batch: "B1"},
+		},
+		{
+			"printed form",
+			"(01)10614141000415(17)251231(10)ABC123",
+			GS1{GTIN: "10614141000415", ExpiryDate: date(2025, time.December, 31), Batch: "ABC123"},
+		},
+		{
+			"day 00 is the end of the month",
+			"(17)240200",
+			GS1{ExpiryDate: date(2024, time.February, 29)},
+		},
+		{
+			"sscc and count",
+			"00106141411234567897" + "3012",
+			GS1{SSCC: "106141411234567897", Count: 12},
+		},
+		{
+			"gtin wins over content gtin",
+			"(02)10614141000415(01)09506000134376(37)6",
+			GS1{GTIN: "09506000134376", Count: 6},
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParseGS1(tt.data)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got.GTIN != tt.want.GTIN || got.SSCC != tt.want.SSCC || got.Batch != tt.want.Batch ||
+			got.Serial != tt.want.Serial || got.Count != tt.want.Count {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+		if !sameDate(got.ExpiryDate, tt.want.ExpiryDate) || !sameDate(got.BestBefore, tt.want.BestBefore) ||
+			!sameDate(got.ProductionDate, tt.want.ProductionDate) {
+			t.Errorf("%s: dates = %v %v %v, want %v %v %v", tt.name,
+				got.ExpiryDate, got.BestBefore, got.ProductionDate,
+				tt.want.ExpiryDate, tt.want.BestBefore, tt.want.ProductionDate)
+		}
+	}
+}
+
+func TestParseGS1Elements(t *testing.T) {
+	got, err := ParseGS1("]C1" + "10LOT" + gs + "0110614141000415")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Element{
+		{AI: "10", Name: "batch", Value: "LOT"},
+		{AI: "01", Name: "gtin", Value: "10614141000415"},
+	}
+	if len(got.Elements) != len(want) {
+		t.Fatalf("elements = %v, want %v", got.Elements, want)
+	}
+	for i := range want {
+		if got.Elements[i] != want[i] {
+			t.Errorf("element %d = %v, want %v", i, got.Elements[i], want[i])
+		}
+	}
+}
+
+func TestParseGS1Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{"empty", "", ErrNotGS1},
+		{"symbology identifier only", "]C1", ErrNotGS1},
+		{"not digits", "AB123", ErrNotGS1},
+		{"unknown identifier", "99123", ErrUnknownAI},
+		{"unknown printed identifier", "(99)123", ErrUnknownAI},
+		{"short fixed value", "01106141410004", ErrNotGS1},
+		{"printed fixed value too long", "(01)106141410004150", ErrNotGS1},
+		{"empty batch", "10" + gs + "0110614141000415", ErrNotGS1},
+		{"batch too long", "10" + "ABCDEFGHIJKLMNOPQRSTU", ErrNotGS1},
+		{"unclosed bracket", "(01", ErrNotGS1},
+		{"gtin check digit", "0110614141000416", ErrNotGS1},
+		{"sscc check digit", "00106141411234567898", ErrNotGS1},
+		{"month 13", "17251301", ErrNotGS1},
+		{"day past the month", "17250230", ErrNotGS1},
+		{"date not digits", "(17)25A101", ErrNotGS1},
+		{"count not a number", "(30)1X", ErrNotGS1},
+	}
+	for _, tt := range tests {
+		if _, err := ParseGS1(tt.data); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
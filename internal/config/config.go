@@ -4,6 +4,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
@@ -11,6 +13,14 @@ type Config struct {
 	DatabaseURL   string
 	Environment   string
 	JWTSecret     string
+	// How often supplier ratings are recalculated from their scorecards;
+	// 0 turns the recalculation off.
+	SupplierRatingInterval time.Duration
+	// How long Idempotency-Key responses are kept for replay.
+	IdempotencyKeyTTL time.Duration
+	// Whether webhook subscriptions may point at loopback and private
+	// hosts, for a local stub; off by default.
+	WebhookAllowPrivateHosts bool
 }
 
 func Load() (*Config, error) {
@@ -21,6 +31,24 @@ func Load() (*Config, error) {
 		JWTSecret:     getEnv("JWT_SECRET", "your-secret-key"),
 	}
 
+	interval, err := time.ParseDuration(getEnv("SUPPLIER_RATING_INTERVAL", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SUPPLIER_RATING_INTERVAL: %w", err)
+	}
+	cfg.SupplierRatingInterval = interval
+
+	ttl, err := time.ParseDuration(getEnv("IDEMPOTENCY_KEY_TTL", "24h"))
+	if err != nil || ttl <= 0 {
+		return nil, fmt.Errorf("invalid IDEMPOTENCY_KEY_TTL: must be a positive duration")
+	}
+	cfg.IdempotencyKeyTTL = ttl
+
+	allowPrivate, err := strconv.ParseBool(getEnv("WEBHOOK_ALLOW_PRIVATE_HOSTS", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid WEBHOOK_ALLOW_PRIVATE_HOSTS: %w", err)
+	}
+	cfg.WebhookAllowPrivateHosts = allowPrivate
+
 	if cfg.DatabaseURL == "" {
 		return nil, fmt.Errorf("DATABASE_URL is required")
 	}
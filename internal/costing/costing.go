@@ -0,0 +1,175 @@
+// Package costing values stock movements. Receipts are valued at their
+// purchase price, issues at FIFO layers, the moving weighted average or a
+// standard cost, and standard-costed receipts report their purchase price
+// variance. Landed costs are allocated over received lines and added to the
+// stock they bought. It has no database dependencies.
+package costing
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+type Method string
+
+const (
+	MethodFIFO            Method = "fifo"
+	MethodWeightedAverage Method = "weighted_average"
+	MethodStandard        Method = "standard"
+)
+
+var (
+	ErrUnknownMethod   = errors.New("unknown costing method")
+	ErrNoStandardCost  = errors.New("standard costing needs a standard cost")
+	ErrInvalidQuantity = errors.New("quantity must be positive")
+)
+
+// UnitCostPlaces is the precision unit costs are kept at; it matches the
+// decimal(12,4) columns they are stored in.
+const UnitCostPlaces = 4
+
+// Valid reports whether m is a known costing method.
+func (m Method) Valid() bool {
+	switch m {
+	case MethodFIFO, MethodWeightedAverage, MethodStandard:
+		return true
+	}
+	return false
+}
+
+// Balance is the quantity and total value on hand of a product in a
+// warehouse.
+type Balance struct {
+	Quantity int32
+	Value    decimal.Decimal
+}
+
+// UnitCost is the average cost of one unit on hand, or fallback when nothing
+// is on hand to average over.
+func (b Balance) UnitCost(fallback decimal.Decimal) decimal.Decimal {
+	if b.Quantity <= 0 || b.Value.IsNegative() {
+		return fallback
+	}
+	return b.Value.Div(decimal.NewFromInt32(b.Quantity)).Round(UnitCostPlaces)
+}
+
+// Layer is what is left of one FIFO receipt.
+type Layer struct {
+	ID        int32
+	Remaining int32
+	UnitCost  decimal.Decimal
+}
+
+// Draw is the part of an issue taken from one layer. LayerID is 0 for the
+// part no layer could cover.
+type Draw struct {
+	LayerID  int32           `json:"layer_id,omitempty"`
+	Quantity int32           `json:"quantity"`
+	UnitCost decimal.Decimal `json:"unit_cost"`
+	Cost     decimal.Decimal `json:"cost"`
+}
+
+// Issue is the valuation of stock leaving a warehouse.
+type Issue struct {
+	Quantity int32           `json:"quantity"`
+	Cost     decimal.Decimal `json:"cost"`
+	Draws    []Draw          `json:"draws,omitempty"`
+}
+
+// UnitCost is the average cost of the issued units.
+func (i Issue) UnitCost() decimal.Decimal {
+	if i.Quantity == 0 {
+		return decimal.Zero
+	}
+	return i.Cost.Div(decimal.NewFromInt32(i.Quantity)).Round(UnitCostPlaces)
+}
+
+// ConsumeFIFO takes quantity units from the layers in the order given,
+// oldest first. Whatever the layers cannot cover, e.g. stock that was on
+// hand before costing started, is valued at fallback.
+func ConsumeFIFO(layers []Layer, quantity int32, fallback decimal.Decimal) Issue {
+	issue := Issue{Quantity: quantity, Cost: decimal.Zero}
+	left := quantity
+	for _, l := range layers {
+		if left <= 0 {
+			break
+		}
+		if l.Remaining <= 0 {
+			continue
+		}
+		qty := l.Remaining
+		if qty > left {
+			qty = left
+		}
+		cost := l.UnitCost.Mul(decimal.NewFromInt32(qty))
+		issue.Draws = append(issue.Draws, Draw{LayerID: l.ID, Quantity: qty, UnitCost: l.UnitCost, Cost: cost})
+		issue.Cost = issue.Cost.Add(cost)
+		left -= qty
+	}
+	if left > 0 {
+		cost := fallback.Mul(decimal.NewFromInt32(left))
+		issue.Draws = append(issue.Draws, Draw{Quantity: left, UnitCost: fallback, Cost: cost})
+		issue.Cost = issue.Cost.Add(cost)
+	}
+	return issue
+}
+
+// ConsumeAverage values an issue at the balance's moving average cost.
+func ConsumeAverage(balance Balance, quantity int32, fallback decimal.Decimal) Issue {
+	unit := balance.UnitCost(fallback)
+	// Emptying the warehouse takes exactly what is left so no rounding
+	// residue stays on the books.
+	if quantity == balance.Quantity && balance.Quantity > 0 {
+		return Issue{Quantity: quantity, Cost: balance.Value}
+	}
+	return Issue{Quantity: quantity, Cost: unit.Mul(decimal.NewFromInt32(quantity))}
+}
+
+// ConsumeStandard values an issue at the standard cost.
+func ConsumeStandard(standard decimal.Decimal, quantity int32) Issue {
+	return Issue{Quantity: quantity, Cost: standard.Mul(decimal.NewFromInt32(quantity))}
+}
+
+// Receipt is the valuation of stock entering a warehouse.
+type Receipt struct {
+	Quantity int32           `json:"quantity"`
+	UnitCost decimal.Decimal `json:"unit_cost"`
+	Cost     decimal.Decimal `json:"cost"`
+	// Variance is what the purchase price exceeded the standard cost by;
+	// only standard-costed receipts have one.
+	Variance decimal.Decimal `json:"variance"`
+}
+
+// Receive values quantity units bought at actualCost each. Under standard
+// costing the stock goes on the books at the standard cost and the
+// difference is returned as purchase price variance.
+func Receive(method Method, quantity int32, actualCost decimal.Decimal, standard decimal.NullDecimal) (Receipt, error) {
+	if quantity <= 0 {
+		return Receipt{}, ErrInvalidQuantity
+	}
+	qty := decimal.NewFromInt32(quantity)
+	actualCost = actualCost.Round(UnitCostPlaces)
+
+	switch method {
+	case MethodFIFO, MethodWeightedAverage:
+		return Receipt{Quantity: quantity, UnitCost: actualCost, Cost: actualCost.Mul(qty), Variance: decimal.Zero}, nil
+	case MethodStandard:
+		if !standard.Valid {
+			return Receipt{}, ErrNoStandardCost
+		}
+		return Receipt{
+			Quantity: quantity,
+			UnitCost: standard.Decimal,
+			Cost:     standard.Decimal.Mul(qty),
+			Variance: actualCost.Sub(standard.Decimal).Mul(qty),
+		}, nil
+	}
+	return Receipt{}, ErrUnknownMethod
+}
+
+// Revalue is the change in value when quantity units on hand move from one
+// standard cost to another.
+func Revalue(quantity int32, from, to decimal.Decimal) decimal.Decimal {
+	return to.Sub(from).Mul(decimal.NewFromInt32(quantity))
+}
@@ -0,0 +1,148 @@
+package costing
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func d(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestBalanceUnitCost(t *testing.T) {
+	tests := []struct {
+		name    string
+		balance Balance
+		want    decimal.Decimal
+	}{
+		{"average", Balance{Quantity: 4, Value: d("10")}, d("2.5")},
+		{"rounded to four places", Balance{Quantity: 3, Value: d("10")}, d("3.3333")},
+		{"nothing on hand", Balance{Quantity: 0, Value: d("0")}, d("7")},
+		{"negative quantity", Balance{Quantity: -2, Value: d("10")}, d("7")},
+		{"negative value", Balance{Quantity: 2, Value: d("-1")}, d("7")},
+	}
+	for _, tt := range tests {
+		if got := tt.balance.UnitCost(d("7")); !got.Equal(tt.want) {
+			t.Errorf("%s: UnitCost = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConsumeFIFO(t *testing.T) {
+	layers := []Layer{
+		{ID: 1, Remaining: 5, UnitCost: d("2")},
+		{ID: 2, Remaining: 0, UnitCost: d("9")},
+		{ID: 3, Remaining: 10, UnitCost: d("3")},
+	}
+	tests := []struct {
+		name     string
+		quantity int32
+		cost     decimal.Decimal
+		draws    []Draw
+	}{
+		{"within the oldest layer", 4, d("8"), []Draw{{LayerID: 1, Quantity: 4, UnitCost: d("2"), Cost: d("8")}}},
+		{"across layers, skipping empty ones", 8, d("19"), []Draw{
+			{LayerID: 1, Quantity: 5, UnitCost: d("2"), Cost: d("10")},
+			{LayerID: 3, Quantity: 3, UnitCost: d("3"), Cost: d("9")},
+		}},
+		{"beyond the layers at the fallback", 17, d("48"), []Draw{
+			{LayerID: 1, Quantity: 5, UnitCost: d("2"), Cost: d("10")},
+			{LayerID: 3, Quantity: 10, UnitCost: d("3"), Cost: d("30")},
+			{Quantity: 2, UnitCost: d("4"), Cost: d("8")},
+		}},
+	}
+	for _, tt := range tests {
+		issue := ConsumeFIFO(layers, tt.quantity, d("4"))
+		if issue.Quantity != tt.quantity || !issue.Cost.Equal(tt.cost) {
+			t.Errorf("%s: issue = %d for %s, want %d for %s", tt.name, issue.Quantity, issue.Cost, tt.quantity, tt.cost)
+		}
+		if len(issue.Draws) != len(tt.draws) {
+			t.Errorf("%s: draws = %v, want %v", tt.name, issue.Draws, tt.draws)
+			continue
+		}
+		for i, want := range tt.draws {
+			got := issue.Draws[i]
+			if got.LayerID != want.LayerID || got.Quantity != want.Quantity || !got.UnitCost.Equal(want.UnitCost) || !got.Cost.Equal(want.Cost) {
+				t.Errorf("%s: draw %d = %+v, want %+v", tt.name, i, got, want)
+			}
+		}
+	}
+}
+
+func TestConsumeAverage(t *testing.T) {
+	balance := Balance{Quantity: 3, Value: d("10")}
+	tests := []struct {
+		name     string
+		balance  Balance
+		quantity int32
+		want     decimal.Decimal
+	}{
+		{"part of the stock", balance, 2, d("6.6666")},
+		{"all of the stock takes its whole value", balance, 3, d("10")},
+		{"nothing on hand uses the fallback", Balance{}, 2, d("10")},
+	}
+	for _, tt := range tests {
+		if got := ConsumeAverage(tt.balance, tt.quantity, d("5")); !got.Cost.Equal(tt.want) {
+			t.Errorf("%s: cost = %s, want %s", tt.name, got.Cost, tt.want)
+		}
+	}
+}
+
+func TestReceive(t *testing.T) {
+	standard := decimal.NullDecimal{Decimal: d("2.5"), Valid: true}
+	tests := []struct {
+		name     string
+		method   Method
+		standard decimal.NullDecimal
+		unitCost decimal.Decimal
+		cost     decimal.Decimal
+		variance decimal.Decimal
+		err      error
+	}{
+		{"fifo at the purchase price", MethodFIFO, standard, d("3.1"), d("31"), d("0"), nil},
+		{"average at the purchase price", MethodWeightedAverage, decimal.NullDecimal{}, d("3.1"), d("31"), d("0"), nil},
+		{"standard with variance", MethodStandard, standard, d("2.5"), d("25"), d("6"), nil},
+		{"standard without a standard cost", MethodStandard, decimal.NullDecimal{}, d("0"), d("0"), d("0"), ErrNoStandardCost},
+		{"unknown method", "lifo", standard, d("0"), d("0"), d("0"), ErrUnknownMethod},
+	}
+	for _, tt := range tests {
+		got, err := Receive(tt.method, 10, d("3.1"), tt.standard)
+		if err != tt.err {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !got.UnitCost.Equal(tt.unitCost) || !got.Cost.Equal(tt.cost) || !got.Variance.Equal(tt.variance) {
+			t.Errorf("%s: receipt = %s each, %s, variance %s, want %s each, %s, variance %s",
+				tt.name, got.UnitCost, got.Cost, got.Variance, tt.unitCost, tt.cost, tt.variance)
+		}
+	}
+}
+
+func TestReceiveRoundsThePurchasePrice(t *testing.T) {
+	got, err := Receive(MethodFIFO, 3, d("1.23456"), decimal.NullDecimal{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.UnitCost.Equal(d("1.2346")) || !got.Cost.Equal(d("3.7038")) {
+		t.Errorf("receipt = %s each, %s, want 1.2346 each, 3.7038", got.UnitCost, got.Cost)
+	}
+}
+
+func TestReceiveInvalidQuantity(t *testing.T) {
+	if _, err := Receive(MethodFIFO, 0, d("1"), decimal.NullDecimal{}); err != ErrInvalidQuantity {
+		t.Errorf("err = %v, want ErrInvalidQuantity", err)
+	}
+}
+
+func TestRevalue(t *testing.T) {
+	if got := Revalue(4, d("2.5"), d("3")); !got.Equal(d("2")) {
+		t.Errorf("Revalue = %s, want 2", got)
+	}
+	if got := Revalue(4, d("3"), d("2.5")); !got.Equal(d("-2")) {
+		t.Errorf("Revalue = %s, want -2", got)
+	}
+}
@@ -0,0 +1,83 @@
+package costing
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+var ErrNoAllocationBasis = errors.New("nothing to allocate by: every line has a zero basis")
+
+// Allocate spreads amount over lines in proportion to their basis, rounding
+// each share to places. The rounding difference goes to the line with the
+// largest basis so the shares always add up to amount.
+func Allocate(amount decimal.Decimal, basis []decimal.Decimal, places int32) ([]decimal.Decimal, error) {
+	total := decimal.Zero
+	largest := -1
+	for i, b := range basis {
+		if b.IsNegative() {
+			continue
+		}
+		total = total.Add(b)
+		if largest < 0 || b.GreaterThan(basis[largest]) {
+			largest = i
+		}
+	}
+	if !total.IsPositive() {
+		return nil, ErrNoAllocationBasis
+	}
+
+	shares := make([]decimal.Decimal, len(basis))
+	allocated := decimal.Zero
+	for i, b := range basis {
+		shares[i] = decimal.Zero
+		if !b.IsPositive() {
+			continue
+		}
+		shares[i] = amount.Mul(b).Div(total).Round(places)
+		allocated = allocated.Add(shares[i])
+	}
+	shares[largest] = shares[largest].Add(amount.Sub(allocated))
+	return shares, nil
+}
+
+// Uplift is what a landed cost share adds to each of the received units.
+func Uplift(share decimal.Decimal, received int32) decimal.Decimal {
+	if received <= 0 {
+		return decimal.Zero
+	}
+	return share.Div(decimal.NewFromInt32(received)).Round(UnitCostPlaces)
+}
+
+// Landing is how a landed cost share for received units is booked.
+type Landing struct {
+	UnitUplift decimal.Decimal `json:"unit_uplift"`
+	// Capitalized is added to the value of the units still on hand.
+	Capitalized decimal.Decimal `json:"capitalized"`
+	// Expensed belongs to units already issued or, under standard costing,
+	// is purchase price variance.
+	Expensed decimal.Decimal `json:"expensed"`
+}
+
+// Land books a landed cost share spread over the received units, of which
+// onHand are still in stock: the FIFO layers left from the receipt, or the
+// warehouse quantity under weighted average. Standard-costed stock keeps its
+// standard cost, so the whole share is variance.
+func Land(method Method, share decimal.Decimal, received, onHand int32) Landing {
+	landing := Landing{UnitUplift: Uplift(share, received), Capitalized: decimal.Zero, Expensed: share}
+	if method == MethodStandard || received <= 0 {
+		return landing
+	}
+	if onHand > received {
+		onHand = received
+	}
+	if onHand <= 0 {
+		return landing
+	}
+	landing.Capitalized = landing.UnitUplift.Mul(decimal.NewFromInt32(onHand))
+	if landing.Capitalized.GreaterThan(share) {
+		landing.Capitalized = share
+	}
+	landing.Expensed = share.Sub(landing.Capitalized)
+	return landing
+}
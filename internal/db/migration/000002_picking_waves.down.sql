@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "picking_wave_items";
+ALTER TABLE "picking_waves" DROP COLUMN IF EXISTS "route_id";
+DROP TYPE IF EXISTS "pick_status";
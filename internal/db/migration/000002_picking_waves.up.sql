@@ -0,0 +1,45 @@
+CREATE TYPE "pick_status" AS ENUM (
+  'pending',
+  'picked',
+  'short',
+  'cancelled'
+);
+
+ALTER TABLE "picking_waves" ADD COLUMN "route_id" int;
+
+CREATE TABLE "picking_wave_items" (
+  "wave_item_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "wave_id" int NOT NULL,
+  "inventory_id" int NOT NULL,
+  "product_id" int NOT NULL,
+  "location_id" int,
+  "quantity_to_pick" int NOT NULL,
+  "quantity_picked" int NOT NULL DEFAULT 0,
+  "status" pick_status NOT NULL DEFAULT 'pending',
+  "picked_by" int,
+  "picked_at" timestamp,
+  "pick_seconds" int,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE INDEX ON "picking_waves" ("warehouse_id", "status", "priority");
+
+CREATE INDEX ON "picking_wave_items" ("wave_id");
+
+CREATE INDEX ON "picking_wave_items" ("inventory_id", "status");
+
+CREATE INDEX ON "picking_wave_items" ("picked_by", "picked_at");
+
+COMMENT ON COLUMN "picking_wave_items"."pick_seconds" IS 'Seconds since the picker''s previous confirmation in the wave (or wave start)';
+
+ALTER TABLE "picking_waves" ADD FOREIGN KEY ("route_id") REFERENCES "picking_routes" ("route_id");
+
+ALTER TABLE "picking_wave_items" ADD FOREIGN KEY ("wave_id") REFERENCES "picking_waves" ("wave_id");
+
+ALTER TABLE "picking_wave_items" ADD FOREIGN KEY ("inventory_id") REFERENCES "inventory" ("inventory_id");
+
+ALTER TABLE "picking_wave_items" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "picking_wave_items" ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("location_id");
+
+ALTER TABLE "picking_wave_items" ADD FOREIGN KEY ("picked_by") REFERENCES "users" ("user_id");
@@ -0,0 +1,6 @@
+ALTER TABLE "picking_wave_items" DROP COLUMN IF EXISTS "pick_sequence";
+ALTER TABLE "locations" DROP COLUMN IF EXISTS "pick_sequence";
+ALTER TABLE "locations" DROP COLUMN IF EXISTS "y_coord";
+ALTER TABLE "locations" DROP COLUMN IF EXISTS "x_coord";
+ALTER TABLE "locations" DROP COLUMN IF EXISTS "zone_id";
+DROP TABLE IF EXISTS "warehouse_zones";
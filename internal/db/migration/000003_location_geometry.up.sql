@@ -0,0 +1,30 @@
+CREATE TABLE "warehouse_zones" (
+  "zone_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "warehouse_id" int NOT NULL,
+  "zone_code" varchar(20) NOT NULL,
+  "name" varchar(100) NOT NULL,
+  "description" text,
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+ALTER TABLE "locations" ADD COLUMN "zone_id" int;
+ALTER TABLE "locations" ADD COLUMN "x_coord" decimal(10,2);
+ALTER TABLE "locations" ADD COLUMN "y_coord" decimal(10,2);
+ALTER TABLE "locations" ADD COLUMN "pick_sequence" int;
+
+ALTER TABLE "picking_wave_items" ADD COLUMN "pick_sequence" int;
+
+CREATE UNIQUE INDEX ON "warehouse_zones" ("warehouse_id", "zone_code");
+
+CREATE INDEX ON "locations" ("zone_id");
+
+COMMENT ON COLUMN "locations"."x_coord" IS 'Position across aisles in metres; locations in the same aisle share x';
+
+COMMENT ON COLUMN "locations"."y_coord" IS 'Position along the aisle in metres from the front cross-aisle';
+
+COMMENT ON COLUMN "picking_wave_items"."pick_sequence" IS 'Position in the optimised pick path';
+
+ALTER TABLE "warehouse_zones" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
+
+ALTER TABLE "locations" ADD FOREIGN KEY ("zone_id") REFERENCES "warehouse_zones" ("zone_id");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "putaway_rules";
+DROP INDEX IF EXISTS "inventory_location_id_idx";
+COMMENT ON COLUMN "locations"."max_capacity" IS NULL;
+ALTER TABLE "locations" DROP COLUMN IF EXISTS "max_volume";
+ALTER TABLE "locations" DROP COLUMN IF EXISTS "max_weight";
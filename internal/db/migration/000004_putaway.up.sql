@@ -0,0 +1,32 @@
+ALTER TABLE "locations" ADD COLUMN "max_weight" decimal(10,3);
+ALTER TABLE "locations" ADD COLUMN "max_volume" decimal(12,3);
+
+CREATE TABLE "putaway_rules" (
+  "rule_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "warehouse_id" int NOT NULL,
+  "category_id" int,
+  "zone_id" int NOT NULL,
+  "priority" int NOT NULL DEFAULT 1,
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE INDEX ON "putaway_rules" ("warehouse_id", "category_id");
+
+CREATE INDEX ON "inventory" ("location_id");
+
+COMMENT ON COLUMN "locations"."max_capacity" IS 'Maximum number of units the location holds';
+
+COMMENT ON COLUMN "locations"."max_weight" IS 'Maximum load in kg';
+
+COMMENT ON COLUMN "locations"."max_volume" IS 'Maximum volume in litres; product dimensions are read as LxWxH in cm';
+
+COMMENT ON COLUMN "putaway_rules"."category_id" IS 'NULL applies the rule to every category';
+
+COMMENT ON COLUMN "putaway_rules"."priority" IS 'Lower numbers are preferred';
+
+ALTER TABLE "putaway_rules" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
+
+ALTER TABLE "putaway_rules" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("category_id");
+
+ALTER TABLE "putaway_rules" ADD FOREIGN KEY ("zone_id") REFERENCES "warehouse_zones" ("zone_id");
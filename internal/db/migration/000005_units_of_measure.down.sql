@@ -0,0 +1,5 @@
+ALTER TABLE "purchase_order_items" ALTER COLUMN "unit_price" TYPE decimal(10,2);
+ALTER TABLE "purchase_order_items" DROP COLUMN IF EXISTS "uom_id";
+ALTER TABLE "product_suppliers" DROP COLUMN IF EXISTS "purchase_uom_id";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "base_uom_id";
+DROP TABLE IF EXISTS "product_uoms";
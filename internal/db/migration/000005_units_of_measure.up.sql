@@ -0,0 +1,39 @@
+CREATE TABLE "product_uoms" (
+  "product_uom_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "product_id" int NOT NULL,
+  "uom_id" int NOT NULL,
+  "conversion_factor" int NOT NULL,
+  "barcode" varchar(100),
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+ALTER TABLE "products" ADD COLUMN "base_uom_id" int;
+
+ALTER TABLE "product_suppliers" ADD COLUMN "purchase_uom_id" int;
+
+ALTER TABLE "purchase_order_items" ADD COLUMN "uom_id" int;
+
+ALTER TABLE "purchase_order_items" ALTER COLUMN "unit_price" TYPE decimal(12,4);
+
+CREATE UNIQUE INDEX ON "product_uoms" ("product_id", "uom_id");
+
+COMMENT ON COLUMN "product_uoms"."conversion_factor" IS 'Base units in one of this unit, e.g. 12 for a box of 12';
+
+COMMENT ON COLUMN "products"."base_uom_id" IS 'Unit every stored quantity of the product is counted in';
+
+COMMENT ON COLUMN "product_suppliers"."purchase_uom_id" IS 'Unit the supplier sells the product in';
+
+COMMENT ON COLUMN "purchase_order_items"."uom_id" IS 'Unit the line was ordered in; quantities and prices are stored per base unit';
+
+COMMENT ON COLUMN "purchase_order_items"."unit_price" IS 'Price of one base unit';
+
+ALTER TABLE "product_uoms" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "product_uoms" ADD FOREIGN KEY ("uom_id") REFERENCES "units_of_measure" ("uom_id");
+
+ALTER TABLE "products" ADD FOREIGN KEY ("base_uom_id") REFERENCES "units_of_measure" ("uom_id");
+
+ALTER TABLE "product_suppliers" ADD FOREIGN KEY ("purchase_uom_id") REFERENCES "units_of_measure" ("uom_id");
+
+ALTER TABLE "purchase_order_items" ADD FOREIGN KEY ("uom_id") REFERENCES "units_of_measure" ("uom_id");
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "purchase_price_variances";
+DROP TABLE IF EXISTS "cost_entries";
+DROP TABLE IF EXISTS "cost_balances";
+DROP TABLE IF EXISTS "cost_layers";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "standard_cost";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "costing_method";
+DROP TYPE IF EXISTS "cost_entry_type";
+DROP TYPE IF EXISTS "costing_method";
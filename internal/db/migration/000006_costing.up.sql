@@ -0,0 +1,125 @@
+CREATE TYPE "costing_method" AS ENUM (
+  'fifo',
+  'weighted_average',
+  'standard'
+);
+
+CREATE TYPE "cost_entry_type" AS ENUM (
+  'receipt',
+  'issue',
+  'revaluation'
+);
+
+ALTER TABLE "products" ADD COLUMN "costing_method" costing_method NOT NULL DEFAULT 'weighted_average';
+
+ALTER TABLE "products" ADD COLUMN "standard_cost" decimal(12,4);
+
+CREATE TABLE "cost_layers" (
+  "layer_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "product_id" int NOT NULL,
+  "warehouse_id" int NOT NULL,
+  "movement_id" int,
+  "po_item_id" int,
+  "quantity_received" int NOT NULL,
+  "quantity_remaining" int NOT NULL,
+  "unit_cost" decimal(12,4) NOT NULL,
+  "received_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "cost_balances" (
+  "product_id" int NOT NULL,
+  "warehouse_id" int NOT NULL,
+  "quantity" int NOT NULL DEFAULT 0,
+  "total_value" decimal(14,4) NOT NULL DEFAULT 0,
+  "updated_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+  PRIMARY KEY ("product_id", "warehouse_id")
+);
+
+CREATE TABLE "cost_entries" (
+  "entry_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "product_id" int NOT NULL,
+  "warehouse_id" int NOT NULL,
+  "movement_id" int,
+  "entry_type" cost_entry_type NOT NULL,
+  "costing_method" costing_method NOT NULL,
+  "quantity" int NOT NULL,
+  "unit_cost" decimal(12,4) NOT NULL,
+  "total_cost" decimal(14,4) NOT NULL,
+  "entry_date" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "purchase_price_variances" (
+  "variance_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "product_id" int NOT NULL,
+  "warehouse_id" int NOT NULL,
+  "movement_id" int,
+  "po_item_id" int,
+  "quantity" int NOT NULL,
+  "standard_cost" decimal(12,4) NOT NULL,
+  "actual_cost" decimal(12,4) NOT NULL,
+  "variance_amount" decimal(14,4) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE INDEX ON "cost_layers" ("product_id", "warehouse_id", "received_at") WHERE "quantity_remaining" > 0;
+
+CREATE INDEX ON "cost_entries" ("entry_date");
+
+CREATE INDEX ON "cost_entries" ("product_id", "warehouse_id");
+
+CREATE INDEX ON "cost_entries" ("movement_id");
+
+CREATE INDEX ON "purchase_price_variances" ("created_at");
+
+COMMENT ON COLUMN "products"."costing_method" IS 'How issues of the product are valued';
+
+COMMENT ON COLUMN "products"."standard_cost" IS 'Unit cost used by standard costing';
+
+COMMENT ON COLUMN "cost_layers"."quantity_remaining" IS 'Units of the receipt not yet issued under FIFO';
+
+COMMENT ON COLUMN "cost_balances"."total_value" IS 'Book value of the quantity on hand';
+
+COMMENT ON COLUMN "cost_entries"."quantity" IS 'Signed: positive for receipts, negative for issues, 0 for revaluations';
+
+COMMENT ON COLUMN "cost_entries"."total_cost" IS 'Signed change in book value';
+
+COMMENT ON COLUMN "purchase_price_variances"."variance_amount" IS 'Written by the application as (actual_cost - standard_cost) * quantity';
+
+ALTER TABLE "cost_layers" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "cost_layers" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
+
+ALTER TABLE "cost_layers" ADD FOREIGN KEY ("movement_id") REFERENCES "stock_movements" ("movement_id");
+
+ALTER TABLE "cost_layers" ADD FOREIGN KEY ("po_item_id") REFERENCES "purchase_order_items" ("po_item_id");
+
+ALTER TABLE "cost_balances" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "cost_balances" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
+
+ALTER TABLE "cost_entries" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "cost_entries" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
+
+ALTER TABLE "cost_entries" ADD FOREIGN KEY ("movement_id") REFERENCES "stock_movements" ("movement_id");
+
+ALTER TABLE "purchase_price_variances" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "purchase_price_variances" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
+
+ALTER TABLE "purchase_price_variances" ADD FOREIGN KEY ("movement_id") REFERENCES "stock_movements" ("movement_id");
+
+ALTER TABLE "purchase_price_variances" ADD FOREIGN KEY ("po_item_id") REFERENCES "purchase_order_items" ("po_item_id");
+
+-- Stock on hand before costing starts is booked at the product's cost price.
+INSERT INTO "cost_balances" ("product_id", "warehouse_id", "quantity", "total_value")
+SELECT i."product_id", i."warehouse_id", SUM(i."quantity"), SUM(i."quantity") * COALESCE(p."cost_price", 0)
+FROM "inventory" i
+JOIN "products" p ON i."product_id" = p."product_id"
+GROUP BY i."product_id", i."warehouse_id", p."cost_price"
+HAVING SUM(i."quantity") <> 0;
+
+INSERT INTO "cost_entries" ("product_id", "warehouse_id", "entry_type", "costing_method", "quantity", "unit_cost", "total_cost")
+SELECT cb."product_id", cb."warehouse_id", 'receipt', p."costing_method", cb."quantity", COALESCE(p."cost_price", 0), cb."total_value"
+FROM "cost_balances" cb
+JOIN "products" p ON cb."product_id" = p."product_id";
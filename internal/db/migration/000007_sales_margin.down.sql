@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "stock_movements_movement_type_movement_date_idx";
+ALTER TABLE "stock_movements" DROP COLUMN IF EXISTS "sale_price";
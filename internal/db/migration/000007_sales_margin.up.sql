@@ -0,0 +1,5 @@
+ALTER TABLE "stock_movements" ADD COLUMN "sale_price" decimal(12,4);
+
+COMMENT ON COLUMN "stock_movements"."sale_price" IS 'Price one unit of a sales delivery was sold at; NULL means the product''s unit_price';
+
+CREATE INDEX ON "stock_movements" ("movement_type", "movement_date");
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS "landed_cost_allocations";
+DROP TABLE IF EXISTS "landed_costs";
+-- PostgreSQL cannot drop an enum value; 'landed_cost' stays on cost_entry_type.
+DELETE FROM "cost_entries" WHERE "entry_type" = 'landed_cost';
+ALTER TABLE "cost_entries" DROP COLUMN IF EXISTS "exchange_rate";
+ALTER TABLE "cost_entries" DROP COLUMN IF EXISTS "currency_code";
+ALTER TABLE "purchase_orders" DROP COLUMN IF EXISTS "currency_code";
+ALTER TABLE "suppliers" DROP COLUMN IF EXISTS "currency_code";
+DROP TABLE IF EXISTS "exchange_rates";
+DROP TABLE IF EXISTS "currencies";
+DROP TYPE IF EXISTS "landed_cost_basis";
+DROP TYPE IF EXISTS "landed_cost_type";
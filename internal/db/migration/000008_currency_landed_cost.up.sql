@@ -0,0 +1,122 @@
+CREATE TYPE "landed_cost_type" AS ENUM (
+  'freight',
+  'duty',
+  'insurance',
+  'other'
+);
+
+CREATE TYPE "landed_cost_basis" AS ENUM (
+  'value',
+  'weight',
+  'quantity'
+);
+
+ALTER TYPE "cost_entry_type" ADD VALUE 'landed_cost';
+
+CREATE TABLE "currencies" (
+  "currency_code" char(3) PRIMARY KEY,
+  "name" varchar(50) NOT NULL,
+  "is_base" boolean NOT NULL DEFAULT false,
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "exchange_rates" (
+  "rate_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "currency_code" char(3) NOT NULL,
+  "rate_date" date NOT NULL,
+  "rate" decimal(18,8) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "landed_costs" (
+  "landed_cost_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "po_id" int NOT NULL,
+  "reference_number" varchar(50),
+  "cost_type" landed_cost_type NOT NULL,
+  "allocation_basis" landed_cost_basis NOT NULL,
+  "currency_code" char(3) NOT NULL,
+  "amount" decimal(12,2) NOT NULL,
+  "exchange_rate" decimal(18,8) NOT NULL,
+  "base_amount" decimal(12,2) NOT NULL,
+  "cost_date" date NOT NULL,
+  "notes" text,
+  "created_by" int,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "landed_cost_allocations" (
+  "allocation_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "landed_cost_id" int NOT NULL,
+  "po_item_id" int NOT NULL,
+  "product_id" int NOT NULL,
+  "warehouse_id" int,
+  "quantity" int NOT NULL,
+  "basis" decimal(14,4) NOT NULL,
+  "amount" decimal(12,4) NOT NULL,
+  "capitalized_amount" decimal(12,4) NOT NULL,
+  "expensed_amount" decimal(12,4) NOT NULL,
+  "cost_entry_id" int
+);
+
+ALTER TABLE "suppliers" ADD COLUMN "currency_code" char(3);
+
+ALTER TABLE "purchase_orders" ADD COLUMN "currency_code" char(3);
+
+ALTER TABLE "cost_entries" ADD COLUMN "currency_code" char(3);
+
+ALTER TABLE "cost_entries" ADD COLUMN "exchange_rate" decimal(18,8);
+
+CREATE UNIQUE INDEX ON "currencies" ("is_base") WHERE "is_base";
+
+CREATE UNIQUE INDEX ON "exchange_rates" ("currency_code", "rate_date");
+
+CREATE INDEX ON "landed_costs" ("po_id");
+
+CREATE INDEX ON "landed_cost_allocations" ("landed_cost_id");
+
+COMMENT ON COLUMN "currencies"."is_base" IS 'Currency inventory is valued in; at most one';
+
+COMMENT ON COLUMN "exchange_rates"."rate" IS 'Base currency units per one unit of this currency';
+
+COMMENT ON COLUMN "suppliers"."currency_code" IS 'Currency the supplier invoices in; NULL means the base currency';
+
+COMMENT ON COLUMN "purchase_orders"."currency_code" IS 'Currency of the order prices; NULL means the base currency';
+
+COMMENT ON COLUMN "cost_entries"."currency_code" IS 'Currency a receipt was bought in, when not the base currency';
+
+COMMENT ON COLUMN "cost_entries"."exchange_rate" IS 'Rate the purchase price was converted into the base currency at';
+
+COMMENT ON COLUMN "landed_costs"."base_amount" IS 'amount converted into the base currency at exchange_rate';
+
+COMMENT ON COLUMN "landed_cost_allocations"."warehouse_id" IS 'Warehouse the received stock went to; NULL when the line was received without stock movements';
+
+COMMENT ON COLUMN "landed_cost_allocations"."basis" IS 'Value, weight or quantity of the line the amount was allocated by';
+
+COMMENT ON COLUMN "landed_cost_allocations"."capitalized_amount" IS 'Part of amount added to the value of stock still on hand';
+
+COMMENT ON COLUMN "landed_cost_allocations"."expensed_amount" IS 'Part of amount for stock already issued, or booked as purchase price variance under standard costing';
+
+ALTER TABLE "exchange_rates" ADD FOREIGN KEY ("currency_code") REFERENCES "currencies" ("currency_code");
+
+ALTER TABLE "suppliers" ADD FOREIGN KEY ("currency_code") REFERENCES "currencies" ("currency_code");
+
+ALTER TABLE "purchase_orders" ADD FOREIGN KEY ("currency_code") REFERENCES "currencies" ("currency_code");
+
+ALTER TABLE "cost_entries" ADD FOREIGN KEY ("currency_code") REFERENCES "currencies" ("currency_code");
+
+ALTER TABLE "landed_costs" ADD FOREIGN KEY ("po_id") REFERENCES "purchase_orders" ("po_id");
+
+ALTER TABLE "landed_costs" ADD FOREIGN KEY ("currency_code") REFERENCES "currencies" ("currency_code");
+
+ALTER TABLE "landed_costs" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("user_id");
+
+ALTER TABLE "landed_cost_allocations" ADD FOREIGN KEY ("landed_cost_id") REFERENCES "landed_costs" ("landed_cost_id");
+
+ALTER TABLE "landed_cost_allocations" ADD FOREIGN KEY ("po_item_id") REFERENCES "purchase_order_items" ("po_item_id");
+
+ALTER TABLE "landed_cost_allocations" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "landed_cost_allocations" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
+
+ALTER TABLE "landed_cost_allocations" ADD FOREIGN KEY ("cost_entry_id") REFERENCES "cost_entries" ("entry_id");
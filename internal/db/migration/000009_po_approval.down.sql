@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "purchase_order_approvals";
+DROP TABLE IF EXISTS "po_approval_levels";
+ALTER TABLE "purchase_orders" DROP COLUMN IF EXISTS "updated_at";
+ALTER TABLE "purchase_orders" DROP COLUMN IF EXISTS "submitted_at";
+DROP TYPE IF EXISTS "po_approval_decision";
@@ -0,0 +1,49 @@
+CREATE TYPE "po_approval_decision" AS ENUM (
+  'approved',
+  'rejected'
+);
+
+CREATE TABLE "po_approval_levels" (
+  "approval_level_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "level" int UNIQUE NOT NULL,
+  "approver_role" user_role NOT NULL,
+  "min_amount" decimal(12,2) NOT NULL DEFAULT 0,
+  "description" varchar(100),
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "purchase_order_approvals" (
+  "approval_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "po_id" int NOT NULL,
+  "level" int NOT NULL,
+  "decision" po_approval_decision NOT NULL,
+  "approver_id" int NOT NULL,
+  "total_amount" decimal(10,2) NOT NULL,
+  "comments" text,
+  "decided_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+ALTER TABLE "purchase_orders" ADD COLUMN "submitted_at" timestamp;
+
+ALTER TABLE "purchase_orders" ADD COLUMN "updated_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP);
+
+UPDATE "purchase_orders" SET "submitted_at" = "created_at" WHERE "status" = 'pending';
+
+INSERT INTO "po_approval_levels" ("level", "approver_role", "min_amount", "description") VALUES
+  (1, 'manager', 0, 'Manager sign-off for every order'),
+  (2, 'admin', 10000, 'Admin sign-off for orders of 10,000 or more');
+
+CREATE INDEX ON "purchase_order_approvals" ("po_id", "decided_at");
+
+COMMENT ON COLUMN "po_approval_levels"."approver_role" IS 'Lowest role that may sign off this level; higher roles may too';
+
+COMMENT ON COLUMN "po_approval_levels"."min_amount" IS 'Orders with a total_amount of at least this need this level';
+
+COMMENT ON COLUMN "purchase_order_approvals"."total_amount" IS 'Order total at the time of the decision';
+
+COMMENT ON COLUMN "purchase_orders"."submitted_at" IS 'Last time the order was submitted for approval; sign-offs before it no longer count';
+
+ALTER TABLE "purchase_order_approvals" ADD FOREIGN KEY ("po_id") REFERENCES "purchase_orders" ("po_id");
+
+ALTER TABLE "purchase_order_approvals" ADD FOREIGN KEY ("approver_id") REFERENCES "users" ("user_id");
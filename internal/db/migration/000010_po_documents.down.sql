@@ -0,0 +1,2 @@
+ALTER TABLE "purchase_orders" DROP COLUMN IF EXISTS "warehouse_id";
+DROP TABLE IF EXISTS "warehouse_letterheads";
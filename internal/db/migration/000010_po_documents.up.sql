@@ -0,0 +1,19 @@
+CREATE TABLE "warehouse_letterheads" (
+  "warehouse_id" int PRIMARY KEY,
+  "header_template" text NOT NULL,
+  "footer_template" text,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+  "updated_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+ALTER TABLE "purchase_orders" ADD COLUMN "warehouse_id" int;
+
+COMMENT ON COLUMN "warehouse_letterheads"."header_template" IS 'Go text/template for the letterhead lines, one per line; the first line is printed in bold';
+
+COMMENT ON COLUMN "warehouse_letterheads"."footer_template" IS 'Go text/template printed at the foot of every page';
+
+COMMENT ON COLUMN "purchase_orders"."warehouse_id" IS 'Warehouse the goods are delivered to; picks the letterhead of the order document';
+
+ALTER TABLE "warehouse_letterheads" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
+
+ALTER TABLE "purchase_orders" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
@@ -0,0 +1,2 @@
+ALTER TABLE "suppliers" DROP COLUMN IF EXISTS "rated_at";
+DROP TABLE IF EXISTS "purchase_order_receipts";
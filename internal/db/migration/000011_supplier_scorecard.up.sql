@@ -0,0 +1,33 @@
+CREATE TABLE "purchase_order_receipts" (
+  "receipt_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "po_item_id" int NOT NULL,
+  "quantity" int NOT NULL,
+  "received_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+  "received_by" int
+);
+
+ALTER TABLE "suppliers" ADD COLUMN "rated_at" timestamp;
+
+CREATE INDEX ON "purchase_order_receipts" ("po_item_id", "received_at");
+
+COMMENT ON COLUMN "purchase_order_receipts"."quantity" IS 'Base units received in this delivery';
+
+COMMENT ON COLUMN "suppliers"."rated_at" IS 'When rating was last recalculated from the supplier scorecard';
+
+ALTER TABLE "purchase_order_receipts" ADD FOREIGN KEY ("po_item_id") REFERENCES "purchase_order_items" ("po_item_id");
+
+ALTER TABLE "purchase_order_receipts" ADD FOREIGN KEY ("received_by") REFERENCES "users" ("user_id");
+
+-- Earlier receipts were not dated; date them by their stock movement where
+-- there is one, otherwise by the order's last update.
+INSERT INTO "purchase_order_receipts" ("po_item_id", "quantity", "received_at")
+SELECT poi."po_item_id", poi."quantity_received",
+       COALESCE(
+         (SELECT MAX(sm."movement_date") FROM "stock_movements" sm
+          WHERE sm."reference_table" = 'purchase_order_items'
+            AND sm."reference_id" = poi."po_item_id"
+            AND sm."movement_type" = 'purchase_receipt'),
+         po."updated_at")
+FROM "purchase_order_items" poi
+JOIN "purchase_orders" po ON po."po_id" = poi."po_id"
+WHERE poi."quantity_received" > 0;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "product_suppliers_one_primary";
+DROP TABLE IF EXISTS "product_supplier_prices";
@@ -0,0 +1,42 @@
+CREATE TABLE "product_supplier_prices" (
+  "price_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "product_supplier_id" int NOT NULL,
+  "unit_price" decimal(10,2) NOT NULL,
+  "effective_from" date NOT NULL,
+  "source" varchar(20) NOT NULL DEFAULT 'manual',
+  "created_by" int,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE UNIQUE INDEX ON "product_supplier_prices" ("product_supplier_id", "effective_from");
+
+-- Older links may have several primary suppliers per product; keep the one
+-- with the lowest ID and make the others secondary.
+UPDATE "product_suppliers" ps
+SET "priority" = 2
+WHERE ps."priority" = 1
+  AND ps."is_active" = true
+  AND EXISTS (
+    SELECT 1 FROM "product_suppliers" other
+    WHERE other."product_id" = ps."product_id"
+      AND other."priority" = 1
+      AND other."is_active" = true
+      AND other."product_supplier_id" < ps."product_supplier_id"
+  );
+
+CREATE UNIQUE INDEX "product_suppliers_one_primary" ON "product_suppliers" ("product_id") WHERE "priority" = 1 AND "is_active" = true;
+
+COMMENT ON COLUMN "product_supplier_prices"."unit_price" IS 'Price of one purchase unit of the link';
+
+COMMENT ON COLUMN "product_supplier_prices"."effective_from" IS 'First day the price applies; product_suppliers.unit_price holds the latest price in effect';
+
+COMMENT ON COLUMN "product_supplier_prices"."source" IS 'manual or price_list';
+
+ALTER TABLE "product_supplier_prices" ADD FOREIGN KEY ("product_supplier_id") REFERENCES "product_suppliers" ("product_supplier_id");
+
+ALTER TABLE "product_supplier_prices" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("user_id");
+
+INSERT INTO "product_supplier_prices" ("product_supplier_id", "unit_price", "effective_from")
+SELECT "product_supplier_id", "unit_price", COALESCE("last_order_date", CURRENT_DATE)
+FROM "product_suppliers"
+WHERE "unit_price" IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "opening_balances";
+DROP TABLE IF EXISTS "import_batches";
+
+-- PostgreSQL cannot drop a value from an enum; 'opening_balance' stays in
+-- movement_type.
@@ -0,0 +1,47 @@
+ALTER TYPE "movement_type" ADD VALUE IF NOT EXISTS 'opening_balance';
+
+CREATE TABLE "import_batches" (
+  "batch_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "kind" varchar(30) NOT NULL,
+  "file_name" varchar(255),
+  "format" varchar(10) NOT NULL,
+  "total_rows" int NOT NULL DEFAULT 0,
+  "imported_rows" int NOT NULL DEFAULT 0,
+  "failed_rows" int NOT NULL DEFAULT 0,
+  "created_by" int,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "opening_balances" (
+  "opening_balance_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "batch_id" int NOT NULL,
+  "line_number" int NOT NULL,
+  "product_id" int NOT NULL,
+  "warehouse_id" int NOT NULL,
+  "location_id" int NOT NULL,
+  "quantity" int NOT NULL,
+  "unit_cost" decimal(12,4),
+  "batch_number" varchar(100),
+  "expiry_date" date,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE INDEX ON "opening_balances" ("batch_id");
+
+COMMENT ON COLUMN "import_batches"."kind" IS 'products, suppliers, categories, locations or opening_stock';
+
+COMMENT ON COLUMN "import_batches"."total_rows" IS 'Data rows in the file, whether imported or not';
+
+COMMENT ON COLUMN "opening_balances"."line_number" IS 'Line of the import file the balance came from';
+
+COMMENT ON COLUMN "opening_balances"."unit_cost" IS 'Value of one unit in the base currency; the product cost price when NULL';
+
+ALTER TABLE "import_batches" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("user_id");
+
+ALTER TABLE "opening_balances" ADD FOREIGN KEY ("batch_id") REFERENCES "import_batches" ("batch_id");
+
+ALTER TABLE "opening_balances" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "opening_balances" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
+
+ALTER TABLE "opening_balances" ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("location_id");
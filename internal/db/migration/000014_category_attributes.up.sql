@@ -0,0 +1,42 @@
+CREATE TYPE "attribute_data_type" AS ENUM (
+  'text',
+  'integer',
+  'decimal',
+  'boolean',
+  'date',
+  'enum'
+);
+
+CREATE TABLE "category_attributes" (
+  "attribute_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "category_id" int NOT NULL,
+  "attribute_code" varchar(50) NOT NULL,
+  "name" varchar(100) NOT NULL,
+  "data_type" attribute_data_type NOT NULL,
+  "is_required" boolean NOT NULL DEFAULT false,
+  "allowed_values" jsonb,
+  "unit" varchar(20),
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "product_attributes" (
+  "product_id" int PRIMARY KEY,
+  "attributes" jsonb NOT NULL DEFAULT '{}',
+  "updated_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE UNIQUE INDEX ON "category_attributes" ("category_id", "attribute_code");
+
+CREATE INDEX ON "product_attributes" USING GIN ("attributes");
+
+COMMENT ON COLUMN "category_attributes"."attribute_code" IS 'Key of the value in product_attributes.attributes; a subcategory defining the same code overrides the inherited definition';
+
+COMMENT ON COLUMN "category_attributes"."allowed_values" IS 'JSON array of the permitted strings; enum attributes only';
+
+COMMENT ON COLUMN "category_attributes"."unit" IS 'Unit the value is expressed in, e.g. V or kg; informational';
+
+COMMENT ON COLUMN "product_attributes"."attributes" IS 'Attribute code to value, validated against the category schema when written';
+
+ALTER TABLE "category_attributes" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("category_id");
+
+ALTER TABLE "product_attributes" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("product_id");
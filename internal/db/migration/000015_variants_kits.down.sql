@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "kit_assembly_lines";
+DROP TABLE IF EXISTS "kit_assemblies";
+DROP TABLE IF EXISTS "kit_components";
+DROP TABLE IF EXISTS "product_variants";
+DROP TABLE IF EXISTS "product_variant_axes";
+DROP TYPE IF EXISTS "assembly_direction";
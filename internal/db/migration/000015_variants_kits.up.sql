@@ -0,0 +1,101 @@
+CREATE TYPE "assembly_direction" AS ENUM (
+  'assemble',
+  'disassemble'
+);
+
+CREATE TABLE "product_variant_axes" (
+  "axis_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "parent_product_id" int NOT NULL,
+  "name" varchar(50) NOT NULL,
+  "position" int NOT NULL DEFAULT 0,
+  "axis_values" jsonb NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "product_variants" (
+  "product_id" int PRIMARY KEY,
+  "parent_product_id" int NOT NULL,
+  "options" jsonb NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "kit_components" (
+  "kit_component_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "kit_product_id" int NOT NULL,
+  "component_product_id" int NOT NULL,
+  "quantity" int NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+  CHECK ("quantity" > 0),
+  CHECK ("kit_product_id" <> "component_product_id")
+);
+
+CREATE TABLE "kit_assemblies" (
+  "assembly_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "kit_product_id" int NOT NULL,
+  "warehouse_id" int NOT NULL,
+  "location_id" int NOT NULL,
+  "direction" assembly_direction NOT NULL,
+  "quantity" int NOT NULL,
+  "unit_cost" decimal(12,4) NOT NULL DEFAULT 0,
+  "total_cost" decimal(14,4) NOT NULL DEFAULT 0,
+  "notes" text,
+  "created_by" int,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "kit_assembly_lines" (
+  "line_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "assembly_id" int NOT NULL,
+  "component_product_id" int NOT NULL,
+  "quantity" int NOT NULL,
+  "unit_cost" decimal(12,4) NOT NULL DEFAULT 0,
+  "total_cost" decimal(14,4) NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX ON "product_variant_axes" ("parent_product_id", "name");
+
+CREATE INDEX ON "product_variants" ("parent_product_id");
+
+CREATE UNIQUE INDEX ON "product_variants" ("parent_product_id", "options");
+
+CREATE UNIQUE INDEX ON "kit_components" ("kit_product_id", "component_product_id");
+
+CREATE INDEX ON "kit_components" ("component_product_id");
+
+CREATE INDEX ON "kit_assemblies" ("kit_product_id", "created_at");
+
+CREATE INDEX ON "kit_assembly_lines" ("assembly_id");
+
+COMMENT ON COLUMN "product_variant_axes"."axis_values" IS 'JSON array of the values in display order, e.g. ["S", "M", "L"]';
+
+COMMENT ON COLUMN "product_variants"."options" IS 'Axis name to value, e.g. {"size": "M", "colour": "Red"}';
+
+COMMENT ON COLUMN "kit_components"."quantity" IS 'Units of the component in one kit';
+
+COMMENT ON COLUMN "kit_assemblies"."location_id" IS 'Where the kits (assemble) or the components (disassemble) were put away';
+
+COMMENT ON COLUMN "kit_assemblies"."unit_cost" IS 'Cost of one kit: the consumed components when assembling, the issued kits when disassembling';
+
+COMMENT ON COLUMN "kit_assembly_lines"."unit_cost" IS 'Cost of one unit of the component consumed or recovered';
+
+ALTER TABLE "product_variant_axes" ADD FOREIGN KEY ("parent_product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "product_variants" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "product_variants" ADD FOREIGN KEY ("parent_product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "kit_components" ADD FOREIGN KEY ("kit_product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "kit_components" ADD FOREIGN KEY ("component_product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "kit_assemblies" ADD FOREIGN KEY ("kit_product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "kit_assemblies" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
+
+ALTER TABLE "kit_assemblies" ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("location_id");
+
+ALTER TABLE "kit_assemblies" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("user_id");
+
+ALTER TABLE "kit_assembly_lines" ADD FOREIGN KEY ("assembly_id") REFERENCES "kit_assemblies" ("assembly_id");
+
+ALTER TABLE "kit_assembly_lines" ADD FOREIGN KEY ("component_product_id") REFERENCES "products" ("product_id");
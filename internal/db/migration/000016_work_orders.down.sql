@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "work_order_consumptions";
+DROP TABLE IF EXISTS "work_order_reservations";
+DROP TABLE IF EXISTS "work_order_components";
+DROP TABLE IF EXISTS "work_orders";
+DROP TYPE IF EXISTS "work_order_status";
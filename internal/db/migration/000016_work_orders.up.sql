@@ -0,0 +1,124 @@
+CREATE TYPE "work_order_status" AS ENUM (
+  'draft',
+  'released',
+  'in_progress',
+  'completed',
+  'cancelled'
+);
+
+CREATE TABLE "work_orders" (
+  "work_order_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "work_order_number" varchar(30) UNIQUE,
+  "product_id" int NOT NULL,
+  "warehouse_id" int NOT NULL,
+  "output_location_id" int NOT NULL,
+  "status" work_order_status NOT NULL DEFAULT 'draft',
+  "planned_quantity" int NOT NULL,
+  "produced_quantity" int NOT NULL DEFAULT 0,
+  "scrap_quantity" int NOT NULL DEFAULT 0,
+  "output_batch_number" varchar(100),
+  "expiry_date" date,
+  "unit_cost" decimal(12,4) NOT NULL DEFAULT 0,
+  "total_cost" decimal(14,4) NOT NULL DEFAULT 0,
+  "notes" text,
+  "created_by" int,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+  "released_at" timestamp,
+  "completed_at" timestamp,
+  CHECK ("planned_quantity" > 0)
+);
+
+CREATE TABLE "work_order_components" (
+  "wo_component_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "work_order_id" int NOT NULL,
+  "component_product_id" int NOT NULL,
+  "quantity_per" int NOT NULL,
+  "planned_quantity" int NOT NULL,
+  "consumed_quantity" int NOT NULL DEFAULT 0,
+  "scrap_quantity" int NOT NULL DEFAULT 0
+);
+
+CREATE TABLE "work_order_reservations" (
+  "reservation_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "work_order_id" int NOT NULL,
+  "wo_component_id" int NOT NULL,
+  "inventory_id" int NOT NULL,
+  "quantity" int NOT NULL,
+  "open_quantity" int NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "work_order_consumptions" (
+  "consumption_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "work_order_id" int NOT NULL,
+  "wo_component_id" int NOT NULL,
+  "component_product_id" int NOT NULL,
+  "inventory_id" int NOT NULL,
+  "movement_id" int NOT NULL,
+  "batch_number" varchar(100),
+  "quantity" int NOT NULL,
+  "is_scrap" boolean NOT NULL DEFAULT false,
+  "total_cost" decimal(14,4) NOT NULL DEFAULT 0,
+  "created_by" int,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE INDEX ON "work_orders" ("status");
+
+CREATE INDEX ON "work_orders" ("product_id", "output_batch_number");
+
+CREATE UNIQUE INDEX ON "work_order_components" ("work_order_id", "component_product_id");
+
+CREATE INDEX ON "work_order_reservations" ("wo_component_id");
+
+CREATE INDEX ON "work_order_reservations" ("inventory_id") WHERE "open_quantity" > 0;
+
+CREATE INDEX ON "work_order_consumptions" ("work_order_id");
+
+CREATE INDEX ON "work_order_consumptions" ("component_product_id", "batch_number");
+
+COMMENT ON COLUMN "work_orders"."output_batch_number" IS 'Lot the output is received under; the work order number when not given';
+
+COMMENT ON COLUMN "work_orders"."scrap_quantity" IS 'Output units made but rejected; they are never received';
+
+COMMENT ON COLUMN "work_orders"."unit_cost" IS 'Cost of one good unit: all consumed components, scrap included, over the produced quantity';
+
+COMMENT ON COLUMN "work_order_components"."quantity_per" IS 'Units of the component in one unit of output, copied from the bill of materials when the order was created';
+
+COMMENT ON COLUMN "work_order_components"."consumed_quantity" IS 'Units issued to the work order, scrapped ones included'
+
+COMMENT ON COLUMN "work_order_components"."scrap_quantity" IS 'Part of the consumed quantity that was wasted rather than built into the output'
+
+COMMENT ON COLUMN "work_order_reservations"."open_quantity" IS 'Part of the reservation still held on the inventory row; consumption and release reduce it';
+
+COMMENT ON COLUMN "work_order_consumptions"."batch_number" IS 'Component lot consumed, for tracing output lots back to their components';
+
+ALTER TABLE "work_orders" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "work_orders" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
+
+ALTER TABLE "work_orders" ADD FOREIGN KEY ("output_location_id") REFERENCES "locations" ("location_id");
+
+ALTER TABLE "work_orders" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("user_id");
+
+ALTER TABLE "work_order_components" ADD FOREIGN KEY ("work_order_id") REFERENCES "work_orders" ("work_order_id");
+
+ALTER TABLE "work_order_components" ADD FOREIGN KEY ("component_product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "work_order_reservations" ADD FOREIGN KEY ("work_order_id") REFERENCES "work_orders" ("work_order_id");
+
+ALTER TABLE "work_order_reservations" ADD FOREIGN KEY ("wo_component_id") REFERENCES "work_order_components" ("wo_component_id");
+
+ALTER TABLE "work_order_reservations" ADD FOREIGN KEY ("inventory_id") REFERENCES "inventory" ("inventory_id");
+
+ALTER TABLE "work_order_consumptions" ADD FOREIGN KEY ("work_order_id") REFERENCES "work_orders" ("work_order_id");
+
+ALTER TABLE "work_order_consumptions" ADD FOREIGN KEY ("wo_component_id") REFERENCES "work_order_components" ("wo_component_id");
+
+ALTER TABLE "work_order_consumptions" ADD FOREIGN KEY ("component_product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "work_order_consumptions" ADD FOREIGN KEY ("inventory_id") REFERENCES "inventory" ("inventory_id");
+
+ALTER TABLE "work_order_consumptions" ADD FOREIGN KEY ("movement_id") REFERENCES "stock_movements" ("movement_id");
+
+ALTER TABLE "work_order_consumptions" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("user_id");
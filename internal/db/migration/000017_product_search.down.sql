@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "product_uoms_barcode_idx";
+DROP INDEX IF EXISTS "products_barcode_idx";
+DROP INDEX IF EXISTS "products_sku_trgm_idx";
+DROP INDEX IF EXISTS "products_name_trgm_idx";
+DROP INDEX IF EXISTS "products_search_document_idx";
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,13 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX "products_search_document_idx" ON "products" USING GIN (
+  to_tsvector('simple', "sku" || ' ' || "name" || ' ' || COALESCE("description", '') || ' ' || COALESCE("barcode", ''))
+);
+
+CREATE INDEX "products_name_trgm_idx" ON "products" USING GIN (lower("name") gin_trgm_ops);
+
+CREATE INDEX "products_sku_trgm_idx" ON "products" USING GIN (lower("sku") gin_trgm_ops);
+
+CREATE INDEX "products_barcode_idx" ON "products" ("barcode");
+
+CREATE INDEX "product_uoms_barcode_idx" ON "product_uoms" ("barcode");
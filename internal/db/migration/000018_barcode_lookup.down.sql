@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "product_identifiers_value_idx";
+DROP INDEX IF EXISTS "inventory_product_batch_idx";
+DROP INDEX IF EXISTS "product_uoms_gtin_idx";
+DROP INDEX IF EXISTS "products_gtin_idx";
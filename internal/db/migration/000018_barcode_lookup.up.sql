@@ -0,0 +1,7 @@
+CREATE INDEX "products_gtin_idx" ON "products" (lpad("barcode", 14, '0')) WHERE length("barcode") <= 14;
+
+CREATE INDEX "product_uoms_gtin_idx" ON "product_uoms" (lpad("barcode", 14, '0')) WHERE length("barcode") <= 14;
+
+CREATE INDEX "inventory_product_batch_idx" ON "inventory" ("product_id", "batch_number");
+
+CREATE INDEX "product_identifiers_value_idx" ON "product_identifiers" ("identifier_value");
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "floor_tasks";
+DROP TYPE IF EXISTS "floor_task_exception";
+DROP TYPE IF EXISTS "floor_task_status";
+DROP TYPE IF EXISTS "floor_task_type";
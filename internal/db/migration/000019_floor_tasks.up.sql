@@ -0,0 +1,82 @@
+CREATE TYPE "floor_task_type" AS ENUM (
+  'putaway',
+  'pick',
+  'count',
+  'move'
+);
+
+CREATE TYPE "floor_task_status" AS ENUM (
+  'open',
+  'assigned',
+  'completed',
+  'exception',
+  'cancelled'
+);
+
+CREATE TYPE "floor_task_exception" AS ENUM (
+  'short_pick',
+  'damaged',
+  'not_found',
+  'wrong_item',
+  'location_blocked'
+);
+
+CREATE TABLE "floor_tasks" (
+  "task_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "warehouse_id" int NOT NULL,
+  "task_type" floor_task_type NOT NULL,
+  "status" floor_task_status NOT NULL DEFAULT 'open',
+  "priority" int NOT NULL DEFAULT 5,
+  "product_id" int NOT NULL,
+  "from_location_id" int,
+  "to_location_id" int,
+  "quantity" int NOT NULL DEFAULT 0,
+  "quantity_done" int,
+  "batch_number" varchar(100),
+  "serial_number" varchar(100),
+  "reference_table" varchar(50),
+  "reference_id" int,
+  "device_id" varchar(100),
+  "assigned_to" int,
+  "assigned_at" timestamp,
+  "request_id" varchar(64),
+  "exception" floor_task_exception,
+  "exception_notes" varchar(255),
+  "completed_at" timestamp,
+  "notes" varchar(255),
+  "created_by" int,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+  CHECK ("quantity" >= 0)
+);
+
+CREATE INDEX ON "floor_tasks" ("warehouse_id", "status", "priority");
+
+CREATE INDEX ON "floor_tasks" ("device_id") WHERE "status" = 'assigned';
+
+CREATE UNIQUE INDEX "floor_tasks_reference_idx" ON "floor_tasks" ("reference_table", "reference_id") WHERE "status" <> 'cancelled';
+
+COMMENT ON COLUMN "floor_tasks"."priority" IS 'Lower runs first, as for picking waves; pick tasks take their wave''s priority';
+
+COMMENT ON COLUMN "floor_tasks"."from_location_id" IS 'Location the device scans to start the task: the pick face, the counted location or the source of a putaway or move';
+
+COMMENT ON COLUMN "floor_tasks"."to_location_id" IS 'Destination of a move; for a putaway the suggested location, replaced by the one scanned';
+
+COMMENT ON COLUMN "floor_tasks"."quantity" IS 'Units to pick, put away or move; 0 for counts, which are blind';
+
+COMMENT ON COLUMN "floor_tasks"."reference_table" IS 'picking_wave_items for pick tasks and stocktake_items for count tasks; those tasks are generated from the open lines';
+
+COMMENT ON COLUMN "floor_tasks"."device_id" IS 'Handheld the task is assigned to, as in location_history';
+
+COMMENT ON COLUMN "floor_tasks"."request_id" IS 'Client id of the confirmation or exception that closed the task; a retry with the same id gets the closed task back instead of posting twice';
+
+ALTER TABLE "floor_tasks" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("warehouse_id");
+
+ALTER TABLE "floor_tasks" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("product_id");
+
+ALTER TABLE "floor_tasks" ADD FOREIGN KEY ("from_location_id") REFERENCES "locations" ("location_id");
+
+ALTER TABLE "floor_tasks" ADD FOREIGN KEY ("to_location_id") REFERENCES "locations" ("location_id");
+
+ALTER TABLE "floor_tasks" ADD FOREIGN KEY ("assigned_to") REFERENCES "users" ("user_id");
+
+ALTER TABLE "floor_tasks" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("user_id");
@@ -0,0 +1,26 @@
+CREATE TABLE "idempotency_keys" (
+  "idempotency_key" varchar(255) NOT NULL,
+  "caller_hash" varchar(64) NOT NULL,
+  "request_method" varchar(10) NOT NULL,
+  "request_path" varchar(2000) NOT NULL,
+  "request_hash" varchar(64) NOT NULL,
+  "status_code" int,
+  "response_headers" jsonb NOT NULL DEFAULT '{}',
+  "response_body" bytea,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+  "completed_at" timestamp,
+  "expires_at" timestamp NOT NULL,
+  PRIMARY KEY ("caller_hash", "idempotency_key")
+);
+
+CREATE INDEX "idempotency_keys_expires_at_idx" ON "idempotency_keys" ("expires_at");
+
+COMMENT ON COLUMN "idempotency_keys"."caller_hash" IS 'SHA-256 of the Authorization header; each caller has keys of its own';
+
+COMMENT ON COLUMN "idempotency_keys"."request_hash" IS 'SHA-256 of the method, path and body; a key sent again with a different request is rejected';
+
+COMMENT ON COLUMN "idempotency_keys"."status_code" IS 'Status of the stored response; null while the first request is still being processed';
+
+COMMENT ON COLUMN "idempotency_keys"."response_headers" IS 'Headers the handler set on the stored response, such as Content-Type, ETag and Location';
+
+COMMENT ON COLUMN "idempotency_keys"."expires_at" IS 'After this the key is forgotten and may be used for a new request';
@@ -0,0 +1,2 @@
+ALTER TABLE "suppliers" DROP COLUMN IF EXISTS "updated_at";
+ALTER TABLE "warehouses" DROP COLUMN IF EXISTS "updated_at";
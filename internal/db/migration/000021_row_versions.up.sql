@@ -0,0 +1,11 @@
+ALTER TABLE "warehouses" ADD COLUMN "updated_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP);
+
+ALTER TABLE "suppliers" ADD COLUMN "updated_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP);
+
+UPDATE "warehouses" SET "updated_at" = "created_at";
+
+UPDATE "suppliers" SET "updated_at" = COALESCE("rated_at", "created_at");
+
+COMMENT ON COLUMN "warehouses"."updated_at" IS 'Set by every update; the warehouse ETag is derived from it';
+
+COMMENT ON COLUMN "suppliers"."updated_at" IS 'Set by every update; the supplier ETag is derived from it';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "webhook_delivery_attempts";
+DROP TABLE IF EXISTS "webhook_deliveries";
+DROP TABLE IF EXISTS "webhook_events";
+DROP TABLE IF EXISTS "webhook_subscription_events";
+DROP TABLE IF EXISTS "webhook_subscriptions";
+DROP TYPE IF EXISTS "webhook_delivery_status";
@@ -0,0 +1,81 @@
+CREATE TYPE "webhook_delivery_status" AS ENUM (
+  'pending',
+  'delivered',
+  'failed'
+);
+
+CREATE TABLE "webhook_subscriptions" (
+  "subscription_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "url" varchar(2000) NOT NULL,
+  "secret" varchar(255) NOT NULL,
+  "description" varchar(255),
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_by" int,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+  "updated_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "webhook_subscription_events" (
+  "subscription_id" int NOT NULL,
+  "event_type" varchar(50) NOT NULL,
+  PRIMARY KEY ("subscription_id", "event_type"),
+  CHECK ("event_type" IN ('inventory.changed', 'po.received', 'transfer.completed', 'stock.below_reorder'))
+);
+
+CREATE INDEX ON "webhook_subscription_events" ("event_type");
+
+CREATE TABLE "webhook_events" (
+  "event_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "event_type" varchar(50) NOT NULL,
+  "payload" jsonb NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE TABLE "webhook_deliveries" (
+  "delivery_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "event_id" int NOT NULL,
+  "subscription_id" int NOT NULL,
+  "status" webhook_delivery_status NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "next_attempt_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+  "last_attempt_at" timestamp,
+  "response_status" int,
+  "last_error" varchar(1000),
+  "delivered_at" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE UNIQUE INDEX ON "webhook_deliveries" ("event_id", "subscription_id");
+
+CREATE INDEX ON "webhook_deliveries" ("subscription_id", "created_at");
+
+CREATE INDEX "webhook_deliveries_due_idx" ON "webhook_deliveries" ("next_attempt_at") WHERE "status" = 'pending';
+
+CREATE TABLE "webhook_delivery_attempts" (
+  "attempt_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "delivery_id" int NOT NULL,
+  "response_status" int,
+  "error" varchar(1000),
+  "duration_ms" int NOT NULL DEFAULT 0,
+  "attempted_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE INDEX ON "webhook_delivery_attempts" ("delivery_id");
+
+COMMENT ON COLUMN "webhook_subscriptions"."secret" IS 'Key of the HMAC-SHA256 signature sent with every delivery';
+
+COMMENT ON COLUMN "webhook_events"."payload" IS 'Event data, written in the same transaction as the change it describes';
+
+COMMENT ON COLUMN "webhook_deliveries"."next_attempt_at" IS 'When a pending delivery is next due; pushed forward while a worker is sending it and by the backoff after a failure';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'failed once every attempt has failed; a redelivery makes it pending again';
+
+ALTER TABLE "webhook_subscriptions" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("user_id");
+
+ALTER TABLE "webhook_subscription_events" ADD FOREIGN KEY ("subscription_id") REFERENCES "webhook_subscriptions" ("subscription_id");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("event_id") REFERENCES "webhook_events" ("event_id");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("subscription_id") REFERENCES "webhook_subscriptions" ("subscription_id");
+
+ALTER TABLE "webhook_delivery_attempts" ADD FOREIGN KEY ("delivery_id") REFERENCES "webhook_deliveries" ("delivery_id");
@@ -0,0 +1,83 @@
+-- name: CreateCategoryAttribute :one
+INSERT INTO category_attributes (
+    category_id, attribute_code, name, data_type, is_required, allowed_values, unit
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetCategoryAttribute :one
+SELECT * FROM category_attributes
+WHERE attribute_id = $1;
+
+-- name: ListCategoryAttributes :many
+SELECT * FROM category_attributes
+WHERE category_id = $1
+ORDER BY attribute_code;
+
+-- name: ListEffectiveCategoryAttributes :many
+WITH RECURSIVE up AS (
+    SELECT c.category_id, c.parent_category_id, 0 AS height, ARRAY[c.category_id] AS seen
+    FROM categories c
+    WHERE c.category_id = $1
+    UNION ALL
+    SELECT p.category_id, p.parent_category_id, up.height + 1, up.seen || p.category_id
+    FROM categories p
+    JOIN up ON p.category_id = up.parent_category_id
+    WHERE NOT p.category_id = ANY(up.seen)
+)
+SELECT DISTINCT ON (ca.attribute_code) ca.*, (up.height > 0)::boolean AS inherited
+FROM category_attributes ca
+JOIN up ON up.category_id = ca.category_id
+ORDER BY ca.attribute_code, up.height;
+
+-- name: UpdateCategoryAttribute :one
+UPDATE category_attributes
+SET name = $2,
+    data_type = $3,
+    is_required = $4,
+    allowed_values = $5,
+    unit = $6
+WHERE attribute_id = $1
+RETURNING *;
+
+-- name: DeleteCategoryAttribute :exec
+DELETE FROM category_attributes
+WHERE attribute_id = $1;
+
+-- name: DeleteCategoryAttributesByCategory :exec
+DELETE FROM category_attributes
+WHERE category_id = $1;
+
+-- name: GetProductAttributes :one
+SELECT * FROM product_attributes
+WHERE product_id = $1;
+
+-- name: UpsertProductAttributes :one
+INSERT INTO product_attributes (product_id, attributes)
+VALUES ($1, $2)
+ON CONFLICT (product_id) DO UPDATE
+SET attributes = EXCLUDED.attributes,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListProductsByAttributes :many
+WITH RECURSIVE subtree AS (
+    SELECT category_id, ARRAY[category_id] AS path
+    FROM categories
+    WHERE category_id = sqlc.narg(category_id)::int
+    UNION ALL
+    SELECT c.category_id, subtree.path || c.category_id
+    FROM categories c
+    JOIN subtree ON c.parent_category_id = subtree.category_id
+    WHERE sqlc.arg(include_descendants)::boolean AND NOT c.category_id = ANY(subtree.path)
+)
+SELECT p.* FROM products p
+JOIN product_attributes pa ON pa.product_id = p.product_id
+WHERE p.is_active = true
+  AND (sqlc.narg(category_id)::int IS NULL OR p.category_id IN (SELECT category_id FROM subtree))
+  AND NOT EXISTS (
+      SELECT 1 FROM jsonb_each_text(sqlc.arg(filter)::jsonb) f
+      WHERE pa.attributes ->> f.key IS DISTINCT FROM f.value
+  )
+ORDER BY p.product_id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
@@ -40,3 +40,78 @@ RETURNING *;
 -- name: DeleteCategory :exec
 DELETE FROM categories 
 WHERE category_id = $1;
+
+-- name: LockCategoryTree :exec
+SELECT pg_advisory_xact_lock(hashtext('categories'));
+
+-- name: ListCategoryTree :many
+WITH RECURSIVE tree AS (
+    SELECT c.category_id, 0 AS depth, ARRAY[c.category_id] AS path
+    FROM categories c
+    WHERE (sqlc.narg(root_id)::int IS NULL AND c.parent_category_id IS NULL)
+       OR c.category_id = sqlc.narg(root_id)::int
+    UNION ALL
+    SELECT c.category_id, t.depth + 1, t.path || c.category_id
+    FROM categories c
+    JOIN tree t ON c.parent_category_id = t.category_id
+    WHERE NOT c.category_id = ANY(t.path)
+)
+SELECT c.*, t.depth::int AS depth,
+       (SELECT COUNT(*) FROM products p WHERE p.category_id = c.category_id AND p.is_active = true) AS product_count
+FROM tree t
+JOIN categories c ON c.category_id = t.category_id
+ORDER BY t.path;
+
+-- name: GetCategoryPath :many
+WITH RECURSIVE up AS (
+    SELECT c.category_id, c.parent_category_id, 0 AS height, ARRAY[c.category_id] AS seen
+    FROM categories c
+    WHERE c.category_id = $1
+    UNION ALL
+    SELECT p.category_id, p.parent_category_id, up.height + 1, up.seen || p.category_id
+    FROM categories p
+    JOIN up ON p.category_id = up.parent_category_id
+    WHERE NOT p.category_id = ANY(up.seen)
+)
+SELECT c.*
+FROM up
+JOIN categories c ON c.category_id = up.category_id
+ORDER BY up.height DESC;
+
+-- name: ListCategoryDescendantIDs :many
+WITH RECURSIVE down AS (
+    SELECT category_id, ARRAY[category_id] AS path
+    FROM categories
+    WHERE category_id = $1
+    UNION ALL
+    SELECT c.category_id, down.path || c.category_id
+    FROM categories c
+    JOIN down ON c.parent_category_id = down.category_id
+    WHERE NOT c.category_id = ANY(down.path)
+)
+SELECT category_id FROM down;
+
+-- name: MoveCategory :one
+UPDATE categories
+SET parent_category_id = $2
+WHERE category_id = $1
+RETURNING *;
+
+-- name: CountCategoryChildren :one
+SELECT COUNT(*) FROM categories
+WHERE parent_category_id = $1;
+
+-- name: CountCategoryProducts :one
+SELECT COUNT(*) FROM products
+WHERE category_id = $1;
+
+-- name: ReassignCategoryChildren :execrows
+UPDATE categories
+SET parent_category_id = sqlc.narg(new_parent_id)
+WHERE parent_category_id = sqlc.arg(category_id);
+
+-- name: ReassignCategoryProducts :execrows
+UPDATE products
+SET category_id = sqlc.narg(new_category_id),
+    updated_at = CURRENT_TIMESTAMP
+WHERE category_id = sqlc.arg(category_id);
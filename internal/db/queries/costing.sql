@@ -0,0 +1,128 @@
+-- name: SetProductCosting :one
+UPDATE products
+SET
+    costing_method = $2,
+    standard_cost = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE product_id = $1
+RETURNING *;
+
+-- name: EnsureCostBalance :exec
+INSERT INTO cost_balances (
+    product_id, warehouse_id
+) VALUES (
+    $1, $2
+) ON CONFLICT (product_id, warehouse_id) DO NOTHING;
+
+-- name: GetCostBalanceForUpdate :one
+SELECT * FROM cost_balances
+WHERE product_id = $1 AND warehouse_id = $2
+FOR UPDATE;
+
+-- name: ListCostBalancesByProductForUpdate :many
+SELECT * FROM cost_balances
+WHERE product_id = $1
+ORDER BY warehouse_id
+FOR UPDATE;
+
+-- name: UpdateCostBalance :one
+UPDATE cost_balances
+SET
+    quantity = quantity + $3,
+    total_value = total_value + $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE product_id = $1 AND warehouse_id = $2
+RETURNING *;
+
+-- name: CreateCostLayer :one
+INSERT INTO cost_layers (
+    product_id, warehouse_id, movement_id, po_item_id,
+    quantity_received, quantity_remaining, unit_cost
+) VALUES (
+    $1, $2, $3, $4, $5, $5, $6
+) RETURNING *;
+
+-- name: ListOpenCostLayersForUpdate :many
+SELECT * FROM cost_layers
+WHERE product_id = $1 AND warehouse_id = $2 AND quantity_remaining > 0
+ORDER BY received_at, layer_id
+FOR UPDATE;
+
+-- name: ListOpenCostLayers :many
+SELECT * FROM cost_layers
+WHERE product_id = $1 AND warehouse_id = $2 AND quantity_remaining > 0
+ORDER BY received_at, layer_id;
+
+-- name: ConsumeCostLayer :exec
+UPDATE cost_layers
+SET quantity_remaining = quantity_remaining - $2
+WHERE layer_id = $1;
+
+-- name: CloseCostLayersByProduct :exec
+UPDATE cost_layers
+SET quantity_remaining = 0
+WHERE product_id = $1 AND quantity_remaining > 0;
+
+-- name: CreateCostEntry :one
+INSERT INTO cost_entries (
+    product_id, warehouse_id, movement_id, entry_type,
+    costing_method, quantity, unit_cost, total_cost,
+    currency_code, exchange_rate
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: ListCostEntriesByMovement :many
+SELECT * FROM cost_entries
+WHERE movement_id = $1
+ORDER BY entry_id;
+
+-- name: CreatePurchasePriceVariance :one
+INSERT INTO purchase_price_variances (
+    product_id, warehouse_id, movement_id, po_item_id,
+    quantity, standard_cost, actual_cost, variance_amount
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: ListPurchasePriceVariances :many
+SELECT ppv.*, p.sku, p.name as product_name
+FROM purchase_price_variances ppv
+JOIN products p ON ppv.product_id = p.product_id
+WHERE ppv.created_at >= @from_date AND ppv.created_at < @to_date
+ORDER BY ppv.created_at, ppv.variance_id;
+
+-- name: GetValuationByWarehouse :many
+SELECT w.warehouse_id, w.code as warehouse_code, w.name as warehouse_name,
+       COUNT(cb.product_id) FILTER (WHERE cb.quantity <> 0) as product_count,
+       COALESCE(SUM(cb.quantity), 0)::int as quantity,
+       COALESCE(SUM(cb.total_value), 0)::numeric as total_value
+FROM warehouses w
+LEFT JOIN cost_balances cb ON cb.warehouse_id = w.warehouse_id
+GROUP BY w.warehouse_id, w.code, w.name
+ORDER BY w.code;
+
+-- name: GetValuationByCategory :many
+SELECT p.category_id, c.name as category_name,
+       COUNT(DISTINCT cb.product_id) FILTER (WHERE cb.quantity <> 0) as product_count,
+       COALESCE(SUM(cb.quantity), 0)::int as quantity,
+       COALESCE(SUM(cb.total_value), 0)::numeric as total_value
+FROM cost_balances cb
+JOIN products p ON cb.product_id = p.product_id
+LEFT JOIN categories c ON p.category_id = c.category_id
+WHERE (sqlc.narg(warehouse_id)::int IS NULL OR cb.warehouse_id = sqlc.narg(warehouse_id))
+GROUP BY p.category_id, c.name
+ORDER BY c.name NULLS LAST;
+
+-- name: GetValuationAsOf :many
+SELECT ce.warehouse_id, ce.product_id, p.sku, p.name as product_name, p.category_id,
+       SUM(ce.quantity)::int as quantity,
+       COALESCE(SUM(ce.total_cost), 0)::numeric as total_value
+FROM cost_entries ce
+JOIN products p ON ce.product_id = p.product_id
+WHERE ce.entry_date < sqlc.arg(before)
+  AND (sqlc.narg(warehouse_id)::int IS NULL OR ce.warehouse_id = sqlc.narg(warehouse_id))
+  AND (sqlc.narg(category_id)::int IS NULL OR p.category_id = sqlc.narg(category_id))
+GROUP BY ce.warehouse_id, ce.product_id, p.sku, p.name, p.category_id
+HAVING SUM(ce.quantity) <> 0 OR SUM(ce.total_cost) <> 0
+ORDER BY ce.warehouse_id, p.sku;
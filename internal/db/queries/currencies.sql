@@ -0,0 +1,46 @@
+-- name: CreateCurrency :one
+INSERT INTO currencies (
+    currency_code, name, is_base
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetCurrency :one
+SELECT * FROM currencies
+WHERE currency_code = $1;
+
+-- name: GetBaseCurrency :one
+SELECT * FROM currencies
+WHERE is_base = true;
+
+-- name: ListCurrencies :many
+SELECT * FROM currencies
+ORDER BY is_base DESC, currency_code;
+
+-- name: UpsertExchangeRate :one
+INSERT INTO exchange_rates (
+    currency_code, rate_date, rate
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (currency_code, rate_date) DO UPDATE
+SET rate = EXCLUDED.rate
+RETURNING *;
+
+-- name: ListExchangeRates :many
+SELECT * FROM exchange_rates
+WHERE currency_code = $1
+ORDER BY rate_date DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetExchangeRateOn :one
+SELECT * FROM exchange_rates
+WHERE currency_code = sqlc.arg(currency_code) AND rate_date <= sqlc.arg(on_date)
+ORDER BY rate_date DESC
+LIMIT 1;
+
+-- name: GetPurchaseOrderItemCurrency :one
+SELECT po.currency_code
+FROM purchase_order_items poi
+JOIN purchase_orders po ON poi.po_id = po.po_id
+WHERE poi.po_item_id = $1;
@@ -0,0 +1,37 @@
+-- name: GetWarehouseLetterhead :one
+SELECT * FROM warehouse_letterheads
+WHERE warehouse_id = $1;
+
+-- name: UpsertWarehouseLetterhead :one
+INSERT INTO warehouse_letterheads (
+    warehouse_id, header_template, footer_template
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (warehouse_id) DO UPDATE
+SET
+    header_template = EXCLUDED.header_template,
+    footer_template = EXCLUDED.footer_template,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetPurchaseOrderDocumentLines :many
+SELECT
+    poi.po_item_id,
+    p.sku,
+    p.name as product_name,
+    poi.quantity_ordered,
+    poi.unit_price,
+    poi.total_price,
+    u.code as uom_code,
+    COALESCE(pu.conversion_factor, 1)::int as conversion_factor,
+    (ps.unit_price / COALESCE(psu.conversion_factor, 1) * COALESCE(pu.conversion_factor, 1))::numeric(12,4) as list_price
+FROM purchase_order_items poi
+JOIN purchase_orders po ON poi.po_id = po.po_id
+JOIN products p ON poi.product_id = p.product_id
+LEFT JOIN units_of_measure u ON poi.uom_id = u.uom_id
+LEFT JOIN product_uoms pu ON pu.product_id = poi.product_id AND pu.uom_id = poi.uom_id
+LEFT JOIN product_suppliers ps ON ps.product_id = poi.product_id AND ps.supplier_id = po.supplier_id
+LEFT JOIN product_uoms psu ON psu.product_id = ps.product_id AND psu.uom_id = ps.purchase_uom_id
+WHERE poi.po_id = $1
+ORDER BY poi.po_item_id;
@@ -0,0 +1,150 @@
+-- name: CreateFloorTask :one
+INSERT INTO floor_tasks (
+    warehouse_id, task_type, priority, product_id, from_location_id, to_location_id,
+    quantity, batch_number, serial_number, notes, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: GetFloorTask :one
+SELECT * FROM floor_tasks
+WHERE task_id = $1;
+
+-- name: GetFloorTaskForUpdate :one
+SELECT * FROM floor_tasks
+WHERE task_id = $1
+FOR UPDATE;
+
+-- name: GetFloorTaskDetail :one
+SELECT
+    t.*,
+    p.sku,
+    p.name AS product_name,
+    fl.location_code AS from_location_code,
+    tl.location_code AS to_location_code
+FROM floor_tasks t
+JOIN products p ON p.product_id = t.product_id
+LEFT JOIN locations fl ON fl.location_id = t.from_location_id
+LEFT JOIN locations tl ON tl.location_id = t.to_location_id
+WHERE t.task_id = $1;
+
+-- name: ListFloorTasks :many
+SELECT * FROM floor_tasks
+WHERE (sqlc.narg(warehouse_id)::int IS NULL OR warehouse_id = sqlc.narg(warehouse_id))
+  AND (sqlc.narg(status)::floor_task_status IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(task_type)::floor_task_type IS NULL OR task_type = sqlc.narg(task_type))
+  AND (sqlc.narg(device_id)::text IS NULL OR device_id = sqlc.narg(device_id))
+ORDER BY priority, task_id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: GetDeviceFloorTask :one
+SELECT * FROM floor_tasks
+WHERE warehouse_id = $1 AND device_id = $2 AND status = 'assigned'
+ORDER BY assigned_at
+LIMIT 1;
+
+-- name: CancelStaleFloorTasks :execrows
+UPDATE floor_tasks t
+SET status = 'cancelled'
+WHERE t.warehouse_id = $1
+  AND t.status IN ('open', 'assigned')
+  AND (
+    (t.reference_table = 'picking_wave_items' AND NOT EXISTS (
+        SELECT 1
+        FROM picking_wave_items pwi
+        JOIN picking_waves pw ON pw.wave_id = pwi.wave_id
+        WHERE pwi.wave_item_id = t.reference_id
+          AND pwi.status = 'pending'
+          AND pw.status IN ('planned', 'in_progress')
+          AND pwi.quantity_to_pick - pwi.quantity_picked = t.quantity
+    ))
+    OR (t.reference_table = 'stocktake_items' AND NOT EXISTS (
+        SELECT 1
+        FROM stocktake_items si
+        JOIN stock_takes s ON s.stocktake_id = si.stocktake_id
+        WHERE si.stocktake_item_id = t.reference_id
+          AND si.counted_quantity IS NULL
+          AND s.status = 'in_progress'
+    ))
+  );
+
+-- name: CreatePickFloorTasks :execrows
+INSERT INTO floor_tasks (
+    warehouse_id, task_type, priority, product_id, from_location_id,
+    quantity, batch_number, serial_number, reference_table, reference_id
+)
+SELECT
+    pw.warehouse_id, 'pick', pw.priority, pwi.product_id, pwi.location_id,
+    pwi.quantity_to_pick - pwi.quantity_picked, i.batch_number, i.serial_number,
+    'picking_wave_items', pwi.wave_item_id
+FROM picking_wave_items pwi
+JOIN picking_waves pw ON pw.wave_id = pwi.wave_id
+JOIN inventory i ON i.inventory_id = pwi.inventory_id
+WHERE pw.warehouse_id = $1
+  AND pw.status IN ('planned', 'in_progress')
+  AND pwi.status = 'pending'
+  AND pwi.location_id IS NOT NULL
+ORDER BY pw.priority, pw.wave_id, pwi.pick_sequence NULLS LAST, pwi.wave_item_id
+ON CONFLICT (reference_table, reference_id) WHERE status <> 'cancelled' DO NOTHING;
+
+-- name: CreateCountFloorTasks :execrows
+INSERT INTO floor_tasks (
+    warehouse_id, task_type, product_id, from_location_id, reference_table, reference_id
+)
+SELECT
+    s.warehouse_id, 'count', si.product_id, si.location_id, 'stocktake_items', si.stocktake_item_id
+FROM stocktake_items si
+JOIN stock_takes s ON s.stocktake_id = si.stocktake_id
+WHERE s.warehouse_id = $1
+  AND s.status = 'in_progress'
+  AND si.counted_quantity IS NULL
+  AND si.location_id IS NOT NULL
+ORDER BY s.stocktake_id, si.stocktake_item_id
+ON CONFLICT (reference_table, reference_id) WHERE status <> 'cancelled' DO NOTHING;
+
+-- name: ClaimNextFloorTask :one
+UPDATE floor_tasks
+SET
+    status = 'assigned',
+    device_id = sqlc.arg(device_id),
+    assigned_to = sqlc.narg(assigned_to),
+    assigned_at = CURRENT_TIMESTAMP
+WHERE task_id = (
+    SELECT task_id FROM floor_tasks
+    WHERE warehouse_id = sqlc.arg(warehouse_id)
+      AND status = 'open'
+      AND (sqlc.narg(task_type)::floor_task_type IS NULL OR task_type = sqlc.narg(task_type))
+    ORDER BY priority, task_id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: ReleaseFloorTask :one
+UPDATE floor_tasks
+SET
+    status = 'open',
+    device_id = NULL,
+    assigned_to = NULL,
+    assigned_at = NULL
+WHERE task_id = $1 AND device_id = $2 AND status = 'assigned'
+RETURNING *;
+
+-- name: CloseFloorTask :one
+UPDATE floor_tasks
+SET
+    status = $2,
+    quantity_done = $3,
+    to_location_id = $4,
+    request_id = $5,
+    exception = $6,
+    exception_notes = $7,
+    completed_at = CURRENT_TIMESTAMP
+WHERE task_id = $1
+RETURNING *;
+
+-- name: CancelFloorTask :one
+UPDATE floor_tasks
+SET status = 'cancelled'
+WHERE task_id = $1 AND status IN ('open', 'assigned', 'exception')
+RETURNING *;
@@ -0,0 +1,41 @@
+-- name: ClaimIdempotencyKey :one
+INSERT INTO idempotency_keys (
+    idempotency_key, caller_hash, request_method, request_path, request_hash, expires_at
+) VALUES (
+    sqlc.arg(idempotency_key), sqlc.arg(caller_hash), sqlc.arg(request_method), sqlc.arg(request_path), sqlc.arg(request_hash),
+    CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg(ttl_seconds)::int)
+)
+ON CONFLICT (caller_hash, idempotency_key) DO UPDATE
+SET
+    request_method = EXCLUDED.request_method,
+    request_path = EXCLUDED.request_path,
+    request_hash = EXCLUDED.request_hash,
+    status_code = NULL,
+    response_headers = '{}',
+    response_body = NULL,
+    created_at = CURRENT_TIMESTAMP,
+    completed_at = NULL,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE caller_hash = $1 AND idempotency_key = $2;
+
+-- name: SaveIdempotencyResponse :exec
+UPDATE idempotency_keys
+SET
+    status_code = $3,
+    response_headers = $4,
+    response_body = $5,
+    completed_at = CURRENT_TIMESTAMP
+WHERE caller_hash = $1 AND idempotency_key = $2;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE caller_hash = $1 AND idempotency_key = $2;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= CURRENT_TIMESTAMP;
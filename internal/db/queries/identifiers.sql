@@ -0,0 +1,40 @@
+-- name: GetProductIdentifierForUpdate :one
+SELECT * FROM product_identifiers
+WHERE product_id = $1
+  AND identifier_type = $2
+  AND identifier_value = $3
+ORDER BY identifier_id
+LIMIT 1
+FOR UPDATE;
+
+-- name: CreateProductIdentifier :one
+INSERT INTO product_identifiers (
+    product_id, identifier_type, identifier_value, location_id, status
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: UpdateProductIdentifierLocation :one
+UPDATE product_identifiers
+SET location_id = $2
+WHERE identifier_id = $1
+RETURNING *;
+
+-- name: CreateLocationHistory :one
+INSERT INTO location_history (
+    identifier_id, from_location_id, to_location_id,
+    movement_type, scanned_by, device_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListLocationHistoryByIdentifier :many
+SELECT * FROM location_history
+WHERE identifier_id = $1
+ORDER BY scanned_at DESC, history_id DESC;
+
+-- name: ListProductIdentifiersByValue :many
+SELECT * FROM product_identifiers
+WHERE identifier_value = sqlc.arg(identifier_value)
+  AND (sqlc.narg(product_id)::int IS NULL OR product_id = sqlc.narg(product_id))
+ORDER BY identifier_id;
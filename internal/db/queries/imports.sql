@@ -0,0 +1,39 @@
+-- name: CreateImportBatch :one
+INSERT INTO import_batches (
+  kind, file_name, format, total_rows, created_by
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetImportBatch :one
+SELECT * FROM import_batches WHERE batch_id = $1;
+
+-- name: ListImportBatches :many
+SELECT * FROM import_batches
+ORDER BY created_at DESC, batch_id DESC
+LIMIT $1 OFFSET $2;
+
+-- name: AddImportBatchRows :one
+UPDATE import_batches
+SET imported_rows = imported_rows + sqlc.arg(imported),
+    failed_rows = failed_rows + sqlc.arg(failed)
+WHERE batch_id = sqlc.arg(batch_id)
+RETURNING *;
+
+-- name: CreateOpeningBalance :one
+INSERT INTO opening_balances (
+  batch_id, line_number, product_id, warehouse_id, location_id,
+  quantity, unit_cost, batch_number, expiry_date
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: GetOpeningBalance :one
+SELECT * FROM opening_balances WHERE opening_balance_id = $1;
+
+-- name: ListOpeningBalancesByBatch :many
+SELECT * FROM opening_balances
+WHERE batch_id = $1
+ORDER BY line_number;
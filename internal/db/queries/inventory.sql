@@ -68,4 +68,78 @@ SET
     status = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE inventory_id = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: GetInventoryForUpdate :one
+SELECT * FROM inventory
+WHERE inventory_id = $1
+FOR UPDATE;
+
+-- name: PickInventory :one
+UPDATE inventory
+SET
+    quantity = quantity - @quantity,
+    reserved_quantity = reserved_quantity - @quantity,
+    updated_at = CURRENT_TIMESTAMP
+WHERE inventory_id = @inventory_id
+  AND quantity >= @quantity
+  AND reserved_quantity >= @quantity
+RETURNING *;
+
+-- name: CreateInventory :one
+INSERT INTO inventory (
+    product_id, warehouse_id, location_id, quantity,
+    batch_number, expiry_date, manufacturing_date, serial_number
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetInventoryAtLocationForUpdate :one
+SELECT * FROM inventory
+WHERE product_id = @product_id
+  AND location_id = @location_id
+  AND batch_number IS NOT DISTINCT FROM @batch_number
+  AND serial_number IS NOT DISTINCT FROM @serial_number
+ORDER BY inventory_id
+LIMIT 1
+FOR UPDATE;
+
+-- name: AddInventoryQuantity :one
+UPDATE inventory
+SET
+    quantity = quantity + $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE inventory_id = $1
+RETURNING *;
+
+-- name: RemoveInventoryQuantity :one
+UPDATE inventory
+SET
+    quantity = quantity - $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE inventory_id = $1 AND (quantity - reserved_quantity) >= $2
+RETURNING *;
+
+-- name: SetInventoryLocation :one
+UPDATE inventory
+SET
+    location_id = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE inventory_id = $1
+RETURNING *;
+
+-- name: ListAvailableInventoryForUpdate :many
+SELECT * FROM inventory
+WHERE product_id = $1
+  AND warehouse_id = $2
+  AND status IN ('in_stock', 'reserved')
+  AND quantity > reserved_quantity
+ORDER BY expiry_date NULLS LAST, inventory_id
+FOR UPDATE;
+
+-- name: ListInventoryByProductBatch :many
+SELECT * FROM inventory
+WHERE product_id = $1
+  AND batch_number = $2
+ORDER BY warehouse_id, location_id, inventory_id;
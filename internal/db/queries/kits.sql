@@ -0,0 +1,96 @@
+-- name: UpsertKitComponent :one
+INSERT INTO kit_components (
+    kit_product_id, component_product_id, quantity
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (kit_product_id, component_product_id) DO UPDATE
+SET quantity = EXCLUDED.quantity
+RETURNING *;
+
+-- name: DeleteKitComponent :execrows
+DELETE FROM kit_components
+WHERE kit_product_id = $1 AND component_product_id = $2;
+
+-- name: ListKitComponents :many
+SELECT kc.*, p.sku, p.name, p.cost_price
+FROM kit_components kc
+JOIN products p ON p.product_id = kc.component_product_id
+WHERE kc.kit_product_id = $1
+ORDER BY kc.kit_component_id;
+
+-- name: ListKitComponentIDsDeep :many
+WITH RECURSIVE parts AS (
+    SELECT kc.component_product_id, ARRAY[kc.kit_product_id, kc.component_product_id] AS path
+    FROM kit_components kc
+    WHERE kc.kit_product_id = $1
+    UNION ALL
+    SELECT kc.component_product_id, parts.path || kc.component_product_id
+    FROM kit_components kc
+    JOIN parts ON kc.kit_product_id = parts.component_product_id
+    WHERE NOT kc.component_product_id = ANY(parts.path)
+)
+SELECT DISTINCT component_product_id FROM parts;
+
+-- name: ListKitsUsingComponent :many
+SELECT kc.*, p.sku, p.name
+FROM kit_components kc
+JOIN products p ON p.product_id = kc.kit_product_id
+WHERE kc.component_product_id = $1
+ORDER BY kc.kit_product_id;
+
+-- name: ListKitComponentAvailability :many
+SELECT kc.component_product_id, p.sku, kc.quantity,
+       COALESCE((
+           SELECT SUM(i.quantity - i.reserved_quantity)
+           FROM inventory i
+           WHERE i.product_id = kc.component_product_id
+             AND i.status IN ('in_stock', 'reserved')
+             AND (sqlc.narg(warehouse_id)::int IS NULL OR i.warehouse_id = sqlc.narg(warehouse_id)::int)
+       ), 0)::int AS available
+FROM kit_components kc
+JOIN products p ON p.product_id = kc.component_product_id
+WHERE kc.kit_product_id = sqlc.arg(kit_product_id)
+ORDER BY kc.kit_component_id;
+
+-- name: CreateKitAssembly :one
+INSERT INTO kit_assemblies (
+    kit_product_id, warehouse_id, location_id, direction, quantity, notes, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: SetKitAssemblyCost :one
+UPDATE kit_assemblies
+SET unit_cost = $2, total_cost = $3
+WHERE assembly_id = $1
+RETURNING *;
+
+-- name: GetKitAssembly :one
+SELECT * FROM kit_assemblies
+WHERE assembly_id = $1;
+
+-- name: ListKitAssemblies :many
+SELECT * FROM kit_assemblies
+WHERE kit_product_id = $1
+ORDER BY created_at DESC, assembly_id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CreateKitAssemblyLine :one
+INSERT INTO kit_assembly_lines (
+    assembly_id, component_product_id, quantity, unit_cost, total_cost
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetKitAssemblyLine :one
+SELECT * FROM kit_assembly_lines
+WHERE line_id = $1;
+
+-- name: ListKitAssemblyLines :many
+SELECT * FROM kit_assembly_lines
+WHERE assembly_id = $1
+ORDER BY line_id;
+
+-- name: LockKitComponents :exec
+SELECT pg_advisory_xact_lock(hashtext('kit_components'));
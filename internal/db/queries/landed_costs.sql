@@ -0,0 +1,61 @@
+-- name: CreateLandedCost :one
+INSERT INTO landed_costs (
+    po_id, reference_number, cost_type, allocation_basis, currency_code,
+    amount, exchange_rate, base_amount, cost_date, notes, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: GetLandedCost :one
+SELECT * FROM landed_costs
+WHERE landed_cost_id = $1;
+
+-- name: ListLandedCostsByPurchaseOrder :many
+SELECT * FROM landed_costs
+WHERE po_id = $1
+ORDER BY cost_date, landed_cost_id;
+
+-- name: CreateLandedCostAllocation :one
+INSERT INTO landed_cost_allocations (
+    landed_cost_id, po_item_id, product_id, warehouse_id, quantity,
+    basis, amount, capitalized_amount, expensed_amount, cost_entry_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: ListLandedCostAllocations :many
+SELECT lca.*, p.sku, p.name as product_name
+FROM landed_cost_allocations lca
+JOIN products p ON lca.product_id = p.product_id
+WHERE lca.landed_cost_id = $1
+ORDER BY lca.allocation_id;
+
+-- name: ListReceivedPurchaseOrderLines :many
+SELECT poi.po_item_id, poi.product_id, poi.quantity_received, poi.unit_price,
+       COALESCE(p.weight, 0)::float8 as unit_weight
+FROM purchase_order_items poi
+JOIN products p ON poi.product_id = p.product_id
+WHERE poi.po_id = $1 AND poi.quantity_received > 0
+ORDER BY poi.po_item_id;
+
+-- name: ListPurchaseOrderItemReceipts :many
+SELECT warehouse_id, SUM(quantity_change)::int as quantity
+FROM stock_movements
+WHERE reference_table = 'purchase_order_items'
+  AND reference_id = $1
+  AND movement_type = 'purchase_receipt'
+GROUP BY warehouse_id
+HAVING SUM(quantity_change) > 0
+ORDER BY warehouse_id;
+
+-- name: RaisePurchaseOrderItemCostLayers :one
+WITH raised AS (
+    UPDATE cost_layers
+    SET unit_cost = unit_cost + sqlc.arg(uplift)
+    WHERE po_item_id = sqlc.arg(po_item_id)
+      AND warehouse_id = sqlc.arg(warehouse_id)
+      AND quantity_remaining > 0
+    RETURNING quantity_remaining
+)
+SELECT COALESCE(SUM(quantity_remaining), 0)::int as quantity_remaining
+FROM raised;
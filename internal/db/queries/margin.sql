@@ -0,0 +1,28 @@
+-- name: ListSalesMarginLines :many
+SELECT sm.movement_id, sm.movement_date, sm.product_id, p.sku, p.name as product_name,
+       p.category_id, c.name as category_name,
+       sm.warehouse_id, w.code as warehouse_code,
+       (-sm.quantity_change)::int as quantity,
+       (-sm.quantity_change * COALESCE(sm.sale_price, p.unit_price))::numeric as revenue,
+       COALESCE(ce.cost, -sm.quantity_change * p.cost_price, 0)::numeric as booked_cost,
+       COALESCE(-sm.quantity_change * p.cost_price, 0)::numeric as cost_price_cost
+FROM stock_movements sm
+JOIN products p ON sm.product_id = p.product_id
+JOIN warehouses w ON sm.warehouse_id = w.warehouse_id
+LEFT JOIN categories c ON p.category_id = c.category_id
+LEFT JOIN (
+    SELECT movement_id, -SUM(total_cost) as cost
+    FROM cost_entries
+    WHERE entry_type = 'issue'
+    GROUP BY movement_id
+) ce ON ce.movement_id = sm.movement_id
+WHERE sm.movement_type = 'sales_delivery'
+  AND sm.movement_date >= sqlc.arg(from_date) AND sm.movement_date < sqlc.arg(to_date)
+  AND (sqlc.narg(warehouse_id)::int IS NULL OR sm.warehouse_id = sqlc.narg(warehouse_id))
+  AND (sqlc.narg(category_id)::int IS NULL OR p.category_id = sqlc.narg(category_id))
+ORDER BY sm.movement_date, sm.movement_id;
+
+-- name: ListProfitClassifications :many
+SELECT * FROM abc_classification
+WHERE criteria = 'profit'
+ORDER BY ranking NULLS LAST, product_id;
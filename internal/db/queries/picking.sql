@@ -0,0 +1,197 @@
+-- name: CreatePickingRoute :one
+INSERT INTO picking_routes (
+    warehouse_id, zone_sequence, estimated_time_minutes
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetPickingRoute :one
+SELECT * FROM picking_routes
+WHERE route_id = $1;
+
+-- name: ListPickingRoutesByWarehouse :many
+SELECT * FROM picking_routes
+WHERE warehouse_id = $1
+ORDER BY route_id;
+
+-- name: UpdatePickingRoute :one
+UPDATE picking_routes
+SET zone_sequence = $2,
+    estimated_time_minutes = $3
+WHERE route_id = $1
+RETURNING *;
+
+-- name: CountPickingWavesByRoute :one
+SELECT COUNT(*) FROM picking_waves
+WHERE route_id = $1;
+
+-- name: CreatePickingWave :one
+INSERT INTO picking_waves (
+    warehouse_id, wave_number, status, priority, assigned_to, route_id
+) VALUES (
+    $1, $2, 'planned', $3, $4, $5
+) RETURNING *;
+
+-- name: GetPickingWave :one
+SELECT * FROM picking_waves
+WHERE wave_id = $1;
+
+-- name: GetPickingWaveForUpdate :one
+SELECT * FROM picking_waves
+WHERE wave_id = $1
+FOR UPDATE;
+
+-- name: ListPickingWavesByWarehouse :many
+SELECT * FROM picking_waves
+WHERE warehouse_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListOpenPickingWaves :many
+SELECT * FROM picking_waves
+WHERE warehouse_id = $1
+  AND status IN ('planned', 'in_progress')
+ORDER BY priority, created_at;
+
+-- name: SetPickingWaveNumber :one
+UPDATE picking_waves
+SET wave_number = $2
+WHERE wave_id = $1
+RETURNING *;
+
+-- name: UpdatePickingWaveTotalItems :one
+UPDATE picking_waves
+SET total_items = $2
+WHERE wave_id = $1
+RETURNING *;
+
+-- name: AssignPickingWave :one
+UPDATE picking_waves
+SET assigned_to = $2
+WHERE wave_id = $1 AND status IN ('planned', 'in_progress')
+RETURNING *;
+
+-- name: SetPickingWaveRoute :one
+UPDATE picking_waves
+SET route_id = $2
+WHERE wave_id = $1
+RETURNING *;
+
+-- name: StartPickingWave :one
+UPDATE picking_waves
+SET status = 'in_progress',
+    start_time = COALESCE(start_time, CURRENT_TIMESTAMP)
+WHERE wave_id = $1 AND status IN ('planned', 'in_progress')
+RETURNING *;
+
+-- name: CompletePickingWave :one
+UPDATE picking_waves
+SET status = 'completed',
+    end_time = CURRENT_TIMESTAMP
+WHERE wave_id = $1
+RETURNING *;
+
+-- name: CancelPickingWave :one
+UPDATE picking_waves
+SET status = 'cancelled',
+    end_time = CURRENT_TIMESTAMP
+WHERE wave_id = $1 AND status IN ('planned', 'in_progress')
+RETURNING *;
+
+-- name: LockWarehousePicking :exec
+SELECT pg_advisory_xact_lock(hashtext('picking_waves'), sqlc.arg(warehouse_id)::int);
+
+-- name: ListUnwavedReservations :many
+SELECT i.inventory_id, i.product_id, i.location_id,
+       (i.reserved_quantity - COALESCE(w.open_quantity, 0) - COALESCE(wo.open_quantity, 0))::int as quantity_to_pick
+FROM inventory i
+LEFT JOIN (
+    SELECT pwi.inventory_id,
+           SUM(pwi.quantity_to_pick - pwi.quantity_picked) as open_quantity
+    FROM picking_wave_items pwi
+    JOIN picking_waves pw ON pwi.wave_id = pw.wave_id
+    WHERE pw.status IN ('planned', 'in_progress')
+      AND pwi.status = 'pending'
+    GROUP BY pwi.inventory_id
+) w ON i.inventory_id = w.inventory_id
+LEFT JOIN (
+    SELECT inventory_id, SUM(open_quantity) as open_quantity
+    FROM work_order_reservations
+    WHERE open_quantity > 0
+    GROUP BY inventory_id
+) wo ON i.inventory_id = wo.inventory_id
+WHERE i.warehouse_id = $1
+  AND i.status IN ('in_stock', 'reserved')
+  AND i.reserved_quantity > COALESCE(w.open_quantity, 0) + COALESCE(wo.open_quantity, 0)
+ORDER BY i.inventory_id
+LIMIT $2;
+
+-- name: CreatePickingWaveItem :one
+INSERT INTO picking_wave_items (
+    wave_id, inventory_id, product_id, location_id, quantity_to_pick
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetPickingWaveItemForUpdate :one
+SELECT * FROM picking_wave_items
+WHERE wave_item_id = $1
+FOR UPDATE;
+
+-- name: GetPickList :many
+SELECT pwi.*, p.sku, p.name as product_name,
+       l.location_code, l.aisle, l.zone_id, l.x_coord, l.y_coord,
+       l.pick_sequence as location_sequence, wz.zone_code
+FROM picking_wave_items pwi
+JOIN picking_waves pw ON pwi.wave_id = pw.wave_id
+JOIN products p ON pwi.product_id = p.product_id
+LEFT JOIN locations l ON pwi.location_id = l.location_id
+LEFT JOIN warehouse_zones wz ON l.zone_id = wz.zone_id
+LEFT JOIN picking_routes r ON pw.route_id = r.route_id
+WHERE pwi.wave_id = $1
+ORDER BY pwi.pick_sequence NULLS LAST, (
+    SELECT z.ord
+    FROM json_array_elements_text(r.zone_sequence) WITH ORDINALITY AS z(zone, ord)
+    WHERE z.zone IN (wz.zone_code, l.aisle)
+) NULLS LAST, l.pick_sequence NULLS LAST, l.location_code NULLS LAST, pwi.wave_item_id;
+
+-- name: SetPickingWaveItemSequence :exec
+UPDATE picking_wave_items
+SET pick_sequence = $2
+WHERE wave_item_id = $1;
+
+-- name: ConfirmPickingWaveItem :one
+UPDATE picking_wave_items
+SET quantity_picked = quantity_picked + @quantity_picked,
+    status = @status,
+    picked_by = @picked_by,
+    picked_at = CURRENT_TIMESTAMP,
+    pick_seconds = EXTRACT(EPOCH FROM CURRENT_TIMESTAMP::timestamp - COALESCE(
+        (SELECT MAX(prev.picked_at) FROM picking_wave_items prev
+         WHERE prev.wave_id = picking_wave_items.wave_id AND prev.picked_by = @picked_by),
+        (SELECT pw.start_time FROM picking_waves pw
+         WHERE pw.wave_id = picking_wave_items.wave_id),
+        CURRENT_TIMESTAMP::timestamp
+    ))::int
+WHERE wave_item_id = @wave_item_id
+RETURNING *;
+
+-- name: CountPendingPickingWaveItems :one
+SELECT COUNT(*) FROM picking_wave_items
+WHERE wave_id = $1 AND status = 'pending';
+
+-- name: GetPickerProductivity :many
+SELECT pwi.picked_by, u.full_name,
+       COUNT(*) as lines_picked,
+       COUNT(DISTINCT pwi.wave_id) as waves,
+       COALESCE(SUM(pwi.quantity_picked), 0)::bigint as units_picked,
+       COALESCE(SUM(pwi.pick_seconds), 0)::bigint as pick_seconds
+FROM picking_wave_items pwi
+JOIN picking_waves pw ON pwi.wave_id = pw.wave_id
+LEFT JOIN users u ON pwi.picked_by = u.user_id
+WHERE pw.warehouse_id = @warehouse_id
+  AND pwi.picked_by IS NOT NULL
+  AND pwi.picked_at >= sqlc.arg(from_time)::timestamp
+  AND pwi.picked_at < sqlc.arg(to_time)::timestamp
+GROUP BY pwi.picked_by, u.full_name
+ORDER BY lines_picked DESC;
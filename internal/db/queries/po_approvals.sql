@@ -0,0 +1,47 @@
+-- name: CreatePoApprovalLevel :one
+INSERT INTO po_approval_levels (
+    level, approver_role, min_amount, description
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListPoApprovalLevels :many
+SELECT * FROM po_approval_levels
+ORDER BY level;
+
+-- name: ListActivePoApprovalLevels :many
+SELECT * FROM po_approval_levels
+WHERE is_active = true
+ORDER BY level;
+
+-- name: UpdatePoApprovalLevel :one
+UPDATE po_approval_levels
+SET
+    approver_role = $2,
+    min_amount = $3,
+    description = $4,
+    is_active = $5
+WHERE approval_level_id = $1
+RETURNING *;
+
+-- name: CreatePurchaseOrderApproval :one
+INSERT INTO purchase_order_approvals (
+    po_id, level, decision, approver_id, total_amount, comments
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListPurchaseOrderApprovals :many
+SELECT a.*, u.full_name as approver_name, u.role as approver_role
+FROM purchase_order_approvals a
+JOIN users u ON a.approver_id = u.user_id
+WHERE a.po_id = $1
+ORDER BY a.decided_at, a.approval_id;
+
+-- name: ListSubmissionApprovals :many
+SELECT a.* FROM purchase_order_approvals a
+JOIN purchase_orders po ON a.po_id = po.po_id
+WHERE a.po_id = $1
+  AND a.decision = 'approved'
+  AND a.decided_at >= po.submitted_at
+ORDER BY a.level;
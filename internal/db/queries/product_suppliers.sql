@@ -0,0 +1,97 @@
+-- name: CreateProductSupplier :one
+INSERT INTO product_suppliers (
+    product_id, supplier_id, priority, lead_time_days, min_order_quantity, is_active, purchase_uom_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetProductSupplier :one
+SELECT * FROM product_suppliers
+WHERE product_supplier_id = $1;
+
+-- name: GetProductSupplierByProduct :one
+SELECT * FROM product_suppliers
+WHERE supplier_id = $1 AND product_id = $2;
+
+-- name: ListSupplierCatalogue :many
+SELECT ps.*, p.sku, p.name AS product_name
+FROM product_suppliers ps
+INNER JOIN products p ON p.product_id = ps.product_id
+WHERE ps.supplier_id = $1
+ORDER BY p.sku
+LIMIT $2 OFFSET $3;
+
+-- name: ListProductSuppliersByProduct :many
+SELECT ps.*, s.code AS supplier_code, s.name AS supplier_name
+FROM product_suppliers ps
+INNER JOIN suppliers s ON s.supplier_id = ps.supplier_id
+WHERE ps.product_id = $1
+ORDER BY ps.is_active DESC, ps.priority, ps.product_supplier_id;
+
+-- name: ListProductSuppliersForUpdate :many
+SELECT * FROM product_suppliers
+WHERE product_id = $1
+ORDER BY priority, product_supplier_id
+FOR UPDATE;
+
+-- name: UpdateProductSupplier :one
+UPDATE product_suppliers
+SET
+    priority = $2,
+    lead_time_days = $3,
+    min_order_quantity = $4,
+    is_active = $5,
+    purchase_uom_id = $6
+WHERE product_supplier_id = $1
+RETURNING *;
+
+-- name: SetProductSupplierPriority :exec
+UPDATE product_suppliers
+SET priority = $2
+WHERE product_supplier_id = $1;
+
+-- name: SetProductPrimarySupplier :exec
+UPDATE products
+SET supplier_id = $2, updated_at = CURRENT_TIMESTAMP
+WHERE product_id = $1;
+
+-- name: DeleteProductSupplier :exec
+DELETE FROM product_suppliers
+WHERE product_supplier_id = $1;
+
+-- name: DeleteProductSupplierPrices :exec
+DELETE FROM product_supplier_prices
+WHERE product_supplier_id = $1;
+
+-- name: UpsertProductSupplierPrice :one
+INSERT INTO product_supplier_prices (
+    product_supplier_id, unit_price, effective_from, source, created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (product_supplier_id, effective_from) DO UPDATE
+SET unit_price = EXCLUDED.unit_price,
+    source = EXCLUDED.source,
+    created_by = EXCLUDED.created_by,
+    created_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListProductSupplierPrices :many
+SELECT
+    psp.*,
+    LAG(psp.unit_price) OVER (ORDER BY psp.effective_from) AS previous_price
+FROM product_supplier_prices psp
+WHERE psp.product_supplier_id = $1
+ORDER BY psp.effective_from DESC;
+
+-- name: ApplyDueProductSupplierPrices :execrows
+UPDATE product_suppliers ps
+SET unit_price = due.unit_price
+FROM (
+    SELECT DISTINCT ON (psp.product_supplier_id) psp.product_supplier_id, psp.unit_price
+    FROM product_supplier_prices psp
+    WHERE psp.effective_from <= CURRENT_DATE
+    ORDER BY psp.product_supplier_id, psp.effective_from DESC
+) due
+WHERE ps.product_supplier_id = due.product_supplier_id
+  AND ps.unit_price IS DISTINCT FROM due.unit_price;
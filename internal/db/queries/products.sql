@@ -33,9 +33,9 @@ INSERT INTO products (
     sku, name, description, category_id, unit_price, cost_price,
     barcode, weight, dimensions, supplier_id, min_stock_level,
     max_stock_level, reorder_point, safety_stock, lead_time_days,
-    auto_reorder, is_active
+    auto_reorder, is_active, base_uom_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
 ) RETURNING *;
 
 -- name: UpdateProduct :one
@@ -57,6 +57,7 @@ SET
     lead_time_days = $15,
     auto_reorder = $16,
     is_active = $17,
+    base_uom_id = $18,
     updated_at = CURRENT_TIMESTAMP
 WHERE product_id = $1
 RETURNING *;
@@ -69,4 +70,95 @@ WHERE product_id = $1;
 -- name: UpdateLastReorderDate :exec
 UPDATE products 
 SET last_reorder_date = CURRENT_DATE, updated_at = CURRENT_TIMESTAMP
-WHERE product_id = $1;
\ No newline at end of file
+WHERE product_id = $1;
+
+-- name: ListProductsInCategoryTree :many
+WITH RECURSIVE subtree AS (
+    SELECT category_id, ARRAY[category_id] AS path
+    FROM categories
+    WHERE category_id = $1
+    UNION ALL
+    SELECT c.category_id, subtree.path || c.category_id
+    FROM categories c
+    JOIN subtree ON c.parent_category_id = subtree.category_id
+    WHERE NOT c.category_id = ANY(subtree.path)
+)
+SELECT p.* FROM products p
+WHERE p.category_id IN (SELECT category_id FROM subtree) AND p.is_active = true
+ORDER BY p.product_id
+LIMIT $2 OFFSET $3;
+
+-- name: SearchProducts :many
+WITH RECURSIVE subtree AS (
+    SELECT category_id, ARRAY[category_id] AS path
+    FROM categories
+    WHERE category_id = sqlc.narg(category_id)::int
+    UNION ALL
+    SELECT c.category_id, subtree.path || c.category_id
+    FROM categories c
+    JOIN subtree ON c.parent_category_id = subtree.category_id
+    WHERE NOT c.category_id = ANY(subtree.path)
+),
+stock AS (
+    SELECT product_id, SUM(quantity - reserved_quantity) AS available
+    FROM inventory
+    GROUP BY product_id
+)
+SELECT p.*,
+       COALESCE(s.available, 0)::int as available_quantity,
+       (CASE WHEN lower(p.sku) = lower(sqlc.arg(term)::text)
+                  OR p.barcode = sqlc.arg(term)
+                  OR EXISTS (SELECT 1 FROM product_uoms pu WHERE pu.product_id = p.product_id AND pu.barcode = sqlc.arg(term))
+             THEN 2 ELSE 0 END
+        + CASE WHEN sqlc.arg(ts_query)::text <> ''
+               THEN ts_rank(to_tsvector('simple', p.sku || ' ' || p.name || ' ' || COALESCE(p.description, '') || ' ' || COALESCE(p.barcode, '')),
+                            to_tsquery('simple', sqlc.arg(ts_query)))
+               ELSE 0 END
+        + GREATEST(word_similarity(lower(sqlc.arg(term)), lower(p.name)), similarity(lower(sqlc.arg(term)), lower(p.sku)))
+       )::real as rank
+FROM products p
+LEFT JOIN stock s ON s.product_id = p.product_id
+WHERE (sqlc.narg(category_id)::int IS NULL OR p.category_id IN (SELECT category_id FROM subtree))
+  AND (sqlc.narg(supplier_id)::int IS NULL OR p.supplier_id = sqlc.narg(supplier_id))
+  AND (sqlc.narg(is_active)::boolean IS NULL OR p.is_active = sqlc.narg(is_active))
+  AND (sqlc.narg(min_price)::numeric IS NULL OR p.unit_price >= sqlc.narg(min_price))
+  AND (sqlc.narg(max_price)::numeric IS NULL OR p.unit_price <= sqlc.narg(max_price))
+  AND (sqlc.narg(stock_status)::text IS NULL
+       OR (sqlc.narg(stock_status) = 'in_stock' AND COALESCE(s.available, 0) > 0)
+       OR (sqlc.narg(stock_status) = 'low_stock' AND COALESCE(s.available, 0) > 0 AND COALESCE(s.available, 0) <= p.reorder_point)
+       OR (sqlc.narg(stock_status) = 'out_of_stock' AND COALESCE(s.available, 0) <= 0))
+  AND (sqlc.arg(term) = ''
+       OR lower(p.sku) = lower(sqlc.arg(term))
+       OR p.barcode = sqlc.arg(term)
+       OR EXISTS (SELECT 1 FROM product_uoms pu WHERE pu.product_id = p.product_id AND pu.barcode = sqlc.arg(term))
+       OR (sqlc.arg(ts_query) <> ''
+           AND to_tsvector('simple', p.sku || ' ' || p.name || ' ' || COALESCE(p.description, '') || ' ' || COALESCE(p.barcode, ''))
+               @@ to_tsquery('simple', sqlc.arg(ts_query)))
+       OR lower(sqlc.arg(term)) <% lower(p.name)
+       OR lower(sqlc.arg(term)) % lower(p.sku))
+ORDER BY rank DESC, p.name, p.product_id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: SetSimilarityThreshold :exec
+SELECT set_config('pg_trgm.similarity_threshold', sqlc.arg(threshold)::real::text, true),
+       set_config('pg_trgm.word_similarity_threshold', sqlc.arg(threshold)::real::text, true);
+
+-- name: GetProductByGTIN :one
+SELECT * FROM products
+WHERE length(barcode) <= 14
+  AND lpad(barcode, 14, '0') = $1
+ORDER BY is_active DESC, product_id
+LIMIT 1;
+
+-- name: GetProductForUpdate :one
+SELECT * FROM products
+WHERE product_id = $1
+FOR UPDATE;
+
+-- name: GetProductAvailability :one
+SELECT p.product_id, p.sku, p.reorder_point,
+       COALESCE(SUM(i.quantity - i.reserved_quantity), 0)::int AS available_qty
+FROM products p
+LEFT JOIN inventory i ON p.product_id = i.product_id
+WHERE p.product_id = $1
+GROUP BY p.product_id;
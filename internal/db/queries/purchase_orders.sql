@@ -1,9 +1,10 @@
 -- name: CreatePurchaseOrder :one
 INSERT INTO purchase_orders (
     po_number, supplier_id, order_date, expected_delivery_date,
-    status, total_amount, notes, created_by
+    status, total_amount, notes, created_by, currency_code,
+    warehouse_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 ) RETURNING *;
 
 -- name: GetPurchaseOrder :one
@@ -29,23 +30,72 @@ WHERE po.status = $1
 ORDER BY po.order_date DESC
 LIMIT $2 OFFSET $3;
 
+-- name: GetPurchaseOrderForUpdate :one
+SELECT * FROM purchase_orders
+WHERE po_id = $1
+FOR UPDATE;
+
 -- name: UpdatePurchaseOrderStatus :one
 UPDATE purchase_orders 
 SET 
     status = $2,
-    total_amount = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE po_id = $1
 RETURNING *;
 
+-- name: SubmitPurchaseOrder :one
+UPDATE purchase_orders
+SET
+    status = 'pending',
+    created_by = COALESCE(created_by, sqlc.narg(submitted_by)),
+    submitted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE po_id = sqlc.arg(po_id)
+RETURNING *;
+
+-- name: RecalculatePurchaseOrderTotal :one
+UPDATE purchase_orders
+SET
+    total_amount = (
+        SELECT COALESCE(SUM(poi.total_price), 0)
+        FROM purchase_order_items poi
+        WHERE poi.po_id = purchase_orders.po_id
+    ),
+    updated_at = CURRENT_TIMESTAMP
+WHERE po_id = $1
+RETURNING *;
+
+-- name: UpdatePurchaseOrderReceiptStatus :exec
+UPDATE purchase_orders
+SET
+    status = CASE
+        WHEN EXISTS (
+            SELECT 1 FROM purchase_order_items poi
+            WHERE poi.po_id = purchase_orders.po_id
+              AND poi.quantity_received < poi.quantity_ordered
+        ) THEN 'partially_received'::purchase_order_status
+        ELSE 'completed'::purchase_order_status
+    END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE po_id = $1
+  AND status IN ('approved', 'partially_received');
+
 -- name: CreatePurchaseOrderItem :one
 INSERT INTO purchase_order_items (
     po_id, product_id, quantity_ordered, quantity_received,
-    unit_price, total_price
+    unit_price, total_price, uom_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
+-- name: GetPurchaseOrderItem :one
+SELECT * FROM purchase_order_items
+WHERE po_item_id = $1;
+
+-- name: CountPurchaseOrderItems :one
+SELECT COUNT(*) FROM purchase_order_items
+WHERE po_id = $1;
+
 -- name: GetPurchaseOrderItems :many
 SELECT poi.*, p.name as product_name, p.sku
 FROM purchase_order_items poi
@@ -56,7 +106,13 @@ ORDER BY poi.po_item_id;
 -- name: UpdatePurchaseOrderItemReceivedQty :one
 UPDATE purchase_order_items 
 SET 
-    quantity_received = quantity_received + $2,
-    updated_at = CURRENT_TIMESTAMP
+    quantity_received = quantity_received + $2
 WHERE po_item_id = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: CreatePurchaseOrderReceipt :one
+INSERT INTO purchase_order_receipts (
+    po_item_id, quantity, received_by
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
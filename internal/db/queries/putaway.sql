@@ -0,0 +1,57 @@
+-- name: CreatePutawayRule :one
+INSERT INTO putaway_rules (
+    warehouse_id, category_id, zone_id, priority
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListPutawayRulesByWarehouse :many
+SELECT * FROM putaway_rules
+WHERE warehouse_id = $1 AND is_active = true
+ORDER BY category_id NULLS LAST, priority, rule_id;
+
+-- name: DeactivatePutawayRule :exec
+UPDATE putaway_rules
+SET is_active = false
+WHERE rule_id = $1;
+
+-- name: ListPutawayZonesForCategory :many
+SELECT zone_id, priority
+FROM putaway_rules
+WHERE warehouse_id = @warehouse_id
+  AND is_active = true
+  AND (
+    category_id = sqlc.narg(category_id)
+    OR (category_id IS NULL AND NOT EXISTS (
+        SELECT 1 FROM putaway_rules pr
+        WHERE pr.warehouse_id = @warehouse_id
+          AND pr.is_active = true
+          AND pr.category_id = sqlc.narg(category_id)
+    ))
+  )
+ORDER BY priority, zone_id;
+
+-- name: ListLocationContents :many
+SELECT i.location_id, i.product_id, i.batch_number,
+       SUM(i.quantity)::int as quantity,
+       COALESCE(p.weight, 0)::float8 as unit_weight,
+       p.dimensions
+FROM inventory i
+JOIN products p ON i.product_id = p.product_id
+WHERE i.warehouse_id = $1
+  AND i.location_id IS NOT NULL
+  AND i.quantity > 0
+GROUP BY i.location_id, i.product_id, i.batch_number, p.weight, p.dimensions
+ORDER BY i.location_id, i.product_id;
+
+-- name: ListContentsOfLocation :many
+SELECT i.location_id, i.product_id, i.batch_number,
+       SUM(i.quantity)::int as quantity,
+       COALESCE(p.weight, 0)::float8 as unit_weight,
+       p.dimensions
+FROM inventory i
+JOIN products p ON i.product_id = p.product_id
+WHERE i.location_id = $1
+  AND i.quantity > 0
+GROUP BY i.location_id, i.product_id, i.batch_number, p.weight, p.dimensions
+ORDER BY i.product_id;
@@ -2,9 +2,9 @@
 INSERT INTO stock_movements (
     reference_number, product_id, warehouse_id, location_id,
     movement_type, quantity_before, quantity_change, quantity_after,
-    reference_id, reference_table, notes, created_by
+    reference_id, reference_table, notes, created_by, sale_price
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 ) RETURNING *;
 
 -- name: GetStockMovement :one
@@ -43,4 +43,7 @@ FROM stock_movements sm
 JOIN warehouses w ON sm.warehouse_id = w.warehouse_id
 WHERE sm.product_id = $1 AND sm.warehouse_id = $2
 ORDER BY sm.movement_date DESC
-LIMIT $3 OFFSET $4;
\ No newline at end of file
+LIMIT $3 OFFSET $4;
+
+-- name: NotifyStockEvent :exec
+SELECT pg_notify(sqlc.arg(channel)::text, sqlc.arg(payload)::text);
@@ -47,6 +47,10 @@ LEFT JOIN locations l ON si.location_id = l.location_id
 WHERE si.stocktake_id = $1
 ORDER BY p.name;
 
+-- name: GetStocktakeItem :one
+SELECT * FROM stocktake_items
+WHERE stocktake_item_id = $1;
+
 -- name: UpdateStocktakeItemCount :one
 UPDATE stocktake_items
 SET counted_quantity = $2,
@@ -1,9 +1,9 @@
 -- name: CreateSupplier :one
 INSERT INTO suppliers (
     code, name, contact_person, email, phone, address, 
-    tax_id, payment_terms, lead_time_days, rating, is_active
+    tax_id, payment_terms, lead_time_days, rating, is_active, currency_code
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 ) RETURNING *;
 
 -- name: GetSupplier :one
@@ -37,18 +37,20 @@ SET
     payment_terms = COALESCE($8, payment_terms),
     lead_time_days = COALESCE($9, lead_time_days),
     rating = COALESCE($10, rating),
-    is_active = COALESCE($11, is_active)
+    is_active = COALESCE($11, is_active),
+    currency_code = COALESCE($12, currency_code),
+    updated_at = CURRENT_TIMESTAMP
 WHERE supplier_id = $1
 RETURNING *;
 
 -- name: DeactivateSupplier :exec
 UPDATE suppliers 
-SET is_active = false 
+SET is_active = false, updated_at = CURRENT_TIMESTAMP
 WHERE supplier_id = $1;
 
 -- name: ActivateSupplier :exec
 UPDATE suppliers 
-SET is_active = true 
+SET is_active = true, updated_at = CURRENT_TIMESTAMP
 WHERE supplier_id = $1;
 
 -- name: SearchSuppliers :many
@@ -67,6 +69,12 @@ SELECT * FROM suppliers
 WHERE is_active = true 
 ORDER BY name;
 
+-- name: SetProductSupplierPurchaseUom :one
+UPDATE product_suppliers
+SET purchase_uom_id = $3
+WHERE supplier_id = $1 AND product_id = $2
+RETURNING product_supplier_id;
+
 -- name: GetSupplierProducts :many
 SELECT p.* 
 FROM products p
@@ -78,10 +86,53 @@ LIMIT $2 OFFSET $3;
 
 -- name: GetSupplierPerformance :one
 SELECT 
-    COUNT(DISTINCT po.po_id) as total_orders,
-    COUNT(DISTINCT po.product_id) as unique_products,
-    AVG(po.unit_price) as avg_unit_price,
-    MAX(po.order_date) as last_order_date
-FROM purchase_order_items po
-INNER JOIN purchase_orders p ON po.po_id = p.po_id
-WHERE p.supplier_id = $1;
\ No newline at end of file
+    COUNT(DISTINCT p.po_id) as total_orders,
+    COUNT(DISTINCT poi.product_id) as unique_products,
+    ROUND(COALESCE(AVG(poi.unit_price), 0), 4)::numeric as avg_unit_price,
+    MAX(p.order_date)::timestamp as last_order_date
+FROM purchase_orders p
+INNER JOIN purchase_order_items poi ON poi.po_id = p.po_id
+WHERE p.supplier_id = $1
+    AND p.status <> 'cancelled';
+
+-- name: ListSupplierScorecardLines :many
+SELECT
+    poi.po_item_id,
+    poi.product_id,
+    p.order_date,
+    p.expected_delivery_date::timestamp AS promised_date,
+    COALESCE(ps.lead_time_days, s.lead_time_days) AS promised_lead_time_days,
+    poi.quantity_ordered,
+    poi.quantity_received,
+    COALESCE((
+        SELECT SUM(ABS(sm.quantity_change))
+        FROM stock_movements sm
+        WHERE sm.reference_table = 'purchase_order_items'
+            AND sm.reference_id = poi.po_item_id
+            AND sm.movement_type = 'return'
+    ), 0)::int AS quantity_returned,
+    poi.unit_price,
+    (SELECT MAX(r.received_at) FROM purchase_order_receipts r WHERE r.po_item_id = poi.po_item_id)::timestamp AS last_received_at
+FROM purchase_order_items poi
+INNER JOIN purchase_orders p ON p.po_id = poi.po_id
+INNER JOIN suppliers s ON s.supplier_id = p.supplier_id
+LEFT JOIN product_suppliers ps ON ps.supplier_id = p.supplier_id AND ps.product_id = poi.product_id
+WHERE p.supplier_id = $1
+    AND p.order_date >= $2
+    AND p.status IN ('approved', 'partially_received', 'completed')
+ORDER BY p.order_date, poi.po_item_id;
+
+-- name: UpdateSupplierRating :exec
+UPDATE suppliers
+SET rating = $2, rated_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE supplier_id = $1;
+
+-- name: UpdateProductSupplierRating :exec
+UPDATE product_suppliers
+SET performance_rating = $3
+WHERE supplier_id = $1 AND product_id = $2;
+
+-- name: GetSupplierForUpdate :one
+SELECT * FROM suppliers
+WHERE supplier_id = $1
+FOR UPDATE;
@@ -27,11 +27,24 @@ INSERT INTO stock_transfer_items (
     $1, $2, $3, $4, $5
 ) RETURNING *;
 
+-- name: GetStockTransferItem :one
+SELECT * FROM stock_transfer_items
+WHERE transfer_item_id = $1;
+
 -- name: UpdateStockTransferItemQuantities :one
 UPDATE stock_transfer_items 
 SET 
     quantity_sent = $2,
-    quantity_received = $3,
-    updated_at = CURRENT_TIMESTAMP
+    quantity_received = $3
 WHERE transfer_item_id = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: GetStockTransferForUpdate :one
+SELECT * FROM stock_transfers
+WHERE transfer_id = $1
+FOR UPDATE;
+
+-- name: ListStockTransferItems :many
+SELECT * FROM stock_transfer_items
+WHERE transfer_id = $1
+ORDER BY transfer_item_id;
@@ -0,0 +1,52 @@
+-- name: CreateUnitOfMeasure :one
+INSERT INTO units_of_measure (
+    code, name, description
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetUnitOfMeasure :one
+SELECT * FROM units_of_measure
+WHERE uom_id = $1;
+
+-- name: ListUnitsOfMeasure :many
+SELECT * FROM units_of_measure
+ORDER BY code;
+
+-- name: CreateProductUom :one
+INSERT INTO product_uoms (
+    product_id, uom_id, conversion_factor, barcode
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListProductUoms :many
+SELECT pu.*, u.code, u.name
+FROM product_uoms pu
+JOIN units_of_measure u ON pu.uom_id = u.uom_id
+WHERE pu.product_id = $1 AND pu.is_active = true
+ORDER BY pu.conversion_factor, u.code;
+
+-- name: DeactivateProductUom :exec
+UPDATE product_uoms
+SET is_active = false
+WHERE product_uom_id = $1;
+
+-- name: GetProductUomFactor :one
+SELECT u.uom_id, 1::int as conversion_factor
+FROM products p
+JOIN units_of_measure u ON p.base_uom_id = u.uom_id
+WHERE p.product_id = @product_id AND u.code = @code
+UNION ALL
+SELECT u.uom_id, pu.conversion_factor
+FROM product_uoms pu
+JOIN units_of_measure u ON pu.uom_id = u.uom_id
+WHERE pu.product_id = @product_id AND u.code = @code AND pu.is_active = true
+LIMIT 1;
+
+-- name: GetProductUomByGTIN :one
+SELECT * FROM product_uoms
+WHERE length(barcode) <= 14
+  AND lpad(barcode, 14, '0') = $1
+ORDER BY is_active DESC, product_uom_id
+LIMIT 1;
@@ -0,0 +1,3 @@
+-- name: GetUser :one
+SELECT * FROM users
+WHERE user_id = $1;
@@ -0,0 +1,37 @@
+-- name: DeleteProductVariantAxes :exec
+DELETE FROM product_variant_axes
+WHERE parent_product_id = $1;
+
+-- name: CreateProductVariantAxis :one
+INSERT INTO product_variant_axes (
+    parent_product_id, name, position, axis_values
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListProductVariantAxes :many
+SELECT * FROM product_variant_axes
+WHERE parent_product_id = $1
+ORDER BY position, axis_id;
+
+-- name: CreateProductVariant :one
+INSERT INTO product_variants (
+    product_id, parent_product_id, options
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetProductVariant :one
+SELECT * FROM product_variants
+WHERE product_id = $1;
+
+-- name: GetProductVariantByOptions :one
+SELECT * FROM product_variants
+WHERE parent_product_id = $1 AND options = $2::jsonb;
+
+-- name: ListProductVariants :many
+SELECT pv.*, p.sku, p.name, p.unit_price, p.is_active
+FROM product_variants pv
+JOIN products p ON p.product_id = pv.product_id
+WHERE pv.parent_product_id = $1
+ORDER BY pv.product_id;
@@ -27,26 +27,33 @@ SET name = $2,
     address = $3,
     contact_person = $4,
     contact_phone = $5,
-    contact_email = $6
+    contact_email = $6,
+    updated_at = CURRENT_TIMESTAMP
 WHERE warehouse_id = $1
 RETURNING *;
 
 -- name: DeactivateWarehouse :exec
 UPDATE warehouses
-SET is_active = false
+SET is_active = false, updated_at = CURRENT_TIMESTAMP
 WHERE warehouse_id = $1;
 
 -- name: CreateLocation :one
 INSERT INTO locations (
-  warehouse_id, location_code, aisle, shelf, bin, max_capacity
+  warehouse_id, location_code, aisle, shelf, bin, max_capacity,
+  zone_id, x_coord, y_coord, pick_sequence, max_weight, max_volume
 ) VALUES (
-  $1, $2, $3, $4, $5, $6
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 )
 RETURNING *;
 
 -- name: GetLocation :one
 SELECT * FROM locations WHERE location_id = $1;
 
+-- name: GetLocationForUpdate :one
+SELECT * FROM locations
+WHERE location_id = $1
+FOR UPDATE;
+
 -- name: GetLocationByCode :one
 SELECT * FROM locations
 WHERE warehouse_id = $1 AND location_code = $2;
@@ -61,7 +68,13 @@ UPDATE locations
 SET aisle = $2,
     shelf = $3,
     bin = $4,
-    max_capacity = $5
+    max_capacity = $5,
+    zone_id = $6,
+    x_coord = $7,
+    y_coord = $8,
+    pick_sequence = $9,
+    max_weight = $10,
+    max_volume = $11
 WHERE location_id = $1
 RETURNING *;
 
@@ -70,6 +83,44 @@ UPDATE locations
 SET is_active = false
 WHERE location_id = $1;
 
+-- name: ListLocationsByZone :many
+SELECT * FROM locations
+WHERE zone_id = $1 AND is_active = true
+ORDER BY pick_sequence NULLS LAST, location_code;
+
+-- name: GetWarehouseDepth :one
+SELECT COALESCE(MAX(y_coord), 0)::float8 as depth
+FROM locations
+WHERE warehouse_id = $1 AND is_active = true;
+
+-- name: CreateWarehouseZone :one
+INSERT INTO warehouse_zones (
+  warehouse_id, zone_code, name, description
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetWarehouseZone :one
+SELECT * FROM warehouse_zones WHERE zone_id = $1;
+
+-- name: GetWarehouseZoneByCode :one
+SELECT * FROM warehouse_zones
+WHERE warehouse_id = $1 AND zone_code = $2;
+
+-- name: ListWarehouseZones :many
+SELECT * FROM warehouse_zones
+WHERE warehouse_id = $1 AND is_active = true
+ORDER BY zone_code;
+
+-- name: UpdateWarehouseZone :one
+UPDATE warehouse_zones
+SET name = $2,
+    description = $3,
+    is_active = $4
+WHERE zone_id = $1
+RETURNING *;
+
 -- name: GetWarehouseInventorySummary :many
 SELECT 
   w.warehouse_id,
@@ -81,4 +132,9 @@ FROM warehouses w
 LEFT JOIN inventory i ON w.warehouse_id = i.warehouse_id
 WHERE w.is_active = true
 GROUP BY w.warehouse_id, w.name
-ORDER BY w.name;
\ No newline at end of file
+ORDER BY w.name;
+
+-- name: GetWarehouseForUpdate :one
+SELECT * FROM warehouses
+WHERE warehouse_id = $1
+FOR UPDATE;
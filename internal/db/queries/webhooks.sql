@@ -0,0 +1,149 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (
+    url, secret, description, is_active, created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscriptions
+WHERE subscription_id = $1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions
+ORDER BY subscription_id;
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET url = sqlc.arg(url),
+    secret = COALESCE(sqlc.narg(secret), secret),
+    description = sqlc.arg(description),
+    is_active = sqlc.arg(is_active),
+    updated_at = CURRENT_TIMESTAMP
+WHERE subscription_id = sqlc.arg(subscription_id)
+RETURNING *;
+
+-- name: DeactivateWebhookSubscription :exec
+UPDATE webhook_subscriptions
+SET is_active = false, updated_at = CURRENT_TIMESTAMP
+WHERE subscription_id = $1;
+
+-- name: AddWebhookSubscriptionEvent :exec
+INSERT INTO webhook_subscription_events (subscription_id, event_type)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteWebhookSubscriptionEvents :exec
+DELETE FROM webhook_subscription_events
+WHERE subscription_id = $1;
+
+-- name: ListWebhookSubscriptionEvents :many
+SELECT event_type FROM webhook_subscription_events
+WHERE subscription_id = $1
+ORDER BY event_type;
+
+-- name: CreateWebhookEvent :one
+INSERT INTO webhook_events (event_type, payload)
+SELECT sqlc.arg(event_type)::varchar, sqlc.arg(payload)::jsonb
+WHERE EXISTS (
+    SELECT 1 FROM webhook_subscription_events se
+    JOIN webhook_subscriptions s ON s.subscription_id = se.subscription_id
+    WHERE se.event_type = sqlc.arg(event_type)::varchar AND s.is_active = true
+)
+RETURNING *;
+
+-- name: CreateWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (event_id, subscription_id)
+SELECT sqlc.arg(event_id), s.subscription_id
+FROM webhook_subscriptions s
+JOIN webhook_subscription_events se ON se.subscription_id = s.subscription_id
+WHERE se.event_type = sqlc.arg(event_type) AND s.is_active = true;
+
+-- name: GetWebhookEvent :one
+SELECT * FROM webhook_events
+WHERE event_id = $1;
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries d
+SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg(lease_seconds)::int)
+FROM webhook_events e, webhook_subscriptions s
+WHERE d.delivery_id IN (
+    SELECT due.delivery_id
+    FROM webhook_deliveries due
+    JOIN webhook_subscriptions ds ON ds.subscription_id = due.subscription_id
+    WHERE due.status = 'pending'
+      AND due.next_attempt_at <= CURRENT_TIMESTAMP
+      AND ds.is_active = true
+    ORDER BY due.next_attempt_at
+    LIMIT sqlc.arg(row_limit)
+    FOR UPDATE OF due SKIP LOCKED
+)
+  AND e.event_id = d.event_id
+  AND s.subscription_id = d.subscription_id
+RETURNING d.delivery_id, d.attempts, e.event_id, e.event_type, e.payload,
+          e.created_at AS event_created_at, s.url, s.secret;
+
+-- name: CreateWebhookDeliveryAttempt :one
+INSERT INTO webhook_delivery_attempts (
+    delivery_id, response_status, error, duration_ms
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: FinishWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries
+SET status = sqlc.arg(status),
+    attempts = attempts + 1,
+    next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg(backoff_seconds)::int),
+    last_attempt_at = CURRENT_TIMESTAMP,
+    response_status = sqlc.arg(response_status),
+    last_error = sqlc.arg(last_error),
+    delivered_at = CASE WHEN sqlc.arg(status) = 'delivered' THEN CURRENT_TIMESTAMP ELSE delivered_at END
+WHERE delivery_id = sqlc.arg(delivery_id)
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE delivery_id = $1;
+
+-- name: ListWebhookDeliveries :many
+SELECT d.*, e.event_type
+FROM webhook_deliveries d
+JOIN webhook_events e ON e.event_id = d.event_id
+WHERE d.subscription_id = sqlc.arg(subscription_id)
+  AND (sqlc.narg(status)::webhook_delivery_status IS NULL OR d.status = sqlc.narg(status))
+ORDER BY d.delivery_id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: ListWebhookDeliveryAttempts :many
+SELECT * FROM webhook_delivery_attempts
+WHERE delivery_id = $1
+ORDER BY attempt_id;
+
+-- name: RedeliverWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'pending',
+    attempts = 0,
+    next_attempt_at = CURRENT_TIMESTAMP,
+    delivered_at = NULL
+WHERE delivery_id = $1
+RETURNING *;
+
+-- name: DeleteOldWebhookEvents :execrows
+WITH old AS (
+    SELECT e.event_id FROM webhook_events e
+    WHERE e.created_at < sqlc.arg(before)
+      AND NOT EXISTS (
+          SELECT 1 FROM webhook_deliveries d
+          WHERE d.event_id = e.event_id AND d.status = 'pending'
+      )
+), old_attempts AS (
+    DELETE FROM webhook_delivery_attempts a
+    USING webhook_deliveries d
+    WHERE a.delivery_id = d.delivery_id AND d.event_id IN (SELECT event_id FROM old)
+), old_deliveries AS (
+    DELETE FROM webhook_deliveries
+    WHERE event_id IN (SELECT event_id FROM old)
+)
+DELETE FROM webhook_events
+WHERE event_id IN (SELECT event_id FROM old);
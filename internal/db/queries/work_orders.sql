@@ -0,0 +1,137 @@
+-- name: CreateWorkOrder :one
+INSERT INTO work_orders (
+    product_id, warehouse_id, output_location_id, planned_quantity,
+    output_batch_number, expiry_date, notes, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: SetWorkOrderNumber :one
+UPDATE work_orders
+SET work_order_number = $2
+WHERE work_order_id = $1
+RETURNING *;
+
+-- name: GetWorkOrder :one
+SELECT * FROM work_orders
+WHERE work_order_id = $1;
+
+-- name: GetWorkOrderForUpdate :one
+SELECT * FROM work_orders
+WHERE work_order_id = $1
+FOR UPDATE;
+
+-- name: ListWorkOrders :many
+SELECT * FROM work_orders
+WHERE (sqlc.narg(status)::work_order_status IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(product_id)::int IS NULL OR product_id = sqlc.narg(product_id))
+  AND (sqlc.narg(warehouse_id)::int IS NULL OR warehouse_id = sqlc.narg(warehouse_id))
+ORDER BY created_at DESC, work_order_id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: SetWorkOrderStatus :one
+UPDATE work_orders
+SET
+    status = $2,
+    released_at = CASE WHEN $2 = 'released' THEN CURRENT_TIMESTAMP ELSE released_at END
+WHERE work_order_id = $1
+RETURNING *;
+
+-- name: CompleteWorkOrder :one
+UPDATE work_orders
+SET
+    status = 'completed',
+    produced_quantity = $2,
+    scrap_quantity = $3,
+    output_batch_number = $4,
+    expiry_date = $5,
+    unit_cost = $6,
+    total_cost = $7,
+    completed_at = CURRENT_TIMESTAMP
+WHERE work_order_id = $1
+RETURNING *;
+
+-- name: CreateWorkOrderComponent :one
+INSERT INTO work_order_components (
+    work_order_id, component_product_id, quantity_per, planned_quantity
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListWorkOrderComponents :many
+SELECT * FROM work_order_components
+WHERE work_order_id = $1
+ORDER BY wo_component_id;
+
+-- name: AddWorkOrderComponentConsumption :one
+UPDATE work_order_components
+SET
+    consumed_quantity = consumed_quantity + $2,
+    scrap_quantity = scrap_quantity + $3
+WHERE wo_component_id = $1
+RETURNING *;
+
+-- name: CreateWorkOrderReservation :one
+INSERT INTO work_order_reservations (
+    work_order_id, wo_component_id, inventory_id, quantity, open_quantity
+) VALUES (
+    $1, $2, $3, $4, $4
+) RETURNING *;
+
+-- name: ListWorkOrderReservations :many
+SELECT * FROM work_order_reservations
+WHERE work_order_id = $1
+ORDER BY reservation_id;
+
+-- name: ListOpenWorkOrderReservationsForUpdate :many
+SELECT r.* FROM work_order_reservations r
+JOIN inventory i ON r.inventory_id = i.inventory_id
+WHERE r.wo_component_id = $1
+  AND r.open_quantity > 0
+ORDER BY i.expiry_date NULLS LAST, r.reservation_id
+FOR UPDATE OF r;
+
+-- name: ReduceWorkOrderReservation :one
+UPDATE work_order_reservations
+SET open_quantity = open_quantity - $2
+WHERE reservation_id = $1 AND open_quantity >= $2
+RETURNING *;
+
+-- name: CreateWorkOrderConsumption :one
+INSERT INTO work_order_consumptions (
+    work_order_id, wo_component_id, component_product_id, inventory_id,
+    movement_id, batch_number, quantity, is_scrap, total_cost, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: ListWorkOrderConsumptions :many
+SELECT * FROM work_order_consumptions
+WHERE work_order_id = $1
+ORDER BY consumption_id;
+
+-- name: TraceWorkOrderLot :many
+SELECT wo.work_order_id, wo.work_order_number, wo.completed_at,
+       c.component_product_id, p.sku, c.batch_number,
+       SUM(c.quantity)::int as quantity
+FROM work_orders wo
+JOIN work_order_consumptions c ON c.work_order_id = wo.work_order_id
+JOIN products p ON c.component_product_id = p.product_id
+WHERE wo.product_id = $1
+  AND wo.output_batch_number = $2
+  AND wo.status = 'completed'
+GROUP BY wo.work_order_id, c.component_product_id, p.sku, c.batch_number
+ORDER BY wo.work_order_id, p.sku, c.batch_number NULLS LAST;
+
+-- name: TraceComponentLot :many
+SELECT wo.work_order_id, wo.work_order_number, wo.completed_at,
+       wo.product_id, p.sku, wo.output_batch_number,
+       SUM(c.quantity)::int as quantity
+FROM work_order_consumptions c
+JOIN work_orders wo ON c.work_order_id = wo.work_order_id
+JOIN products p ON wo.product_id = p.product_id
+WHERE c.component_product_id = $1
+  AND c.batch_number = $2
+  AND wo.status = 'completed'
+GROUP BY wo.work_order_id, p.sku
+ORDER BY wo.work_order_id;
@@ -8,8 +8,33 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
+const countCategoryChildren = `-- name: CountCategoryChildren :one
+SELECT COUNT(*) FROM categories
+WHERE parent_category_id = $1
+`
+
+func (q *Queries) CountCategoryChildren(ctx context.Context, parentCategoryID sql.NullInt32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCategoryChildren, parentCategoryID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countCategoryProducts = `-- name: CountCategoryProducts :one
+SELECT COUNT(*) FROM products
+WHERE category_id = $1
+`
+
+func (q *Queries) CountCategoryProducts(ctx context.Context, categoryID sql.NullInt32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCategoryProducts, categoryID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCategory = `-- name: CreateCategory :one
 INSERT INTO categories (
     category_code, name, parent_category_id, description
@@ -92,6 +117,53 @@ func (q *Queries) GetCategoryByCode(ctx context.Context, categoryCode string) (C
 	return i, err
 }
 
+const getCategoryPath = `-- name: GetCategoryPath :many
+WITH RECURSIVE up AS (
+    SELECT c.category_id, c.parent_category_id, 0 AS height, ARRAY[c.category_id] AS seen
+    FROM categories c
+    WHERE c.category_id = $1
+    UNION ALL
+    SELECT p.category_id, p.parent_category_id, up.height + 1, up.seen || p.category_id
+    FROM categories p
+    JOIN up ON p.category_id = up.parent_category_id
+    WHERE NOT p.category_id = ANY(up.seen)
+)
+SELECT c.category_id, c.category_code, c.name, c.parent_category_id, c.description, c.created_at
+FROM up
+JOIN categories c ON c.category_id = up.category_id
+ORDER BY up.height DESC
+`
+
+func (q *Queries) GetCategoryPath(ctx context.Context, categoryID int32) ([]Category, error) {
+	rows, err := q.db.QueryContext(ctx, getCategoryPath, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Category
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.CategoryCode,
+			&i.Name,
+			&i.ParentCategoryID,
+			&i.Description,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCategories = `-- name: ListCategories :many
 SELECT category_id, category_code, name, parent_category_id, description, created_at FROM categories 
 ORDER BY category_id
@@ -133,6 +205,105 @@ func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams)
 	return items, nil
 }
 
+const listCategoryDescendantIDs = `-- name: ListCategoryDescendantIDs :many
+WITH RECURSIVE down AS (
+    SELECT category_id, ARRAY[category_id] AS path
+    FROM categories
+    WHERE category_id = $1
+    UNION ALL
+    SELECT c.category_id, down.path || c.category_id
+    FROM categories c
+    JOIN down ON c.parent_category_id = down.category_id
+    WHERE NOT c.category_id = ANY(down.path)
+)
+SELECT category_id FROM down
+`
+
+func (q *Queries) ListCategoryDescendantIDs(ctx context.Context, categoryID int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listCategoryDescendantIDs, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var category_id int32
+		if err := rows.Scan(&category_id); err != nil {
+			return nil, err
+		}
+		items = append(items, category_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryTree = `-- name: ListCategoryTree :many
+WITH RECURSIVE tree AS (
+    SELECT c.category_id, 0 AS depth, ARRAY[c.category_id] AS path
+    FROM categories c
+    WHERE ($1::int IS NULL AND c.parent_category_id IS NULL)
+       OR c.category_id = $1::int
+    UNION ALL
+    SELECT c.category_id, t.depth + 1, t.path || c.category_id
+    FROM categories c
+    JOIN tree t ON c.parent_category_id = t.category_id
+    WHERE NOT c.category_id = ANY(t.path)
+)
+SELECT c.category_id, c.category_code, c.name, c.parent_category_id, c.description, c.created_at, t.depth::int AS depth,
+       (SELECT COUNT(*) FROM products p WHERE p.category_id = c.category_id AND p.is_active = true) AS product_count
+FROM tree t
+JOIN categories c ON c.category_id = t.category_id
+ORDER BY t.path
+`
+
+type ListCategoryTreeRow struct {
+	CategoryID       int32          `json:"category_id"`
+	CategoryCode     string         `json:"category_code"`
+	Name             string         `json:"name"`
+	ParentCategoryID sql.NullInt32  `json:"parent_category_id"`
+	Description      sql.NullString `json:"description"`
+	CreatedAt        time.Time      `json:"created_at"`
+	Depth            int32          `json:"depth"`
+	ProductCount     int64          `json:"product_count"`
+}
+
+func (q *Queries) ListCategoryTree(ctx context.Context, rootID sql.NullInt32) ([]ListCategoryTreeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCategoryTree, rootID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCategoryTreeRow
+	for rows.Next() {
+		var i ListCategoryTreeRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.CategoryCode,
+			&i.Name,
+			&i.ParentCategoryID,
+			&i.Description,
+			&i.CreatedAt,
+			&i.Depth,
+			&i.ProductCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRootCategories = `-- name: ListRootCategories :many
 SELECT category_id, category_code, name, parent_category_id, description, created_at FROM categories 
 WHERE parent_category_id IS NULL
@@ -205,6 +376,80 @@ func (q *Queries) ListSubCategories(ctx context.Context, parentCategoryID sql.Nu
 	return items, nil
 }
 
+const lockCategoryTree = `-- name: LockCategoryTree :exec
+SELECT pg_advisory_xact_lock(hashtext('categories'))
+`
+
+func (q *Queries) LockCategoryTree(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, lockCategoryTree)
+	return err
+}
+
+const moveCategory = `-- name: MoveCategory :one
+UPDATE categories
+SET parent_category_id = $2
+WHERE category_id = $1
+RETURNING category_id, category_code, name, parent_category_id, description, created_at
+`
+
+type MoveCategoryParams struct {
+	CategoryID       int32         `json:"category_id"`
+	ParentCategoryID sql.NullInt32 `json:"parent_category_id"`
+}
+
+func (q *Queries) MoveCategory(ctx context.Context, arg MoveCategoryParams) (Category, error) {
+	row := q.db.QueryRowContext(ctx, moveCategory, arg.CategoryID, arg.ParentCategoryID)
+	var i Category
+	err := row.Scan(
+		&i.CategoryID,
+		&i.CategoryCode,
+		&i.Name,
+		&i.ParentCategoryID,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const reassignCategoryChildren = `-- name: ReassignCategoryChildren :execrows
+UPDATE categories
+SET parent_category_id = $1
+WHERE parent_category_id = $2
+`
+
+type ReassignCategoryChildrenParams struct {
+	NewParentID sql.NullInt32 `json:"new_parent_id"`
+	CategoryID  sql.NullInt32 `json:"category_id"`
+}
+
+func (q *Queries) ReassignCategoryChildren(ctx context.Context, arg ReassignCategoryChildrenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reassignCategoryChildren, arg.NewParentID, arg.CategoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const reassignCategoryProducts = `-- name: ReassignCategoryProducts :execrows
+UPDATE products
+SET category_id = $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE category_id = $2
+`
+
+type ReassignCategoryProductsParams struct {
+	NewCategoryID sql.NullInt32 `json:"new_category_id"`
+	CategoryID    sql.NullInt32 `json:"category_id"`
+}
+
+func (q *Queries) ReassignCategoryProducts(ctx context.Context, arg ReassignCategoryProductsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reassignCategoryProducts, arg.NewCategoryID, arg.CategoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateCategory = `-- name: UpdateCategory :one
 UPDATE categories 
 SET 
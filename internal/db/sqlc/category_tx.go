@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrCategoryCycle          = errors.New("category cannot be placed under itself or one of its descendants")
+	ErrCategoryInUse          = errors.New("category still has subcategories or products")
+	ErrParentCategoryNotFound = errors.New("parent category not found")
+)
+
+// checkCategoryParent makes sure parent exists and is neither the category
+// nor one of its descendants. The caller holds the category tree lock, so
+// no concurrent move can invalidate the answer before it commits.
+func checkCategoryParent(ctx context.Context, q *Queries, categoryID int32, parent sql.NullInt32) error {
+	if !parent.Valid {
+		return nil
+	}
+	if _, err := q.GetCategory(ctx, parent.Int32); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrParentCategoryNotFound
+		}
+		return err
+	}
+	subtree, err := q.ListCategoryDescendantIDs(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+	for _, id := range subtree {
+		if id == parent.Int32 {
+			return ErrCategoryCycle
+		}
+	}
+	return nil
+}
+
+// MoveCategoryTx moves a category, with its whole subtree, under a new
+// parent, or to the root when the parent is null.
+func (store *SQLStore) MoveCategoryTx(ctx context.Context, arg MoveCategoryParams) (Category, error) {
+	var result Category
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+		if _, err := q.GetCategory(ctx, arg.CategoryID); err != nil {
+			return err
+		}
+		if err := checkCategoryParent(ctx, q, arg.CategoryID, arg.ParentCategoryID); err != nil {
+			return err
+		}
+
+		var err error
+		result, err = q.MoveCategory(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateCategoryTx updates a category, refusing a parent that would turn the
+// tree into a cycle.
+func (store *SQLStore) UpdateCategoryTx(ctx context.Context, arg UpdateCategoryParams) (Category, error) {
+	var result Category
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+		if _, err := q.GetCategory(ctx, arg.CategoryID); err != nil {
+			return err
+		}
+		if err := checkCategoryParent(ctx, q, arg.CategoryID, arg.ParentCategoryID); err != nil {
+			return err
+		}
+
+		var err error
+		result, err = q.UpdateCategory(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+type DeleteCategoryTxParams struct {
+	CategoryID int32
+	// Reassign moves the category's subcategories and products to
+	// ReassignTo before deleting it, instead of refusing. A null ReassignTo
+	// means the deleted category's own parent: subcategories of a root
+	// category become roots and its products are left uncategorised.
+	Reassign   bool
+	ReassignTo sql.NullInt32
+}
+
+type DeleteCategoryTxResult struct {
+	Category           Category      `json:"category"`
+	ReassignedTo       sql.NullInt32 `json:"reassigned_to"`
+	MovedSubcategories int64         `json:"moved_subcategories"`
+	MovedProducts      int64         `json:"moved_products"`
+}
+
+// DeleteCategoryTx deletes a category and its attribute definitions. One
+// with subcategories or products, inactive products included, is refused
+// with ErrCategoryInUse unless they are reassigned.
+func (store *SQLStore) DeleteCategoryTx(ctx context.Context, arg DeleteCategoryTxParams) (DeleteCategoryTxResult, error) {
+	var result DeleteCategoryTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+		category, err := q.GetCategory(ctx, arg.CategoryID)
+		if err != nil {
+			return err
+		}
+		result.Category = category
+
+		id := sql.NullInt32{Int32: category.CategoryID, Valid: true}
+		if arg.Reassign {
+			target := arg.ReassignTo
+			if !target.Valid {
+				target = category.ParentCategoryID
+			}
+			if err := checkCategoryParent(ctx, q, category.CategoryID, target); err != nil {
+				return err
+			}
+			result.ReassignedTo = target
+
+			if result.MovedSubcategories, err = q.ReassignCategoryChildren(ctx, ReassignCategoryChildrenParams{
+				NewParentID: target,
+				CategoryID:  id,
+			}); err != nil {
+				return err
+			}
+			if result.MovedProducts, err = q.ReassignCategoryProducts(ctx, ReassignCategoryProductsParams{
+				NewCategoryID: target,
+				CategoryID:    id,
+			}); err != nil {
+				return err
+			}
+		} else {
+			children, err := q.CountCategoryChildren(ctx, id)
+			if err != nil {
+				return err
+			}
+			products, err := q.CountCategoryProducts(ctx, id)
+			if err != nil {
+				return err
+			}
+			if children > 0 || products > 0 {
+				return fmt.Errorf("%w: %d subcategories, %d products", ErrCategoryInUse, children, products)
+			}
+		}
+
+		if err := q.DeleteCategoryAttributesByCategory(ctx, category.CategoryID); err != nil {
+			return err
+		}
+		return q.DeleteCategory(ctx, category.CategoryID)
+	})
+
+	return result, err
+}
@@ -0,0 +1,357 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/molu/stock-management-system/internal/costing"
+	"github.com/shopspring/decimal"
+)
+
+var ErrCostingMethodInvalid = errors.New("costing method must be fifo, weighted_average or standard")
+
+// receiptPrice is what a receipt was bought at, in the base currency.
+type receiptPrice struct {
+	UnitCost     decimal.NullDecimal
+	PoItemID     sql.NullInt32
+	CurrencyCode sql.NullString
+	ExchangeRate decimal.NullDecimal
+}
+
+// receiptUnitCost is the price a receipt came in at, by what it references.
+// A purchase order line gives its unit price, converted at the day's exchange
+// rate when the order is in a foreign currency.
+// An opening balance gives its imported unit cost.
+// A kit assembly or assembly line gives the cost of the kits or components it
+// produced.
+// A work order gives the unit cost of its output.
+// Other movements have no price of their own.
+func receiptUnitCost(ctx context.Context, q *Queries, m StockMovement) (receiptPrice, error) {
+	var price receiptPrice
+	if !m.ReferenceID.Valid {
+		return price, nil
+	}
+
+	switch m.ReferenceTable.String {
+	case "purchase_order_items":
+		item, err := q.GetPurchaseOrderItem(ctx, m.ReferenceID.Int32)
+		if err != nil {
+			return price, err
+		}
+		price.UnitCost = decimal.NullDecimal{Decimal: item.UnitPrice, Valid: true}
+		price.PoItemID = sql.NullInt32{Int32: item.PoItemID, Valid: true}
+
+		currency, err := q.GetPurchaseOrderItemCurrency(ctx, item.PoItemID)
+		if err != nil {
+			return price, err
+		}
+		rate, err := exchangeRate(ctx, q, currency.String, m.MovementDate)
+		if err != nil || !rate.Valid {
+			return price, err
+		}
+		price.UnitCost.Decimal = item.UnitPrice.Mul(rate.Decimal).Round(costing.UnitCostPlaces)
+		price.CurrencyCode = currency
+		price.ExchangeRate = rate
+	case "opening_balances":
+		balance, err := q.GetOpeningBalance(ctx, m.ReferenceID.Int32)
+		if err != nil {
+			return price, err
+		}
+		price.UnitCost = balance.UnitCost
+	case "kit_assemblies":
+		assembly, err := q.GetKitAssembly(ctx, m.ReferenceID.Int32)
+		if err != nil {
+			return price, err
+		}
+		price.UnitCost = decimal.NullDecimal{Decimal: assembly.UnitCost, Valid: true}
+	case "kit_assembly_lines":
+		line, err := q.GetKitAssemblyLine(ctx, m.ReferenceID.Int32)
+		if err != nil {
+			return price, err
+		}
+		price.UnitCost = decimal.NullDecimal{Decimal: line.UnitCost, Valid: true}
+	case "work_orders":
+		wo, err := q.GetWorkOrder(ctx, m.ReferenceID.Int32)
+		if err != nil {
+			return price, err
+		}
+		price.UnitCost = decimal.NullDecimal{Decimal: wo.UnitCost, Valid: true}
+	}
+	return price, nil
+}
+
+// postMovementCost books the value of a stock movement. Receipts are valued at
+// their purchase order price (or the current unit cost when they have none),
+// adding a FIFO layer or a purchase price variance depending on the product's
+// costing method; issues consume FIFO layers or are valued at the moving
+// average or standard cost. Stock transfers stay within the warehouse
+// balance and are not booked. It returns nil when nothing was booked.
+func postMovementCost(ctx context.Context, q *Queries, m StockMovement) (*CostEntry, error) {
+	if m.QuantityChange == 0 || m.MovementType == MovementTypeStockTransfer {
+		return nil, nil
+	}
+
+	product, err := q.GetProduct(ctx, m.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := q.EnsureCostBalance(ctx, EnsureCostBalanceParams{
+		ProductID:   m.ProductID,
+		WarehouseID: m.WarehouseID,
+	}); err != nil {
+		return nil, err
+	}
+	balance, err := q.GetCostBalanceForUpdate(ctx, GetCostBalanceForUpdateParams{
+		ProductID:   m.ProductID,
+		WarehouseID: m.WarehouseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	method := costing.Method(product.CostingMethod)
+	onHand := costing.Balance{Quantity: balance.Quantity, Value: balance.TotalValue}
+	current := onHand.UnitCost(product.CostPrice)
+	if method == costing.MethodStandard && product.StandardCost.Valid {
+		current = product.StandardCost.Decimal
+	}
+
+	movementID := sql.NullInt32{Int32: m.MovementID, Valid: true}
+	entry := CreateCostEntryParams{
+		ProductID:     m.ProductID,
+		WarehouseID:   m.WarehouseID,
+		MovementID:    movementID,
+		CostingMethod: product.CostingMethod,
+		Quantity:      m.QuantityChange,
+	}
+
+	if m.QuantityChange > 0 {
+		price, err := receiptUnitCost(ctx, q, m)
+		if err != nil {
+			return nil, err
+		}
+		actual := current
+		if price.UnitCost.Valid {
+			actual = price.UnitCost.Decimal
+		}
+
+		receipt, err := costing.Receive(method, m.QuantityChange, actual, product.StandardCost)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case method == costing.MethodFIFO:
+			_, err = q.CreateCostLayer(ctx, CreateCostLayerParams{
+				ProductID:        m.ProductID,
+				WarehouseID:      m.WarehouseID,
+				MovementID:       movementID,
+				PoItemID:         price.PoItemID,
+				QuantityReceived: m.QuantityChange,
+				UnitCost:         receipt.UnitCost,
+			})
+		case method == costing.MethodStandard && !receipt.Variance.IsZero():
+			_, err = q.CreatePurchasePriceVariance(ctx, CreatePurchasePriceVarianceParams{
+				ProductID:      m.ProductID,
+				WarehouseID:    m.WarehouseID,
+				MovementID:     movementID,
+				PoItemID:       price.PoItemID,
+				Quantity:       m.QuantityChange,
+				StandardCost:   receipt.UnitCost,
+				ActualCost:     actual.Round(costing.UnitCostPlaces),
+				VarianceAmount: receipt.Variance,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		entry.EntryType = CostEntryTypeReceipt
+		entry.UnitCost = receipt.UnitCost
+		entry.TotalCost = receipt.Cost
+		entry.CurrencyCode = price.CurrencyCode
+		entry.ExchangeRate = price.ExchangeRate
+	} else {
+		quantity := -m.QuantityChange
+
+		var issue costing.Issue
+		switch method {
+		case costing.MethodFIFO:
+			rows, err := q.ListOpenCostLayersForUpdate(ctx, ListOpenCostLayersForUpdateParams{
+				ProductID:   m.ProductID,
+				WarehouseID: m.WarehouseID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			layers := make([]costing.Layer, len(rows))
+			for i, row := range rows {
+				layers[i] = costing.Layer{ID: row.LayerID, Remaining: row.QuantityRemaining, UnitCost: row.UnitCost}
+			}
+
+			issue = costing.ConsumeFIFO(layers, quantity, current)
+			for _, draw := range issue.Draws {
+				if draw.LayerID == 0 {
+					continue
+				}
+				if err := q.ConsumeCostLayer(ctx, ConsumeCostLayerParams{
+					LayerID:           draw.LayerID,
+					QuantityRemaining: draw.Quantity,
+				}); err != nil {
+					return nil, err
+				}
+			}
+		case costing.MethodStandard:
+			issue = costing.ConsumeStandard(current, quantity)
+		default:
+			issue = costing.ConsumeAverage(onHand, quantity, current)
+		}
+
+		entry.EntryType = CostEntryTypeIssue
+		entry.UnitCost = issue.UnitCost()
+		entry.TotalCost = issue.Cost.Neg()
+	}
+
+	if _, err := q.UpdateCostBalance(ctx, UpdateCostBalanceParams{
+		ProductID:   m.ProductID,
+		WarehouseID: m.WarehouseID,
+		Quantity:    entry.Quantity,
+		TotalValue:  entry.TotalCost,
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := q.CreateCostEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+type CreateStockMovementTxResult struct {
+	Movement StockMovement `json:"movement"`
+	Cost     *CostEntry    `json:"cost,omitempty"`
+}
+
+// CreateStockMovementTx posts a stock movement together with its cost entry.
+func (store *SQLStore) CreateStockMovementTx(ctx context.Context, arg CreateStockMovementParams) (CreateStockMovementTxResult, error) {
+	var result CreateStockMovementTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Movement, err = postStockMovement(ctx, q, arg)
+		if err != nil {
+			return err
+		}
+
+		result.Cost, err = postMovementCost(ctx, q, result.Movement)
+		return err
+	})
+
+	return result, err
+}
+
+type SetProductCostingTxParams struct {
+	ProductID     int32
+	CostingMethod CostingMethod
+	StandardCost  decimal.NullDecimal
+}
+
+type SetProductCostingTxResult struct {
+	Product      Product     `json:"product"`
+	Revaluations []CostEntry `json:"revaluations"`
+}
+
+// SetProductCostingTx changes how a product is costed. Moving to or changing
+// a standard cost revalues the stock on hand in every warehouse; changing the
+// method closes the open FIFO layers, and moving to FIFO opens one layer per
+// warehouse at the current average cost.
+func (store *SQLStore) SetProductCostingTx(ctx context.Context, arg SetProductCostingTxParams) (SetProductCostingTxResult, error) {
+	var result SetProductCostingTxResult
+
+	method := costing.Method(arg.CostingMethod)
+	if !method.Valid() {
+		return result, ErrCostingMethodInvalid
+	}
+	if method == costing.MethodStandard && !arg.StandardCost.Valid {
+		return result, costing.ErrNoStandardCost
+	}
+	if arg.StandardCost.Valid {
+		arg.StandardCost.Decimal = arg.StandardCost.Decimal.Round(costing.UnitCostPlaces)
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		product, err := q.GetProduct(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+
+		balances, err := q.ListCostBalancesByProductForUpdate(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+
+		revalue := method == costing.MethodStandard &&
+			(product.CostingMethod != CostingMethodStandard || !product.StandardCost.Valid || !product.StandardCost.Decimal.Equal(arg.StandardCost.Decimal))
+		if revalue {
+			for _, b := range balances {
+				delta := arg.StandardCost.Decimal.Mul(decimal.NewFromInt32(b.Quantity)).Sub(b.TotalValue)
+				if delta.IsZero() {
+					continue
+				}
+				if _, err := q.UpdateCostBalance(ctx, UpdateCostBalanceParams{
+					ProductID:   b.ProductID,
+					WarehouseID: b.WarehouseID,
+					TotalValue:  delta,
+				}); err != nil {
+					return err
+				}
+				entry, err := q.CreateCostEntry(ctx, CreateCostEntryParams{
+					ProductID:     b.ProductID,
+					WarehouseID:   b.WarehouseID,
+					EntryType:     CostEntryTypeRevaluation,
+					CostingMethod: arg.CostingMethod,
+					UnitCost:      arg.StandardCost.Decimal,
+					TotalCost:     delta,
+				})
+				if err != nil {
+					return err
+				}
+				result.Revaluations = append(result.Revaluations, entry)
+			}
+		}
+
+		if product.CostingMethod != arg.CostingMethod {
+			if err := q.CloseCostLayersByProduct(ctx, arg.ProductID); err != nil {
+				return err
+			}
+			if method == costing.MethodFIFO {
+				for _, b := range balances {
+					if b.Quantity <= 0 {
+						continue
+					}
+					onHand := costing.Balance{Quantity: b.Quantity, Value: b.TotalValue}
+					if _, err := q.CreateCostLayer(ctx, CreateCostLayerParams{
+						ProductID:        b.ProductID,
+						WarehouseID:      b.WarehouseID,
+						QuantityReceived: b.Quantity,
+						UnitCost:         onHand.UnitCost(product.CostPrice),
+					}); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		result.Product, err = q.SetProductCosting(ctx, SetProductCostingParams{
+			ProductID:     arg.ProductID,
+			CostingMethod: arg.CostingMethod,
+			StandardCost:  arg.StandardCost,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,187 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/molu/stock-management-system/internal/etag"
+)
+
+var (
+	ErrPreconditionFailed   = errors.New("the row was changed since it was read; fetch it again and retry")
+	ErrPreconditionRequired = errors.New("If-Match is required; send the ETag the row was read with")
+)
+
+// ETag returns the entity tag of the product's current version.
+func (p Product) ETag() string {
+	return etag.Of(p.ProductID, p.UpdatedAt)
+}
+
+// ETag returns the entity tag of the warehouse's current version.
+func (w Warehouse) ETag() string {
+	return etag.Of(w.WarehouseID, w.UpdatedAt)
+}
+
+// ETag returns the entity tag of the supplier's current version.
+func (s Supplier) ETag() string {
+	return etag.Of(s.SupplierID, s.UpdatedAt)
+}
+
+// ETag returns the entity tag of the inventory row's current version.
+func (i Inventory) ETag() string {
+	return etag.Of(i.InventoryID, i.UpdatedAt)
+}
+
+// checkIfMatch compares an If-Match value against the locked row's tag.
+// Writes must be conditional, so an empty value is refused; "*" is the way to
+// overwrite whatever version is stored.
+func checkIfMatch(ifMatch, current string) error {
+	if ifMatch == "" {
+		return ErrPreconditionRequired
+	}
+	if !etag.Match(ifMatch, current) {
+		return ErrPreconditionFailed
+	}
+	return nil
+}
+
+type UpdateProductTxParams struct {
+	UpdateProductParams
+	IfMatch string
+}
+
+// UpdateProductTx updates a product if it still matches IfMatch.
+func (store *SQLStore) UpdateProductTx(ctx context.Context, arg UpdateProductTxParams) (Product, error) {
+	var result Product
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		product, err := q.GetProductForUpdate(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+		if err := checkIfMatch(arg.IfMatch, product.ETag()); err != nil {
+			return err
+		}
+
+		result, err = q.UpdateProduct(ctx, arg.UpdateProductParams)
+		return err
+	})
+
+	return result, err
+}
+
+type DeleteProductTxParams struct {
+	ProductID int32
+	IfMatch   string
+}
+
+// DeleteProductTx soft deletes a product if it still matches IfMatch.
+func (store *SQLStore) DeleteProductTx(ctx context.Context, arg DeleteProductTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		product, err := q.GetProductForUpdate(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+		if err := checkIfMatch(arg.IfMatch, product.ETag()); err != nil {
+			return err
+		}
+
+		return q.SoftDeleteProduct(ctx, arg.ProductID)
+	})
+}
+
+type UpdateWarehouseTxParams struct {
+	UpdateWarehouseParams
+	IfMatch string
+}
+
+// UpdateWarehouseTx updates a warehouse if it still matches IfMatch.
+func (store *SQLStore) UpdateWarehouseTx(ctx context.Context, arg UpdateWarehouseTxParams) (Warehouse, error) {
+	var result Warehouse
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		warehouse, err := q.GetWarehouseForUpdate(ctx, arg.WarehouseID)
+		if err != nil {
+			return err
+		}
+		if err := checkIfMatch(arg.IfMatch, warehouse.ETag()); err != nil {
+			return err
+		}
+
+		result, err = q.UpdateWarehouse(ctx, arg.UpdateWarehouseParams)
+		return err
+	})
+
+	return result, err
+}
+
+type DeactivateWarehouseTxParams struct {
+	WarehouseID int32
+	IfMatch     string
+}
+
+// DeactivateWarehouseTx deactivates a warehouse if it still matches IfMatch.
+func (store *SQLStore) DeactivateWarehouseTx(ctx context.Context, arg DeactivateWarehouseTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		warehouse, err := q.GetWarehouseForUpdate(ctx, arg.WarehouseID)
+		if err != nil {
+			return err
+		}
+		if err := checkIfMatch(arg.IfMatch, warehouse.ETag()); err != nil {
+			return err
+		}
+
+		return q.DeactivateWarehouse(ctx, arg.WarehouseID)
+	})
+}
+
+type UpdateSupplierTxParams struct {
+	UpdateSupplierParams
+	IfMatch string
+}
+
+// UpdateSupplierTx updates a supplier if it still matches IfMatch.
+func (store *SQLStore) UpdateSupplierTx(ctx context.Context, arg UpdateSupplierTxParams) (Supplier, error) {
+	var result Supplier
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		supplier, err := q.GetSupplierForUpdate(ctx, arg.SupplierID)
+		if err != nil {
+			return err
+		}
+		if err := checkIfMatch(arg.IfMatch, supplier.ETag()); err != nil {
+			return err
+		}
+
+		result, err = q.UpdateSupplier(ctx, arg.UpdateSupplierParams)
+		return err
+	})
+
+	return result, err
+}
+
+type UpdateInventoryStatusTxParams struct {
+	UpdateInventoryStatusParams
+	IfMatch string
+}
+
+// UpdateInventoryStatusTx sets the status of an inventory row if it still
+// matches IfMatch.
+func (store *SQLStore) UpdateInventoryStatusTx(ctx context.Context, arg UpdateInventoryStatusTxParams) (Inventory, error) {
+	var result Inventory
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		inv, err := q.GetInventoryForUpdate(ctx, arg.InventoryID)
+		if err != nil {
+			return err
+		}
+		if err := checkIfMatch(arg.IfMatch, inv.ETag()); err != nil {
+			return err
+		}
+
+		result, err = q.UpdateInventoryStatus(ctx, arg.UpdateInventoryStatusParams)
+		return err
+	})
+
+	return result, err
+}
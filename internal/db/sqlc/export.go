@@ -0,0 +1,426 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Exports stream their rows to a callback as they are read from the
+// database instead of collecting them into a slice like sqlc's :many
+// queries, so exporting a large table holds one row in memory at a time.
+// Every filter is optional: a NULL parameter matches all rows.
+
+const exportProducts = `SELECT p.product_id, p.sku, p.name, p.description, p.category_id,
+  p.unit_price, p.cost_price, p.barcode, p.weight, p.dimensions,
+  p.supplier_id, p.min_stock_level, p.max_stock_level, p.reorder_point,
+  p.safety_stock, p.lead_time_days, p.auto_reorder, p.last_reorder_date,
+  p.is_active, p.created_at, p.updated_at, p.base_uom_id, p.costing_method,
+  p.standard_cost, c.category_code, s.code AS supplier_code
+FROM products p
+LEFT JOIN categories c ON p.category_id = c.category_id
+LEFT JOIN suppliers s ON p.supplier_id = s.supplier_id
+WHERE ($1::int IS NULL OR p.category_id = $1)
+  AND ($2::int IS NULL OR p.supplier_id = $2)
+  AND ($3::boolean IS NULL OR p.is_active = $3)
+ORDER BY p.product_id
+`
+
+type ExportProductsParams struct {
+	CategoryID sql.NullInt32 `json:"category_id"`
+	SupplierID sql.NullInt32 `json:"supplier_id"`
+	IsActive   sql.NullBool  `json:"is_active"`
+}
+
+type ExportProductRow struct {
+	ProductID       int32               `json:"product_id"`
+	Sku             string              `json:"sku"`
+	Name            string              `json:"name"`
+	Description     sql.NullString      `json:"description"`
+	CategoryID      sql.NullInt32       `json:"category_id"`
+	UnitPrice       decimal.Decimal     `json:"unit_price"`
+	CostPrice       decimal.Decimal     `json:"cost_price"`
+	Barcode         sql.NullString      `json:"barcode"`
+	Weight          decimal.Decimal     `json:"weight"`
+	Dimensions      sql.NullString      `json:"dimensions"`
+	SupplierID      sql.NullInt32       `json:"supplier_id"`
+	MinStockLevel   int32               `json:"min_stock_level"`
+	MaxStockLevel   sql.NullInt32       `json:"max_stock_level"`
+	ReorderPoint    sql.NullInt32       `json:"reorder_point"`
+	SafetyStock     sql.NullInt32       `json:"safety_stock"`
+	LeadTimeDays    sql.NullInt32       `json:"lead_time_days"`
+	AutoReorder     bool                `json:"auto_reorder"`
+	LastReorderDate sql.NullTime        `json:"last_reorder_date"`
+	IsActive        bool                `json:"is_active"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+	BaseUomID       sql.NullInt32       `json:"base_uom_id"`
+	CostingMethod   CostingMethod       `json:"costing_method"`
+	StandardCost    decimal.NullDecimal `json:"standard_cost"`
+	CategoryCode    sql.NullString      `json:"category_code"`
+	SupplierCode    sql.NullString      `json:"supplier_code"`
+}
+
+// ExportProducts streams products in ID order.
+func (q *Queries) ExportProducts(ctx context.Context, arg ExportProductsParams, fn func(ExportProductRow) error) error {
+	rows, err := q.db.QueryContext(ctx, exportProducts, arg.CategoryID, arg.SupplierID, arg.IsActive)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i ExportProductRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.Sku,
+			&i.Name,
+			&i.Description,
+			&i.CategoryID,
+			&i.UnitPrice,
+			&i.CostPrice,
+			&i.Barcode,
+			&i.Weight,
+			&i.Dimensions,
+			&i.SupplierID,
+			&i.MinStockLevel,
+			&i.MaxStockLevel,
+			&i.ReorderPoint,
+			&i.SafetyStock,
+			&i.LeadTimeDays,
+			&i.AutoReorder,
+			&i.LastReorderDate,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.BaseUomID,
+			&i.CostingMethod,
+			&i.StandardCost,
+			&i.CategoryCode,
+			&i.SupplierCode,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+const exportInventory = `SELECT i.inventory_id, i.product_id, i.warehouse_id, i.location_id,
+  i.quantity, i.reserved_quantity, i.batch_number, i.expiry_date,
+  i.manufacturing_date, i.serial_number, i.status, i.last_counted_date,
+  i.created_at, i.updated_at, p.sku, p.name AS product_name,
+  w.code AS warehouse_code, l.location_code
+FROM inventory i
+JOIN products p ON i.product_id = p.product_id
+JOIN warehouses w ON i.warehouse_id = w.warehouse_id
+LEFT JOIN locations l ON i.location_id = l.location_id
+WHERE ($1::int IS NULL OR i.warehouse_id = $1)
+  AND ($2::int IS NULL OR i.product_id = $2)
+  AND ($3::text IS NULL OR i.status::text = $3)
+  AND ($4::date IS NULL OR i.expiry_date < $4)
+ORDER BY i.inventory_id
+`
+
+type ExportInventoryParams struct {
+	WarehouseID    sql.NullInt32  `json:"warehouse_id"`
+	ProductID      sql.NullInt32  `json:"product_id"`
+	Status         sql.NullString `json:"status"`
+	ExpiringBefore sql.NullTime   `json:"expiring_before"`
+}
+
+type ExportInventoryRow struct {
+	InventoryID       int32           `json:"inventory_id"`
+	ProductID         int32           `json:"product_id"`
+	WarehouseID       int32           `json:"warehouse_id"`
+	LocationID        sql.NullInt32   `json:"location_id"`
+	Quantity          int32           `json:"quantity"`
+	ReservedQuantity  int32           `json:"reserved_quantity"`
+	BatchNumber       sql.NullString  `json:"batch_number"`
+	ExpiryDate        sql.NullTime    `json:"expiry_date"`
+	ManufacturingDate sql.NullTime    `json:"manufacturing_date"`
+	SerialNumber      sql.NullString  `json:"serial_number"`
+	Status            InventoryStatus `json:"status"`
+	LastCountedDate   sql.NullTime    `json:"last_counted_date"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	Sku               string          `json:"sku"`
+	ProductName       string          `json:"product_name"`
+	WarehouseCode     string          `json:"warehouse_code"`
+	LocationCode      sql.NullString  `json:"location_code"`
+}
+
+// ExportInventory streams inventory rows in ID order.
+func (q *Queries) ExportInventory(ctx context.Context, arg ExportInventoryParams, fn func(ExportInventoryRow) error) error {
+	rows, err := q.db.QueryContext(ctx, exportInventory, arg.WarehouseID, arg.ProductID, arg.Status, arg.ExpiringBefore)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i ExportInventoryRow
+		if err := rows.Scan(
+			&i.InventoryID,
+			&i.ProductID,
+			&i.WarehouseID,
+			&i.LocationID,
+			&i.Quantity,
+			&i.ReservedQuantity,
+			&i.BatchNumber,
+			&i.ExpiryDate,
+			&i.ManufacturingDate,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LastCountedDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Sku,
+			&i.ProductName,
+			&i.WarehouseCode,
+			&i.LocationCode,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+const exportStockMovements = `SELECT sm.movement_id, sm.reference_number, sm.product_id, sm.warehouse_id,
+  sm.location_id, sm.movement_type, sm.quantity_before, sm.quantity_change,
+  sm.quantity_after, sm.reference_id, sm.reference_table, sm.notes,
+  sm.movement_date, sm.created_by, sm.sale_price, p.sku,
+  w.code AS warehouse_code, l.location_code
+FROM stock_movements sm
+JOIN products p ON sm.product_id = p.product_id
+JOIN warehouses w ON sm.warehouse_id = w.warehouse_id
+LEFT JOIN locations l ON sm.location_id = l.location_id
+WHERE ($1::int IS NULL OR sm.product_id = $1)
+  AND ($2::int IS NULL OR sm.warehouse_id = $2)
+  AND ($3::text IS NULL OR sm.movement_type::text = $3)
+  AND ($4::timestamp IS NULL OR sm.movement_date >= $4)
+  AND ($5::timestamp IS NULL OR sm.movement_date < $5)
+ORDER BY sm.movement_id
+`
+
+type ExportStockMovementsParams struct {
+	ProductID    sql.NullInt32  `json:"product_id"`
+	WarehouseID  sql.NullInt32  `json:"warehouse_id"`
+	MovementType sql.NullString `json:"movement_type"`
+	From         sql.NullTime   `json:"from"`
+	To           sql.NullTime   `json:"to"`
+}
+
+type ExportStockMovementRow struct {
+	MovementID      int32               `json:"movement_id"`
+	ReferenceNumber sql.NullString      `json:"reference_number"`
+	ProductID       int32               `json:"product_id"`
+	WarehouseID     int32               `json:"warehouse_id"`
+	LocationID      sql.NullInt32       `json:"location_id"`
+	MovementType    MovementType        `json:"movement_type"`
+	QuantityBefore  sql.NullInt32       `json:"quantity_before"`
+	QuantityChange  int32               `json:"quantity_change"`
+	QuantityAfter   sql.NullInt32       `json:"quantity_after"`
+	ReferenceID     sql.NullInt32       `json:"reference_id"`
+	ReferenceTable  sql.NullString      `json:"reference_table"`
+	Notes           sql.NullString      `json:"notes"`
+	MovementDate    time.Time           `json:"movement_date"`
+	CreatedBy       sql.NullInt32       `json:"created_by"`
+	SalePrice       decimal.NullDecimal `json:"sale_price"`
+	Sku             string              `json:"sku"`
+	WarehouseCode   string              `json:"warehouse_code"`
+	LocationCode    sql.NullString      `json:"location_code"`
+}
+
+// ExportStockMovements streams stock movements in ID order, which is
+// also the order they were posted in.
+func (q *Queries) ExportStockMovements(ctx context.Context, arg ExportStockMovementsParams, fn func(ExportStockMovementRow) error) error {
+	rows, err := q.db.QueryContext(ctx, exportStockMovements, arg.ProductID, arg.WarehouseID, arg.MovementType, arg.From, arg.To)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i ExportStockMovementRow
+		if err := rows.Scan(
+			&i.MovementID,
+			&i.ReferenceNumber,
+			&i.ProductID,
+			&i.WarehouseID,
+			&i.LocationID,
+			&i.MovementType,
+			&i.QuantityBefore,
+			&i.QuantityChange,
+			&i.QuantityAfter,
+			&i.ReferenceID,
+			&i.ReferenceTable,
+			&i.Notes,
+			&i.MovementDate,
+			&i.CreatedBy,
+			&i.SalePrice,
+			&i.Sku,
+			&i.WarehouseCode,
+			&i.LocationCode,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+const exportPurchaseOrders = `SELECT po.po_id, po.po_number, po.supplier_id, po.order_date,
+  po.expected_delivery_date, po.status, po.total_amount, po.notes,
+  po.created_by, po.created_at, po.currency_code, po.submitted_at,
+  po.updated_at, po.warehouse_id, s.code AS supplier_code,
+  s.name AS supplier_name
+FROM purchase_orders po
+LEFT JOIN suppliers s ON po.supplier_id = s.supplier_id
+WHERE ($1::text IS NULL OR po.status::text = $1)
+  AND ($2::int IS NULL OR po.supplier_id = $2)
+  AND ($3::timestamp IS NULL OR po.order_date >= $3)
+  AND ($4::timestamp IS NULL OR po.order_date < $4)
+ORDER BY po.po_id
+`
+
+type ExportPurchaseOrdersParams struct {
+	Status     sql.NullString `json:"status"`
+	SupplierID sql.NullInt32  `json:"supplier_id"`
+	From       sql.NullTime   `json:"from"`
+	To         sql.NullTime   `json:"to"`
+}
+
+type ExportPurchaseOrderRow struct {
+	PoID                 int32               `json:"po_id"`
+	PoNumber             string              `json:"po_number"`
+	SupplierID           int32               `json:"supplier_id"`
+	OrderDate            time.Time           `json:"order_date"`
+	ExpectedDeliveryDate time.Time           `json:"expected_delivery_date"`
+	Status               PurchaseOrderStatus `json:"status"`
+	TotalAmount          decimal.Decimal     `json:"total_amount"`
+	Notes                sql.NullString      `json:"notes"`
+	CreatedBy            sql.NullInt32       `json:"created_by"`
+	CreatedAt            time.Time           `json:"created_at"`
+	CurrencyCode         sql.NullString      `json:"currency_code"`
+	SubmittedAt          sql.NullTime        `json:"submitted_at"`
+	UpdatedAt            time.Time           `json:"updated_at"`
+	WarehouseID          sql.NullInt32       `json:"warehouse_id"`
+	SupplierCode         sql.NullString      `json:"supplier_code"`
+	SupplierName         sql.NullString      `json:"supplier_name"`
+}
+
+// ExportPurchaseOrders streams purchase orders in ID order.
+func (q *Queries) ExportPurchaseOrders(ctx context.Context, arg ExportPurchaseOrdersParams, fn func(ExportPurchaseOrderRow) error) error {
+	rows, err := q.db.QueryContext(ctx, exportPurchaseOrders, arg.Status, arg.SupplierID, arg.From, arg.To)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i ExportPurchaseOrderRow
+		if err := rows.Scan(
+			&i.PoID,
+			&i.PoNumber,
+			&i.SupplierID,
+			&i.OrderDate,
+			&i.ExpectedDeliveryDate,
+			&i.Status,
+			&i.TotalAmount,
+			&i.Notes,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.CurrencyCode,
+			&i.SubmittedAt,
+			&i.UpdatedAt,
+			&i.WarehouseID,
+			&i.SupplierCode,
+			&i.SupplierName,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+const exportStocktakes = `SELECT st.stocktake_id, st.stocktake_number, st.warehouse_id, st.start_date,
+  st.end_date, st.status, st.notes, st.created_by, st.created_at,
+  w.code AS warehouse_code
+FROM stock_takes st
+JOIN warehouses w ON st.warehouse_id = w.warehouse_id
+WHERE ($1::int IS NULL OR st.warehouse_id = $1)
+  AND ($2::text IS NULL OR st.status::text = $2)
+ORDER BY st.stocktake_id
+`
+
+type ExportStocktakesParams struct {
+	WarehouseID sql.NullInt32  `json:"warehouse_id"`
+	Status      sql.NullString `json:"status"`
+}
+
+type ExportStocktakeRow struct {
+	StocktakeID     int32           `json:"stocktake_id"`
+	StocktakeNumber string          `json:"stocktake_number"`
+	WarehouseID     int32           `json:"warehouse_id"`
+	StartDate       time.Time       `json:"start_date"`
+	EndDate         time.Time       `json:"end_date"`
+	Status          StocktakeStatus `json:"status"`
+	Notes           sql.NullString  `json:"notes"`
+	CreatedBy       sql.NullInt32   `json:"created_by"`
+	CreatedAt       time.Time       `json:"created_at"`
+	WarehouseCode   string          `json:"warehouse_code"`
+}
+
+// ExportStocktakes streams stocktakes in ID order.
+func (q *Queries) ExportStocktakes(ctx context.Context, arg ExportStocktakesParams, fn func(ExportStocktakeRow) error) error {
+	rows, err := q.db.QueryContext(ctx, exportStocktakes, arg.WarehouseID, arg.Status)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i ExportStocktakeRow
+		if err := rows.Scan(
+			&i.StocktakeID,
+			&i.StocktakeNumber,
+			&i.WarehouseID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Status,
+			&i.Notes,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.WarehouseCode,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	return rows.Err()
+}
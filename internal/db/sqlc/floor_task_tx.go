@@ -0,0 +1,367 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/molu/stock-management-system/internal/barcode"
+)
+
+var (
+	ErrNoFloorTask           = errors.New("no open floor task for this device")
+	ErrFloorTaskNotAssigned  = errors.New("floor task is not assigned to this device")
+	ErrFloorTaskClosed       = errors.New("floor task is already closed")
+	ErrFloorTaskStale        = errors.New("the pick or count line behind this floor task has changed; pull the next task")
+	ErrWrongLocation         = errors.New("scanned location is not the task's location")
+	ErrWrongItem             = errors.New("scanned item does not match the task's product, batch or serial")
+	ErrFloorTaskQuantity     = errors.New("quantity must match the task; report a short pick or other exception instead")
+	ErrCountQuantityRequired = errors.New("a count task needs the counted quantity, zero or more")
+	ErrInvalidFloorException = errors.New("a short pick can only be reported on a pick task, with fewer units than the task")
+)
+
+type NextFloorTaskTxParams struct {
+	WarehouseID int32
+	DeviceID    string
+	AssignedTo  sql.NullInt32
+	// TaskType limits the claim to one kind of task.
+	TaskType NullFloorTaskType
+}
+
+// NextFloorTaskTx hands a device its next task. Tasks whose pick or count
+// line was closed or changed elsewhere are cancelled first. A device that
+// still holds a task gets that one back, so repeating the call after a lost
+// response claims nothing new. Otherwise pick tasks are generated for the
+// pending lines of open waves and count tasks for the uncounted lines of
+// stocktakes in progress, and the most urgent open task is claimed, skipping
+// any that another device is claiming at the same moment.
+func (store *SQLStore) NextFloorTaskTx(ctx context.Context, arg NextFloorTaskTxParams) (FloorTask, error) {
+	var task FloorTask
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if _, err := q.CancelStaleFloorTasks(ctx, arg.WarehouseID); err != nil {
+			return err
+		}
+
+		device := sql.NullString{String: arg.DeviceID, Valid: true}
+		var err error
+		task, err = q.GetDeviceFloorTask(ctx, GetDeviceFloorTaskParams{
+			WarehouseID: arg.WarehouseID,
+			DeviceID:    device,
+		})
+		if err == nil || !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if _, err := q.CreatePickFloorTasks(ctx, arg.WarehouseID); err != nil {
+			return err
+		}
+		if _, err := q.CreateCountFloorTasks(ctx, arg.WarehouseID); err != nil {
+			return err
+		}
+
+		task, err = q.ClaimNextFloorTask(ctx, ClaimNextFloorTaskParams{
+			DeviceID:    device,
+			AssignedTo:  arg.AssignedTo,
+			WarehouseID: arg.WarehouseID,
+			TaskType:    arg.TaskType,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNoFloorTask
+		}
+		return err
+	})
+
+	return task, err
+}
+
+type ConfirmFloorTaskTxParams struct {
+	TaskID   int32
+	DeviceID string
+	// RequestID is chosen by the device and sent again on retries.
+	RequestID    string
+	LocationCode string
+	ItemCode     string
+	// ToLocationCode is the destination scanned for a putaway or move.
+	ToLocationCode string
+	// Quantity is the counted quantity of a count task. For other tasks it
+	// may be left out; when given it must be the task quantity.
+	Quantity sql.NullInt32
+	UserID   sql.NullInt32
+}
+
+type FloorTaskTxResult struct {
+	Task FloorTask `json:"task"`
+	// Replayed is set when the task had already been closed by the same
+	// request, so nothing was posted this time.
+	Replayed bool `json:"replayed,omitempty"`
+}
+
+// ConfirmFloorTaskTx completes a device's task once the scanned location and
+// item match it: a pick is confirmed on its wave line, a count recorded on
+// its stocktake line, and a putaway or move transfers the stock to the
+// scanned destination. A retry of a confirmation that already went through
+// returns the completed task without posting again.
+func (store *SQLStore) ConfirmFloorTaskTx(ctx context.Context, arg ConfirmFloorTaskTxParams) (FloorTaskTxResult, error) {
+	var result FloorTaskTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		task, replayed, err := lockDeviceFloorTask(ctx, q, arg.TaskID, arg.DeviceID, arg.RequestID)
+		if err != nil || replayed {
+			result = FloorTaskTxResult{Task: task, Replayed: replayed}
+			return err
+		}
+
+		if err := scanFloorTaskLocation(ctx, q, task, arg.LocationCode); err != nil {
+			return err
+		}
+		if err := scanFloorTaskItem(ctx, q, task, arg.ItemCode); err != nil {
+			return err
+		}
+
+		done := task.Quantity
+		if task.TaskType == FloorTaskTypeCount {
+			if !arg.Quantity.Valid || arg.Quantity.Int32 < 0 {
+				return ErrCountQuantityRequired
+			}
+			done = arg.Quantity.Int32
+		} else if arg.Quantity.Valid && arg.Quantity.Int32 != task.Quantity {
+			return ErrFloorTaskQuantity
+		}
+
+		toLocation := task.ToLocationID
+		switch task.TaskType {
+		case FloorTaskTypePick:
+			_, err = confirmPick(ctx, q, ConfirmPickTxParams{
+				WaveItemID: task.ReferenceID.Int32,
+				Quantity:   done,
+				PickedBy:   arg.UserID,
+			})
+			err = staleFloorTaskError(err)
+		case FloorTaskTypeCount:
+			err = countFloorTask(ctx, q, task, done, arg.UserID)
+		case FloorTaskTypePutaway, FloorTaskTypeMove:
+			var dest Location
+			dest, err = q.GetLocationByCode(ctx, GetLocationByCodeParams{
+				WarehouseID:  task.WarehouseID,
+				LocationCode: strings.TrimSpace(arg.ToLocationCode),
+			})
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrWrongLocation
+			case err != nil:
+				return err
+			case task.TaskType == FloorTaskTypeMove && dest.LocationID != task.ToLocationID.Int32:
+				return ErrWrongLocation
+			}
+			toLocation = sql.NullInt32{Int32: dest.LocationID, Valid: true}
+			_, err = moveStock(ctx, q, MoveStockTxParams{
+				ProductID:      task.ProductID,
+				FromLocationID: task.FromLocationID.Int32,
+				ToLocationID:   dest.LocationID,
+				Quantity:       done,
+				BatchNumber:    task.BatchNumber,
+				SerialNumber:   task.SerialNumber,
+				MovedBy:        arg.UserID,
+				DeviceID:       task.DeviceID,
+				Notes:          task.Notes,
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		result.Task, err = q.CloseFloorTask(ctx, CloseFloorTaskParams{
+			TaskID:       task.TaskID,
+			Status:       FloorTaskStatusCompleted,
+			QuantityDone: sql.NullInt32{Int32: done, Valid: true},
+			ToLocationID: toLocation,
+			RequestID:    sql.NullString{String: arg.RequestID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type ReportFloorTaskExceptionTxParams struct {
+	TaskID    int32
+	DeviceID  string
+	RequestID string
+	Exception FloorTaskException
+	// Quantity is what was found for a short pick.
+	Quantity int32
+	Notes    sql.NullString
+	UserID   sql.NullInt32
+}
+
+// ReportFloorTaskExceptionTx closes a device's task as an exception. A short
+// pick confirms the units found and closes the wave line as short; any other
+// exception leaves stock alone for a supervisor to sort out, and cancelling
+// the task lets a new one be generated for its line. Retries are handled as
+// in ConfirmFloorTaskTx.
+func (store *SQLStore) ReportFloorTaskExceptionTx(ctx context.Context, arg ReportFloorTaskExceptionTxParams) (FloorTaskTxResult, error) {
+	var result FloorTaskTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		task, replayed, err := lockDeviceFloorTask(ctx, q, arg.TaskID, arg.DeviceID, arg.RequestID)
+		if err != nil || replayed {
+			result = FloorTaskTxResult{Task: task, Replayed: replayed}
+			return err
+		}
+
+		var done sql.NullInt32
+		if arg.Exception == FloorTaskExceptionShortPick {
+			if task.TaskType != FloorTaskTypePick || arg.Quantity < 0 || arg.Quantity >= task.Quantity {
+				return ErrInvalidFloorException
+			}
+			_, err = confirmPick(ctx, q, ConfirmPickTxParams{
+				WaveItemID: task.ReferenceID.Int32,
+				Quantity:   arg.Quantity,
+				PickedBy:   arg.UserID,
+				Short:      true,
+			})
+			if err = staleFloorTaskError(err); err != nil {
+				return err
+			}
+			done = sql.NullInt32{Int32: arg.Quantity, Valid: true}
+		}
+
+		result.Task, err = q.CloseFloorTask(ctx, CloseFloorTaskParams{
+			TaskID:         task.TaskID,
+			Status:         FloorTaskStatusException,
+			QuantityDone:   done,
+			ToLocationID:   task.ToLocationID,
+			RequestID:      sql.NullString{String: arg.RequestID, Valid: true},
+			Exception:      NullFloorTaskException{FloorTaskException: arg.Exception, Valid: true},
+			ExceptionNotes: arg.Notes,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// lockDeviceFloorTask locks a task the device is about to close. A task
+// already closed by this device with this request id is returned with
+// replayed set, so the caller answers the retry without posting again.
+func lockDeviceFloorTask(ctx context.Context, q *Queries, taskID int32, deviceID, requestID string) (FloorTask, bool, error) {
+	task, err := q.GetFloorTaskForUpdate(ctx, taskID)
+	if err != nil {
+		return task, false, err
+	}
+	switch task.Status {
+	case FloorTaskStatusCompleted, FloorTaskStatusException:
+		if task.DeviceID.String == deviceID && task.RequestID.Valid && task.RequestID.String == requestID {
+			return task, true, nil
+		}
+		return task, false, ErrFloorTaskClosed
+	case FloorTaskStatusCancelled:
+		return task, false, ErrFloorTaskClosed
+	}
+	if task.Status != FloorTaskStatusAssigned || task.DeviceID.String != deviceID {
+		return task, false, ErrFloorTaskNotAssigned
+	}
+	return task, false, nil
+}
+
+// scanFloorTaskLocation checks the scanned location code is the location
+// the task starts at.
+func scanFloorTaskLocation(ctx context.Context, q *Queries, task FloorTask, code string) error {
+	loc, err := q.GetLocationByCode(ctx, GetLocationByCodeParams{
+		WarehouseID:  task.WarehouseID,
+		LocationCode: strings.TrimSpace(code),
+	})
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && loc.LocationID != task.FromLocationID.Int32) {
+		return ErrWrongLocation
+	}
+	return err
+}
+
+// scanFloorTaskItem checks the scanned item code identifies the task's
+// product: its SKU, an EAN/UPC barcode of the product or one of its units
+// of measure, GS1 data with such a GTIN and the task's batch and serial if
+// it has them, or a serial or other identifier of the product.
+func scanFloorTaskItem(ctx context.Context, q *Queries, task FloorTask, code string) error {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return ErrWrongItem
+	}
+
+	product, err := q.GetProduct(ctx, task.ProductID)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(code, product.Sku) {
+		return nil
+	}
+
+	gtin := ""
+	if barcode.ValidGTIN(code) {
+		gtin = code
+	} else if g, err := barcode.ParseGS1(code); err == nil && g.GTIN != "" {
+		if (task.BatchNumber.Valid && g.Batch != "" && g.Batch != task.BatchNumber.String) ||
+			(task.SerialNumber.Valid && g.Serial != "" && g.Serial != task.SerialNumber.String) {
+			return ErrWrongItem
+		}
+		gtin = g.GTIN
+	}
+	if gtin != "" {
+		full := barcode.Equivalents(gtin)[0]
+		if product.Barcode.Valid && barcode.ValidGTIN(product.Barcode.String) && barcode.Equivalents(product.Barcode.String)[0] == full {
+			return nil
+		}
+		uom, err := q.GetProductUomByGTIN(ctx, full)
+		if err == nil && uom.ProductID == product.ProductID {
+			return nil
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		return ErrWrongItem
+	}
+
+	identifiers, err := q.ListProductIdentifiersByValue(ctx, ListProductIdentifiersByValueParams{
+		IdentifierValue: code,
+		ProductID:       sql.NullInt32{Int32: product.ProductID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if len(identifiers) == 0 || (task.SerialNumber.Valid && code != task.SerialNumber.String) {
+		return ErrWrongItem
+	}
+	return nil
+}
+
+// countFloorTask records the count on the task's stocktake line, which must
+// still be uncounted on a stocktake in progress.
+func countFloorTask(ctx context.Context, q *Queries, task FloorTask, counted int32, countedBy sql.NullInt32) error {
+	item, err := q.GetStocktakeItem(ctx, task.ReferenceID.Int32)
+	if err != nil {
+		return err
+	}
+	stocktake, err := q.GetStocktake(ctx, item.StocktakeID)
+	if err != nil {
+		return err
+	}
+	if item.CountedQuantity.Valid || stocktake.Status != StocktakeStatusInProgress {
+		return ErrFloorTaskStale
+	}
+	_, err = q.UpdateStocktakeItemCount(ctx, UpdateStocktakeItemCountParams{
+		StocktakeItemID: item.StocktakeItemID,
+		CountedQuantity: sql.NullInt32{Int32: counted, Valid: true},
+		CountedBy:       countedBy,
+	})
+	return err
+}
+
+// staleFloorTaskError reports a pick line closed, or changed, since its task
+// was generated as a stale task.
+func staleFloorTaskError(err error) error {
+	if errors.Is(err, ErrPickLineClosed) || errors.Is(err, ErrWaveNotOpen) || errors.Is(err, ErrInvalidPickQuantity) {
+		return ErrFloorTaskStale
+	}
+	return err
+}
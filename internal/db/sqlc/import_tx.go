@@ -0,0 +1,517 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/molu/stock-management-system/internal/importer"
+	"github.com/molu/stock-management-system/internal/putaway"
+	"github.com/shopspring/decimal"
+)
+
+// errDryRun rolls back the transaction of a dry run once every row has been
+// tried.
+var errDryRun = errors.New("dry run")
+
+type ImportTxParams struct {
+	Kind     importer.Kind
+	Format   importer.Format
+	FileName sql.NullString
+	// TotalRows counts every data row of the file; Records are the rows that
+	// passed importer.Validate and Invalid the errors of the rest.
+	TotalRows int32
+	Records   []importer.Record
+	Invalid   []importer.RowError
+	// DryRun tries every row and rolls everything back.
+	DryRun bool
+	// ChunkSize commits every ChunkSize rows, skipping rows that fail. Zero
+	// imports the file as a whole or not at all.
+	ChunkSize int
+	CreatedBy sql.NullInt32
+}
+
+type ImportChunk struct {
+	FirstLine int   `json:"first_line"`
+	LastLine  int   `json:"last_line"`
+	Imported  int32 `json:"imported"`
+	Failed    int32 `json:"failed"`
+}
+
+type ImportTxResult struct {
+	Kind      importer.Kind `json:"kind"`
+	DryRun    bool          `json:"dry_run"`
+	Committed bool          `json:"committed"`
+	TotalRows int32         `json:"total_rows"`
+	// Valid counts rows that imported (or would have, in a dry run) and
+	// Imported the rows actually committed.
+	Valid    int32               `json:"valid"`
+	Imported int32               `json:"imported"`
+	Failed   int32               `json:"failed"`
+	Batch    *ImportBatch        `json:"batch,omitempty"`
+	Chunks   []ImportChunk       `json:"chunks,omitempty"`
+	Errors   []importer.RowError `json:"errors"`
+}
+
+// ImportTx loads validated import records. Each row runs under a savepoint so
+// a failing row is reported and the rest still run. A dry run and a whole-file
+// import use one transaction, which a dry run always rolls back and a
+// whole-file import rolls back if any row of the file failed. A chunked import
+// commits each chunk's good rows in its own transaction. Committed imports
+// are recorded as an import batch.
+func (store *SQLStore) ImportTx(ctx context.Context, arg ImportTxParams) (ImportTxResult, error) {
+	result := ImportTxResult{
+		Kind:      arg.Kind,
+		DryRun:    arg.DryRun,
+		TotalRows: arg.TotalRows,
+		Errors:    append([]importer.RowError{}, arg.Invalid...),
+	}
+	invalid := int32(failedLines(arg.Invalid))
+
+	if arg.DryRun || arg.ChunkSize <= 0 {
+		var batch ImportBatch
+		err := store.execTx(ctx, func(q *Queries) error {
+			var err error
+			batch, err = openImportBatch(ctx, q, arg, invalid)
+			if err != nil {
+				return err
+			}
+			imported, errs, err := importRecords(ctx, q, arg.Kind, batch.BatchID, arg.Records, arg.CreatedBy)
+			if err != nil {
+				return err
+			}
+			result.Valid = imported
+			result.Errors = append(result.Errors, errs...)
+			if arg.DryRun || len(result.Errors) > 0 {
+				return errDryRun
+			}
+			batch, err = q.AddImportBatchRows(ctx, AddImportBatchRowsParams{
+				Imported: imported,
+				BatchID:  batch.BatchID,
+			})
+			return err
+		})
+		if err != nil && !errors.Is(err, errDryRun) {
+			return result, err
+		}
+		if err == nil {
+			result.Committed = true
+			result.Imported = result.Valid
+			result.Batch = &batch
+		}
+		result.Failed = int32(failedLines(result.Errors))
+		sortRowErrors(result.Errors)
+		return result, nil
+	}
+
+	var batch ImportBatch
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		batch, err = openImportBatch(ctx, q, arg, invalid)
+		return err
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Batch = &batch
+
+	for start := 0; start < len(arg.Records); start += arg.ChunkSize {
+		records := arg.Records[start:min(start+arg.ChunkSize, len(arg.Records))]
+		chunk := ImportChunk{FirstLine: records[0].Line, LastLine: records[len(records)-1].Line}
+
+		err := store.execTx(ctx, func(q *Queries) error {
+			imported, errs, err := importRecords(ctx, q, arg.Kind, batch.BatchID, records, arg.CreatedBy)
+			if err != nil {
+				return err
+			}
+			chunk.Imported = imported
+			chunk.Failed = int32(failedLines(errs))
+			result.Errors = append(result.Errors, errs...)
+
+			batch, err = q.AddImportBatchRows(ctx, AddImportBatchRowsParams{
+				Imported: chunk.Imported,
+				Failed:   chunk.Failed,
+				BatchID:  batch.BatchID,
+			})
+			return err
+		})
+		if err != nil {
+			return result, fmt.Errorf("import lines %d-%d: %w", chunk.FirstLine, chunk.LastLine, err)
+		}
+		result.Chunks = append(result.Chunks, chunk)
+		result.Valid += chunk.Imported
+		result.Imported += chunk.Imported
+	}
+
+	result.Committed = true
+	result.Failed = int32(failedLines(result.Errors))
+	sortRowErrors(result.Errors)
+	return result, nil
+}
+
+// openImportBatch records a new import batch, counting the rows that failed
+// validation as failed.
+func openImportBatch(ctx context.Context, q *Queries, arg ImportTxParams, invalid int32) (ImportBatch, error) {
+	batch, err := q.CreateImportBatch(ctx, CreateImportBatchParams{
+		Kind:      string(arg.Kind),
+		FileName:  arg.FileName,
+		Format:    string(arg.Format),
+		TotalRows: arg.TotalRows,
+		CreatedBy: arg.CreatedBy,
+	})
+	if err != nil || invalid == 0 {
+		return batch, err
+	}
+	return q.AddImportBatchRows(ctx, AddImportBatchRowsParams{
+		Failed:  invalid,
+		BatchID: batch.BatchID,
+	})
+}
+
+// importRecords imports each record under a savepoint. Rows that fail are
+// rolled back to their savepoint and reported; an error is returned only
+// when the transaction itself cannot go on.
+func importRecords(ctx context.Context, q *Queries, kind importer.Kind, batchID int32, records []importer.Record, createdBy sql.NullInt32) (int32, []importer.RowError, error) {
+	var imported int32
+	var errs []importer.RowError
+
+	for _, rec := range records {
+		if _, err := q.db.ExecContext(ctx, "SAVEPOINT import_row"); err != nil {
+			return imported, errs, err
+		}
+
+		err := importRecord(ctx, q, kind, batchID, rec, createdBy)
+		if err == nil {
+			if _, err := q.db.ExecContext(ctx, "RELEASE SAVEPOINT import_row"); err != nil {
+				return imported, errs, err
+			}
+			imported++
+			continue
+		}
+
+		if _, rbErr := q.db.ExecContext(ctx, "ROLLBACK TO SAVEPOINT import_row"); rbErr != nil {
+			return imported, errs, fmt.Errorf("line %d: %v, rb err: %v", rec.Line, err, rbErr)
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return imported, errs, ctxErr
+		}
+		var rowErr importer.RowError
+		if !errors.As(err, &rowErr) {
+			rowErr = importer.RowError{Line: rec.Line, Message: err.Error()}
+		}
+		errs = append(errs, rowErr)
+	}
+	return imported, errs, nil
+}
+
+func importRecord(ctx context.Context, q *Queries, kind importer.Kind, batchID int32, rec importer.Record, createdBy sql.NullInt32) error {
+	switch kind {
+	case importer.KindCategories:
+		return importCategory(ctx, q, rec)
+	case importer.KindSuppliers:
+		return importSupplier(ctx, q, rec)
+	case importer.KindProducts:
+		return importProduct(ctx, q, rec)
+	case importer.KindLocations:
+		return importLocation(ctx, q, rec)
+	case importer.KindOpeningStock:
+		return importOpeningBalance(ctx, q, batchID, rec, createdBy)
+	}
+	return fmt.Errorf("unknown import kind %q", kind)
+}
+
+func importCategory(ctx context.Context, q *Queries, rec importer.Record) error {
+	code := rec.Text("category_code")
+	if _, err := q.GetCategoryByCode(ctx, code); err == nil {
+		return rowError(rec, "category_code", "category %s already exists", code)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	var parentID sql.NullInt32
+	if parentCode := rec.Text("parent_code"); parentCode != "" {
+		parent, err := q.GetCategoryByCode(ctx, parentCode)
+		if errors.Is(err, sql.ErrNoRows) {
+			return rowError(rec, "parent_code", "category %s does not exist", parentCode)
+		} else if err != nil {
+			return err
+		}
+		parentID = sql.NullInt32{Int32: parent.CategoryID, Valid: true}
+	}
+
+	_, err := q.CreateCategory(ctx, CreateCategoryParams{
+		CategoryCode:     code,
+		Name:             rec.Text("name"),
+		ParentCategoryID: parentID,
+		Description:      importText(rec, "description"),
+	})
+	return err
+}
+
+func importSupplier(ctx context.Context, q *Queries, rec importer.Record) error {
+	code := rec.Text("code")
+	if _, err := q.GetSupplierByCode(ctx, code); err == nil {
+		return rowError(rec, "code", "supplier %s already exists", code)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	currency := importText(rec, "currency_code")
+	if currency.Valid {
+		currency.String = strings.ToUpper(currency.String)
+		if _, err := q.GetCurrency(ctx, currency.String); errors.Is(err, sql.ErrNoRows) {
+			return rowError(rec, "currency_code", "currency %s does not exist", currency.String)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	_, err := q.CreateSupplier(ctx, CreateSupplierParams{
+		Code:          code,
+		Name:          rec.Text("name"),
+		ContactPerson: importText(rec, "contact_person"),
+		Email:         importText(rec, "email"),
+		Phone:         importText(rec, "phone"),
+		Address:       importText(rec, "address"),
+		TaxID:         importText(rec, "tax_id"),
+		PaymentTerms:  importText(rec, "payment_terms"),
+		LeadTimeDays:  importInt(rec, "lead_time_days"),
+		Rating:        decimal.Zero,
+		IsActive:      rec.Bool("is_active", true),
+		CurrencyCode:  currency,
+	})
+	return err
+}
+
+// importProduct creates a product. A supplier code also links the supplier
+// to the product as its primary supplier.
+func importProduct(ctx context.Context, q *Queries, rec importer.Record) error {
+	sku := rec.Text("sku")
+	if _, err := q.GetProductBySKU(ctx, sku); err == nil {
+		return rowError(rec, "sku", "product %s already exists", sku)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	var categoryID sql.NullInt32
+	if code := rec.Text("category_code"); code != "" {
+		category, err := q.GetCategoryByCode(ctx, code)
+		if errors.Is(err, sql.ErrNoRows) {
+			return rowError(rec, "category_code", "category %s does not exist", code)
+		} else if err != nil {
+			return err
+		}
+		categoryID = sql.NullInt32{Int32: category.CategoryID, Valid: true}
+	}
+
+	var supplierID sql.NullInt32
+	if code := rec.Text("supplier_code"); code != "" {
+		supplier, err := q.GetSupplierByCode(ctx, code)
+		if errors.Is(err, sql.ErrNoRows) {
+			return rowError(rec, "supplier_code", "supplier %s does not exist", code)
+		} else if err != nil {
+			return err
+		}
+		supplierID = sql.NullInt32{Int32: supplier.SupplierID, Valid: true}
+	}
+
+	var minStock int32
+	if n := rec.Int("min_stock_level"); n != nil {
+		minStock = *n
+	}
+	product, err := q.CreateProduct(ctx, CreateProductParams{
+		Sku:           sku,
+		Name:          rec.Text("name"),
+		Description:   importText(rec, "description"),
+		CategoryID:    categoryID,
+		UnitPrice:     *rec.Decimal("unit_price"),
+		CostPrice:     importDecimal(rec, "cost_price").Decimal,
+		Barcode:       importText(rec, "barcode"),
+		Weight:        importDecimal(rec, "weight").Decimal,
+		Dimensions:    importText(rec, "dimensions"),
+		SupplierID:    supplierID,
+		MinStockLevel: minStock,
+		MaxStockLevel: importInt(rec, "max_stock_level"),
+		ReorderPoint:  importInt(rec, "reorder_point"),
+		SafetyStock:   importInt(rec, "safety_stock"),
+		LeadTimeDays:  importInt(rec, "lead_time_days"),
+		AutoReorder:   rec.Bool("auto_reorder", false),
+		IsActive:      rec.Bool("is_active", true),
+	})
+	if err != nil || !supplierID.Valid {
+		return err
+	}
+
+	_, err = q.CreateProductSupplier(ctx, CreateProductSupplierParams{
+		ProductID:    product.ProductID,
+		SupplierID:   supplierID.Int32,
+		Priority:     1,
+		LeadTimeDays: product.LeadTimeDays,
+		IsActive:     true,
+	})
+	return err
+}
+
+func importLocation(ctx context.Context, q *Queries, rec importer.Record) error {
+	warehouse, err := importWarehouse(ctx, q, rec)
+	if err != nil {
+		return err
+	}
+
+	code := rec.Text("location_code")
+	_, err = q.GetLocationByCode(ctx, GetLocationByCodeParams{
+		WarehouseID:  warehouse.WarehouseID,
+		LocationCode: code,
+	})
+	if err == nil {
+		return rowError(rec, "location_code", "location %s already exists in warehouse %s", code, warehouse.Code)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	var zoneID sql.NullInt32
+	if zoneCode := rec.Text("zone_code"); zoneCode != "" {
+		zone, err := q.GetWarehouseZoneByCode(ctx, GetWarehouseZoneByCodeParams{
+			WarehouseID: warehouse.WarehouseID,
+			ZoneCode:    zoneCode,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return rowError(rec, "zone_code", "zone %s does not exist in warehouse %s", zoneCode, warehouse.Code)
+		} else if err != nil {
+			return err
+		}
+		zoneID = sql.NullInt32{Int32: zone.ZoneID, Valid: true}
+	}
+
+	_, err = q.CreateLocation(ctx, CreateLocationParams{
+		WarehouseID:  warehouse.WarehouseID,
+		LocationCode: code,
+		Aisle:        importText(rec, "aisle"),
+		Shelf:        importText(rec, "shelf"),
+		Bin:          importText(rec, "bin"),
+		MaxCapacity:  importInt(rec, "max_capacity"),
+		ZoneID:       zoneID,
+		XCoord:       importDecimal(rec, "x_coord"),
+		YCoord:       importDecimal(rec, "y_coord"),
+		PickSequence: importInt(rec, "pick_sequence"),
+		MaxWeight:    importDecimal(rec, "max_weight"),
+		MaxVolume:    importDecimal(rec, "max_volume"),
+	})
+	return err
+}
+
+// importOpeningBalance records an opening balance line and puts the stock
+// away with an opening_balance movement, so the stock ledger and cost
+// balances start from it. The movement is valued at the line's unit cost.
+func importOpeningBalance(ctx context.Context, q *Queries, batchID int32, rec importer.Record, createdBy sql.NullInt32) error {
+	sku := rec.Text("sku")
+	product, err := q.GetProductBySKU(ctx, sku)
+	if errors.Is(err, sql.ErrNoRows) {
+		return rowError(rec, "sku", "product %s does not exist", sku)
+	} else if err != nil {
+		return err
+	}
+
+	warehouse, err := importWarehouse(ctx, q, rec)
+	if err != nil {
+		return err
+	}
+	code := rec.Text("location_code")
+	loc, err := q.GetLocationByCode(ctx, GetLocationByCodeParams{
+		WarehouseID:  warehouse.WarehouseID,
+		LocationCode: code,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return rowError(rec, "location_code", "location %s does not exist in warehouse %s", code, warehouse.Code)
+	} else if err != nil {
+		return err
+	}
+
+	var expiry sql.NullTime
+	if t := rec.Date("expiry_date"); t != nil {
+		expiry = sql.NullTime{Time: *t, Valid: true}
+	}
+	quantity := *rec.Int("quantity")
+	balance, err := q.CreateOpeningBalance(ctx, CreateOpeningBalanceParams{
+		BatchID:     batchID,
+		LineNumber:  int32(rec.Line),
+		ProductID:   product.ProductID,
+		WarehouseID: warehouse.WarehouseID,
+		LocationID:  loc.LocationID,
+		Quantity:    quantity,
+		UnitCost:    importDecimal(rec, "unit_cost"),
+		BatchNumber: importText(rec, "batch_number"),
+		ExpiryDate:  expiry,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = putawayStock(ctx, q, PutawayTxParams{
+		ProductID:      product.ProductID,
+		LocationID:     loc.LocationID,
+		Quantity:       quantity,
+		BatchNumber:    balance.BatchNumber,
+		ExpiryDate:     balance.ExpiryDate,
+		ReferenceID:    sql.NullInt32{Int32: balance.OpeningBalanceID, Valid: true},
+		ReferenceTable: sql.NullString{String: "opening_balances", Valid: true},
+		Notes:          sql.NullString{String: fmt.Sprintf("Opening balance, import batch %d", batchID), Valid: true},
+		CreatedBy:      createdBy,
+		MovementType:   MovementTypeOpeningBalance,
+	})
+	switch {
+	case errors.Is(err, ErrLocationInactive):
+		return rowError(rec, "location_code", "location %s is not active", code)
+	case errors.Is(err, putaway.ErrCapacityExceeded):
+		return rowError(rec, "quantity", "%v", err)
+	}
+	return err
+}
+
+func importWarehouse(ctx context.Context, q *Queries, rec importer.Record) (Warehouse, error) {
+	code := rec.Text("warehouse_code")
+	warehouse, err := q.GetWarehouseByCode(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return warehouse, rowError(rec, "warehouse_code", "warehouse %s does not exist", code)
+	}
+	return warehouse, err
+}
+
+func rowError(rec importer.Record, column, format string, args ...interface{}) importer.RowError {
+	return importer.RowError{Line: rec.Line, Column: column, Message: fmt.Sprintf(format, args...)}
+}
+
+func importText(rec importer.Record, column string) sql.NullString {
+	v := rec.Text(column)
+	return sql.NullString{String: v, Valid: v != ""}
+}
+
+func importInt(rec importer.Record, column string) sql.NullInt32 {
+	if n := rec.Int(column); n != nil {
+		return sql.NullInt32{Int32: *n, Valid: true}
+	}
+	return sql.NullInt32{}
+}
+
+func importDecimal(rec importer.Record, column string) decimal.NullDecimal {
+	if d := rec.Decimal(column); d != nil {
+		return decimal.NullDecimal{Decimal: *d, Valid: true}
+	}
+	return decimal.NullDecimal{}
+}
+
+// failedLines counts the distinct lines among errs.
+func failedLines(errs []importer.RowError) int {
+	lines := make(map[int]bool, len(errs))
+	for _, e := range errs {
+		lines[e.Line] = true
+	}
+	return len(lines)
+}
+
+func sortRowErrors(errs []importer.RowError) {
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+}
@@ -11,6 +11,94 @@ import (
 	"time"
 )
 
+const addInventoryQuantity = `-- name: AddInventoryQuantity :one
+UPDATE inventory
+SET
+    quantity = quantity + $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE inventory_id = $1
+RETURNING inventory_id, product_id, warehouse_id, location_id, quantity, reserved_quantity, batch_number, expiry_date, manufacturing_date, serial_number, status, last_counted_date, created_at, updated_at
+`
+
+type AddInventoryQuantityParams struct {
+	InventoryID int32 `json:"inventory_id"`
+	Quantity    int32 `json:"quantity"`
+}
+
+func (q *Queries) AddInventoryQuantity(ctx context.Context, arg AddInventoryQuantityParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, addInventoryQuantity, arg.InventoryID, arg.Quantity)
+	var i Inventory
+	err := row.Scan(
+		&i.InventoryID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.LocationID,
+		&i.Quantity,
+		&i.ReservedQuantity,
+		&i.BatchNumber,
+		&i.ExpiryDate,
+		&i.ManufacturingDate,
+		&i.SerialNumber,
+		&i.Status,
+		&i.LastCountedDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createInventory = `-- name: CreateInventory :one
+INSERT INTO inventory (
+    product_id, warehouse_id, location_id, quantity,
+    batch_number, expiry_date, manufacturing_date, serial_number
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING inventory_id, product_id, warehouse_id, location_id, quantity, reserved_quantity, batch_number, expiry_date, manufacturing_date, serial_number, status, last_counted_date, created_at, updated_at
+`
+
+type CreateInventoryParams struct {
+	ProductID         int32          `json:"product_id"`
+	WarehouseID       int32          `json:"warehouse_id"`
+	LocationID        sql.NullInt32  `json:"location_id"`
+	Quantity          int32          `json:"quantity"`
+	BatchNumber       sql.NullString `json:"batch_number"`
+	ExpiryDate        sql.NullTime   `json:"expiry_date"`
+	ManufacturingDate sql.NullTime   `json:"manufacturing_date"`
+	SerialNumber      sql.NullString `json:"serial_number"`
+}
+
+func (q *Queries) CreateInventory(ctx context.Context, arg CreateInventoryParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, createInventory,
+		arg.ProductID,
+		arg.WarehouseID,
+		arg.LocationID,
+		arg.Quantity,
+		arg.BatchNumber,
+		arg.ExpiryDate,
+		arg.ManufacturingDate,
+		arg.SerialNumber,
+	)
+	var i Inventory
+	err := row.Scan(
+		&i.InventoryID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.LocationID,
+		&i.Quantity,
+		&i.ReservedQuantity,
+		&i.BatchNumber,
+		&i.ExpiryDate,
+		&i.ManufacturingDate,
+		&i.SerialNumber,
+		&i.Status,
+		&i.LastCountedDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getInventory = `-- name: GetInventory :one
 SELECT inventory_id, product_id, warehouse_id, location_id, quantity, reserved_quantity, batch_number, expiry_date, manufacturing_date, serial_number, status, last_counted_date, created_at, updated_at FROM inventory 
 WHERE inventory_id = $1
@@ -38,6 +126,51 @@ func (q *Queries) GetInventory(ctx context.Context, inventoryID int32) (Inventor
 	return i, err
 }
 
+const getInventoryAtLocationForUpdate = `-- name: GetInventoryAtLocationForUpdate :one
+SELECT inventory_id, product_id, warehouse_id, location_id, quantity, reserved_quantity, batch_number, expiry_date, manufacturing_date, serial_number, status, last_counted_date, created_at, updated_at FROM inventory
+WHERE product_id = $1
+  AND location_id = $2
+  AND batch_number IS NOT DISTINCT FROM $3
+  AND serial_number IS NOT DISTINCT FROM $4
+ORDER BY inventory_id
+LIMIT 1
+FOR UPDATE
+`
+
+type GetInventoryAtLocationForUpdateParams struct {
+	ProductID    int32          `json:"product_id"`
+	LocationID   sql.NullInt32  `json:"location_id"`
+	BatchNumber  sql.NullString `json:"batch_number"`
+	SerialNumber sql.NullString `json:"serial_number"`
+}
+
+func (q *Queries) GetInventoryAtLocationForUpdate(ctx context.Context, arg GetInventoryAtLocationForUpdateParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, getInventoryAtLocationForUpdate,
+		arg.ProductID,
+		arg.LocationID,
+		arg.BatchNumber,
+		arg.SerialNumber,
+	)
+	var i Inventory
+	err := row.Scan(
+		&i.InventoryID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.LocationID,
+		&i.Quantity,
+		&i.ReservedQuantity,
+		&i.BatchNumber,
+		&i.ExpiryDate,
+		&i.ManufacturingDate,
+		&i.SerialNumber,
+		&i.Status,
+		&i.LastCountedDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getInventoryByLocation = `-- name: GetInventoryByLocation :one
 SELECT inventory_id, product_id, warehouse_id, location_id, quantity, reserved_quantity, batch_number, expiry_date, manufacturing_date, serial_number, status, last_counted_date, created_at, updated_at FROM inventory 
 WHERE product_id = $1 AND warehouse_id = $2 AND location_id = $3
@@ -103,6 +236,87 @@ func (q *Queries) GetInventoryByProductWarehouse(ctx context.Context, arg GetInv
 	return i, err
 }
 
+const getInventoryForUpdate = `-- name: GetInventoryForUpdate :one
+SELECT inventory_id, product_id, warehouse_id, location_id, quantity, reserved_quantity, batch_number, expiry_date, manufacturing_date, serial_number, status, last_counted_date, created_at, updated_at FROM inventory
+WHERE inventory_id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetInventoryForUpdate(ctx context.Context, inventoryID int32) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, getInventoryForUpdate, inventoryID)
+	var i Inventory
+	err := row.Scan(
+		&i.InventoryID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.LocationID,
+		&i.Quantity,
+		&i.ReservedQuantity,
+		&i.BatchNumber,
+		&i.ExpiryDate,
+		&i.ManufacturingDate,
+		&i.SerialNumber,
+		&i.Status,
+		&i.LastCountedDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listAvailableInventoryForUpdate = `-- name: ListAvailableInventoryForUpdate :many
+SELECT inventory_id, product_id, warehouse_id, location_id, quantity, reserved_quantity, batch_number, expiry_date, manufacturing_date, serial_number, status, last_counted_date, created_at, updated_at FROM inventory
+WHERE product_id = $1
+  AND warehouse_id = $2
+  AND status IN ('in_stock', 'reserved')
+  AND quantity > reserved_quantity
+ORDER BY expiry_date NULLS LAST, inventory_id
+FOR UPDATE
+`
+
+type ListAvailableInventoryForUpdateParams struct {
+	ProductID   int32 `json:"product_id"`
+	WarehouseID int32 `json:"warehouse_id"`
+}
+
+func (q *Queries) ListAvailableInventoryForUpdate(ctx context.Context, arg ListAvailableInventoryForUpdateParams) ([]Inventory, error) {
+	rows, err := q.db.QueryContext(ctx, listAvailableInventoryForUpdate, arg.ProductID, arg.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Inventory
+	for rows.Next() {
+		var i Inventory
+		if err := rows.Scan(
+			&i.InventoryID,
+			&i.ProductID,
+			&i.WarehouseID,
+			&i.LocationID,
+			&i.Quantity,
+			&i.ReservedQuantity,
+			&i.BatchNumber,
+			&i.ExpiryDate,
+			&i.ManufacturingDate,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LastCountedDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listExpiringInventory = `-- name: ListExpiringInventory :many
 SELECT i.inventory_id, i.product_id, i.warehouse_id, i.location_id, i.quantity, i.reserved_quantity, i.batch_number, i.expiry_date, i.manufacturing_date, i.serial_number, i.status, i.last_counted_date, i.created_at, i.updated_at, p.name as product_name, p.sku, 
        w.name as warehouse_name, w.code as warehouse_code
@@ -246,6 +460,56 @@ func (q *Queries) ListInventoryByProduct(ctx context.Context, productID int32) (
 	return items, nil
 }
 
+const listInventoryByProductBatch = `-- name: ListInventoryByProductBatch :many
+SELECT inventory_id, product_id, warehouse_id, location_id, quantity, reserved_quantity, batch_number, expiry_date, manufacturing_date, serial_number, status, last_counted_date, created_at, updated_at FROM inventory
+WHERE product_id = $1
+  AND batch_number = $2
+ORDER BY warehouse_id, location_id, inventory_id
+`
+
+type ListInventoryByProductBatchParams struct {
+	ProductID   int32          `json:"product_id"`
+	BatchNumber sql.NullString `json:"batch_number"`
+}
+
+func (q *Queries) ListInventoryByProductBatch(ctx context.Context, arg ListInventoryByProductBatchParams) ([]Inventory, error) {
+	rows, err := q.db.QueryContext(ctx, listInventoryByProductBatch, arg.ProductID, arg.BatchNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Inventory
+	for rows.Next() {
+		var i Inventory
+		if err := rows.Scan(
+			&i.InventoryID,
+			&i.ProductID,
+			&i.WarehouseID,
+			&i.LocationID,
+			&i.Quantity,
+			&i.ReservedQuantity,
+			&i.BatchNumber,
+			&i.ExpiryDate,
+			&i.ManufacturingDate,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LastCountedDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listInventoryByWarehouse = `-- name: ListInventoryByWarehouse :many
 SELECT i.inventory_id, i.product_id, i.warehouse_id, i.location_id, i.quantity, i.reserved_quantity, i.batch_number, i.expiry_date, i.manufacturing_date, i.serial_number, i.status, i.last_counted_date, i.created_at, i.updated_at, p.name as product_name, p.sku
 FROM inventory i
@@ -320,6 +584,45 @@ func (q *Queries) ListInventoryByWarehouse(ctx context.Context, arg ListInventor
 	return items, nil
 }
 
+const pickInventory = `-- name: PickInventory :one
+UPDATE inventory
+SET
+    quantity = quantity - $1,
+    reserved_quantity = reserved_quantity - $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE inventory_id = $2
+  AND quantity >= $1
+  AND reserved_quantity >= $1
+RETURNING inventory_id, product_id, warehouse_id, location_id, quantity, reserved_quantity, batch_number, expiry_date, manufacturing_date, serial_number, status, last_counted_date, created_at, updated_at
+`
+
+type PickInventoryParams struct {
+	Quantity    int32 `json:"quantity"`
+	InventoryID int32 `json:"inventory_id"`
+}
+
+func (q *Queries) PickInventory(ctx context.Context, arg PickInventoryParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, pickInventory, arg.Quantity, arg.InventoryID)
+	var i Inventory
+	err := row.Scan(
+		&i.InventoryID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.LocationID,
+		&i.Quantity,
+		&i.ReservedQuantity,
+		&i.BatchNumber,
+		&i.ExpiryDate,
+		&i.ManufacturingDate,
+		&i.SerialNumber,
+		&i.Status,
+		&i.LastCountedDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const releaseInventoryReservation = `-- name: ReleaseInventoryReservation :one
 UPDATE inventory 
 SET 
@@ -356,6 +659,42 @@ func (q *Queries) ReleaseInventoryReservation(ctx context.Context, arg ReleaseIn
 	return i, err
 }
 
+const removeInventoryQuantity = `-- name: RemoveInventoryQuantity :one
+UPDATE inventory
+SET
+    quantity = quantity - $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE inventory_id = $1 AND (quantity - reserved_quantity) >= $2
+RETURNING inventory_id, product_id, warehouse_id, location_id, quantity, reserved_quantity, batch_number, expiry_date, manufacturing_date, serial_number, status, last_counted_date, created_at, updated_at
+`
+
+type RemoveInventoryQuantityParams struct {
+	InventoryID int32 `json:"inventory_id"`
+	Quantity    int32 `json:"quantity"`
+}
+
+func (q *Queries) RemoveInventoryQuantity(ctx context.Context, arg RemoveInventoryQuantityParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, removeInventoryQuantity, arg.InventoryID, arg.Quantity)
+	var i Inventory
+	err := row.Scan(
+		&i.InventoryID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.LocationID,
+		&i.Quantity,
+		&i.ReservedQuantity,
+		&i.BatchNumber,
+		&i.ExpiryDate,
+		&i.ManufacturingDate,
+		&i.SerialNumber,
+		&i.Status,
+		&i.LastCountedDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const reserveInventory = `-- name: ReserveInventory :one
 UPDATE inventory 
 SET 
@@ -392,6 +731,42 @@ func (q *Queries) ReserveInventory(ctx context.Context, arg ReserveInventoryPara
 	return i, err
 }
 
+const setInventoryLocation = `-- name: SetInventoryLocation :one
+UPDATE inventory
+SET
+    location_id = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE inventory_id = $1
+RETURNING inventory_id, product_id, warehouse_id, location_id, quantity, reserved_quantity, batch_number, expiry_date, manufacturing_date, serial_number, status, last_counted_date, created_at, updated_at
+`
+
+type SetInventoryLocationParams struct {
+	InventoryID int32         `json:"inventory_id"`
+	LocationID  sql.NullInt32 `json:"location_id"`
+}
+
+func (q *Queries) SetInventoryLocation(ctx context.Context, arg SetInventoryLocationParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, setInventoryLocation, arg.InventoryID, arg.LocationID)
+	var i Inventory
+	err := row.Scan(
+		&i.InventoryID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.LocationID,
+		&i.Quantity,
+		&i.ReservedQuantity,
+		&i.BatchNumber,
+		&i.ExpiryDate,
+		&i.ManufacturingDate,
+		&i.SerialNumber,
+		&i.Status,
+		&i.LastCountedDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateInventoryQuantity = `-- name: UpdateInventoryQuantity :one
 UPDATE inventory 
 SET 
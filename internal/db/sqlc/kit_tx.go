@@ -0,0 +1,359 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/molu/stock-management-system/internal/kitting"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrKitCycle            = errors.New("component is the kit itself or contains it")
+	ErrInvalidKitQuantity  = errors.New("quantity must be positive")
+	ErrInvalidKitDirection = errors.New("direction must be assemble or disassemble")
+)
+
+// issuedLot is what issueLots drew from one inventory row.
+type issuedLot struct {
+	Inventory Inventory
+	Quantity  int32
+	Movement  StockMovement
+	Cost      decimal.Decimal
+}
+
+// issueLots takes quantity units of a product out of a warehouse, first
+// expiring first across its unreserved inventory rows, posting and costing
+// one movement per row drawn from. A valid batch restricts it to that lot.
+func issueLots(ctx context.Context, q *Queries, productID, warehouseID, quantity int32, batch sql.NullString, movement CreateStockMovementParams) ([]issuedLot, error) {
+	rows, err := q.ListAvailableInventoryForUpdate(ctx, ListAvailableInventoryForUpdateParams{
+		ProductID:   productID,
+		WarehouseID: warehouseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if batch.Valid {
+		lot := rows[:0]
+		for _, row := range rows {
+			if row.BatchNumber == batch {
+				lot = append(lot, row)
+			}
+		}
+		rows = lot
+	}
+
+	var available int32
+	for _, row := range rows {
+		available += row.Quantity - row.ReservedQuantity
+	}
+	if available < quantity {
+		return nil, ErrInsufficientStock
+	}
+
+	var lots []issuedLot
+	remaining := quantity
+	for _, row := range rows {
+		if remaining == 0 {
+			break
+		}
+		take := row.Quantity - row.ReservedQuantity
+		if take > remaining {
+			take = remaining
+		}
+
+		inv, err := q.RemoveInventoryQuantity(ctx, RemoveInventoryQuantityParams{
+			InventoryID: row.InventoryID,
+			Quantity:    take,
+		})
+		if err != nil {
+			return nil, err
+		}
+		lot, err := postIssue(ctx, q, row, inv, take, movement)
+		if err != nil {
+			return nil, err
+		}
+		lots = append(lots, lot)
+		remaining -= take
+	}
+	return lots, nil
+}
+
+// postIssue posts and costs the movement of take units leaving an inventory
+// row, given the row before and after.
+func postIssue(ctx context.Context, q *Queries, before, after Inventory, take int32, movement CreateStockMovementParams) (issuedLot, error) {
+	lot := issuedLot{Inventory: before, Quantity: take, Cost: decimal.Zero}
+
+	arg := movement
+	arg.ProductID = before.ProductID
+	arg.WarehouseID = before.WarehouseID
+	arg.LocationID = before.LocationID
+	arg.QuantityBefore = sql.NullInt32{Int32: before.Quantity, Valid: true}
+	arg.QuantityChange = -take
+	arg.QuantityAfter = sql.NullInt32{Int32: after.Quantity, Valid: true}
+	m, err := postStockMovement(ctx, q, arg)
+	if err != nil {
+		return lot, err
+	}
+	cost, err := postMovementCost(ctx, q, m)
+	if err != nil {
+		return lot, err
+	}
+	if cost != nil {
+		lot.Cost = cost.TotalCost.Neg()
+	}
+	lot.Movement = m
+	return lot, nil
+}
+
+// issueStock is issueLots from any lot, returning the movements and their
+// combined cost.
+func issueStock(ctx context.Context, q *Queries, productID, warehouseID, quantity int32, movement CreateStockMovementParams) ([]StockMovement, decimal.Decimal, error) {
+	total := decimal.Zero
+	lots, err := issueLots(ctx, q, productID, warehouseID, quantity, sql.NullString{}, movement)
+	if err != nil {
+		return nil, total, err
+	}
+	movements := make([]StockMovement, len(lots))
+	for i, lot := range lots {
+		movements[i] = lot.Movement
+		total = total.Add(lot.Cost)
+	}
+	return movements, total, nil
+}
+
+type SetKitComponentTxParams struct {
+	KitProductID       int32
+	ComponentProductID int32
+	Quantity           int32
+}
+
+// SetKitComponentTx adds a component to a kit's bill of materials or changes
+// its quantity. Kits may contain other kits, but never themselves, however
+// deep the nesting.
+func (store *SQLStore) SetKitComponentTx(ctx context.Context, arg SetKitComponentTxParams) (KitComponent, error) {
+	var result KitComponent
+
+	if arg.Quantity <= 0 {
+		return result, ErrInvalidKitQuantity
+	}
+	if arg.KitProductID == arg.ComponentProductID {
+		return result, ErrKitCycle
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.LockKitComponents(ctx); err != nil {
+			return err
+		}
+		if _, err := q.GetProduct(ctx, arg.KitProductID); err != nil {
+			return err
+		}
+		if _, err := q.GetProduct(ctx, arg.ComponentProductID); err != nil {
+			return err
+		}
+
+		parts, err := q.ListKitComponentIDsDeep(ctx, arg.ComponentProductID)
+		if err != nil {
+			return err
+		}
+		for _, id := range parts {
+			if id == arg.KitProductID {
+				return ErrKitCycle
+			}
+		}
+
+		result, err = q.UpsertKitComponent(ctx, UpsertKitComponentParams(arg))
+		return err
+	})
+
+	return result, err
+}
+
+type KitAssemblyTxParams struct {
+	KitProductID int32
+	// LocationID receives the kits when assembling and the components when
+	// disassembling; its warehouse supplies the stock consumed.
+	LocationID int32
+	Direction  AssemblyDirection
+	Quantity   int32
+	Notes      sql.NullString
+	CreatedBy  sql.NullInt32
+}
+
+type KitAssemblyTxResult struct {
+	Assembly  KitAssembly       `json:"assembly"`
+	Lines     []KitAssemblyLine `json:"lines"`
+	Movements []StockMovement   `json:"movements"`
+}
+
+// KitAssemblyTx builds kits from their components or takes kits apart, as
+// production movements. Assembling consumes the components from the
+// location's warehouse and puts the kits away at the location, costed at
+// what the components cost. Disassembling consumes the kits and puts the
+// components away, sharing the kits' cost over the components by their own
+// cost prices.
+func (store *SQLStore) KitAssemblyTx(ctx context.Context, arg KitAssemblyTxParams) (KitAssemblyTxResult, error) {
+	var result KitAssemblyTxResult
+
+	if arg.Quantity <= 0 {
+		return result, ErrInvalidKitQuantity
+	}
+	if !arg.Direction.Valid() {
+		return result, ErrInvalidKitDirection
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		loc, err := q.GetLocation(ctx, arg.LocationID)
+		if err != nil {
+			return err
+		}
+		components, err := q.ListKitComponents(ctx, arg.KitProductID)
+		if err != nil {
+			return err
+		}
+		if len(components) == 0 {
+			return kitting.ErrNoComponents
+		}
+
+		result.Assembly, err = q.CreateKitAssembly(ctx, CreateKitAssemblyParams{
+			KitProductID: arg.KitProductID,
+			WarehouseID:  loc.WarehouseID,
+			LocationID:   loc.LocationID,
+			Direction:    arg.Direction,
+			Quantity:     arg.Quantity,
+			Notes:        arg.Notes,
+			CreatedBy:    arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		issue := CreateStockMovementParams{
+			MovementType:   MovementTypeProduction,
+			ReferenceID:    sql.NullInt32{Int32: result.Assembly.AssemblyID, Valid: true},
+			ReferenceTable: sql.NullString{String: "kit_assemblies", Valid: true},
+			Notes:          arg.Notes,
+			CreatedBy:      arg.CreatedBy,
+		}
+
+		var total decimal.Decimal
+		if arg.Direction == AssemblyDirectionAssemble {
+			total, err = assembleKits(ctx, q, arg, loc, components, issue, &result)
+		} else {
+			total, err = disassembleKits(ctx, q, arg, loc, components, issue, &result)
+		}
+		if err != nil {
+			return err
+		}
+
+		result.Assembly, err = q.SetKitAssemblyCost(ctx, SetKitAssemblyCostParams{
+			AssemblyID: result.Assembly.AssemblyID,
+			UnitCost:   kitting.UnitCost(total, arg.Quantity),
+			TotalCost:  total,
+		})
+		if err != nil {
+			return err
+		}
+
+		// The kits are put away only now, so their receipt is costed at
+		// the unit cost just recorded on the assembly.
+		if arg.Direction == AssemblyDirectionAssemble {
+			put, err := putawayStock(ctx, q, PutawayTxParams{
+				ProductID:      arg.KitProductID,
+				LocationID:     loc.LocationID,
+				Quantity:       arg.Quantity,
+				ReferenceID:    issue.ReferenceID,
+				ReferenceTable: issue.ReferenceTable,
+				Notes:          arg.Notes,
+				CreatedBy:      arg.CreatedBy,
+				MovementType:   MovementTypeProduction,
+			})
+			if err != nil {
+				return err
+			}
+			result.Movements = append(result.Movements, put.Movement)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// assembleKits consumes the components of the kits and records a line per
+// component; it returns what they cost.
+func assembleKits(ctx context.Context, q *Queries, arg KitAssemblyTxParams, loc Location, components []ListKitComponentsRow, issue CreateStockMovementParams, result *KitAssemblyTxResult) (decimal.Decimal, error) {
+	total := decimal.Zero
+	for _, c := range components {
+		need := c.Quantity * arg.Quantity
+		movements, cost, err := issueStock(ctx, q, c.ComponentProductID, loc.WarehouseID, need, issue)
+		if err != nil {
+			if errors.Is(err, ErrInsufficientStock) {
+				return total, fmt.Errorf("%w of component %s: %d needed", ErrInsufficientStock, c.Sku, need)
+			}
+			return total, err
+		}
+		result.Movements = append(result.Movements, movements...)
+
+		line, err := q.CreateKitAssemblyLine(ctx, CreateKitAssemblyLineParams{
+			AssemblyID:         result.Assembly.AssemblyID,
+			ComponentProductID: c.ComponentProductID,
+			Quantity:           need,
+			UnitCost:           kitting.UnitCost(cost, need),
+			TotalCost:          cost,
+		})
+		if err != nil {
+			return total, err
+		}
+		result.Lines = append(result.Lines, line)
+		total = total.Add(cost)
+	}
+	return total, nil
+}
+
+// disassembleKits consumes the kits and puts their components away, each
+// line costed at its share of the kits' cost; it returns what the kits cost.
+func disassembleKits(ctx context.Context, q *Queries, arg KitAssemblyTxParams, loc Location, components []ListKitComponentsRow, issue CreateStockMovementParams, result *KitAssemblyTxResult) (decimal.Decimal, error) {
+	movements, total, err := issueStock(ctx, q, arg.KitProductID, loc.WarehouseID, arg.Quantity, issue)
+	if err != nil {
+		return total, err
+	}
+	result.Movements = append(result.Movements, movements...)
+
+	shares := make([]kitting.Share, len(components))
+	for i, c := range components {
+		shares[i] = kitting.Share{Quantity: c.Quantity * arg.Quantity, UnitCost: c.CostPrice}
+	}
+	unitCosts := kitting.Split(total, shares)
+
+	for i, c := range components {
+		need := shares[i].Quantity
+		line, err := q.CreateKitAssemblyLine(ctx, CreateKitAssemblyLineParams{
+			AssemblyID:         result.Assembly.AssemblyID,
+			ComponentProductID: c.ComponentProductID,
+			Quantity:           need,
+			UnitCost:           unitCosts[i],
+			TotalCost:          unitCosts[i].Mul(decimal.NewFromInt32(need)),
+		})
+		if err != nil {
+			return total, err
+		}
+		result.Lines = append(result.Lines, line)
+
+		put, err := putawayStock(ctx, q, PutawayTxParams{
+			ProductID:      c.ComponentProductID,
+			LocationID:     loc.LocationID,
+			Quantity:       need,
+			ReferenceID:    sql.NullInt32{Int32: line.LineID, Valid: true},
+			ReferenceTable: sql.NullString{String: "kit_assembly_lines", Valid: true},
+			Notes:          arg.Notes,
+			CreatedBy:      arg.CreatedBy,
+			MovementType:   MovementTypeProduction,
+		})
+		if err != nil {
+			return total, err
+		}
+		result.Movements = append(result.Movements, put.Movement)
+	}
+	return total, nil
+}
@@ -0,0 +1,297 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/molu/stock-management-system/internal/costing"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNoBaseCurrency         = errors.New("no base currency is set up")
+	ErrNoExchangeRate         = errors.New("no exchange rate on or before the date")
+	ErrNothingReceived        = errors.New("purchase order has no received lines")
+	ErrLandedCostTypeInvalid  = errors.New("cost_type must be freight, duty, insurance or other")
+	ErrLandedCostBasisInvalid = errors.New("allocation_basis must be value, weight or quantity")
+)
+
+// exchangeRate is the latest rate on or before the given day for converting
+// amounts in currency into the base currency. It is not valid when no
+// conversion is needed: no currency, or the base currency itself.
+func exchangeRate(ctx context.Context, q *Queries, currency string, on time.Time) (decimal.NullDecimal, error) {
+	if currency == "" {
+		return decimal.NullDecimal{}, nil
+	}
+
+	base, err := q.GetBaseCurrency(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return decimal.NullDecimal{}, ErrNoBaseCurrency
+		}
+		return decimal.NullDecimal{}, err
+	}
+	if base.CurrencyCode == currency {
+		return decimal.NullDecimal{}, nil
+	}
+
+	rate, err := q.GetExchangeRateOn(ctx, GetExchangeRateOnParams{
+		CurrencyCode: currency,
+		OnDate:       on,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return decimal.NullDecimal{}, fmt.Errorf("%w: %s on %s", ErrNoExchangeRate, currency, on.Format("2006-01-02"))
+		}
+		return decimal.NullDecimal{}, err
+	}
+	return decimal.NullDecimal{Decimal: rate.Rate, Valid: true}, nil
+}
+
+type CreateLandedCostTxParams struct {
+	PoID            int32
+	ReferenceNumber sql.NullString
+	CostType        LandedCostType
+	AllocationBasis LandedCostBasis
+	// CurrencyCode is the currency of Amount; empty means the base currency.
+	CurrencyCode string
+	Amount       decimal.Decimal
+	CostDate     time.Time
+	Notes        sql.NullString
+	CreatedBy    sql.NullInt32
+}
+
+type CreateLandedCostTxResult struct {
+	LandedCost  LandedCost             `json:"landed_cost"`
+	Allocations []LandedCostAllocation `json:"allocations"`
+}
+
+// CreateLandedCostTx records a freight, duty, insurance or other charge on a
+// purchase order and spreads it, converted into the base currency, over the
+// received lines by value, weight or quantity. Each line's share is split
+// over the warehouses its receipts went to and added to the stock still on
+// hand there: FIFO layers from the receipt are raised, weighted average
+// balances gain value and standard-costed products book the share as
+// purchase price variance.
+func (store *SQLStore) CreateLandedCostTx(ctx context.Context, arg CreateLandedCostTxParams) (CreateLandedCostTxResult, error) {
+	var result CreateLandedCostTxResult
+
+	if !arg.CostType.Valid() {
+		return result, ErrLandedCostTypeInvalid
+	}
+	if !arg.AllocationBasis.Valid() {
+		return result, ErrLandedCostBasisInvalid
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		currency := arg.CurrencyCode
+		if currency == "" {
+			base, err := q.GetBaseCurrency(ctx)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return ErrNoBaseCurrency
+				}
+				return err
+			}
+			currency = base.CurrencyCode
+		}
+
+		rate, err := exchangeRate(ctx, q, currency, arg.CostDate)
+		if err != nil {
+			return err
+		}
+		if !rate.Valid {
+			rate = decimal.NullDecimal{Decimal: decimal.NewFromInt(1), Valid: true}
+		}
+		baseAmount := arg.Amount.Mul(rate.Decimal).Round(2)
+
+		lines, err := q.ListReceivedPurchaseOrderLines(ctx, arg.PoID)
+		if err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return ErrNothingReceived
+		}
+
+		basis := make([]decimal.Decimal, len(lines))
+		for i, line := range lines {
+			received := decimal.NewFromInt32(line.QuantityReceived)
+			switch arg.AllocationBasis {
+			case LandedCostBasisValue:
+				basis[i] = line.UnitPrice.Mul(received)
+			case LandedCostBasisWeight:
+				basis[i] = decimal.NewFromFloat(line.UnitWeight).Mul(received)
+			default:
+				basis[i] = received
+			}
+			basis[i] = basis[i].Round(4)
+		}
+		shares, err := costing.Allocate(baseAmount, basis, 2)
+		if err != nil {
+			return err
+		}
+
+		result.LandedCost, err = q.CreateLandedCost(ctx, CreateLandedCostParams{
+			PoID:            arg.PoID,
+			ReferenceNumber: arg.ReferenceNumber,
+			CostType:        arg.CostType,
+			AllocationBasis: arg.AllocationBasis,
+			CurrencyCode:    currency,
+			Amount:          arg.Amount,
+			ExchangeRate:    rate.Decimal,
+			BaseAmount:      baseAmount,
+			CostDate:        arg.CostDate,
+			Notes:           arg.Notes,
+			CreatedBy:       arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, line := range lines {
+			if shares[i].IsZero() {
+				continue
+			}
+			allocations, err := landLine(ctx, q, result.LandedCost.LandedCostID, line, basis[i], shares[i])
+			if err != nil {
+				return err
+			}
+			result.Allocations = append(result.Allocations, allocations...)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// landLine books one purchase order line's share of a landed cost, split
+// over the warehouses its stock was received into by quantity. A line that
+// was marked received without any stock movement has nothing on hand to
+// carry the cost, so its share is expensed.
+func landLine(ctx context.Context, q *Queries, landedCostID int32, line ListReceivedPurchaseOrderLinesRow, basis, share decimal.Decimal) ([]LandedCostAllocation, error) {
+	poItemID := sql.NullInt32{Int32: line.PoItemID, Valid: true}
+
+	receipts, err := q.ListPurchaseOrderItemReceipts(ctx, poItemID)
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) == 0 {
+		allocation, err := q.CreateLandedCostAllocation(ctx, CreateLandedCostAllocationParams{
+			LandedCostID:      landedCostID,
+			PoItemID:          line.PoItemID,
+			ProductID:         line.ProductID,
+			Quantity:          line.QuantityReceived,
+			Basis:             basis,
+			Amount:            share,
+			CapitalizedAmount: decimal.Zero,
+			ExpensedAmount:    share,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []LandedCostAllocation{allocation}, nil
+	}
+
+	quantities := make([]decimal.Decimal, len(receipts))
+	for i, r := range receipts {
+		quantities[i] = decimal.NewFromInt32(r.Quantity)
+	}
+	split, err := costing.Allocate(share, quantities, costing.UnitCostPlaces)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := q.GetProduct(ctx, line.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	method := costing.Method(product.CostingMethod)
+
+	allocations := make([]LandedCostAllocation, 0, len(receipts))
+	for i, r := range receipts {
+		if err := q.EnsureCostBalance(ctx, EnsureCostBalanceParams{
+			ProductID:   line.ProductID,
+			WarehouseID: r.WarehouseID,
+		}); err != nil {
+			return nil, err
+		}
+		balance, err := q.GetCostBalanceForUpdate(ctx, GetCostBalanceForUpdateParams{
+			ProductID:   line.ProductID,
+			WarehouseID: r.WarehouseID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var onHand int32
+		switch method {
+		case costing.MethodFIFO:
+			onHand, err = q.RaisePurchaseOrderItemCostLayers(ctx, RaisePurchaseOrderItemCostLayersParams{
+				Uplift:      costing.Uplift(split[i], r.Quantity),
+				PoItemID:    poItemID,
+				WarehouseID: r.WarehouseID,
+			})
+		case costing.MethodWeightedAverage:
+			onHand = balance.Quantity
+		case costing.MethodStandard:
+			_, err = q.CreatePurchasePriceVariance(ctx, CreatePurchasePriceVarianceParams{
+				ProductID:      line.ProductID,
+				WarehouseID:    r.WarehouseID,
+				PoItemID:       poItemID,
+				Quantity:       r.Quantity,
+				StandardCost:   product.StandardCost.Decimal,
+				ActualCost:     product.StandardCost.Decimal.Add(costing.Uplift(split[i], r.Quantity)),
+				VarianceAmount: split[i],
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		landing := costing.Land(method, split[i], r.Quantity, onHand)
+
+		var entryID sql.NullInt32
+		if landing.Capitalized.IsPositive() {
+			if _, err := q.UpdateCostBalance(ctx, UpdateCostBalanceParams{
+				ProductID:   line.ProductID,
+				WarehouseID: r.WarehouseID,
+				TotalValue:  landing.Capitalized,
+			}); err != nil {
+				return nil, err
+			}
+			entry, err := q.CreateCostEntry(ctx, CreateCostEntryParams{
+				ProductID:     line.ProductID,
+				WarehouseID:   r.WarehouseID,
+				EntryType:     CostEntryTypeLandedCost,
+				CostingMethod: product.CostingMethod,
+				UnitCost:      landing.UnitUplift,
+				TotalCost:     landing.Capitalized,
+			})
+			if err != nil {
+				return nil, err
+			}
+			entryID = sql.NullInt32{Int32: entry.EntryID, Valid: true}
+		}
+
+		allocation, err := q.CreateLandedCostAllocation(ctx, CreateLandedCostAllocationParams{
+			LandedCostID:      landedCostID,
+			PoItemID:          line.PoItemID,
+			ProductID:         line.ProductID,
+			WarehouseID:       sql.NullInt32{Int32: r.WarehouseID, Valid: true},
+			Quantity:          r.Quantity,
+			Basis:             basis,
+			Amount:            split[i],
+			CapitalizedAmount: landing.Capitalized,
+			ExpensedAmount:    landing.Expensed,
+			CostEntryID:       entryID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, allocation)
+	}
+	return allocations, nil
+}
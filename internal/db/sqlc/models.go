@@ -332,6 +332,134 @@ func AllAdjustmentStatusValues() []AdjustmentStatus {
 	}
 }
 
+type AssemblyDirection string
+
+const (
+	AssemblyDirectionAssemble    AssemblyDirection = "assemble"
+	AssemblyDirectionDisassemble AssemblyDirection = "disassemble"
+)
+
+func (e *AssemblyDirection) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AssemblyDirection(s)
+	case string:
+		*e = AssemblyDirection(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AssemblyDirection: %T", src)
+	}
+	return nil
+}
+
+type NullAssemblyDirection struct {
+	AssemblyDirection AssemblyDirection `json:"assembly_direction"`
+	Valid             bool              `json:"valid"` // Valid is true if AssemblyDirection is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAssemblyDirection) Scan(value interface{}) error {
+	if value == nil {
+		ns.AssemblyDirection, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AssemblyDirection.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAssemblyDirection) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AssemblyDirection), nil
+}
+
+func (e AssemblyDirection) Valid() bool {
+	switch e {
+	case AssemblyDirectionAssemble,
+		AssemblyDirectionDisassemble:
+		return true
+	}
+	return false
+}
+
+func AllAssemblyDirectionValues() []AssemblyDirection {
+	return []AssemblyDirection{
+		AssemblyDirectionAssemble,
+		AssemblyDirectionDisassemble,
+	}
+}
+
+type AttributeDataType string
+
+const (
+	AttributeDataTypeText    AttributeDataType = "text"
+	AttributeDataTypeInteger AttributeDataType = "integer"
+	AttributeDataTypeDecimal AttributeDataType = "decimal"
+	AttributeDataTypeBoolean AttributeDataType = "boolean"
+	AttributeDataTypeDate    AttributeDataType = "date"
+	AttributeDataTypeEnum    AttributeDataType = "enum"
+)
+
+func (e *AttributeDataType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AttributeDataType(s)
+	case string:
+		*e = AttributeDataType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AttributeDataType: %T", src)
+	}
+	return nil
+}
+
+type NullAttributeDataType struct {
+	AttributeDataType AttributeDataType `json:"attribute_data_type"`
+	Valid             bool              `json:"valid"` // Valid is true if AttributeDataType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAttributeDataType) Scan(value interface{}) error {
+	if value == nil {
+		ns.AttributeDataType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AttributeDataType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAttributeDataType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AttributeDataType), nil
+}
+
+func (e AttributeDataType) Valid() bool {
+	switch e {
+	case AttributeDataTypeText,
+		AttributeDataTypeInteger,
+		AttributeDataTypeDecimal,
+		AttributeDataTypeBoolean,
+		AttributeDataTypeDate,
+		AttributeDataTypeEnum:
+		return true
+	}
+	return false
+}
+
+func AllAttributeDataTypeValues() []AttributeDataType {
+	return []AttributeDataType{
+		AttributeDataTypeText,
+		AttributeDataTypeInteger,
+		AttributeDataTypeDecimal,
+		AttributeDataTypeBoolean,
+		AttributeDataTypeDate,
+		AttributeDataTypeEnum,
+	}
+}
+
 type AuditAction string
 
 const (
@@ -457,6 +585,131 @@ func AllConditionTypeValues() []ConditionType {
 	}
 }
 
+type CostEntryType string
+
+const (
+	CostEntryTypeReceipt     CostEntryType = "receipt"
+	CostEntryTypeIssue       CostEntryType = "issue"
+	CostEntryTypeRevaluation CostEntryType = "revaluation"
+	CostEntryTypeLandedCost  CostEntryType = "landed_cost"
+)
+
+func (e *CostEntryType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CostEntryType(s)
+	case string:
+		*e = CostEntryType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CostEntryType: %T", src)
+	}
+	return nil
+}
+
+type NullCostEntryType struct {
+	CostEntryType CostEntryType `json:"cost_entry_type"`
+	Valid         bool          `json:"valid"` // Valid is true if CostEntryType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCostEntryType) Scan(value interface{}) error {
+	if value == nil {
+		ns.CostEntryType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CostEntryType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCostEntryType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CostEntryType), nil
+}
+
+func (e CostEntryType) Valid() bool {
+	switch e {
+	case CostEntryTypeReceipt,
+		CostEntryTypeIssue,
+		CostEntryTypeRevaluation,
+		CostEntryTypeLandedCost:
+		return true
+	}
+	return false
+}
+
+func AllCostEntryTypeValues() []CostEntryType {
+	return []CostEntryType{
+		CostEntryTypeReceipt,
+		CostEntryTypeIssue,
+		CostEntryTypeRevaluation,
+		CostEntryTypeLandedCost,
+	}
+}
+
+type CostingMethod string
+
+const (
+	CostingMethodFifo            CostingMethod = "fifo"
+	CostingMethodWeightedAverage CostingMethod = "weighted_average"
+	CostingMethodStandard        CostingMethod = "standard"
+)
+
+func (e *CostingMethod) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CostingMethod(s)
+	case string:
+		*e = CostingMethod(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CostingMethod: %T", src)
+	}
+	return nil
+}
+
+type NullCostingMethod struct {
+	CostingMethod CostingMethod `json:"costing_method"`
+	Valid         bool          `json:"valid"` // Valid is true if CostingMethod is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCostingMethod) Scan(value interface{}) error {
+	if value == nil {
+		ns.CostingMethod, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CostingMethod.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCostingMethod) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CostingMethod), nil
+}
+
+func (e CostingMethod) Valid() bool {
+	switch e {
+	case CostingMethodFifo,
+		CostingMethodWeightedAverage,
+		CostingMethodStandard:
+		return true
+	}
+	return false
+}
+
+func AllCostingMethodValues() []CostingMethod {
+	return []CostingMethod{
+		CostingMethodFifo,
+		CostingMethodWeightedAverage,
+		CostingMethodStandard,
+	}
+}
+
 type CountingMethod string
 
 const (
@@ -518,6 +771,204 @@ func AllCountingMethodValues() []CountingMethod {
 	}
 }
 
+type FloorTaskException string
+
+const (
+	FloorTaskExceptionShortPick       FloorTaskException = "short_pick"
+	FloorTaskExceptionDamaged         FloorTaskException = "damaged"
+	FloorTaskExceptionNotFound        FloorTaskException = "not_found"
+	FloorTaskExceptionWrongItem       FloorTaskException = "wrong_item"
+	FloorTaskExceptionLocationBlocked FloorTaskException = "location_blocked"
+)
+
+func (e *FloorTaskException) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = FloorTaskException(s)
+	case string:
+		*e = FloorTaskException(s)
+	default:
+		return fmt.Errorf("unsupported scan type for FloorTaskException: %T", src)
+	}
+	return nil
+}
+
+type NullFloorTaskException struct {
+	FloorTaskException FloorTaskException `json:"floor_task_exception"`
+	Valid              bool               `json:"valid"` // Valid is true if FloorTaskException is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFloorTaskException) Scan(value interface{}) error {
+	if value == nil {
+		ns.FloorTaskException, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FloorTaskException.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFloorTaskException) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FloorTaskException), nil
+}
+
+func (e FloorTaskException) Valid() bool {
+	switch e {
+	case FloorTaskExceptionShortPick,
+		FloorTaskExceptionDamaged,
+		FloorTaskExceptionNotFound,
+		FloorTaskExceptionWrongItem,
+		FloorTaskExceptionLocationBlocked:
+		return true
+	}
+	return false
+}
+
+func AllFloorTaskExceptionValues() []FloorTaskException {
+	return []FloorTaskException{
+		FloorTaskExceptionShortPick,
+		FloorTaskExceptionDamaged,
+		FloorTaskExceptionNotFound,
+		FloorTaskExceptionWrongItem,
+		FloorTaskExceptionLocationBlocked,
+	}
+}
+
+type FloorTaskStatus string
+
+const (
+	FloorTaskStatusOpen      FloorTaskStatus = "open"
+	FloorTaskStatusAssigned  FloorTaskStatus = "assigned"
+	FloorTaskStatusCompleted FloorTaskStatus = "completed"
+	FloorTaskStatusException FloorTaskStatus = "exception"
+	FloorTaskStatusCancelled FloorTaskStatus = "cancelled"
+)
+
+func (e *FloorTaskStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = FloorTaskStatus(s)
+	case string:
+		*e = FloorTaskStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for FloorTaskStatus: %T", src)
+	}
+	return nil
+}
+
+type NullFloorTaskStatus struct {
+	FloorTaskStatus FloorTaskStatus `json:"floor_task_status"`
+	Valid           bool            `json:"valid"` // Valid is true if FloorTaskStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFloorTaskStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.FloorTaskStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FloorTaskStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFloorTaskStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FloorTaskStatus), nil
+}
+
+func (e FloorTaskStatus) Valid() bool {
+	switch e {
+	case FloorTaskStatusOpen,
+		FloorTaskStatusAssigned,
+		FloorTaskStatusCompleted,
+		FloorTaskStatusException,
+		FloorTaskStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func AllFloorTaskStatusValues() []FloorTaskStatus {
+	return []FloorTaskStatus{
+		FloorTaskStatusOpen,
+		FloorTaskStatusAssigned,
+		FloorTaskStatusCompleted,
+		FloorTaskStatusException,
+		FloorTaskStatusCancelled,
+	}
+}
+
+type FloorTaskType string
+
+const (
+	FloorTaskTypePutaway FloorTaskType = "putaway"
+	FloorTaskTypePick    FloorTaskType = "pick"
+	FloorTaskTypeCount   FloorTaskType = "count"
+	FloorTaskTypeMove    FloorTaskType = "move"
+)
+
+func (e *FloorTaskType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = FloorTaskType(s)
+	case string:
+		*e = FloorTaskType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for FloorTaskType: %T", src)
+	}
+	return nil
+}
+
+type NullFloorTaskType struct {
+	FloorTaskType FloorTaskType `json:"floor_task_type"`
+	Valid         bool          `json:"valid"` // Valid is true if FloorTaskType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFloorTaskType) Scan(value interface{}) error {
+	if value == nil {
+		ns.FloorTaskType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FloorTaskType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFloorTaskType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FloorTaskType), nil
+}
+
+func (e FloorTaskType) Valid() bool {
+	switch e {
+	case FloorTaskTypePutaway,
+		FloorTaskTypePick,
+		FloorTaskTypeCount,
+		FloorTaskTypeMove:
+		return true
+	}
+	return false
+}
+
+func AllFloorTaskTypeValues() []FloorTaskType {
+	return []FloorTaskType{
+		FloorTaskTypePutaway,
+		FloorTaskTypePick,
+		FloorTaskTypeCount,
+		FloorTaskTypeMove,
+	}
+}
+
 type IdentifierStatus string
 
 const (
@@ -662,127 +1113,252 @@ const (
 	IncidentTypeUnknown    IncidentType = "unknown"
 )
 
-func (e *IncidentType) Scan(src interface{}) error {
+func (e *IncidentType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = IncidentType(s)
+	case string:
+		*e = IncidentType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for IncidentType: %T", src)
+	}
+	return nil
+}
+
+type NullIncidentType struct {
+	IncidentType IncidentType `json:"incident_type"`
+	Valid        bool         `json:"valid"` // Valid is true if IncidentType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullIncidentType) Scan(value interface{}) error {
+	if value == nil {
+		ns.IncidentType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.IncidentType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullIncidentType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.IncidentType), nil
+}
+
+func (e IncidentType) Valid() bool {
+	switch e {
+	case IncidentTypeTheft,
+		IncidentTypeDamage,
+		IncidentTypeMiscount,
+		IncidentTypeExpiration,
+		IncidentTypeUnknown:
+		return true
+	}
+	return false
+}
+
+func AllIncidentTypeValues() []IncidentType {
+	return []IncidentType{
+		IncidentTypeTheft,
+		IncidentTypeDamage,
+		IncidentTypeMiscount,
+		IncidentTypeExpiration,
+		IncidentTypeUnknown,
+	}
+}
+
+type InventoryStatus string
+
+const (
+	InventoryStatusInStock  InventoryStatus = "in_stock"
+	InventoryStatusReserved InventoryStatus = "reserved"
+	InventoryStatusShipped  InventoryStatus = "shipped"
+	InventoryStatusReturned InventoryStatus = "returned"
+	InventoryStatusDamaged  InventoryStatus = "damaged"
+)
+
+func (e *InventoryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = InventoryStatus(s)
+	case string:
+		*e = InventoryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for InventoryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullInventoryStatus struct {
+	InventoryStatus InventoryStatus `json:"inventory_status"`
+	Valid           bool            `json:"valid"` // Valid is true if InventoryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullInventoryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.InventoryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.InventoryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullInventoryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.InventoryStatus), nil
+}
+
+func (e InventoryStatus) Valid() bool {
+	switch e {
+	case InventoryStatusInStock,
+		InventoryStatusReserved,
+		InventoryStatusShipped,
+		InventoryStatusReturned,
+		InventoryStatusDamaged:
+		return true
+	}
+	return false
+}
+
+func AllInventoryStatusValues() []InventoryStatus {
+	return []InventoryStatus{
+		InventoryStatusInStock,
+		InventoryStatusReserved,
+		InventoryStatusShipped,
+		InventoryStatusReturned,
+		InventoryStatusDamaged,
+	}
+}
+
+type LandedCostBasis string
+
+const (
+	LandedCostBasisValue    LandedCostBasis = "value"
+	LandedCostBasisWeight   LandedCostBasis = "weight"
+	LandedCostBasisQuantity LandedCostBasis = "quantity"
+)
+
+func (e *LandedCostBasis) Scan(src interface{}) error {
 	switch s := src.(type) {
 	case []byte:
-		*e = IncidentType(s)
+		*e = LandedCostBasis(s)
 	case string:
-		*e = IncidentType(s)
+		*e = LandedCostBasis(s)
 	default:
-		return fmt.Errorf("unsupported scan type for IncidentType: %T", src)
+		return fmt.Errorf("unsupported scan type for LandedCostBasis: %T", src)
 	}
 	return nil
 }
 
-type NullIncidentType struct {
-	IncidentType IncidentType `json:"incident_type"`
-	Valid        bool         `json:"valid"` // Valid is true if IncidentType is not NULL
+type NullLandedCostBasis struct {
+	LandedCostBasis LandedCostBasis `json:"landed_cost_basis"`
+	Valid           bool            `json:"valid"` // Valid is true if LandedCostBasis is not NULL
 }
 
 // Scan implements the Scanner interface.
-func (ns *NullIncidentType) Scan(value interface{}) error {
+func (ns *NullLandedCostBasis) Scan(value interface{}) error {
 	if value == nil {
-		ns.IncidentType, ns.Valid = "", false
+		ns.LandedCostBasis, ns.Valid = "", false
 		return nil
 	}
 	ns.Valid = true
-	return ns.IncidentType.Scan(value)
+	return ns.LandedCostBasis.Scan(value)
 }
 
 // Value implements the driver Valuer interface.
-func (ns NullIncidentType) Value() (driver.Value, error) {
+func (ns NullLandedCostBasis) Value() (driver.Value, error) {
 	if !ns.Valid {
 		return nil, nil
 	}
-	return string(ns.IncidentType), nil
+	return string(ns.LandedCostBasis), nil
 }
 
-func (e IncidentType) Valid() bool {
+func (e LandedCostBasis) Valid() bool {
 	switch e {
-	case IncidentTypeTheft,
-		IncidentTypeDamage,
-		IncidentTypeMiscount,
-		IncidentTypeExpiration,
-		IncidentTypeUnknown:
+	case LandedCostBasisValue,
+		LandedCostBasisWeight,
+		LandedCostBasisQuantity:
 		return true
 	}
 	return false
 }
 
-func AllIncidentTypeValues() []IncidentType {
-	return []IncidentType{
-		IncidentTypeTheft,
-		IncidentTypeDamage,
-		IncidentTypeMiscount,
-		IncidentTypeExpiration,
-		IncidentTypeUnknown,
+func AllLandedCostBasisValues() []LandedCostBasis {
+	return []LandedCostBasis{
+		LandedCostBasisValue,
+		LandedCostBasisWeight,
+		LandedCostBasisQuantity,
 	}
 }
 
-type InventoryStatus string
+type LandedCostType string
 
 const (
-	InventoryStatusInStock  InventoryStatus = "in_stock"
-	InventoryStatusReserved InventoryStatus = "reserved"
-	InventoryStatusShipped  InventoryStatus = "shipped"
-	InventoryStatusReturned InventoryStatus = "returned"
-	InventoryStatusDamaged  InventoryStatus = "damaged"
+	LandedCostTypeFreight   LandedCostType = "freight"
+	LandedCostTypeDuty      LandedCostType = "duty"
+	LandedCostTypeInsurance LandedCostType = "insurance"
+	LandedCostTypeOther     LandedCostType = "other"
 )
 
-func (e *InventoryStatus) Scan(src interface{}) error {
+func (e *LandedCostType) Scan(src interface{}) error {
 	switch s := src.(type) {
 	case []byte:
-		*e = InventoryStatus(s)
+		*e = LandedCostType(s)
 	case string:
-		*e = InventoryStatus(s)
+		*e = LandedCostType(s)
 	default:
-		return fmt.Errorf("unsupported scan type for InventoryStatus: %T", src)
+		return fmt.Errorf("unsupported scan type for LandedCostType: %T", src)
 	}
 	return nil
 }
 
-type NullInventoryStatus struct {
-	InventoryStatus InventoryStatus `json:"inventory_status"`
-	Valid           bool            `json:"valid"` // Valid is true if InventoryStatus is not NULL
+type NullLandedCostType struct {
+	LandedCostType LandedCostType `json:"landed_cost_type"`
+	Valid          bool           `json:"valid"` // Valid is true if LandedCostType is not NULL
 }
 
 // Scan implements the Scanner interface.
-func (ns *NullInventoryStatus) Scan(value interface{}) error {
+func (ns *NullLandedCostType) Scan(value interface{}) error {
 	if value == nil {
-		ns.InventoryStatus, ns.Valid = "", false
+		ns.LandedCostType, ns.Valid = "", false
 		return nil
 	}
 	ns.Valid = true
-	return ns.InventoryStatus.Scan(value)
+	return ns.LandedCostType.Scan(value)
 }
 
 // Value implements the driver Valuer interface.
-func (ns NullInventoryStatus) Value() (driver.Value, error) {
+func (ns NullLandedCostType) Value() (driver.Value, error) {
 	if !ns.Valid {
 		return nil, nil
 	}
-	return string(ns.InventoryStatus), nil
+	return string(ns.LandedCostType), nil
 }
 
-func (e InventoryStatus) Valid() bool {
+func (e LandedCostType) Valid() bool {
 	switch e {
-	case InventoryStatusInStock,
-		InventoryStatusReserved,
-		InventoryStatusShipped,
-		InventoryStatusReturned,
-		InventoryStatusDamaged:
+	case LandedCostTypeFreight,
+		LandedCostTypeDuty,
+		LandedCostTypeInsurance,
+		LandedCostTypeOther:
 		return true
 	}
 	return false
 }
 
-func AllInventoryStatusValues() []InventoryStatus {
-	return []InventoryStatus{
-		InventoryStatusInStock,
-		InventoryStatusReserved,
-		InventoryStatusShipped,
-		InventoryStatusReturned,
-		InventoryStatusDamaged,
+func AllLandedCostTypeValues() []LandedCostType {
+	return []LandedCostType{
+		LandedCostTypeFreight,
+		LandedCostTypeDuty,
+		LandedCostTypeInsurance,
+		LandedCostTypeOther,
 	}
 }
 
@@ -860,6 +1436,7 @@ const (
 	MovementTypeReturn          MovementType = "return"
 	MovementTypeDamage          MovementType = "damage"
 	MovementTypeProduction      MovementType = "production"
+	MovementTypeOpeningBalance  MovementType = "opening_balance"
 )
 
 func (e *MovementType) Scan(src interface{}) error {
@@ -905,7 +1482,8 @@ func (e MovementType) Valid() bool {
 		MovementTypeStockAdjustment,
 		MovementTypeReturn,
 		MovementTypeDamage,
-		MovementTypeProduction:
+		MovementTypeProduction,
+		MovementTypeOpeningBalance:
 		return true
 	}
 	return false
@@ -920,6 +1498,129 @@ func AllMovementTypeValues() []MovementType {
 		MovementTypeReturn,
 		MovementTypeDamage,
 		MovementTypeProduction,
+		MovementTypeOpeningBalance,
+	}
+}
+
+type PickStatus string
+
+const (
+	PickStatusPending   PickStatus = "pending"
+	PickStatusPicked    PickStatus = "picked"
+	PickStatusShort     PickStatus = "short"
+	PickStatusCancelled PickStatus = "cancelled"
+)
+
+func (e *PickStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PickStatus(s)
+	case string:
+		*e = PickStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PickStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPickStatus struct {
+	PickStatus PickStatus `json:"pick_status"`
+	Valid      bool       `json:"valid"` // Valid is true if PickStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPickStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PickStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PickStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPickStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PickStatus), nil
+}
+
+func (e PickStatus) Valid() bool {
+	switch e {
+	case PickStatusPending,
+		PickStatusPicked,
+		PickStatusShort,
+		PickStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func AllPickStatusValues() []PickStatus {
+	return []PickStatus{
+		PickStatusPending,
+		PickStatusPicked,
+		PickStatusShort,
+		PickStatusCancelled,
+	}
+}
+
+type PoApprovalDecision string
+
+const (
+	PoApprovalDecisionApproved PoApprovalDecision = "approved"
+	PoApprovalDecisionRejected PoApprovalDecision = "rejected"
+)
+
+func (e *PoApprovalDecision) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PoApprovalDecision(s)
+	case string:
+		*e = PoApprovalDecision(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PoApprovalDecision: %T", src)
+	}
+	return nil
+}
+
+type NullPoApprovalDecision struct {
+	PoApprovalDecision PoApprovalDecision `json:"po_approval_decision"`
+	Valid              bool               `json:"valid"` // Valid is true if PoApprovalDecision is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPoApprovalDecision) Scan(value interface{}) error {
+	if value == nil {
+		ns.PoApprovalDecision, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PoApprovalDecision.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPoApprovalDecision) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PoApprovalDecision), nil
+}
+
+func (e PoApprovalDecision) Valid() bool {
+	switch e {
+	case PoApprovalDecisionApproved,
+		PoApprovalDecisionRejected:
+		return true
+	}
+	return false
+}
+
+func AllPoApprovalDecisionValues() []PoApprovalDecision {
+	return []PoApprovalDecision{
+		PoApprovalDecisionApproved,
+		PoApprovalDecisionRejected,
 	}
 }
 
@@ -1313,6 +2014,134 @@ func AllWaveStatusValues() []WaveStatus {
 	}
 }
 
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+func (e *WebhookDeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveryStatus(s)
+	case string:
+		*e = WebhookDeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveryStatus struct {
+	WebhookDeliveryStatus WebhookDeliveryStatus `json:"webhook_delivery_status"`
+	Valid                 bool                  `json:"valid"` // Valid is true if WebhookDeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveryStatus), nil
+}
+
+func (e WebhookDeliveryStatus) Valid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending,
+		WebhookDeliveryStatusDelivered,
+		WebhookDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func AllWebhookDeliveryStatusValues() []WebhookDeliveryStatus {
+	return []WebhookDeliveryStatus{
+		WebhookDeliveryStatusPending,
+		WebhookDeliveryStatusDelivered,
+		WebhookDeliveryStatusFailed,
+	}
+}
+
+type WorkOrderStatus string
+
+const (
+	WorkOrderStatusDraft      WorkOrderStatus = "draft"
+	WorkOrderStatusReleased   WorkOrderStatus = "released"
+	WorkOrderStatusInProgress WorkOrderStatus = "in_progress"
+	WorkOrderStatusCompleted  WorkOrderStatus = "completed"
+	WorkOrderStatusCancelled  WorkOrderStatus = "cancelled"
+)
+
+func (e *WorkOrderStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WorkOrderStatus(s)
+	case string:
+		*e = WorkOrderStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WorkOrderStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWorkOrderStatus struct {
+	WorkOrderStatus WorkOrderStatus `json:"work_order_status"`
+	Valid           bool            `json:"valid"` // Valid is true if WorkOrderStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWorkOrderStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WorkOrderStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WorkOrderStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWorkOrderStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WorkOrderStatus), nil
+}
+
+func (e WorkOrderStatus) Valid() bool {
+	switch e {
+	case WorkOrderStatusDraft,
+		WorkOrderStatusReleased,
+		WorkOrderStatusInProgress,
+		WorkOrderStatusCompleted,
+		WorkOrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func AllWorkOrderStatusValues() []WorkOrderStatus {
+	return []WorkOrderStatus{
+		WorkOrderStatusDraft,
+		WorkOrderStatusReleased,
+		WorkOrderStatusInProgress,
+		WorkOrderStatusCompleted,
+		WorkOrderStatusCancelled,
+	}
+}
+
 type AbcClassification struct {
 	ClassificationID int32         `json:"classification_id"`
 	ProductID        int32         `json:"product_id"`
@@ -1343,6 +2172,68 @@ type Category struct {
 	CreatedAt        time.Time      `json:"created_at"`
 }
 
+type CategoryAttribute struct {
+	AttributeID   int32                 `json:"attribute_id"`
+	CategoryID    int32                 `json:"category_id"`
+	AttributeCode string                `json:"attribute_code"`
+	Name          string                `json:"name"`
+	DataType      AttributeDataType     `json:"data_type"`
+	IsRequired    bool                  `json:"is_required"`
+	AllowedValues pqtype.NullRawMessage `json:"allowed_values"`
+	Unit          sql.NullString        `json:"unit"`
+	CreatedAt     time.Time             `json:"created_at"`
+}
+
+type CostBalance struct {
+	ProductID   int32 `json:"product_id"`
+	WarehouseID int32 `json:"warehouse_id"`
+	Quantity    int32 `json:"quantity"`
+	// Book value of the quantity on hand
+	TotalValue decimal.Decimal `json:"total_value"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+type CostEntry struct {
+	EntryID       int32         `json:"entry_id"`
+	ProductID     int32         `json:"product_id"`
+	WarehouseID   int32         `json:"warehouse_id"`
+	MovementID    sql.NullInt32 `json:"movement_id"`
+	EntryType     CostEntryType `json:"entry_type"`
+	CostingMethod CostingMethod `json:"costing_method"`
+	// Signed: positive for receipts, negative for issues, 0 for revaluations
+	Quantity int32           `json:"quantity"`
+	UnitCost decimal.Decimal `json:"unit_cost"`
+	// Signed change in book value
+	TotalCost decimal.Decimal `json:"total_cost"`
+	EntryDate time.Time       `json:"entry_date"`
+	// Currency a receipt was bought in, when not the base currency
+	CurrencyCode sql.NullString `json:"currency_code"`
+	// Rate the purchase price was converted into the base currency at
+	ExchangeRate decimal.NullDecimal `json:"exchange_rate"`
+}
+
+type CostLayer struct {
+	LayerID          int32         `json:"layer_id"`
+	ProductID        int32         `json:"product_id"`
+	WarehouseID      int32         `json:"warehouse_id"`
+	MovementID       sql.NullInt32 `json:"movement_id"`
+	PoItemID         sql.NullInt32 `json:"po_item_id"`
+	QuantityReceived int32         `json:"quantity_received"`
+	// Units of the receipt not yet issued under FIFO
+	QuantityRemaining int32           `json:"quantity_remaining"`
+	UnitCost          decimal.Decimal `json:"unit_cost"`
+	ReceivedAt        time.Time       `json:"received_at"`
+}
+
+type Currency struct {
+	CurrencyCode string `json:"currency_code"`
+	Name         string `json:"name"`
+	// Currency inventory is valued in; at most one
+	IsBase    bool      `json:"is_base"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type CycleCountSchedule struct {
 	ScheduleID     int32          `json:"schedule_id"`
 	WarehouseID    sql.NullInt32  `json:"warehouse_id"`
@@ -1354,6 +2245,80 @@ type CycleCountSchedule struct {
 	AssignedTo     sql.NullInt32  `json:"assigned_to"`
 }
 
+type ExchangeRate struct {
+	RateID       int32     `json:"rate_id"`
+	CurrencyCode string    `json:"currency_code"`
+	RateDate     time.Time `json:"rate_date"`
+	// Base currency units per one unit of this currency
+	Rate      decimal.Decimal `json:"rate"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type FloorTask struct {
+	TaskID      int32           `json:"task_id"`
+	WarehouseID int32           `json:"warehouse_id"`
+	TaskType    FloorTaskType   `json:"task_type"`
+	Status      FloorTaskStatus `json:"status"`
+	// Lower runs first, as for picking waves; pick tasks take their wave's priority
+	Priority  int32 `json:"priority"`
+	ProductID int32 `json:"product_id"`
+	// Location the device scans to start the task: the pick face, the counted location or the source of a putaway or move
+	FromLocationID sql.NullInt32 `json:"from_location_id"`
+	// Destination of a move; for a putaway the suggested location, replaced by the one scanned
+	ToLocationID sql.NullInt32 `json:"to_location_id"`
+	// Units to pick, put away or move; 0 for counts, which are blind
+	Quantity     int32          `json:"quantity"`
+	QuantityDone sql.NullInt32  `json:"quantity_done"`
+	BatchNumber  sql.NullString `json:"batch_number"`
+	SerialNumber sql.NullString `json:"serial_number"`
+	// picking_wave_items for pick tasks and stocktake_items for count tasks; those tasks are generated from the open lines
+	ReferenceTable sql.NullString `json:"reference_table"`
+	ReferenceID    sql.NullInt32  `json:"reference_id"`
+	// Handheld the task is assigned to, as in location_history
+	DeviceID   sql.NullString `json:"device_id"`
+	AssignedTo sql.NullInt32  `json:"assigned_to"`
+	AssignedAt sql.NullTime   `json:"assigned_at"`
+	// Client id of the confirmation or exception that closed the task; a retry with the same id gets the closed task back instead of posting twice
+	RequestID      sql.NullString         `json:"request_id"`
+	Exception      NullFloorTaskException `json:"exception"`
+	ExceptionNotes sql.NullString         `json:"exception_notes"`
+	CompletedAt    sql.NullTime           `json:"completed_at"`
+	Notes          sql.NullString         `json:"notes"`
+	CreatedBy      sql.NullInt32          `json:"created_by"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+type IdempotencyKey struct {
+	IdempotencyKey string `json:"idempotency_key"`
+	// SHA-256 of the Authorization header; each caller has keys of its own
+	CallerHash    string `json:"caller_hash"`
+	RequestMethod string `json:"request_method"`
+	RequestPath   string `json:"request_path"`
+	// SHA-256 of the method, path and body; a key sent again with a different request is rejected
+	RequestHash string `json:"request_hash"`
+	// Status of the stored response; null while the first request is still being processed
+	StatusCode sql.NullInt32 `json:"status_code"`
+	// Headers the handler set on the stored response, such as Content-Type, ETag and Location
+	ResponseHeaders json.RawMessage `json:"response_headers"`
+	ResponseBody    []byte          `json:"response_body"`
+	CreatedAt       time.Time       `json:"created_at"`
+	CompletedAt     sql.NullTime    `json:"completed_at"`
+	// After this the key is forgotten and may be used for a new request
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type ImportBatch struct {
+	BatchID      int32          `json:"batch_id"`
+	Kind         string         `json:"kind"`
+	FileName     sql.NullString `json:"file_name"`
+	Format       string         `json:"format"`
+	TotalRows    int32          `json:"total_rows"`
+	ImportedRows int32          `json:"imported_rows"`
+	FailedRows   int32          `json:"failed_rows"`
+	CreatedBy    sql.NullInt32  `json:"created_by"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
 type Inventory struct {
 	InventoryID       int32           `json:"inventory_id"`
 	ProductID         int32           `json:"product_id"`
@@ -1382,6 +2347,72 @@ type InventoryForecasting struct {
 	CalculatedSafetyStock  sql.NullInt32   `json:"calculated_safety_stock"`
 }
 
+type KitAssembly struct {
+	AssemblyID   int32             `json:"assembly_id"`
+	KitProductID int32             `json:"kit_product_id"`
+	WarehouseID  int32             `json:"warehouse_id"`
+	LocationID   int32             `json:"location_id"`
+	Direction    AssemblyDirection `json:"direction"`
+	Quantity     int32             `json:"quantity"`
+	UnitCost     decimal.Decimal   `json:"unit_cost"`
+	TotalCost    decimal.Decimal   `json:"total_cost"`
+	Notes        sql.NullString    `json:"notes"`
+	CreatedBy    sql.NullInt32     `json:"created_by"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+type KitAssemblyLine struct {
+	LineID             int32           `json:"line_id"`
+	AssemblyID         int32           `json:"assembly_id"`
+	ComponentProductID int32           `json:"component_product_id"`
+	Quantity           int32           `json:"quantity"`
+	UnitCost           decimal.Decimal `json:"unit_cost"`
+	TotalCost          decimal.Decimal `json:"total_cost"`
+}
+
+type KitComponent struct {
+	KitComponentID     int32     `json:"kit_component_id"`
+	KitProductID       int32     `json:"kit_product_id"`
+	ComponentProductID int32     `json:"component_product_id"`
+	Quantity           int32     `json:"quantity"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type LandedCost struct {
+	LandedCostID    int32           `json:"landed_cost_id"`
+	PoID            int32           `json:"po_id"`
+	ReferenceNumber sql.NullString  `json:"reference_number"`
+	CostType        LandedCostType  `json:"cost_type"`
+	AllocationBasis LandedCostBasis `json:"allocation_basis"`
+	CurrencyCode    string          `json:"currency_code"`
+	Amount          decimal.Decimal `json:"amount"`
+	ExchangeRate    decimal.Decimal `json:"exchange_rate"`
+	// amount converted into the base currency at exchange_rate
+	BaseAmount decimal.Decimal `json:"base_amount"`
+	CostDate   time.Time       `json:"cost_date"`
+	Notes      sql.NullString  `json:"notes"`
+	CreatedBy  sql.NullInt32   `json:"created_by"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type LandedCostAllocation struct {
+	AllocationID int32 `json:"allocation_id"`
+	LandedCostID int32 `json:"landed_cost_id"`
+	PoItemID     int32 `json:"po_item_id"`
+	ProductID    int32 `json:"product_id"`
+	// Warehouse the received stock went to; NULL when the line was received without stock movements
+	WarehouseID sql.NullInt32 `json:"warehouse_id"`
+	Quantity    int32         `json:"quantity"`
+	// Value, weight or quantity of the line the amount was allocated by
+	Basis  decimal.Decimal `json:"basis"`
+	Amount decimal.Decimal `json:"amount"`
+	// Part of amount added to the value of stock still on hand
+	CapitalizedAmount decimal.Decimal `json:"capitalized_amount"`
+	// Part of amount for stock already issued, or booked as purchase price variance under standard costing
+	ExpensedAmount decimal.Decimal `json:"expensed_amount"`
+	CostEntryID    sql.NullInt32   `json:"cost_entry_id"`
+}
+
 type Location struct {
 	LocationID   int32          `json:"location_id"`
 	WarehouseID  int32          `json:"warehouse_id"`
@@ -1389,8 +2420,19 @@ type Location struct {
 	Aisle        sql.NullString `json:"aisle"`
 	Shelf        sql.NullString `json:"shelf"`
 	Bin          sql.NullString `json:"bin"`
-	MaxCapacity  sql.NullInt32  `json:"max_capacity"`
-	IsActive     bool           `json:"is_active"`
+	// Maximum number of units the location holds
+	MaxCapacity sql.NullInt32 `json:"max_capacity"`
+	IsActive    bool          `json:"is_active"`
+	ZoneID      sql.NullInt32 `json:"zone_id"`
+	// Position across aisles in metres; locations in the same aisle share x
+	XCoord decimal.NullDecimal `json:"x_coord"`
+	// Position along the aisle in metres from the front cross-aisle
+	YCoord       decimal.NullDecimal `json:"y_coord"`
+	PickSequence sql.NullInt32       `json:"pick_sequence"`
+	// Maximum load in kg
+	MaxWeight decimal.NullDecimal `json:"max_weight"`
+	// Maximum volume in litres; product dimensions are read as LxWxH in cm
+	MaxVolume decimal.NullDecimal `json:"max_volume"`
 }
 
 type LocationHistory struct {
@@ -1404,6 +2446,20 @@ type LocationHistory struct {
 	DeviceID       sql.NullString           `json:"device_id"`
 }
 
+type OpeningBalance struct {
+	OpeningBalanceID int32               `json:"opening_balance_id"`
+	BatchID          int32               `json:"batch_id"`
+	LineNumber       int32               `json:"line_number"`
+	ProductID        int32               `json:"product_id"`
+	WarehouseID      int32               `json:"warehouse_id"`
+	LocationID       int32               `json:"location_id"`
+	Quantity         int32               `json:"quantity"`
+	UnitCost         decimal.NullDecimal `json:"unit_cost"`
+	BatchNumber      sql.NullString      `json:"batch_number"`
+	ExpiryDate       sql.NullTime        `json:"expiry_date"`
+	CreatedAt        time.Time           `json:"created_at"`
+}
+
 type PickingRoute struct {
 	RouteID     int32 `json:"route_id"`
 	WarehouseID int32 `json:"warehouse_id"`
@@ -1421,9 +2477,40 @@ type PickingWafe struct {
 	Priority    int32          `json:"priority"`
 	TotalItems  sql.NullInt32  `json:"total_items"`
 	AssignedTo  sql.NullInt32  `json:"assigned_to"`
-	StartTime   time.Time      `json:"start_time"`
-	EndTime     time.Time      `json:"end_time"`
+	StartTime   sql.NullTime   `json:"start_time"`
+	EndTime     sql.NullTime   `json:"end_time"`
 	CreatedAt   time.Time      `json:"created_at"`
+	RouteID     sql.NullInt32  `json:"route_id"`
+}
+
+type PickingWaveItem struct {
+	WaveItemID     int32         `json:"wave_item_id"`
+	WaveID         int32         `json:"wave_id"`
+	InventoryID    int32         `json:"inventory_id"`
+	ProductID      int32         `json:"product_id"`
+	LocationID     sql.NullInt32 `json:"location_id"`
+	QuantityToPick int32         `json:"quantity_to_pick"`
+	QuantityPicked int32         `json:"quantity_picked"`
+	Status         PickStatus    `json:"status"`
+	PickedBy       sql.NullInt32 `json:"picked_by"`
+	PickedAt       sql.NullTime  `json:"picked_at"`
+	// Seconds since the picker's previous confirmation in the wave (or wave start)
+	PickSeconds sql.NullInt32 `json:"pick_seconds"`
+	CreatedAt   time.Time     `json:"created_at"`
+	// Position in the optimised pick path
+	PickSequence sql.NullInt32 `json:"pick_sequence"`
+}
+
+type PoApprovalLevel struct {
+	ApprovalLevelID int32 `json:"approval_level_id"`
+	Level           int32 `json:"level"`
+	// Lowest role that may sign off this level; higher roles may too
+	ApproverRole UserRole `json:"approver_role"`
+	// Orders with a total_amount of at least this need this level
+	MinAmount   decimal.Decimal `json:"min_amount"`
+	Description sql.NullString  `json:"description"`
+	IsActive    bool            `json:"is_active"`
+	CreatedAt   time.Time       `json:"created_at"`
 }
 
 type Product struct {
@@ -1448,6 +2535,18 @@ type Product struct {
 	IsActive        bool            `json:"is_active"`
 	CreatedAt       time.Time       `json:"created_at"`
 	UpdatedAt       time.Time       `json:"updated_at"`
+	// Unit every stored quantity of the product is counted in
+	BaseUomID sql.NullInt32 `json:"base_uom_id"`
+	// How issues of the product are valued
+	CostingMethod CostingMethod `json:"costing_method"`
+	// Unit cost used by standard costing
+	StandardCost decimal.NullDecimal `json:"standard_cost"`
+}
+
+type ProductAttribute struct {
+	ProductID  int32           `json:"product_id"`
+	Attributes json.RawMessage `json:"attributes"`
+	UpdatedAt  time.Time       `json:"updated_at"`
 }
 
 type ProductIdentifier struct {
@@ -1465,13 +2564,55 @@ type ProductSupplier struct {
 	ProductID         int32 `json:"product_id"`
 	SupplierID        int32 `json:"supplier_id"`
 	// 1 = primary, 2 = secondary, etc.
-	Priority          int32           `json:"priority"`
-	LeadTimeDays      sql.NullInt32   `json:"lead_time_days"`
-	UnitPrice         decimal.Decimal `json:"unit_price"`
-	MinOrderQuantity  sql.NullInt32   `json:"min_order_quantity"`
-	IsActive          bool            `json:"is_active"`
-	LastOrderDate     time.Time       `json:"last_order_date"`
-	PerformanceRating decimal.Decimal `json:"performance_rating"`
+	Priority          int32               `json:"priority"`
+	LeadTimeDays      sql.NullInt32       `json:"lead_time_days"`
+	UnitPrice         decimal.NullDecimal `json:"unit_price"`
+	MinOrderQuantity  sql.NullInt32       `json:"min_order_quantity"`
+	IsActive          bool                `json:"is_active"`
+	LastOrderDate     sql.NullTime        `json:"last_order_date"`
+	PerformanceRating decimal.NullDecimal `json:"performance_rating"`
+	// Unit the supplier sells the product in
+	PurchaseUomID sql.NullInt32 `json:"purchase_uom_id"`
+}
+
+type ProductSupplierPrice struct {
+	PriceID           int32 `json:"price_id"`
+	ProductSupplierID int32 `json:"product_supplier_id"`
+	// Price of one purchase unit of the link
+	UnitPrice decimal.Decimal `json:"unit_price"`
+	// First day the price applies; product_suppliers.unit_price holds the latest price in effect
+	EffectiveFrom time.Time `json:"effective_from"`
+	// manual or price_list
+	Source    string        `json:"source"`
+	CreatedBy sql.NullInt32 `json:"created_by"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type ProductUom struct {
+	ProductUomID int32 `json:"product_uom_id"`
+	ProductID    int32 `json:"product_id"`
+	UomID        int32 `json:"uom_id"`
+	// Base units in one of this unit, e.g. 12 for a box of 12
+	ConversionFactor int32          `json:"conversion_factor"`
+	Barcode          sql.NullString `json:"barcode"`
+	IsActive         bool           `json:"is_active"`
+	CreatedAt        time.Time      `json:"created_at"`
+}
+
+type ProductVariant struct {
+	ProductID       int32           `json:"product_id"`
+	ParentProductID int32           `json:"parent_product_id"`
+	Options         json.RawMessage `json:"options"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+type ProductVariantAxis struct {
+	AxisID          int32           `json:"axis_id"`
+	ParentProductID int32           `json:"parent_product_id"`
+	Name            string          `json:"name"`
+	Position        int32           `json:"position"`
+	AxisValues      json.RawMessage `json:"axis_values"`
+	CreatedAt       time.Time       `json:"created_at"`
 }
 
 type PurchaseOrder struct {
@@ -1485,17 +2626,74 @@ type PurchaseOrder struct {
 	Notes                sql.NullString      `json:"notes"`
 	CreatedBy            sql.NullInt32       `json:"created_by"`
 	CreatedAt            time.Time           `json:"created_at"`
+	// Currency of the order prices; NULL means the base currency
+	CurrencyCode sql.NullString `json:"currency_code"`
+	// Last time the order was submitted for approval; sign-offs before it no longer count
+	SubmittedAt sql.NullTime `json:"submitted_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	// Warehouse the goods are delivered to; picks the letterhead of the order document
+	WarehouseID sql.NullInt32 `json:"warehouse_id"`
+}
+
+type PurchaseOrderApproval struct {
+	ApprovalID int32              `json:"approval_id"`
+	PoID       int32              `json:"po_id"`
+	Level      int32              `json:"level"`
+	Decision   PoApprovalDecision `json:"decision"`
+	ApproverID int32              `json:"approver_id"`
+	// Order total at the time of the decision
+	TotalAmount decimal.Decimal `json:"total_amount"`
+	Comments    sql.NullString  `json:"comments"`
+	DecidedAt   time.Time       `json:"decided_at"`
 }
 
 type PurchaseOrderItem struct {
-	PoItemID         int32           `json:"po_item_id"`
-	PoID             int32           `json:"po_id"`
-	ProductID        int32           `json:"product_id"`
-	QuantityOrdered  int32           `json:"quantity_ordered"`
-	QuantityReceived int32           `json:"quantity_received"`
-	UnitPrice        decimal.Decimal `json:"unit_price"`
+	PoItemID         int32 `json:"po_item_id"`
+	PoID             int32 `json:"po_id"`
+	ProductID        int32 `json:"product_id"`
+	QuantityOrdered  int32 `json:"quantity_ordered"`
+	QuantityReceived int32 `json:"quantity_received"`
+	// Price of one base unit
+	UnitPrice decimal.Decimal `json:"unit_price"`
 	// Generated: quantity_ordered * unit_price
 	TotalPrice decimal.Decimal `json:"total_price"`
+	// Unit the line was ordered in; quantities and prices are stored per base unit
+	UomID sql.NullInt32 `json:"uom_id"`
+}
+
+type PurchaseOrderReceipt struct {
+	ReceiptID int32 `json:"receipt_id"`
+	PoItemID  int32 `json:"po_item_id"`
+	// Base units received in this delivery
+	Quantity   int32         `json:"quantity"`
+	ReceivedAt time.Time     `json:"received_at"`
+	ReceivedBy sql.NullInt32 `json:"received_by"`
+}
+
+type PurchasePriceVariance struct {
+	VarianceID   int32           `json:"variance_id"`
+	ProductID    int32           `json:"product_id"`
+	WarehouseID  int32           `json:"warehouse_id"`
+	MovementID   sql.NullInt32   `json:"movement_id"`
+	PoItemID     sql.NullInt32   `json:"po_item_id"`
+	Quantity     int32           `json:"quantity"`
+	StandardCost decimal.Decimal `json:"standard_cost"`
+	ActualCost   decimal.Decimal `json:"actual_cost"`
+	// Generated: (actual_cost - standard_cost) * quantity
+	VarianceAmount decimal.Decimal `json:"variance_amount"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type PutawayRule struct {
+	RuleID      int32 `json:"rule_id"`
+	WarehouseID int32 `json:"warehouse_id"`
+	// NULL applies the rule to every category
+	CategoryID sql.NullInt32 `json:"category_id"`
+	ZoneID     int32         `json:"zone_id"`
+	// Lower numbers are preferred
+	Priority  int32     `json:"priority"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type ReconciliationRule struct {
@@ -1580,6 +2778,8 @@ type StockMovement struct {
 	Notes           sql.NullString `json:"notes"`
 	MovementDate    time.Time      `json:"movement_date"`
 	CreatedBy       sql.NullInt32  `json:"created_by"`
+	// Price one unit of a sales delivery was sold at; NULL means the product's unit_price
+	SalePrice decimal.NullDecimal `json:"sale_price"`
 }
 
 type StockTake struct {
@@ -1646,6 +2846,12 @@ type Supplier struct {
 	Rating        decimal.Decimal `json:"rating"`
 	IsActive      bool            `json:"is_active"`
 	CreatedAt     time.Time       `json:"created_at"`
+	// Currency the supplier invoices in; NULL means the base currency
+	CurrencyCode sql.NullString `json:"currency_code"`
+	// When rating was last recalculated from the supplier scorecard
+	RatedAt sql.NullTime `json:"rated_at"`
+	// Set by every update; the supplier ETag is derived from it
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type UnitsOfMeasure struct {
@@ -1677,4 +2883,140 @@ type Warehouse struct {
 	ContactEmail  sql.NullString `json:"contact_email"`
 	IsActive      bool           `json:"is_active"`
 	CreatedAt     time.Time      `json:"created_at"`
+	// Set by every update; the warehouse ETag is derived from it
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WarehouseLetterhead struct {
+	WarehouseID int32 `json:"warehouse_id"`
+	// Go text/template for the letterhead lines, one per line; the first line is printed in bold
+	HeaderTemplate string `json:"header_template"`
+	// Go text/template printed at the foot of every page
+	FooterTemplate sql.NullString `json:"footer_template"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+type WarehouseZone struct {
+	ZoneID      int32          `json:"zone_id"`
+	WarehouseID int32          `json:"warehouse_id"`
+	ZoneCode    string         `json:"zone_code"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	IsActive    bool           `json:"is_active"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	DeliveryID     int32 `json:"delivery_id"`
+	EventID        int32 `json:"event_id"`
+	SubscriptionID int32 `json:"subscription_id"`
+	// failed once every attempt has failed; a redelivery makes it pending again
+	Status   WebhookDeliveryStatus `json:"status"`
+	Attempts int32                 `json:"attempts"`
+	// When a pending delivery is next due; pushed forward while a worker is sending it and by the backoff after a failure
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	LastAttemptAt  sql.NullTime   `json:"last_attempt_at"`
+	ResponseStatus sql.NullInt32  `json:"response_status"`
+	LastError      sql.NullString `json:"last_error"`
+	DeliveredAt    sql.NullTime   `json:"delivered_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+type WebhookDeliveryAttempt struct {
+	AttemptID      int32          `json:"attempt_id"`
+	DeliveryID     int32          `json:"delivery_id"`
+	ResponseStatus sql.NullInt32  `json:"response_status"`
+	Error          sql.NullString `json:"error"`
+	DurationMs     int32          `json:"duration_ms"`
+	AttemptedAt    time.Time      `json:"attempted_at"`
+}
+
+type WebhookEvent struct {
+	EventID   int32  `json:"event_id"`
+	EventType string `json:"event_type"`
+	// Event data, written in the same transaction as the change it describes
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type WebhookSubscription struct {
+	SubscriptionID int32  `json:"subscription_id"`
+	Url            string `json:"url"`
+	// Key of the HMAC-SHA256 signature sent with every delivery
+	Secret      string         `json:"secret"`
+	Description sql.NullString `json:"description"`
+	IsActive    bool           `json:"is_active"`
+	CreatedBy   sql.NullInt32  `json:"created_by"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type WebhookSubscriptionEvent struct {
+	SubscriptionID int32  `json:"subscription_id"`
+	EventType      string `json:"event_type"`
+}
+
+type WorkOrder struct {
+	WorkOrderID      int32           `json:"work_order_id"`
+	WorkOrderNumber  sql.NullString  `json:"work_order_number"`
+	ProductID        int32           `json:"product_id"`
+	WarehouseID      int32           `json:"warehouse_id"`
+	OutputLocationID int32           `json:"output_location_id"`
+	Status           WorkOrderStatus `json:"status"`
+	PlannedQuantity  int32           `json:"planned_quantity"`
+	ProducedQuantity int32           `json:"produced_quantity"`
+	// Output units made but rejected; they are never received
+	ScrapQuantity int32 `json:"scrap_quantity"`
+	// Lot the output is received under; the work order number when not given
+	OutputBatchNumber sql.NullString `json:"output_batch_number"`
+	ExpiryDate        sql.NullTime   `json:"expiry_date"`
+	// Cost of one good unit: all consumed components, scrap included, over the produced quantity
+	UnitCost    decimal.Decimal `json:"unit_cost"`
+	TotalCost   decimal.Decimal `json:"total_cost"`
+	Notes       sql.NullString  `json:"notes"`
+	CreatedBy   sql.NullInt32   `json:"created_by"`
+	CreatedAt   time.Time       `json:"created_at"`
+	ReleasedAt  sql.NullTime    `json:"released_at"`
+	CompletedAt sql.NullTime    `json:"completed_at"`
+}
+
+type WorkOrderComponent struct {
+	WoComponentID      int32 `json:"wo_component_id"`
+	WorkOrderID        int32 `json:"work_order_id"`
+	ComponentProductID int32 `json:"component_product_id"`
+	// Units of the component in one unit of output, copied from the bill of materials when the order was created
+	QuantityPer     int32 `json:"quantity_per"`
+	PlannedQuantity int32 `json:"planned_quantity"`
+	// Units issued to the work order, scrapped ones included
+	ConsumedQuantity int32 `json:"consumed_quantity"`
+	// Part of the consumed quantity that was wasted rather than built into the output
+	ScrapQuantity int32 `json:"scrap_quantity"`
+}
+
+type WorkOrderConsumption struct {
+	ConsumptionID      int32 `json:"consumption_id"`
+	WorkOrderID        int32 `json:"work_order_id"`
+	WoComponentID      int32 `json:"wo_component_id"`
+	ComponentProductID int32 `json:"component_product_id"`
+	InventoryID        int32 `json:"inventory_id"`
+	MovementID         int32 `json:"movement_id"`
+	// Component lot consumed, for tracing output lots back to their components
+	BatchNumber sql.NullString  `json:"batch_number"`
+	Quantity    int32           `json:"quantity"`
+	IsScrap     bool            `json:"is_scrap"`
+	TotalCost   decimal.Decimal `json:"total_cost"`
+	CreatedBy   sql.NullInt32   `json:"created_by"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+type WorkOrderReservation struct {
+	ReservationID int32 `json:"reservation_id"`
+	WorkOrderID   int32 `json:"work_order_id"`
+	WoComponentID int32 `json:"wo_component_id"`
+	InventoryID   int32 `json:"inventory_id"`
+	Quantity      int32 `json:"quantity"`
+	// Part of the reservation still held on the inventory row; consumption and release reduce it
+	OpenQuantity int32     `json:"open_quantity"`
+	CreatedAt    time.Time `json:"created_at"`
 }
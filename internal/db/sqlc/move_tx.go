@@ -0,0 +1,219 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var (
+	ErrSameLocation        = errors.New("source and destination location are the same")
+	ErrWarehouseMismatch   = errors.New("locations belong to different warehouses")
+	ErrInvalidMoveQuantity = errors.New("move quantity must be positive, and 1 for a serial number")
+)
+
+type MoveStockTxParams struct {
+	ProductID      int32
+	FromLocationID int32
+	ToLocationID   int32
+	Quantity       int32
+	BatchNumber    sql.NullString
+	SerialNumber   sql.NullString
+	MovedBy        sql.NullInt32
+	DeviceID       sql.NullString
+	Notes          sql.NullString
+}
+
+type MoveStockTxResult struct {
+	From        Inventory        `json:"from"`
+	To          Inventory        `json:"to"`
+	OutMovement StockMovement    `json:"out_movement"`
+	InMovement  StockMovement    `json:"in_movement"`
+	History     *LocationHistory `json:"history,omitempty"`
+}
+
+// MoveStockTx moves stock between two locations of one warehouse. Moving a
+// whole unreserved row into a location without matching stock relocates the
+// row; otherwise the source row is split and the quantity merged into the
+// matching destination row (same product, batch and serial) or a new one.
+// Both legs are posted as stock_transfer movements, the inbound one pointing
+// at the outbound one, and serial numbers get a location_history entry.
+func (store *SQLStore) MoveStockTx(ctx context.Context, arg MoveStockTxParams) (MoveStockTxResult, error) {
+	var result MoveStockTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = moveStock(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// moveStock does the work of MoveStockTx inside an open transaction.
+func moveStock(ctx context.Context, q *Queries, arg MoveStockTxParams) (MoveStockTxResult, error) {
+	var result MoveStockTxResult
+
+	if arg.FromLocationID == arg.ToLocationID {
+		return result, ErrSameLocation
+	}
+	if arg.Quantity <= 0 || (arg.SerialNumber.Valid && arg.Quantity != 1) {
+		return result, ErrInvalidMoveQuantity
+	}
+
+	fromLocation := sql.NullInt32{Int32: arg.FromLocationID, Valid: true}
+	toLocation := sql.NullInt32{Int32: arg.ToLocationID, Valid: true}
+
+	source, err := q.GetInventoryAtLocationForUpdate(ctx, GetInventoryAtLocationForUpdateParams{
+		ProductID:    arg.ProductID,
+		LocationID:   fromLocation,
+		BatchNumber:  arg.BatchNumber,
+		SerialNumber: arg.SerialNumber,
+	})
+	if err != nil {
+		return result, err
+	}
+	if source.Quantity-source.ReservedQuantity < arg.Quantity {
+		return result, ErrInsufficientStock
+	}
+
+	product, err := q.GetProduct(ctx, arg.ProductID)
+	if err != nil {
+		return result, err
+	}
+
+	to, err := checkLocationCapacity(ctx, q, arg.ToLocationID, product, arg.BatchNumber.String, arg.Quantity)
+	if err != nil {
+		return result, err
+	}
+	if to.WarehouseID != source.WarehouseID {
+		return result, ErrWarehouseMismatch
+	}
+
+	target, err := q.GetInventoryAtLocationForUpdate(ctx, GetInventoryAtLocationForUpdateParams{
+		ProductID:    arg.ProductID,
+		LocationID:   toLocation,
+		BatchNumber:  arg.BatchNumber,
+		SerialNumber: arg.SerialNumber,
+	})
+	hasTarget := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return result, err
+	}
+
+	var fromBefore, toBefore int32 = source.Quantity, 0
+	switch {
+	case !hasTarget && arg.Quantity == source.Quantity:
+		result.To, err = q.SetInventoryLocation(ctx, SetInventoryLocationParams{
+			InventoryID: source.InventoryID,
+			LocationID:  toLocation,
+		})
+		result.From = source
+		result.From.Quantity = 0
+	default:
+		result.From, err = q.RemoveInventoryQuantity(ctx, RemoveInventoryQuantityParams{
+			InventoryID: source.InventoryID,
+			Quantity:    arg.Quantity,
+		})
+		if err != nil {
+			break
+		}
+		if hasTarget {
+			toBefore = target.Quantity
+			result.To, err = q.AddInventoryQuantity(ctx, AddInventoryQuantityParams{
+				InventoryID: target.InventoryID,
+				Quantity:    arg.Quantity,
+			})
+			break
+		}
+		result.To, err = q.CreateInventory(ctx, CreateInventoryParams{
+			ProductID:         arg.ProductID,
+			WarehouseID:       source.WarehouseID,
+			LocationID:        toLocation,
+			Quantity:          arg.Quantity,
+			BatchNumber:       source.BatchNumber,
+			ExpiryDate:        source.ExpiryDate,
+			ManufacturingDate: source.ManufacturingDate,
+			SerialNumber:      source.SerialNumber,
+		})
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.OutMovement, err = postStockMovement(ctx, q, CreateStockMovementParams{
+		ProductID:      arg.ProductID,
+		WarehouseID:    source.WarehouseID,
+		LocationID:     fromLocation,
+		MovementType:   MovementTypeStockTransfer,
+		QuantityBefore: sql.NullInt32{Int32: fromBefore, Valid: true},
+		QuantityChange: -arg.Quantity,
+		QuantityAfter:  sql.NullInt32{Int32: result.From.Quantity, Valid: true},
+		ReferenceID:    sql.NullInt32{Int32: source.InventoryID, Valid: true},
+		ReferenceTable: sql.NullString{String: "inventory", Valid: true},
+		Notes:          arg.Notes,
+		CreatedBy:      arg.MovedBy,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.InMovement, err = postStockMovement(ctx, q, CreateStockMovementParams{
+		ProductID:      arg.ProductID,
+		WarehouseID:    source.WarehouseID,
+		LocationID:     toLocation,
+		MovementType:   MovementTypeStockTransfer,
+		QuantityBefore: sql.NullInt32{Int32: toBefore, Valid: true},
+		QuantityChange: arg.Quantity,
+		QuantityAfter:  sql.NullInt32{Int32: result.To.Quantity, Valid: true},
+		ReferenceID:    sql.NullInt32{Int32: result.OutMovement.MovementID, Valid: true},
+		ReferenceTable: sql.NullString{String: "stock_movements", Valid: true},
+		Notes:          arg.Notes,
+		CreatedBy:      arg.MovedBy,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if !arg.SerialNumber.Valid {
+		return result, nil
+	}
+
+	identifier, err := q.GetProductIdentifierForUpdate(ctx, GetProductIdentifierForUpdateParams{
+		ProductID:       arg.ProductID,
+		IdentifierType:  IdentifierTypeSerial,
+		IdentifierValue: arg.SerialNumber.String,
+	})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		identifier, err = q.CreateProductIdentifier(ctx, CreateProductIdentifierParams{
+			ProductID:       arg.ProductID,
+			IdentifierType:  IdentifierTypeSerial,
+			IdentifierValue: arg.SerialNumber.String,
+			LocationID:      toLocation,
+			Status:          NullIdentifierStatus{IdentifierStatus: IdentifierStatusActive, Valid: true},
+		})
+	case err == nil:
+		identifier, err = q.UpdateProductIdentifierLocation(ctx, UpdateProductIdentifierLocationParams{
+			IdentifierID: identifier.IdentifierID,
+			LocationID:   toLocation,
+		})
+	}
+	if err != nil {
+		return result, err
+	}
+
+	history, err := q.CreateLocationHistory(ctx, CreateLocationHistoryParams{
+		IdentifierID:   identifier.IdentifierID,
+		FromLocationID: fromLocation,
+		ToLocationID:   toLocation,
+		MovementType:   NullLocationMovementType{LocationMovementType: LocationMovementTypeTransfer, Valid: true},
+		ScannedBy:      arg.MovedBy,
+		DeviceID:       arg.DeviceID,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.History = &history
+	return result, nil
+}
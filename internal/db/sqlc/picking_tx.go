@@ -0,0 +1,319 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNothingToPick       = errors.New("no open reservations to pick in this warehouse")
+	ErrWaveNotOpen         = errors.New("picking wave is not open")
+	ErrPickLineClosed      = errors.New("pick line is already closed")
+	ErrInvalidPickQuantity = errors.New("pick quantity must be between 1 and the open quantity")
+	ErrInsufficientStock   = errors.New("insufficient stock")
+)
+
+type CreatePickingWaveTxParams struct {
+	WarehouseID int32
+	WaveNumber  sql.NullString
+	Priority    int32
+	AssignedTo  sql.NullInt32
+	RouteID     sql.NullInt32
+	MaxLines    int32
+}
+
+type CreatePickingWaveTxResult struct {
+	Wave  PickingWafe       `json:"wave"`
+	Items []PickingWaveItem `json:"items"`
+}
+
+// CreatePickingWaveTx batches the reserved quantities of a warehouse that are
+// not yet on an open wave into a new planned wave. Waves of one warehouse are
+// created one at a time, so two cannot take the same reservations.
+func (store *SQLStore) CreatePickingWaveTx(ctx context.Context, arg CreatePickingWaveTxParams) (CreatePickingWaveTxResult, error) {
+	var result CreatePickingWaveTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// Taken before the reservations are read, so they are read after
+		// any wave created meanwhile has committed.
+		if err := q.LockWarehousePicking(ctx, arg.WarehouseID); err != nil {
+			return err
+		}
+
+		reservations, err := q.ListUnwavedReservations(ctx, ListUnwavedReservationsParams{
+			WarehouseID: arg.WarehouseID,
+			Limit:       arg.MaxLines,
+		})
+		if err != nil {
+			return err
+		}
+		if len(reservations) == 0 {
+			return ErrNothingToPick
+		}
+
+		wave, err := q.CreatePickingWave(ctx, CreatePickingWaveParams{
+			WarehouseID: arg.WarehouseID,
+			WaveNumber:  arg.WaveNumber,
+			Priority:    arg.Priority,
+			AssignedTo:  arg.AssignedTo,
+			RouteID:     arg.RouteID,
+		})
+		if err != nil {
+			return err
+		}
+
+		var totalItems int32
+		for _, res := range reservations {
+			item, err := q.CreatePickingWaveItem(ctx, CreatePickingWaveItemParams{
+				WaveID:         wave.WaveID,
+				InventoryID:    res.InventoryID,
+				ProductID:      res.ProductID,
+				LocationID:     res.LocationID,
+				QuantityToPick: res.QuantityToPick,
+			})
+			if err != nil {
+				return err
+			}
+			totalItems += item.QuantityToPick
+			result.Items = append(result.Items, item)
+		}
+
+		if !arg.WaveNumber.Valid {
+			wave.WaveNumber = sql.NullString{String: fmt.Sprintf("WAVE-%d-%06d", arg.WarehouseID, wave.WaveID), Valid: true}
+			if wave, err = q.SetPickingWaveNumber(ctx, SetPickingWaveNumberParams{
+				WaveID:     wave.WaveID,
+				WaveNumber: wave.WaveNumber,
+			}); err != nil {
+				return err
+			}
+		}
+
+		result.Wave, err = q.UpdatePickingWaveTotalItems(ctx, UpdatePickingWaveTotalItemsParams{
+			WaveID:     wave.WaveID,
+			TotalItems: sql.NullInt32{Int32: totalItems, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type ConfirmPickTxParams struct {
+	WaveItemID int32
+	Quantity   int32
+	PickedBy   sql.NullInt32
+	// Short closes the line even when less than the open quantity was found.
+	Short bool
+	// SalePrice is what one unit was sold at, if known.
+	SalePrice decimal.NullDecimal
+}
+
+type ConfirmPickTxResult struct {
+	Item      PickingWaveItem `json:"item"`
+	Wave      PickingWafe     `json:"wave"`
+	Inventory Inventory       `json:"inventory"`
+	Movement  StockMovement   `json:"movement"`
+	Cost      *CostEntry      `json:"cost,omitempty"`
+}
+
+// ConfirmPickTx records a picker's confirmation of one pick line: it takes the
+// quantity out of stock and the reservation, posts and costs the outbound
+// movement and moves the wave along (planned -> in_progress -> completed).
+func (store *SQLStore) ConfirmPickTx(ctx context.Context, arg ConfirmPickTxParams) (ConfirmPickTxResult, error) {
+	var result ConfirmPickTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = confirmPick(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// confirmPick does the work of ConfirmPickTx inside an open transaction.
+func confirmPick(ctx context.Context, q *Queries, arg ConfirmPickTxParams) (ConfirmPickTxResult, error) {
+	var result ConfirmPickTxResult
+
+	item, err := q.GetPickingWaveItemForUpdate(ctx, arg.WaveItemID)
+	if err != nil {
+		return result, err
+	}
+	if item.Status != PickStatusPending {
+		return result, ErrPickLineClosed
+	}
+
+	wave, err := q.GetPickingWaveForUpdate(ctx, item.WaveID)
+	if err != nil {
+		return result, err
+	}
+	if !wave.Status.Valid || (wave.Status.WaveStatus != WaveStatusPlanned && wave.Status.WaveStatus != WaveStatusInProgress) {
+		return result, ErrWaveNotOpen
+	}
+
+	open := item.QuantityToPick - item.QuantityPicked
+	if arg.Quantity < 0 || arg.Quantity > open || (arg.Quantity == 0 && !arg.Short) {
+		return result, ErrInvalidPickQuantity
+	}
+
+	if wave.Status.WaveStatus == WaveStatusPlanned {
+		if wave, err = q.StartPickingWave(ctx, wave.WaveID); err != nil {
+			return result, err
+		}
+	}
+
+	inv, err := q.GetInventoryForUpdate(ctx, item.InventoryID)
+	if err != nil {
+		return result, err
+	}
+
+	if arg.Quantity > 0 {
+		result.Inventory, err = q.PickInventory(ctx, PickInventoryParams{
+			Quantity:    arg.Quantity,
+			InventoryID: inv.InventoryID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return result, ErrInsufficientStock
+			}
+			return result, err
+		}
+
+		result.Movement, err = postStockMovement(ctx, q, CreateStockMovementParams{
+			ProductID:      item.ProductID,
+			WarehouseID:    wave.WarehouseID,
+			LocationID:     item.LocationID,
+			MovementType:   MovementTypeSalesDelivery,
+			QuantityBefore: sql.NullInt32{Int32: inv.Quantity, Valid: true},
+			QuantityChange: -arg.Quantity,
+			QuantityAfter:  sql.NullInt32{Int32: result.Inventory.Quantity, Valid: true},
+			ReferenceID:    sql.NullInt32{Int32: item.WaveItemID, Valid: true},
+			ReferenceTable: sql.NullString{String: "picking_wave_items", Valid: true},
+			Notes:          wave.WaveNumber,
+			CreatedBy:      arg.PickedBy,
+			SalePrice:      arg.SalePrice,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result.Cost, err = postMovementCost(ctx, q, result.Movement)
+		if err != nil {
+			return result, err
+		}
+	} else {
+		result.Inventory = inv
+	}
+
+	status := PickStatusPending
+	switch {
+	case arg.Quantity == open:
+		status = PickStatusPicked
+	case arg.Short:
+		status = PickStatusShort
+	}
+
+	result.Item, err = q.ConfirmPickingWaveItem(ctx, ConfirmPickingWaveItemParams{
+		QuantityPicked: arg.Quantity,
+		Status:         status,
+		PickedBy:       arg.PickedBy,
+		WaveItemID:     item.WaveItemID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	pending, err := q.CountPendingPickingWaveItems(ctx, wave.WaveID)
+	if err != nil {
+		return result, err
+	}
+	if pending == 0 {
+		if wave, err = q.CompletePickingWave(ctx, wave.WaveID); err != nil {
+			return result, err
+		}
+	}
+
+	result.Wave = wave
+	return result, nil
+}
+
+type SavePickPathTxParams struct {
+	WaveID int32
+	// WaveItemIDs lists the wave's lines in walking order.
+	WaveItemIDs          []int32
+	ZoneSequence         json.RawMessage
+	EstimatedTimeMinutes int32
+}
+
+type SavePickPathTxResult struct {
+	Wave  PickingWafe  `json:"wave"`
+	Route PickingRoute `json:"route"`
+}
+
+// SavePickPathTx stores an optimised walking order on the lines of an open
+// wave and records the path on the wave's route. A route shared with other
+// waves is left alone and the wave gets a route of its own.
+func (store *SQLStore) SavePickPathTx(ctx context.Context, arg SavePickPathTxParams) (SavePickPathTxResult, error) {
+	var result SavePickPathTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		wave, err := q.GetPickingWaveForUpdate(ctx, arg.WaveID)
+		if err != nil {
+			return err
+		}
+		if !wave.Status.Valid || (wave.Status.WaveStatus != WaveStatusPlanned && wave.Status.WaveStatus != WaveStatusInProgress) {
+			return ErrWaveNotOpen
+		}
+
+		for i, id := range arg.WaveItemIDs {
+			if err := q.SetPickingWaveItemSequence(ctx, SetPickingWaveItemSequenceParams{
+				WaveItemID:   id,
+				PickSequence: sql.NullInt32{Int32: int32(i + 1), Valid: true},
+			}); err != nil {
+				return err
+			}
+		}
+
+		estimate := sql.NullInt32{Int32: arg.EstimatedTimeMinutes, Valid: true}
+		shared := true
+		if wave.RouteID.Valid {
+			waves, err := q.CountPickingWavesByRoute(ctx, wave.RouteID)
+			if err != nil {
+				return err
+			}
+			shared = waves > 1
+		}
+
+		if !shared {
+			result.Route, err = q.UpdatePickingRoute(ctx, UpdatePickingRouteParams{
+				RouteID:              wave.RouteID.Int32,
+				ZoneSequence:         arg.ZoneSequence,
+				EstimatedTimeMinutes: estimate,
+			})
+			result.Wave = wave
+			return err
+		}
+
+		result.Route, err = q.CreatePickingRoute(ctx, CreatePickingRouteParams{
+			WarehouseID:          wave.WarehouseID,
+			ZoneSequence:         arg.ZoneSequence,
+			EstimatedTimeMinutes: estimate,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Wave, err = q.SetPickingWaveRoute(ctx, SetPickingWaveRouteParams{
+			WaveID:  wave.WaveID,
+			RouteID: sql.NullInt32{Int32: result.Route.RouteID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,355 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/molu/stock-management-system/internal/purchasing"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrProductSupplierExists   = errors.New("supplier is already linked to this product")
+	ErrProductSupplierNotFound = errors.New("supplier is not linked to this product")
+	ErrPriceListEmpty          = errors.New("price list has no lines")
+)
+
+// Price sources recorded in the price history.
+const (
+	PriceSourceManual    = "manual"
+	PriceSourcePriceList = "price_list"
+)
+
+// makeRoomForPrimary demotes the product's other active primary suppliers
+// before link becomes one, so the one-primary index is never violated.
+func makeRoomForPrimary(ctx context.Context, q *Queries, links []ProductSupplier, link int32) error {
+	for _, l := range links {
+		if l.ProductSupplierID != link && l.IsActive && l.Priority == purchasing.PrimaryPriority {
+			if err := q.SetProductSupplierPriority(ctx, SetProductSupplierPriorityParams{
+				ProductSupplierID: l.ProductSupplierID,
+				Priority:          purchasing.PrimaryPriority + 1,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// keepOnePrimary makes sure the product has exactly one active primary
+// supplier, preferring prefer, and points products.supplier_id at it. A
+// product without active suppliers is left as it is.
+func keepOnePrimary(ctx context.Context, q *Queries, productID, prefer int32) error {
+	links, err := q.ListProductSuppliersForUpdate(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	rules := make([]purchasing.SupplierLink, len(links))
+	for i, l := range links {
+		rules[i] = purchasing.SupplierLink{ID: l.ProductSupplierID, Priority: l.Priority, Active: l.IsActive}
+	}
+	primary, demote, ok := purchasing.Primary(rules, prefer)
+	if !ok {
+		return nil
+	}
+
+	for _, id := range demote {
+		if err := q.SetProductSupplierPriority(ctx, SetProductSupplierPriorityParams{
+			ProductSupplierID: id,
+			Priority:          purchasing.PrimaryPriority + 1,
+		}); err != nil {
+			return err
+		}
+	}
+	for _, l := range links {
+		if l.ProductSupplierID != primary {
+			continue
+		}
+		if l.Priority != purchasing.PrimaryPriority {
+			if err := q.SetProductSupplierPriority(ctx, SetProductSupplierPriorityParams{
+				ProductSupplierID: primary,
+				Priority:          purchasing.PrimaryPriority,
+			}); err != nil {
+				return err
+			}
+		}
+		return q.SetProductPrimarySupplier(ctx, SetProductPrimarySupplierParams{
+			ProductID:  productID,
+			SupplierID: sql.NullInt32{Int32: l.SupplierID, Valid: true},
+		})
+	}
+	return nil
+}
+
+// recordPrice adds a price to the link's history and brings every link's
+// current price up to date.
+func recordPrice(ctx context.Context, q *Queries, arg UpsertProductSupplierPriceParams) (ProductSupplierPrice, error) {
+	price, err := q.UpsertProductSupplierPrice(ctx, arg)
+	if err != nil {
+		return price, err
+	}
+	_, err = q.ApplyDueProductSupplierPrices(ctx)
+	return price, err
+}
+
+type ProductSupplierTxParams struct {
+	// The link to update; the product and supplier of an existing link
+	// cannot change.
+	ProductSupplierID int32
+	ProductID         int32
+	SupplierID        int32
+	Priority          int32
+	LeadTimeDays      sql.NullInt32
+	MinOrderQuantity  sql.NullInt32
+	IsActive          bool
+	PurchaseUomID     sql.NullInt32
+	// UnitPrice, when valid, is recorded in the price history from
+	// EffectiveFrom.
+	UnitPrice     decimal.NullDecimal
+	EffectiveFrom time.Time
+	CreatedBy     sql.NullInt32
+}
+
+// CreateProductSupplierTx links a supplier to a product. The first active
+// supplier of a product becomes its primary supplier whatever priority was
+// asked for, and a new priority-1 link takes over from the old primary.
+func (store *SQLStore) CreateProductSupplierTx(ctx context.Context, arg ProductSupplierTxParams) (ProductSupplier, error) {
+	var result ProductSupplier
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		links, err := q.ListProductSuppliersForUpdate(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+		for _, l := range links {
+			if l.SupplierID == arg.SupplierID {
+				return ErrProductSupplierExists
+			}
+		}
+		if arg.IsActive && arg.Priority == purchasing.PrimaryPriority {
+			if err := makeRoomForPrimary(ctx, q, links, 0); err != nil {
+				return err
+			}
+		}
+
+		result, err = q.CreateProductSupplier(ctx, CreateProductSupplierParams{
+			ProductID:        arg.ProductID,
+			SupplierID:       arg.SupplierID,
+			Priority:         arg.Priority,
+			LeadTimeDays:     arg.LeadTimeDays,
+			MinOrderQuantity: arg.MinOrderQuantity,
+			IsActive:         arg.IsActive,
+			PurchaseUomID:    arg.PurchaseUomID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.UnitPrice.Valid {
+			if _, err := recordPrice(ctx, q, UpsertProductSupplierPriceParams{
+				ProductSupplierID: result.ProductSupplierID,
+				UnitPrice:         arg.UnitPrice.Decimal,
+				EffectiveFrom:     arg.EffectiveFrom,
+				Source:            PriceSourceManual,
+				CreatedBy:         arg.CreatedBy,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := keepOnePrimary(ctx, q, arg.ProductID, result.ProductSupplierID); err != nil {
+			return err
+		}
+		result, err = q.GetProductSupplier(ctx, result.ProductSupplierID)
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateProductSupplierTx replaces a link's terms. Making it priority 1
+// demotes the old primary to 2; demoting or deactivating the primary
+// promotes the active link with the best priority.
+func (store *SQLStore) UpdateProductSupplierTx(ctx context.Context, arg ProductSupplierTxParams) (ProductSupplier, error) {
+	var result ProductSupplier
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		link, err := q.GetProductSupplier(ctx, arg.ProductSupplierID)
+		if err != nil {
+			return err
+		}
+		links, err := q.ListProductSuppliersForUpdate(ctx, link.ProductID)
+		if err != nil {
+			return err
+		}
+		if arg.IsActive && arg.Priority == purchasing.PrimaryPriority {
+			if err := makeRoomForPrimary(ctx, q, links, link.ProductSupplierID); err != nil {
+				return err
+			}
+		}
+
+		result, err = q.UpdateProductSupplier(ctx, UpdateProductSupplierParams{
+			ProductSupplierID: link.ProductSupplierID,
+			Priority:          arg.Priority,
+			LeadTimeDays:      arg.LeadTimeDays,
+			MinOrderQuantity:  arg.MinOrderQuantity,
+			IsActive:          arg.IsActive,
+			PurchaseUomID:     arg.PurchaseUomID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.UnitPrice.Valid {
+			if _, err := recordPrice(ctx, q, UpsertProductSupplierPriceParams{
+				ProductSupplierID: link.ProductSupplierID,
+				UnitPrice:         arg.UnitPrice.Decimal,
+				EffectiveFrom:     arg.EffectiveFrom,
+				Source:            PriceSourceManual,
+				CreatedBy:         arg.CreatedBy,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := keepOnePrimary(ctx, q, link.ProductID, link.ProductSupplierID); err != nil {
+			return err
+		}
+		result, err = q.GetProductSupplier(ctx, link.ProductSupplierID)
+		return err
+	})
+
+	return result, err
+}
+
+// DeleteProductSupplierTx removes a link and its price history. When it was
+// the primary supplier, the next active link by priority takes over.
+func (store *SQLStore) DeleteProductSupplierTx(ctx context.Context, productSupplierID int32) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		link, err := q.GetProductSupplier(ctx, productSupplierID)
+		if err != nil {
+			return err
+		}
+		if _, err := q.ListProductSuppliersForUpdate(ctx, link.ProductID); err != nil {
+			return err
+		}
+		if err := q.DeleteProductSupplierPrices(ctx, productSupplierID); err != nil {
+			return err
+		}
+		if err := q.DeleteProductSupplier(ctx, productSupplierID); err != nil {
+			return err
+		}
+		return keepOnePrimary(ctx, q, link.ProductID, 0)
+	})
+}
+
+type PriceListLine struct {
+	ProductID     int32
+	UnitPrice     decimal.Decimal
+	EffectiveFrom time.Time
+	// Terms left invalid keep the link's current value.
+	MinOrderQuantity sql.NullInt32
+	LeadTimeDays     sql.NullInt32
+}
+
+type ImportSupplierPriceListTxParams struct {
+	SupplierID int32
+	Lines      []PriceListLine
+	// AddMissing links products the supplier does not supply yet instead of
+	// failing on them.
+	AddMissing bool
+	CreatedBy  sql.NullInt32
+}
+
+type ImportSupplierPriceListTxResult struct {
+	Prices []ProductSupplierPrice `json:"prices"`
+	// Links created for products the supplier did not supply yet.
+	LinksCreated int `json:"links_created"`
+	// Current prices changed by lines already in effect.
+	PricesApplied int64 `json:"prices_applied"`
+}
+
+// ImportSupplierPriceListTx records a supplier's price list in the price
+// history. Lines effective today or earlier change the current price
+// straight away; later ones wait for their date. A line for the same product
+// and date as an earlier import replaces it.
+func (store *SQLStore) ImportSupplierPriceListTx(ctx context.Context, arg ImportSupplierPriceListTxParams) (ImportSupplierPriceListTxResult, error) {
+	result := ImportSupplierPriceListTxResult{Prices: []ProductSupplierPrice{}}
+	if len(arg.Lines) == 0 {
+		return result, ErrPriceListEmpty
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		for i, line := range arg.Lines {
+			link, err := q.GetProductSupplierByProduct(ctx, GetProductSupplierByProductParams{
+				SupplierID: arg.SupplierID,
+				ProductID:  line.ProductID,
+			})
+			switch {
+			case errors.Is(err, sql.ErrNoRows) && arg.AddMissing:
+				links, err := q.ListProductSuppliersForUpdate(ctx, line.ProductID)
+				if err != nil {
+					return err
+				}
+				link, err = q.CreateProductSupplier(ctx, CreateProductSupplierParams{
+					ProductID:        line.ProductID,
+					SupplierID:       arg.SupplierID,
+					Priority:         int32(len(links) + 1),
+					LeadTimeDays:     line.LeadTimeDays,
+					MinOrderQuantity: line.MinOrderQuantity,
+					IsActive:         true,
+				})
+				if err != nil {
+					return err
+				}
+				if err := keepOnePrimary(ctx, q, line.ProductID, link.ProductSupplierID); err != nil {
+					return err
+				}
+				result.LinksCreated++
+			case errors.Is(err, sql.ErrNoRows):
+				return fmt.Errorf("line %d: product %d: %w", i+1, line.ProductID, ErrProductSupplierNotFound)
+			case err != nil:
+				return err
+			case line.MinOrderQuantity.Valid || line.LeadTimeDays.Valid:
+				if line.MinOrderQuantity.Valid {
+					link.MinOrderQuantity = line.MinOrderQuantity
+				}
+				if line.LeadTimeDays.Valid {
+					link.LeadTimeDays = line.LeadTimeDays
+				}
+				if _, err := q.UpdateProductSupplier(ctx, UpdateProductSupplierParams{
+					ProductSupplierID: link.ProductSupplierID,
+					Priority:          link.Priority,
+					LeadTimeDays:      link.LeadTimeDays,
+					MinOrderQuantity:  link.MinOrderQuantity,
+					IsActive:          link.IsActive,
+					PurchaseUomID:     link.PurchaseUomID,
+				}); err != nil {
+					return err
+				}
+			}
+
+			price, err := q.UpsertProductSupplierPrice(ctx, UpsertProductSupplierPriceParams{
+				ProductSupplierID: link.ProductSupplierID,
+				UnitPrice:         line.UnitPrice,
+				EffectiveFrom:     line.EffectiveFrom,
+				Source:            PriceSourcePriceList,
+				CreatedBy:         arg.CreatedBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.Prices = append(result.Prices, price)
+		}
+
+		var err error
+		result.PricesApplied, err = q.ApplyDueProductSupplierPrices(ctx)
+		return err
+	})
+
+	return result, err
+}
@@ -18,10 +18,10 @@ INSERT INTO products (
     sku, name, description, category_id, unit_price, cost_price,
     barcode, weight, dimensions, supplier_id, min_stock_level,
     max_stock_level, reorder_point, safety_stock, lead_time_days,
-    auto_reorder, is_active
+    auto_reorder, is_active, base_uom_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
-) RETURNING product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+) RETURNING product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at, base_uom_id, costing_method, standard_cost
 `
 
 type CreateProductParams struct {
@@ -42,6 +42,7 @@ type CreateProductParams struct {
 	LeadTimeDays  sql.NullInt32   `json:"lead_time_days"`
 	AutoReorder   bool            `json:"auto_reorder"`
 	IsActive      bool            `json:"is_active"`
+	BaseUomID     sql.NullInt32   `json:"base_uom_id"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error) {
@@ -63,6 +64,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		arg.LeadTimeDays,
 		arg.AutoReorder,
 		arg.IsActive,
+		arg.BaseUomID,
 	)
 	var i Product
 	err := row.Scan(
@@ -87,12 +89,15 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BaseUomID,
+		&i.CostingMethod,
+		&i.StandardCost,
 	)
 	return i, err
 }
 
 const getProduct = `-- name: GetProduct :one
-SELECT product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at FROM products 
+SELECT product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at, base_uom_id, costing_method, standard_cost FROM products 
 WHERE product_id = $1
 `
 
@@ -121,12 +126,83 @@ func (q *Queries) GetProduct(ctx context.Context, productID int32) (Product, err
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BaseUomID,
+		&i.CostingMethod,
+		&i.StandardCost,
+	)
+	return i, err
+}
+
+const getProductAvailability = `-- name: GetProductAvailability :one
+SELECT p.product_id, p.sku, p.reorder_point,
+       COALESCE(SUM(i.quantity - i.reserved_quantity), 0)::int AS available_qty
+FROM products p
+LEFT JOIN inventory i ON p.product_id = i.product_id
+WHERE p.product_id = $1
+GROUP BY p.product_id
+`
+
+type GetProductAvailabilityRow struct {
+	ProductID    int32         `json:"product_id"`
+	Sku          string        `json:"sku"`
+	ReorderPoint sql.NullInt32 `json:"reorder_point"`
+	AvailableQty int32         `json:"available_qty"`
+}
+
+func (q *Queries) GetProductAvailability(ctx context.Context, productID int32) (GetProductAvailabilityRow, error) {
+	row := q.db.QueryRowContext(ctx, getProductAvailability, productID)
+	var i GetProductAvailabilityRow
+	err := row.Scan(
+		&i.ProductID,
+		&i.Sku,
+		&i.ReorderPoint,
+		&i.AvailableQty,
+	)
+	return i, err
+}
+
+const getProductByGTIN = `-- name: GetProductByGTIN :one
+SELECT product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at, base_uom_id, costing_method, standard_cost FROM products
+WHERE length(barcode) <= 14
+  AND lpad(barcode, 14, '0') = $1
+ORDER BY is_active DESC, product_id
+LIMIT 1
+`
+
+func (q *Queries) GetProductByGTIN(ctx context.Context, barcode string) (Product, error) {
+	row := q.db.QueryRowContext(ctx, getProductByGTIN, barcode)
+	var i Product
+	err := row.Scan(
+		&i.ProductID,
+		&i.Sku,
+		&i.Name,
+		&i.Description,
+		&i.CategoryID,
+		&i.UnitPrice,
+		&i.CostPrice,
+		&i.Barcode,
+		&i.Weight,
+		&i.Dimensions,
+		&i.SupplierID,
+		&i.MinStockLevel,
+		&i.MaxStockLevel,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.LeadTimeDays,
+		&i.AutoReorder,
+		&i.LastReorderDate,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BaseUomID,
+		&i.CostingMethod,
+		&i.StandardCost,
 	)
 	return i, err
 }
 
 const getProductBySKU = `-- name: GetProductBySKU :one
-SELECT product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at FROM products 
+SELECT product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at, base_uom_id, costing_method, standard_cost FROM products 
 WHERE sku = $1
 `
 
@@ -155,12 +231,53 @@ func (q *Queries) GetProductBySKU(ctx context.Context, sku string) (Product, err
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BaseUomID,
+		&i.CostingMethod,
+		&i.StandardCost,
+	)
+	return i, err
+}
+
+const getProductForUpdate = `-- name: GetProductForUpdate :one
+SELECT product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at, base_uom_id, costing_method, standard_cost FROM products
+WHERE product_id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetProductForUpdate(ctx context.Context, productID int32) (Product, error) {
+	row := q.db.QueryRowContext(ctx, getProductForUpdate, productID)
+	var i Product
+	err := row.Scan(
+		&i.ProductID,
+		&i.Sku,
+		&i.Name,
+		&i.Description,
+		&i.CategoryID,
+		&i.UnitPrice,
+		&i.CostPrice,
+		&i.Barcode,
+		&i.Weight,
+		&i.Dimensions,
+		&i.SupplierID,
+		&i.MinStockLevel,
+		&i.MaxStockLevel,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.LeadTimeDays,
+		&i.AutoReorder,
+		&i.LastReorderDate,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BaseUomID,
+		&i.CostingMethod,
+		&i.StandardCost,
 	)
 	return i, err
 }
 
 const listProducts = `-- name: ListProducts :many
-SELECT product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at FROM products 
+SELECT product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at, base_uom_id, costing_method, standard_cost FROM products 
 WHERE is_active = true
 ORDER BY product_id
 LIMIT $1 OFFSET $2
@@ -202,6 +319,9 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BaseUomID,
+			&i.CostingMethod,
+			&i.StandardCost,
 		); err != nil {
 			return nil, err
 		}
@@ -217,7 +337,7 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 }
 
 const listProductsBelowReorderPoint = `-- name: ListProductsBelowReorderPoint :many
-SELECT p.product_id, p.sku, p.name, p.description, p.category_id, p.unit_price, p.cost_price, p.barcode, p.weight, p.dimensions, p.supplier_id, p.min_stock_level, p.max_stock_level, p.reorder_point, p.safety_stock, p.lead_time_days, p.auto_reorder, p.last_reorder_date, p.is_active, p.created_at, p.updated_at,
+SELECT p.product_id, p.sku, p.name, p.description, p.category_id, p.unit_price, p.cost_price, p.barcode, p.weight, p.dimensions, p.supplier_id, p.min_stock_level, p.max_stock_level, p.reorder_point, p.safety_stock, p.lead_time_days, p.auto_reorder, p.last_reorder_date, p.is_active, p.created_at, p.updated_at, p.base_uom_id, p.costing_method, p.standard_cost,
        COALESCE(SUM(i.quantity - i.reserved_quantity), 0) as available_qty
 FROM products p
 LEFT JOIN inventory i ON p.product_id = i.product_id
@@ -228,28 +348,31 @@ ORDER BY p.product_id
 `
 
 type ListProductsBelowReorderPointRow struct {
-	ProductID       int32           `json:"product_id"`
-	Sku             string          `json:"sku"`
-	Name            string          `json:"name"`
-	Description     sql.NullString  `json:"description"`
-	CategoryID      sql.NullInt32   `json:"category_id"`
-	UnitPrice       decimal.Decimal `json:"unit_price"`
-	CostPrice       decimal.Decimal `json:"cost_price"`
-	Barcode         sql.NullString  `json:"barcode"`
-	Weight          decimal.Decimal `json:"weight"`
-	Dimensions      sql.NullString  `json:"dimensions"`
-	SupplierID      sql.NullInt32   `json:"supplier_id"`
-	MinStockLevel   int32           `json:"min_stock_level"`
-	MaxStockLevel   sql.NullInt32   `json:"max_stock_level"`
-	ReorderPoint    sql.NullInt32   `json:"reorder_point"`
-	SafetyStock     sql.NullInt32   `json:"safety_stock"`
-	LeadTimeDays    sql.NullInt32   `json:"lead_time_days"`
-	AutoReorder     bool            `json:"auto_reorder"`
-	LastReorderDate sql.NullTime    `json:"last_reorder_date"`
-	IsActive        bool            `json:"is_active"`
-	CreatedAt       time.Time       `json:"created_at"`
-	UpdatedAt       time.Time       `json:"updated_at"`
-	AvailableQty    interface{}     `json:"available_qty"`
+	ProductID       int32               `json:"product_id"`
+	Sku             string              `json:"sku"`
+	Name            string              `json:"name"`
+	Description     sql.NullString      `json:"description"`
+	CategoryID      sql.NullInt32       `json:"category_id"`
+	UnitPrice       decimal.Decimal     `json:"unit_price"`
+	CostPrice       decimal.Decimal     `json:"cost_price"`
+	Barcode         sql.NullString      `json:"barcode"`
+	Weight          decimal.Decimal     `json:"weight"`
+	Dimensions      sql.NullString      `json:"dimensions"`
+	SupplierID      sql.NullInt32       `json:"supplier_id"`
+	MinStockLevel   int32               `json:"min_stock_level"`
+	MaxStockLevel   sql.NullInt32       `json:"max_stock_level"`
+	ReorderPoint    sql.NullInt32       `json:"reorder_point"`
+	SafetyStock     sql.NullInt32       `json:"safety_stock"`
+	LeadTimeDays    sql.NullInt32       `json:"lead_time_days"`
+	AutoReorder     bool                `json:"auto_reorder"`
+	LastReorderDate sql.NullTime        `json:"last_reorder_date"`
+	IsActive        bool                `json:"is_active"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+	BaseUomID       sql.NullInt32       `json:"base_uom_id"`
+	CostingMethod   CostingMethod       `json:"costing_method"`
+	StandardCost    decimal.NullDecimal `json:"standard_cost"`
+	AvailableQty    interface{}         `json:"available_qty"`
 }
 
 func (q *Queries) ListProductsBelowReorderPoint(ctx context.Context) ([]ListProductsBelowReorderPointRow, error) {
@@ -283,6 +406,9 @@ func (q *Queries) ListProductsBelowReorderPoint(ctx context.Context) ([]ListProd
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BaseUomID,
+			&i.CostingMethod,
+			&i.StandardCost,
 			&i.AvailableQty,
 		); err != nil {
 			return nil, err
@@ -299,7 +425,7 @@ func (q *Queries) ListProductsBelowReorderPoint(ctx context.Context) ([]ListProd
 }
 
 const listProductsByCategory = `-- name: ListProductsByCategory :many
-SELECT product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at FROM products 
+SELECT product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at, base_uom_id, costing_method, standard_cost FROM products 
 WHERE category_id = $1 AND is_active = true
 ORDER BY product_id
 LIMIT $2 OFFSET $3
@@ -342,6 +468,80 @@ func (q *Queries) ListProductsByCategory(ctx context.Context, arg ListProductsBy
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BaseUomID,
+			&i.CostingMethod,
+			&i.StandardCost,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsInCategoryTree = `-- name: ListProductsInCategoryTree :many
+WITH RECURSIVE subtree AS (
+    SELECT category_id, ARRAY[category_id] AS path
+    FROM categories
+    WHERE category_id = $1
+    UNION ALL
+    SELECT c.category_id, subtree.path || c.category_id
+    FROM categories c
+    JOIN subtree ON c.parent_category_id = subtree.category_id
+    WHERE NOT c.category_id = ANY(subtree.path)
+)
+SELECT p.product_id, p.sku, p.name, p.description, p.category_id, p.unit_price, p.cost_price, p.barcode, p.weight, p.dimensions, p.supplier_id, p.min_stock_level, p.max_stock_level, p.reorder_point, p.safety_stock, p.lead_time_days, p.auto_reorder, p.last_reorder_date, p.is_active, p.created_at, p.updated_at, p.base_uom_id, p.costing_method, p.standard_cost FROM products p
+WHERE p.category_id IN (SELECT category_id FROM subtree) AND p.is_active = true
+ORDER BY p.product_id
+LIMIT $2 OFFSET $3
+`
+
+type ListProductsInCategoryTreeParams struct {
+	CategoryID int32 `json:"category_id"`
+	Limit      int32 `json:"limit"`
+	Offset     int32 `json:"offset"`
+}
+
+func (q *Queries) ListProductsInCategoryTree(ctx context.Context, arg ListProductsInCategoryTreeParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsInCategoryTree, arg.CategoryID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.Sku,
+			&i.Name,
+			&i.Description,
+			&i.CategoryID,
+			&i.UnitPrice,
+			&i.CostPrice,
+			&i.Barcode,
+			&i.Weight,
+			&i.Dimensions,
+			&i.SupplierID,
+			&i.MinStockLevel,
+			&i.MaxStockLevel,
+			&i.ReorderPoint,
+			&i.SafetyStock,
+			&i.LeadTimeDays,
+			&i.AutoReorder,
+			&i.LastReorderDate,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.BaseUomID,
+			&i.CostingMethod,
+			&i.StandardCost,
 		); err != nil {
 			return nil, err
 		}
@@ -356,6 +556,171 @@ func (q *Queries) ListProductsByCategory(ctx context.Context, arg ListProductsBy
 	return items, nil
 }
 
+const searchProducts = `-- name: SearchProducts :many
+WITH RECURSIVE subtree AS (
+    SELECT category_id, ARRAY[category_id] AS path
+    FROM categories
+    WHERE category_id = $1::int
+    UNION ALL
+    SELECT c.category_id, subtree.path || c.category_id
+    FROM categories c
+    JOIN subtree ON c.parent_category_id = subtree.category_id
+    WHERE NOT c.category_id = ANY(subtree.path)
+),
+stock AS (
+    SELECT product_id, SUM(quantity - reserved_quantity) AS available
+    FROM inventory
+    GROUP BY product_id
+)
+SELECT p.product_id, p.sku, p.name, p.description, p.category_id, p.unit_price, p.cost_price, p.barcode, p.weight, p.dimensions, p.supplier_id, p.min_stock_level, p.max_stock_level, p.reorder_point, p.safety_stock, p.lead_time_days, p.auto_reorder, p.last_reorder_date, p.is_active, p.created_at, p.updated_at, p.base_uom_id, p.costing_method, p.standard_cost,
+       COALESCE(s.available, 0)::int as available_quantity,
+       (CASE WHEN lower(p.sku) = lower($2::text)
+                  OR p.barcode = $2
+                  OR EXISTS (SELECT 1 FROM product_uoms pu WHERE pu.product_id = p.product_id AND pu.barcode = $2)
+             THEN 2 ELSE 0 END
+        + CASE WHEN $3::text <> ''
+               THEN ts_rank(to_tsvector('simple', p.sku || ' ' || p.name || ' ' || COALESCE(p.description, '') || ' ' || COALESCE(p.barcode, '')),
+                            to_tsquery('simple', $3))
+               ELSE 0 END
+        + GREATEST(word_similarity(lower($2), lower(p.name)), similarity(lower($2), lower(p.sku)))
+       )::real as rank
+FROM products p
+LEFT JOIN stock s ON s.product_id = p.product_id
+WHERE ($1::int IS NULL OR p.category_id IN (SELECT category_id FROM subtree))
+  AND ($4::int IS NULL OR p.supplier_id = $4)
+  AND ($5::boolean IS NULL OR p.is_active = $5)
+  AND ($6::numeric IS NULL OR p.unit_price >= $6)
+  AND ($7::numeric IS NULL OR p.unit_price <= $7)
+  AND ($8::text IS NULL
+       OR ($8 = 'in_stock' AND COALESCE(s.available, 0) > 0)
+       OR ($8 = 'low_stock' AND COALESCE(s.available, 0) > 0 AND COALESCE(s.available, 0) <= p.reorder_point)
+       OR ($8 = 'out_of_stock' AND COALESCE(s.available, 0) <= 0))
+  AND ($2 = ''
+       OR lower(p.sku) = lower($2)
+       OR p.barcode = $2
+       OR EXISTS (SELECT 1 FROM product_uoms pu WHERE pu.product_id = p.product_id AND pu.barcode = $2)
+       OR ($3 <> ''
+           AND to_tsvector('simple', p.sku || ' ' || p.name || ' ' || COALESCE(p.description, '') || ' ' || COALESCE(p.barcode, ''))
+               @@ to_tsquery('simple', $3))
+       OR lower($2) <% lower(p.name)
+       OR lower($2) % lower(p.sku))
+ORDER BY rank DESC, p.name, p.product_id
+LIMIT $9 OFFSET $10
+`
+
+type SearchProductsParams struct {
+	CategoryID  sql.NullInt32       `json:"category_id"`
+	Term        string              `json:"term"`
+	TsQuery     string              `json:"ts_query"`
+	SupplierID  sql.NullInt32       `json:"supplier_id"`
+	IsActive    sql.NullBool        `json:"is_active"`
+	MinPrice    decimal.NullDecimal `json:"min_price"`
+	MaxPrice    decimal.NullDecimal `json:"max_price"`
+	StockStatus sql.NullString      `json:"stock_status"`
+	RowLimit    int32               `json:"row_limit"`
+	RowOffset   int32               `json:"row_offset"`
+}
+
+type SearchProductsRow struct {
+	ProductID         int32               `json:"product_id"`
+	Sku               string              `json:"sku"`
+	Name              string              `json:"name"`
+	Description       sql.NullString      `json:"description"`
+	CategoryID        sql.NullInt32       `json:"category_id"`
+	UnitPrice         decimal.Decimal     `json:"unit_price"`
+	CostPrice         decimal.Decimal     `json:"cost_price"`
+	Barcode           sql.NullString      `json:"barcode"`
+	Weight            decimal.Decimal     `json:"weight"`
+	Dimensions        sql.NullString      `json:"dimensions"`
+	SupplierID        sql.NullInt32       `json:"supplier_id"`
+	MinStockLevel     int32               `json:"min_stock_level"`
+	MaxStockLevel     sql.NullInt32       `json:"max_stock_level"`
+	ReorderPoint      sql.NullInt32       `json:"reorder_point"`
+	SafetyStock       sql.NullInt32       `json:"safety_stock"`
+	LeadTimeDays      sql.NullInt32       `json:"lead_time_days"`
+	AutoReorder       bool                `json:"auto_reorder"`
+	LastReorderDate   sql.NullTime        `json:"last_reorder_date"`
+	IsActive          bool                `json:"is_active"`
+	CreatedAt         time.Time           `json:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+	BaseUomID         sql.NullInt32       `json:"base_uom_id"`
+	CostingMethod     CostingMethod       `json:"costing_method"`
+	StandardCost      decimal.NullDecimal `json:"standard_cost"`
+	AvailableQuantity int32               `json:"available_quantity"`
+	Rank              float32             `json:"rank"`
+}
+
+func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams) ([]SearchProductsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchProducts,
+		arg.CategoryID,
+		arg.Term,
+		arg.TsQuery,
+		arg.SupplierID,
+		arg.IsActive,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.StockStatus,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchProductsRow
+	for rows.Next() {
+		var i SearchProductsRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.Sku,
+			&i.Name,
+			&i.Description,
+			&i.CategoryID,
+			&i.UnitPrice,
+			&i.CostPrice,
+			&i.Barcode,
+			&i.Weight,
+			&i.Dimensions,
+			&i.SupplierID,
+			&i.MinStockLevel,
+			&i.MaxStockLevel,
+			&i.ReorderPoint,
+			&i.SafetyStock,
+			&i.LeadTimeDays,
+			&i.AutoReorder,
+			&i.LastReorderDate,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.BaseUomID,
+			&i.CostingMethod,
+			&i.StandardCost,
+			&i.AvailableQuantity,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setSimilarityThreshold = `-- name: SetSimilarityThreshold :exec
+SELECT set_config('pg_trgm.similarity_threshold', $1::real::text, true),
+       set_config('pg_trgm.word_similarity_threshold', $1::real::text, true)
+`
+
+func (q *Queries) SetSimilarityThreshold(ctx context.Context, threshold float32) error {
+	_, err := q.db.ExecContext(ctx, setSimilarityThreshold, threshold)
+	return err
+}
+
 const softDeleteProduct = `-- name: SoftDeleteProduct :exec
 UPDATE products 
 SET is_active = false, updated_at = CURRENT_TIMESTAMP
@@ -397,9 +762,10 @@ SET
     lead_time_days = $15,
     auto_reorder = $16,
     is_active = $17,
+    base_uom_id = $18,
     updated_at = CURRENT_TIMESTAMP
 WHERE product_id = $1
-RETURNING product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at
+RETURNING product_id, sku, name, description, category_id, unit_price, cost_price, barcode, weight, dimensions, supplier_id, min_stock_level, max_stock_level, reorder_point, safety_stock, lead_time_days, auto_reorder, last_reorder_date, is_active, created_at, updated_at, base_uom_id, costing_method, standard_cost
 `
 
 type UpdateProductParams struct {
@@ -420,6 +786,7 @@ type UpdateProductParams struct {
 	LeadTimeDays  sql.NullInt32   `json:"lead_time_days"`
 	AutoReorder   bool            `json:"auto_reorder"`
 	IsActive      bool            `json:"is_active"`
+	BaseUomID     sql.NullInt32   `json:"base_uom_id"`
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error) {
@@ -441,6 +808,7 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		arg.LeadTimeDays,
 		arg.AutoReorder,
 		arg.IsActive,
+		arg.BaseUomID,
 	)
 	var i Product
 	err := row.Scan(
@@ -465,6 +833,9 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BaseUomID,
+		&i.CostingMethod,
+		&i.StandardCost,
 	)
 	return i, err
 }
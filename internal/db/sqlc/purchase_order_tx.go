@@ -0,0 +1,332 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/molu/stock-management-system/internal/purchasing"
+	"github.com/molu/stock-management-system/internal/webhook"
+)
+
+var (
+	ErrPurchaseOrderEmpty      = errors.New("purchase order has no items")
+	ErrPurchaseOrderNotPending = errors.New("purchase order is not pending approval")
+	ErrPurchaseOrderNotDraft   = errors.New("items can only be added to draft purchase orders")
+	ErrPurchaseOrderNotOpen    = errors.New("only approved purchase orders can be received")
+	ErrApproverInactive        = errors.New("approver is not an active user")
+	ErrApproverRole            = errors.New("approver's role may not sign off this level")
+	ErrSelfApproval            = errors.New("purchase orders cannot be approved by the user who created them")
+	ErrCreatorUnknown          = errors.New("purchase order has no recorded creator; reject it and submit it again")
+	ErrAlreadySignedOff        = errors.New("approver has already signed off another level of this order")
+	ErrPoApprovalDecision      = errors.New("decision must be approved or rejected")
+)
+
+// approvalLevels converts the active approval levels for the workflow rules.
+func approvalLevels(ctx context.Context, q *Queries) ([]purchasing.Level, error) {
+	rows, err := q.ListActivePoApprovalLevels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	levels := make([]purchasing.Level, len(rows))
+	for i, l := range rows {
+		levels[i] = purchasing.Level{
+			Level:     l.Level,
+			Role:      purchasing.Role(l.ApproverRole),
+			MinAmount: l.MinAmount,
+		}
+	}
+	return levels, nil
+}
+
+// outstandingApprovals returns the approval levels a pending purchase order
+// still needs, in the order they must be signed off.
+func outstandingApprovals(ctx context.Context, q *Queries, po PurchaseOrder) ([]purchasing.Level, error) {
+	levels, err := approvalLevels(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := q.ListSubmissionApprovals(ctx, po.PoID)
+	if err != nil {
+		return nil, err
+	}
+	done := make([]int32, len(signed))
+	for i, s := range signed {
+		done[i] = s.Level
+	}
+	return purchasing.Outstanding(purchasing.RequiredLevels(levels, po.TotalAmount), done), nil
+}
+
+type ChangePurchaseOrderStatusTxParams struct {
+	PoID   int32
+	Status PurchaseOrderStatus
+	// ChangedBy is the user making the change.
+	ChangedBy int32
+}
+
+// ChangePurchaseOrderStatusTx moves a purchase order to a new status if the
+// workflow allows it. Submitting recalculates total_amount from the items,
+// records the submitter as the creator of an order that has none, and
+// approves the order straight away when no approval level applies.
+func (store *SQLStore) ChangePurchaseOrderStatusTx(ctx context.Context, arg ChangePurchaseOrderStatusTxParams) (PurchaseOrder, error) {
+	var result PurchaseOrder
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		po, err := q.GetPurchaseOrderForUpdate(ctx, arg.PoID)
+		if err != nil {
+			return err
+		}
+		if err := purchasing.CheckTransition(purchasing.Status(po.Status), purchasing.Status(arg.Status)); err != nil {
+			return err
+		}
+
+		if arg.Status != PurchaseOrderStatusPending {
+			result, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{
+				PoID:   arg.PoID,
+				Status: arg.Status,
+			})
+			return err
+		}
+
+		items, err := q.CountPurchaseOrderItems(ctx, arg.PoID)
+		if err != nil {
+			return err
+		}
+		if items == 0 {
+			return ErrPurchaseOrderEmpty
+		}
+		if _, err := q.RecalculatePurchaseOrderTotal(ctx, arg.PoID); err != nil {
+			return err
+		}
+		result, err = q.SubmitPurchaseOrder(ctx, SubmitPurchaseOrderParams{
+			SubmittedBy: sql.NullInt32{Int32: arg.ChangedBy, Valid: true},
+			PoID:        arg.PoID,
+		})
+		if err != nil {
+			return err
+		}
+
+		outstanding, err := outstandingApprovals(ctx, q, result)
+		if err != nil {
+			return err
+		}
+		if len(outstanding) == 0 {
+			result, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{
+				PoID:   arg.PoID,
+				Status: PurchaseOrderStatusApproved,
+			})
+		}
+		return err
+	})
+
+	return result, err
+}
+
+type DecidePurchaseOrderTxParams struct {
+	PoID       int32
+	ApproverID int32
+	Decision   PoApprovalDecision
+	Comments   sql.NullString
+}
+
+type DecidePurchaseOrderTxResult struct {
+	PurchaseOrder PurchaseOrder         `json:"purchase_order"`
+	Approval      PurchaseOrderApproval `json:"approval"`
+	// Outstanding are the levels still to sign off after this decision.
+	Outstanding []purchasing.Level `json:"outstanding_levels"`
+}
+
+// DecidePurchaseOrderTx records a sign-off or rejection of the next
+// outstanding approval level of a pending purchase order. The approver must
+// hold the level's role or a higher one, may not have created the order and
+// signs at most one level per submission. Signing the last level approves
+// the order; a rejection sends it back to draft.
+func (store *SQLStore) DecidePurchaseOrderTx(ctx context.Context, arg DecidePurchaseOrderTxParams) (DecidePurchaseOrderTxResult, error) {
+	var result DecidePurchaseOrderTxResult
+
+	if !arg.Decision.Valid() {
+		return result, ErrPoApprovalDecision
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		po, err := q.GetPurchaseOrderForUpdate(ctx, arg.PoID)
+		if err != nil {
+			return err
+		}
+		if po.Status != PurchaseOrderStatusPending {
+			return ErrPurchaseOrderNotPending
+		}
+
+		approver, err := q.GetUser(ctx, arg.ApproverID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrApproverInactive
+			}
+			return err
+		}
+		if !approver.IsActive {
+			return ErrApproverInactive
+		}
+		// Without a creator self-approval cannot be ruled out; a rejection
+		// is still allowed, so the order can be submitted again.
+		if !po.CreatedBy.Valid && arg.Decision == PoApprovalDecisionApproved {
+			return ErrCreatorUnknown
+		}
+		if po.CreatedBy.Valid && po.CreatedBy.Int32 == approver.UserID {
+			return ErrSelfApproval
+		}
+
+		signed, err := q.ListSubmissionApprovals(ctx, po.PoID)
+		if err != nil {
+			return err
+		}
+		for _, s := range signed {
+			if s.ApproverID == approver.UserID {
+				return ErrAlreadySignedOff
+			}
+		}
+
+		outstanding, err := outstandingApprovals(ctx, q, po)
+		if err != nil {
+			return err
+		}
+		if len(outstanding) == 0 {
+			// The levels changed since submission and none is left to sign.
+			status := PurchaseOrderStatusApproved
+			if arg.Decision == PoApprovalDecisionRejected {
+				status = PurchaseOrderStatusDraft
+			}
+			result.PurchaseOrder, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{
+				PoID:   po.PoID,
+				Status: status,
+			})
+			return err
+		}
+		next := outstanding[0]
+		if !purchasing.CanSign(purchasing.Role(approver.Role), next.Role) {
+			return ErrApproverRole
+		}
+
+		result.Approval, err = q.CreatePurchaseOrderApproval(ctx, CreatePurchaseOrderApprovalParams{
+			PoID:        po.PoID,
+			Level:       next.Level,
+			Decision:    arg.Decision,
+			ApproverID:  approver.UserID,
+			TotalAmount: po.TotalAmount,
+			Comments:    arg.Comments,
+		})
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case arg.Decision == PoApprovalDecisionRejected:
+			result.PurchaseOrder, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{
+				PoID:   po.PoID,
+				Status: PurchaseOrderStatusDraft,
+			})
+		case len(outstanding) == 1:
+			result.PurchaseOrder, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{
+				PoID:   po.PoID,
+				Status: PurchaseOrderStatusApproved,
+			})
+		default:
+			result.PurchaseOrder = po
+			result.Outstanding = outstanding[1:]
+		}
+		return err
+	})
+
+	return result, err
+}
+
+// CreatePurchaseOrderItemTx adds a line to a draft purchase order and
+// recalculates the order total, with the order locked so it cannot be
+// submitted in between.
+func (store *SQLStore) CreatePurchaseOrderItemTx(ctx context.Context, arg CreatePurchaseOrderItemParams) (PurchaseOrderItem, error) {
+	var result PurchaseOrderItem
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		po, err := q.GetPurchaseOrderForUpdate(ctx, arg.PoID)
+		if err != nil {
+			return err
+		}
+		if !purchasing.Editable(purchasing.Status(po.Status)) {
+			return ErrPurchaseOrderNotDraft
+		}
+
+		result, err = q.CreatePurchaseOrderItem(ctx, arg)
+		if err != nil {
+			return err
+		}
+		_, err = q.RecalculatePurchaseOrderTotal(ctx, arg.PoID)
+		return err
+	})
+
+	return result, err
+}
+
+type ReceivePurchaseOrderItemTxParams struct {
+	PoItemID   int32
+	Quantity   int32
+	ReceivedBy sql.NullInt32
+}
+
+// ReceivePurchaseOrderItemTx adds a delivery to a line of an approved or
+// partially received purchase order, dates it for the supplier scorecard,
+// moves the order on to partially_received or completed and sends
+// po.received. The order is locked so it cannot be cancelled meanwhile.
+func (store *SQLStore) ReceivePurchaseOrderItemTx(ctx context.Context, arg ReceivePurchaseOrderItemTxParams) (PurchaseOrderItem, error) {
+	var result PurchaseOrderItem
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		item, err := q.GetPurchaseOrderItem(ctx, arg.PoItemID)
+		if err != nil {
+			return err
+		}
+		locked, err := q.GetPurchaseOrderForUpdate(ctx, item.PoID)
+		if err != nil {
+			return err
+		}
+		if !purchasing.Receivable(purchasing.Status(locked.Status)) {
+			return ErrPurchaseOrderNotOpen
+		}
+
+		result, err = q.UpdatePurchaseOrderItemReceivedQty(ctx, UpdatePurchaseOrderItemReceivedQtyParams{
+			PoItemID:         arg.PoItemID,
+			QuantityReceived: arg.Quantity,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := q.CreatePurchaseOrderReceipt(ctx, CreatePurchaseOrderReceiptParams{
+			PoItemID:   arg.PoItemID,
+			Quantity:   arg.Quantity,
+			ReceivedBy: arg.ReceivedBy,
+		}); err != nil {
+			return err
+		}
+
+		if err := q.UpdatePurchaseOrderReceiptStatus(ctx, result.PoID); err != nil {
+			return err
+		}
+
+		po, err := q.GetPurchaseOrder(ctx, result.PoID)
+		if err != nil {
+			return err
+		}
+		return enqueueWebhookEvent(ctx, q, webhook.EventPOReceived, webhook.POReceived{
+			PoID:             po.PoID,
+			PoNumber:         po.PoNumber,
+			PoStatus:         string(po.Status),
+			PoItemID:         result.PoItemID,
+			ProductID:        result.ProductID,
+			Quantity:         arg.Quantity,
+			QuantityReceived: result.QuantityReceived,
+			QuantityOrdered:  result.QuantityOrdered,
+		})
+	})
+
+	return result, err
+}
@@ -13,13 +13,26 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const countPurchaseOrderItems = `-- name: CountPurchaseOrderItems :one
+SELECT COUNT(*) FROM purchase_order_items
+WHERE po_id = $1
+`
+
+func (q *Queries) CountPurchaseOrderItems(ctx context.Context, poID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPurchaseOrderItems, poID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createPurchaseOrder = `-- name: CreatePurchaseOrder :one
 INSERT INTO purchase_orders (
     po_number, supplier_id, order_date, expected_delivery_date,
-    status, total_amount, notes, created_by
+    status, total_amount, notes, created_by, currency_code,
+    warehouse_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING po_id, po_number, supplier_id, order_date, expected_delivery_date, status, total_amount, notes, created_by, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING po_id, po_number, supplier_id, order_date, expected_delivery_date, status, total_amount, notes, created_by, created_at, currency_code, submitted_at, updated_at, warehouse_id
 `
 
 type CreatePurchaseOrderParams struct {
@@ -31,6 +44,8 @@ type CreatePurchaseOrderParams struct {
 	TotalAmount          decimal.Decimal     `json:"total_amount"`
 	Notes                sql.NullString      `json:"notes"`
 	CreatedBy            sql.NullInt32       `json:"created_by"`
+	CurrencyCode         sql.NullString      `json:"currency_code"`
+	WarehouseID          sql.NullInt32       `json:"warehouse_id"`
 }
 
 func (q *Queries) CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (PurchaseOrder, error) {
@@ -43,6 +58,8 @@ func (q *Queries) CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrd
 		arg.TotalAmount,
 		arg.Notes,
 		arg.CreatedBy,
+		arg.CurrencyCode,
+		arg.WarehouseID,
 	)
 	var i PurchaseOrder
 	err := row.Scan(
@@ -56,6 +73,10 @@ func (q *Queries) CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrd
 		&i.Notes,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.CurrencyCode,
+		&i.SubmittedAt,
+		&i.UpdatedAt,
+		&i.WarehouseID,
 	)
 	return i, err
 }
@@ -63,10 +84,10 @@ func (q *Queries) CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrd
 const createPurchaseOrderItem = `-- name: CreatePurchaseOrderItem :one
 INSERT INTO purchase_order_items (
     po_id, product_id, quantity_ordered, quantity_received,
-    unit_price, total_price
+    unit_price, total_price, uom_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING po_item_id, po_id, product_id, quantity_ordered, quantity_received, unit_price, total_price
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING po_item_id, po_id, product_id, quantity_ordered, quantity_received, unit_price, total_price, uom_id
 `
 
 type CreatePurchaseOrderItemParams struct {
@@ -76,6 +97,7 @@ type CreatePurchaseOrderItemParams struct {
 	QuantityReceived int32           `json:"quantity_received"`
 	UnitPrice        decimal.Decimal `json:"unit_price"`
 	TotalPrice       decimal.Decimal `json:"total_price"`
+	UomID            sql.NullInt32   `json:"uom_id"`
 }
 
 func (q *Queries) CreatePurchaseOrderItem(ctx context.Context, arg CreatePurchaseOrderItemParams) (PurchaseOrderItem, error) {
@@ -86,6 +108,7 @@ func (q *Queries) CreatePurchaseOrderItem(ctx context.Context, arg CreatePurchas
 		arg.QuantityReceived,
 		arg.UnitPrice,
 		arg.TotalPrice,
+		arg.UomID,
 	)
 	var i PurchaseOrderItem
 	err := row.Scan(
@@ -96,12 +119,40 @@ func (q *Queries) CreatePurchaseOrderItem(ctx context.Context, arg CreatePurchas
 		&i.QuantityReceived,
 		&i.UnitPrice,
 		&i.TotalPrice,
+		&i.UomID,
+	)
+	return i, err
+}
+
+const createPurchaseOrderReceipt = `-- name: CreatePurchaseOrderReceipt :one
+INSERT INTO purchase_order_receipts (
+    po_item_id, quantity, received_by
+) VALUES (
+    $1, $2, $3
+) RETURNING receipt_id, po_item_id, quantity, received_at, received_by
+`
+
+type CreatePurchaseOrderReceiptParams struct {
+	PoItemID   int32         `json:"po_item_id"`
+	Quantity   int32         `json:"quantity"`
+	ReceivedBy sql.NullInt32 `json:"received_by"`
+}
+
+func (q *Queries) CreatePurchaseOrderReceipt(ctx context.Context, arg CreatePurchaseOrderReceiptParams) (PurchaseOrderReceipt, error) {
+	row := q.db.QueryRowContext(ctx, createPurchaseOrderReceipt, arg.PoItemID, arg.Quantity, arg.ReceivedBy)
+	var i PurchaseOrderReceipt
+	err := row.Scan(
+		&i.ReceiptID,
+		&i.PoItemID,
+		&i.Quantity,
+		&i.ReceivedAt,
+		&i.ReceivedBy,
 	)
 	return i, err
 }
 
 const getPurchaseOrder = `-- name: GetPurchaseOrder :one
-SELECT po.po_id, po.po_number, po.supplier_id, po.order_date, po.expected_delivery_date, po.status, po.total_amount, po.notes, po.created_by, po.created_at, s.name as supplier_name, s.code as supplier_code,
+SELECT po.po_id, po.po_number, po.supplier_id, po.order_date, po.expected_delivery_date, po.status, po.total_amount, po.notes, po.created_by, po.created_at, po.currency_code, po.submitted_at, po.updated_at, po.warehouse_id, s.name as supplier_name, s.code as supplier_code,
        u.full_name as creator_name
 FROM purchase_orders po
 LEFT JOIN suppliers s ON po.supplier_id = s.supplier_id
@@ -120,6 +171,10 @@ type GetPurchaseOrderRow struct {
 	Notes                sql.NullString      `json:"notes"`
 	CreatedBy            sql.NullInt32       `json:"created_by"`
 	CreatedAt            time.Time           `json:"created_at"`
+	CurrencyCode         sql.NullString      `json:"currency_code"`
+	SubmittedAt          sql.NullTime        `json:"submitted_at"`
+	UpdatedAt            time.Time           `json:"updated_at"`
+	WarehouseID          sql.NullInt32       `json:"warehouse_id"`
 	SupplierName         sql.NullString      `json:"supplier_name"`
 	SupplierCode         sql.NullString      `json:"supplier_code"`
 	CreatorName          sql.NullString      `json:"creator_name"`
@@ -139,6 +194,10 @@ func (q *Queries) GetPurchaseOrder(ctx context.Context, poID int32) (GetPurchase
 		&i.Notes,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.CurrencyCode,
+		&i.SubmittedAt,
+		&i.UpdatedAt,
+		&i.WarehouseID,
 		&i.SupplierName,
 		&i.SupplierCode,
 		&i.CreatorName,
@@ -146,8 +205,57 @@ func (q *Queries) GetPurchaseOrder(ctx context.Context, poID int32) (GetPurchase
 	return i, err
 }
 
+const getPurchaseOrderForUpdate = `-- name: GetPurchaseOrderForUpdate :one
+SELECT po_id, po_number, supplier_id, order_date, expected_delivery_date, status, total_amount, notes, created_by, created_at, currency_code, submitted_at, updated_at, warehouse_id FROM purchase_orders
+WHERE po_id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetPurchaseOrderForUpdate(ctx context.Context, poID int32) (PurchaseOrder, error) {
+	row := q.db.QueryRowContext(ctx, getPurchaseOrderForUpdate, poID)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.PoID,
+		&i.PoNumber,
+		&i.SupplierID,
+		&i.OrderDate,
+		&i.ExpectedDeliveryDate,
+		&i.Status,
+		&i.TotalAmount,
+		&i.Notes,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.CurrencyCode,
+		&i.SubmittedAt,
+		&i.UpdatedAt,
+		&i.WarehouseID,
+	)
+	return i, err
+}
+
+const getPurchaseOrderItem = `-- name: GetPurchaseOrderItem :one
+SELECT po_item_id, po_id, product_id, quantity_ordered, quantity_received, unit_price, total_price, uom_id FROM purchase_order_items
+WHERE po_item_id = $1
+`
+
+func (q *Queries) GetPurchaseOrderItem(ctx context.Context, poItemID int32) (PurchaseOrderItem, error) {
+	row := q.db.QueryRowContext(ctx, getPurchaseOrderItem, poItemID)
+	var i PurchaseOrderItem
+	err := row.Scan(
+		&i.PoItemID,
+		&i.PoID,
+		&i.ProductID,
+		&i.QuantityOrdered,
+		&i.QuantityReceived,
+		&i.UnitPrice,
+		&i.TotalPrice,
+		&i.UomID,
+	)
+	return i, err
+}
+
 const getPurchaseOrderItems = `-- name: GetPurchaseOrderItems :many
-SELECT poi.po_item_id, poi.po_id, poi.product_id, poi.quantity_ordered, poi.quantity_received, poi.unit_price, poi.total_price, p.name as product_name, p.sku
+SELECT poi.po_item_id, poi.po_id, poi.product_id, poi.quantity_ordered, poi.quantity_received, poi.unit_price, poi.total_price, poi.uom_id, p.name as product_name, p.sku
 FROM purchase_order_items poi
 JOIN products p ON poi.product_id = p.product_id
 WHERE poi.po_id = $1
@@ -162,6 +270,7 @@ type GetPurchaseOrderItemsRow struct {
 	QuantityReceived int32           `json:"quantity_received"`
 	UnitPrice        decimal.Decimal `json:"unit_price"`
 	TotalPrice       decimal.Decimal `json:"total_price"`
+	UomID            sql.NullInt32   `json:"uom_id"`
 	ProductName      string          `json:"product_name"`
 	Sku              string          `json:"sku"`
 }
@@ -183,6 +292,7 @@ func (q *Queries) GetPurchaseOrderItems(ctx context.Context, poID int32) ([]GetP
 			&i.QuantityReceived,
 			&i.UnitPrice,
 			&i.TotalPrice,
+			&i.UomID,
 			&i.ProductName,
 			&i.Sku,
 		); err != nil {
@@ -200,7 +310,7 @@ func (q *Queries) GetPurchaseOrderItems(ctx context.Context, poID int32) ([]GetP
 }
 
 const listPurchaseOrders = `-- name: ListPurchaseOrders :many
-SELECT po.po_id, po.po_number, po.supplier_id, po.order_date, po.expected_delivery_date, po.status, po.total_amount, po.notes, po.created_by, po.created_at, s.name as supplier_name
+SELECT po.po_id, po.po_number, po.supplier_id, po.order_date, po.expected_delivery_date, po.status, po.total_amount, po.notes, po.created_by, po.created_at, po.currency_code, po.submitted_at, po.updated_at, po.warehouse_id, s.name as supplier_name
 FROM purchase_orders po
 LEFT JOIN suppliers s ON po.supplier_id = s.supplier_id
 ORDER BY po.order_date DESC
@@ -223,6 +333,10 @@ type ListPurchaseOrdersRow struct {
 	Notes                sql.NullString      `json:"notes"`
 	CreatedBy            sql.NullInt32       `json:"created_by"`
 	CreatedAt            time.Time           `json:"created_at"`
+	CurrencyCode         sql.NullString      `json:"currency_code"`
+	SubmittedAt          sql.NullTime        `json:"submitted_at"`
+	UpdatedAt            time.Time           `json:"updated_at"`
+	WarehouseID          sql.NullInt32       `json:"warehouse_id"`
 	SupplierName         sql.NullString      `json:"supplier_name"`
 }
 
@@ -246,6 +360,10 @@ func (q *Queries) ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrders
 			&i.Notes,
 			&i.CreatedBy,
 			&i.CreatedAt,
+			&i.CurrencyCode,
+			&i.SubmittedAt,
+			&i.UpdatedAt,
+			&i.WarehouseID,
 			&i.SupplierName,
 		); err != nil {
 			return nil, err
@@ -262,7 +380,7 @@ func (q *Queries) ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrders
 }
 
 const listPurchaseOrdersByStatus = `-- name: ListPurchaseOrdersByStatus :many
-SELECT po.po_id, po.po_number, po.supplier_id, po.order_date, po.expected_delivery_date, po.status, po.total_amount, po.notes, po.created_by, po.created_at, s.name as supplier_name
+SELECT po.po_id, po.po_number, po.supplier_id, po.order_date, po.expected_delivery_date, po.status, po.total_amount, po.notes, po.created_by, po.created_at, po.currency_code, po.submitted_at, po.updated_at, po.warehouse_id, s.name as supplier_name
 FROM purchase_orders po
 LEFT JOIN suppliers s ON po.supplier_id = s.supplier_id
 WHERE po.status = $1
@@ -287,6 +405,10 @@ type ListPurchaseOrdersByStatusRow struct {
 	Notes                sql.NullString      `json:"notes"`
 	CreatedBy            sql.NullInt32       `json:"created_by"`
 	CreatedAt            time.Time           `json:"created_at"`
+	CurrencyCode         sql.NullString      `json:"currency_code"`
+	SubmittedAt          sql.NullTime        `json:"submitted_at"`
+	UpdatedAt            time.Time           `json:"updated_at"`
+	WarehouseID          sql.NullInt32       `json:"warehouse_id"`
 	SupplierName         sql.NullString      `json:"supplier_name"`
 }
 
@@ -310,6 +432,10 @@ func (q *Queries) ListPurchaseOrdersByStatus(ctx context.Context, arg ListPurcha
 			&i.Notes,
 			&i.CreatedBy,
 			&i.CreatedAt,
+			&i.CurrencyCode,
+			&i.SubmittedAt,
+			&i.UpdatedAt,
+			&i.WarehouseID,
 			&i.SupplierName,
 		); err != nil {
 			return nil, err
@@ -325,13 +451,85 @@ func (q *Queries) ListPurchaseOrdersByStatus(ctx context.Context, arg ListPurcha
 	return items, nil
 }
 
+const recalculatePurchaseOrderTotal = `-- name: RecalculatePurchaseOrderTotal :one
+UPDATE purchase_orders
+SET
+    total_amount = (
+        SELECT COALESCE(SUM(poi.total_price), 0)
+        FROM purchase_order_items poi
+        WHERE poi.po_id = purchase_orders.po_id
+    ),
+    updated_at = CURRENT_TIMESTAMP
+WHERE po_id = $1
+RETURNING po_id, po_number, supplier_id, order_date, expected_delivery_date, status, total_amount, notes, created_by, created_at, currency_code, submitted_at, updated_at, warehouse_id
+`
+
+func (q *Queries) RecalculatePurchaseOrderTotal(ctx context.Context, poID int32) (PurchaseOrder, error) {
+	row := q.db.QueryRowContext(ctx, recalculatePurchaseOrderTotal, poID)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.PoID,
+		&i.PoNumber,
+		&i.SupplierID,
+		&i.OrderDate,
+		&i.ExpectedDeliveryDate,
+		&i.Status,
+		&i.TotalAmount,
+		&i.Notes,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.CurrencyCode,
+		&i.SubmittedAt,
+		&i.UpdatedAt,
+		&i.WarehouseID,
+	)
+	return i, err
+}
+
+const submitPurchaseOrder = `-- name: SubmitPurchaseOrder :one
+UPDATE purchase_orders
+SET
+    status = 'pending',
+    created_by = COALESCE(created_by, $1),
+    submitted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE po_id = $2
+RETURNING po_id, po_number, supplier_id, order_date, expected_delivery_date, status, total_amount, notes, created_by, created_at, currency_code, submitted_at, updated_at, warehouse_id
+`
+
+type SubmitPurchaseOrderParams struct {
+	SubmittedBy sql.NullInt32 `json:"submitted_by"`
+	PoID        int32         `json:"po_id"`
+}
+
+func (q *Queries) SubmitPurchaseOrder(ctx context.Context, arg SubmitPurchaseOrderParams) (PurchaseOrder, error) {
+	row := q.db.QueryRowContext(ctx, submitPurchaseOrder, arg.SubmittedBy, arg.PoID)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.PoID,
+		&i.PoNumber,
+		&i.SupplierID,
+		&i.OrderDate,
+		&i.ExpectedDeliveryDate,
+		&i.Status,
+		&i.TotalAmount,
+		&i.Notes,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.CurrencyCode,
+		&i.SubmittedAt,
+		&i.UpdatedAt,
+		&i.WarehouseID,
+	)
+	return i, err
+}
+
 const updatePurchaseOrderItemReceivedQty = `-- name: UpdatePurchaseOrderItemReceivedQty :one
 UPDATE purchase_order_items 
 SET 
-    quantity_received = quantity_received + $2,
-    updated_at = CURRENT_TIMESTAMP
+    quantity_received = quantity_received + $2
 WHERE po_item_id = $1
-RETURNING po_item_id, po_id, product_id, quantity_ordered, quantity_received, unit_price, total_price
+RETURNING po_item_id, po_id, product_id, quantity_ordered, quantity_received, unit_price, total_price, uom_id
 `
 
 type UpdatePurchaseOrderItemReceivedQtyParams struct {
@@ -350,28 +548,48 @@ func (q *Queries) UpdatePurchaseOrderItemReceivedQty(ctx context.Context, arg Up
 		&i.QuantityReceived,
 		&i.UnitPrice,
 		&i.TotalPrice,
+		&i.UomID,
 	)
 	return i, err
 }
 
+const updatePurchaseOrderReceiptStatus = `-- name: UpdatePurchaseOrderReceiptStatus :exec
+UPDATE purchase_orders
+SET
+    status = CASE
+        WHEN EXISTS (
+            SELECT 1 FROM purchase_order_items poi
+            WHERE poi.po_id = purchase_orders.po_id
+              AND poi.quantity_received < poi.quantity_ordered
+        ) THEN 'partially_received'::purchase_order_status
+        ELSE 'completed'::purchase_order_status
+    END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE po_id = $1
+  AND status IN ('approved', 'partially_received')
+`
+
+func (q *Queries) UpdatePurchaseOrderReceiptStatus(ctx context.Context, poID int32) error {
+	_, err := q.db.ExecContext(ctx, updatePurchaseOrderReceiptStatus, poID)
+	return err
+}
+
 const updatePurchaseOrderStatus = `-- name: UpdatePurchaseOrderStatus :one
 UPDATE purchase_orders 
 SET 
     status = $2,
-    total_amount = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE po_id = $1
-RETURNING po_id, po_number, supplier_id, order_date, expected_delivery_date, status, total_amount, notes, created_by, created_at
+RETURNING po_id, po_number, supplier_id, order_date, expected_delivery_date, status, total_amount, notes, created_by, created_at, currency_code, submitted_at, updated_at, warehouse_id
 `
 
 type UpdatePurchaseOrderStatusParams struct {
-	PoID        int32               `json:"po_id"`
-	Status      PurchaseOrderStatus `json:"status"`
-	TotalAmount decimal.Decimal     `json:"total_amount"`
+	PoID   int32               `json:"po_id"`
+	Status PurchaseOrderStatus `json:"status"`
 }
 
 func (q *Queries) UpdatePurchaseOrderStatus(ctx context.Context, arg UpdatePurchaseOrderStatusParams) (PurchaseOrder, error) {
-	row := q.db.QueryRowContext(ctx, updatePurchaseOrderStatus, arg.PoID, arg.Status, arg.TotalAmount)
+	row := q.db.QueryRowContext(ctx, updatePurchaseOrderStatus, arg.PoID, arg.Status)
 	var i PurchaseOrder
 	err := row.Scan(
 		&i.PoID,
@@ -384,6 +602,10 @@ func (q *Queries) UpdatePurchaseOrderStatus(ctx context.Context, arg UpdatePurch
 		&i.Notes,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.CurrencyCode,
+		&i.SubmittedAt,
+		&i.UpdatedAt,
+		&i.WarehouseID,
 	)
 	return i, err
 }
@@ -0,0 +1,277 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/molu/stock-management-system/internal/purchasing"
+	"github.com/molu/stock-management-system/internal/putaway"
+	"github.com/molu/stock-management-system/internal/webhook"
+)
+
+var (
+	ErrLocationInactive    = errors.New("location is not active")
+	ErrPutawayReference    = errors.New("putaway can only reference a purchase order line")
+	ErrPutawayWrongProduct = errors.New("purchase order line is for another product")
+)
+
+// PutawayLimits returns the configured limits of the location.
+func (l Location) PutawayLimits() putaway.Limits {
+	return putaway.Limits{
+		MaxUnits:  l.MaxCapacity.Int32,
+		MaxWeight: l.MaxWeight.Decimal.InexactFloat64(),
+		MaxVolume: l.MaxVolume.Decimal.InexactFloat64(),
+	}
+}
+
+// PutawayItem describes one unit of the product for capacity checks.
+func (p Product) PutawayItem(batchNumber string) putaway.Item {
+	volume, _ := putaway.ParseDimensions(p.Dimensions.String)
+	return putaway.Item{
+		ProductID:   p.ProductID,
+		BatchNumber: batchNumber,
+		UnitWeight:  p.Weight.InexactFloat64(),
+		UnitVolume:  volume,
+	}
+}
+
+// PutawayOccupancy sums the contents rows of one location into its current
+// usage.
+func PutawayOccupancy(rows []ListLocationContentsRow) (putaway.Usage, []putaway.Content) {
+	var usage putaway.Usage
+	contents := make([]putaway.Content, 0, len(rows))
+	for _, row := range rows {
+		volume, _ := putaway.ParseDimensions(row.Dimensions.String)
+		usage.Units += row.Quantity
+		usage.Weight += float64(row.Quantity) * row.UnitWeight
+		usage.Volume += float64(row.Quantity) * volume
+		contents = append(contents, putaway.Content{
+			ProductID:   row.ProductID,
+			BatchNumber: row.BatchNumber.String,
+			Quantity:    row.Quantity,
+		})
+	}
+	return usage, contents
+}
+
+// checkLocationCapacity locks the location and rejects adding quantity units
+// of the product when that would break the location's limits.
+func checkLocationCapacity(ctx context.Context, q *Queries, locationID int32, product Product, batchNumber string, quantity int32) (Location, error) {
+	loc, err := q.GetLocationForUpdate(ctx, locationID)
+	if err != nil {
+		return loc, err
+	}
+	if !loc.IsActive {
+		return loc, ErrLocationInactive
+	}
+
+	rows, err := q.ListContentsOfLocation(ctx, sql.NullInt32{Int32: locationID, Valid: true})
+	if err != nil {
+		return loc, err
+	}
+	contents := make([]ListLocationContentsRow, len(rows))
+	for i, row := range rows {
+		contents[i] = ListLocationContentsRow(row)
+	}
+
+	usage, _ := PutawayOccupancy(contents)
+	return loc, putaway.Check(loc.PutawayLimits(), usage, product.PutawayItem(batchNumber), quantity)
+}
+
+type PutawayTxParams struct {
+	ProductID         int32
+	LocationID        int32
+	Quantity          int32
+	BatchNumber       sql.NullString
+	SerialNumber      sql.NullString
+	ExpiryDate        sql.NullTime
+	ManufacturingDate sql.NullTime
+	ReferenceID       sql.NullInt32
+	ReferenceTable    sql.NullString
+	Notes             sql.NullString
+	CreatedBy         sql.NullInt32
+	// MovementType defaults to a purchase receipt.
+	MovementType MovementType
+}
+
+type PutawayTxResult struct {
+	Inventory Inventory     `json:"inventory"`
+	Movement  StockMovement `json:"movement"`
+	Cost      *CostEntry    `json:"cost,omitempty"`
+}
+
+// PutawayTx stores received goods in a location. The location's capacity is
+// checked under a row lock, the stock is merged into the matching inventory
+// row (same product, batch and serial) or a new one, and a purchase receipt
+// movement is posted and costed. A reference must be a line of the same
+// product on a receivable purchase order, since the receipt is costed from it.
+func (store *SQLStore) PutawayTx(ctx context.Context, arg PutawayTxParams) (PutawayTxResult, error) {
+	var result PutawayTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := checkPutawayReference(ctx, q, arg); err != nil {
+			return err
+		}
+
+		var err error
+		result, err = putawayStock(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// checkPutawayReference checks that the purchase order line a putaway
+// references is for the product being stored and that its order, locked for
+// the rest of the transaction, can still be received.
+func checkPutawayReference(ctx context.Context, q *Queries, arg PutawayTxParams) error {
+	if !arg.ReferenceID.Valid && !arg.ReferenceTable.Valid {
+		return nil
+	}
+	if !arg.ReferenceID.Valid || arg.ReferenceTable.String != "purchase_order_items" {
+		return ErrPutawayReference
+	}
+
+	item, err := q.GetPurchaseOrderItem(ctx, arg.ReferenceID.Int32)
+	if err != nil {
+		return err
+	}
+	if item.ProductID != arg.ProductID {
+		return ErrPutawayWrongProduct
+	}
+
+	po, err := q.GetPurchaseOrderForUpdate(ctx, item.PoID)
+	if err != nil {
+		return err
+	}
+	if !purchasing.Receivable(purchasing.Status(po.Status)) {
+		return ErrPurchaseOrderNotOpen
+	}
+	return nil
+}
+
+// putawayStock does the work of PutawayTx inside an open transaction.
+func putawayStock(ctx context.Context, q *Queries, arg PutawayTxParams) (PutawayTxResult, error) {
+	var result PutawayTxResult
+
+	product, err := q.GetProduct(ctx, arg.ProductID)
+	if err != nil {
+		return result, err
+	}
+
+	loc, err := checkLocationCapacity(ctx, q, arg.LocationID, product, arg.BatchNumber.String, arg.Quantity)
+	if err != nil {
+		return result, err
+	}
+
+	locationID := sql.NullInt32{Int32: loc.LocationID, Valid: true}
+	var before int32
+	inv, err := q.GetInventoryAtLocationForUpdate(ctx, GetInventoryAtLocationForUpdateParams{
+		ProductID:    arg.ProductID,
+		LocationID:   locationID,
+		BatchNumber:  arg.BatchNumber,
+		SerialNumber: arg.SerialNumber,
+	})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		result.Inventory, err = q.CreateInventory(ctx, CreateInventoryParams{
+			ProductID:         arg.ProductID,
+			WarehouseID:       loc.WarehouseID,
+			LocationID:        locationID,
+			Quantity:          arg.Quantity,
+			BatchNumber:       arg.BatchNumber,
+			ExpiryDate:        arg.ExpiryDate,
+			ManufacturingDate: arg.ManufacturingDate,
+			SerialNumber:      arg.SerialNumber,
+		})
+	case err == nil:
+		before = inv.Quantity
+		result.Inventory, err = q.AddInventoryQuantity(ctx, AddInventoryQuantityParams{
+			InventoryID: inv.InventoryID,
+			Quantity:    arg.Quantity,
+		})
+	}
+	if err != nil {
+		return result, err
+	}
+
+	movementType := arg.MovementType
+	if movementType == "" {
+		movementType = MovementTypePurchaseReceipt
+	}
+	result.Movement, err = postStockMovement(ctx, q, CreateStockMovementParams{
+		ProductID:      arg.ProductID,
+		WarehouseID:    loc.WarehouseID,
+		LocationID:     locationID,
+		MovementType:   movementType,
+		QuantityBefore: sql.NullInt32{Int32: before, Valid: true},
+		QuantityChange: arg.Quantity,
+		QuantityAfter:  sql.NullInt32{Int32: result.Inventory.Quantity, Valid: true},
+		ReferenceID:    arg.ReferenceID,
+		ReferenceTable: arg.ReferenceTable,
+		Notes:          arg.Notes,
+		CreatedBy:      arg.CreatedBy,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Cost, err = postMovementCost(ctx, q, result.Movement)
+	return result, err
+}
+
+type SetInventoryQuantityTxParams struct {
+	InventoryID      int32
+	Quantity         int32
+	ReservedQuantity int32
+	IfMatch          string
+}
+
+// SetInventoryQuantityTx overwrites the quantities of an inventory row,
+// rejecting increases that would overfill its location and changes to a row
+// that no longer matches IfMatch. The correction records no
+// movement, so its inventory change is published here.
+func (store *SQLStore) SetInventoryQuantityTx(ctx context.Context, arg SetInventoryQuantityTxParams) (Inventory, error) {
+	var result Inventory
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		inv, err := q.GetInventoryForUpdate(ctx, arg.InventoryID)
+		if err != nil {
+			return err
+		}
+		if err := checkIfMatch(arg.IfMatch, inv.ETag()); err != nil {
+			return err
+		}
+
+		if inv.LocationID.Valid && arg.Quantity > inv.Quantity {
+			product, err := q.GetProduct(ctx, inv.ProductID)
+			if err != nil {
+				return err
+			}
+			if _, err := checkLocationCapacity(ctx, q, inv.LocationID.Int32, product, inv.BatchNumber.String, arg.Quantity-inv.Quantity); err != nil {
+				return err
+			}
+		}
+
+		result, err = q.UpdateInventoryQuantity(ctx, UpdateInventoryQuantityParams{
+			InventoryID:      arg.InventoryID,
+			Quantity:         arg.Quantity,
+			ReservedQuantity: arg.ReservedQuantity,
+		})
+		if err != nil {
+			return err
+		}
+
+		return publishInventoryChange(ctx, q, webhook.InventoryChanged{
+			ProductID:      result.ProductID,
+			WarehouseID:    result.WarehouseID,
+			LocationID:     result.LocationID.Int32,
+			InventoryID:    result.InventoryID,
+			QuantityChange: result.Quantity - inv.Quantity,
+			QuantityAfter:  &result.Quantity,
+		}, true)
+	})
+
+	return result, err
+}
@@ -7,16 +7,68 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 type Querier interface {
 	ActivateSupplier(ctx context.Context, supplierID int32) error
+	AddImportBatchRows(ctx context.Context, arg AddImportBatchRowsParams) (ImportBatch, error)
+	AddInventoryQuantity(ctx context.Context, arg AddInventoryQuantityParams) (Inventory, error)
+	AddWebhookSubscriptionEvent(ctx context.Context, arg AddWebhookSubscriptionEventParams) error
+	AddWorkOrderComponentConsumption(ctx context.Context, arg AddWorkOrderComponentConsumptionParams) (WorkOrderComponent, error)
+	ApplyDueProductSupplierPrices(ctx context.Context) (int64, error)
 	ApproveStockAdjustment(ctx context.Context, arg ApproveStockAdjustmentParams) (StockAdjustment, error)
+	AssignPickingWave(ctx context.Context, arg AssignPickingWaveParams) (PickingWafe, error)
+	CancelFloorTask(ctx context.Context, taskID int32) (FloorTask, error)
+	CancelPickingWave(ctx context.Context, waveID int32) (PickingWafe, error)
+	CancelStaleFloorTasks(ctx context.Context, warehouseID int32) (int64, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]ClaimDueWebhookDeliveriesRow, error)
+	ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (IdempotencyKey, error)
+	ClaimNextFloorTask(ctx context.Context, arg ClaimNextFloorTaskParams) (FloorTask, error)
+	CloseCostLayersByProduct(ctx context.Context, productID int32) error
+	CloseFloorTask(ctx context.Context, arg CloseFloorTaskParams) (FloorTask, error)
+	CompletePickingWave(ctx context.Context, waveID int32) (PickingWafe, error)
+	CompleteWorkOrder(ctx context.Context, arg CompleteWorkOrderParams) (WorkOrder, error)
+	ConfirmPickingWaveItem(ctx context.Context, arg ConfirmPickingWaveItemParams) (PickingWaveItem, error)
+	ConsumeCostLayer(ctx context.Context, arg ConsumeCostLayerParams) error
+	CountCategoryChildren(ctx context.Context, parentCategoryID sql.NullInt32) (int64, error)
+	CountCategoryProducts(ctx context.Context, categoryID sql.NullInt32) (int64, error)
+	CountPendingPickingWaveItems(ctx context.Context, waveID int32) (int64, error)
+	CountPickingWavesByRoute(ctx context.Context, routeID sql.NullInt32) (int64, error)
+	CountPurchaseOrderItems(ctx context.Context, poID int32) (int64, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	CreateCategoryAttribute(ctx context.Context, arg CreateCategoryAttributeParams) (CategoryAttribute, error)
+	CreateCostEntry(ctx context.Context, arg CreateCostEntryParams) (CostEntry, error)
+	CreateCostLayer(ctx context.Context, arg CreateCostLayerParams) (CostLayer, error)
+	CreateCountFloorTasks(ctx context.Context, warehouseID int32) (int64, error)
+	CreateCurrency(ctx context.Context, arg CreateCurrencyParams) (Currency, error)
+	CreateFloorTask(ctx context.Context, arg CreateFloorTaskParams) (FloorTask, error)
+	CreateImportBatch(ctx context.Context, arg CreateImportBatchParams) (ImportBatch, error)
+	CreateInventory(ctx context.Context, arg CreateInventoryParams) (Inventory, error)
+	CreateKitAssembly(ctx context.Context, arg CreateKitAssemblyParams) (KitAssembly, error)
+	CreateKitAssemblyLine(ctx context.Context, arg CreateKitAssemblyLineParams) (KitAssemblyLine, error)
+	CreateLandedCost(ctx context.Context, arg CreateLandedCostParams) (LandedCost, error)
+	CreateLandedCostAllocation(ctx context.Context, arg CreateLandedCostAllocationParams) (LandedCostAllocation, error)
 	CreateLocation(ctx context.Context, arg CreateLocationParams) (Location, error)
+	CreateLocationHistory(ctx context.Context, arg CreateLocationHistoryParams) (LocationHistory, error)
+	CreateOpeningBalance(ctx context.Context, arg CreateOpeningBalanceParams) (OpeningBalance, error)
+	CreatePickFloorTasks(ctx context.Context, warehouseID int32) (int64, error)
+	CreatePickingRoute(ctx context.Context, arg CreatePickingRouteParams) (PickingRoute, error)
+	CreatePickingWave(ctx context.Context, arg CreatePickingWaveParams) (PickingWafe, error)
+	CreatePickingWaveItem(ctx context.Context, arg CreatePickingWaveItemParams) (PickingWaveItem, error)
+	CreatePoApprovalLevel(ctx context.Context, arg CreatePoApprovalLevelParams) (PoApprovalLevel, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	CreateProductIdentifier(ctx context.Context, arg CreateProductIdentifierParams) (ProductIdentifier, error)
+	CreateProductSupplier(ctx context.Context, arg CreateProductSupplierParams) (ProductSupplier, error)
+	CreateProductUom(ctx context.Context, arg CreateProductUomParams) (ProductUom, error)
+	CreateProductVariant(ctx context.Context, arg CreateProductVariantParams) (ProductVariant, error)
+	CreateProductVariantAxis(ctx context.Context, arg CreateProductVariantAxisParams) (ProductVariantAxis, error)
 	CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (PurchaseOrder, error)
+	CreatePurchaseOrderApproval(ctx context.Context, arg CreatePurchaseOrderApprovalParams) (PurchaseOrderApproval, error)
 	CreatePurchaseOrderItem(ctx context.Context, arg CreatePurchaseOrderItemParams) (PurchaseOrderItem, error)
+	CreatePurchaseOrderReceipt(ctx context.Context, arg CreatePurchaseOrderReceiptParams) (PurchaseOrderReceipt, error)
+	CreatePurchasePriceVariance(ctx context.Context, arg CreatePurchasePriceVarianceParams) (PurchasePriceVariance, error)
+	CreatePutawayRule(ctx context.Context, arg CreatePutawayRuleParams) (PutawayRule, error)
 	CreateStockAdjustment(ctx context.Context, arg CreateStockAdjustmentParams) (StockAdjustment, error)
 	CreateStockAdjustmentItem(ctx context.Context, arg CreateStockAdjustmentItemParams) (CreateStockAdjustmentItemRow, error)
 	CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) (StockMovement, error)
@@ -25,75 +77,273 @@ type Querier interface {
 	CreateStocktake(ctx context.Context, arg CreateStocktakeParams) (StockTake, error)
 	CreateStocktakeItem(ctx context.Context, arg CreateStocktakeItemParams) (StocktakeItem, error)
 	CreateSupplier(ctx context.Context, arg CreateSupplierParams) (Supplier, error)
+	CreateUnitOfMeasure(ctx context.Context, arg CreateUnitOfMeasureParams) (UnitsOfMeasure, error)
 	CreateWarehouse(ctx context.Context, arg CreateWarehouseParams) (Warehouse, error)
+	CreateWarehouseZone(ctx context.Context, arg CreateWarehouseZoneParams) (WarehouseZone, error)
+	CreateWebhookDeliveries(ctx context.Context, arg CreateWebhookDeliveriesParams) (int64, error)
+	CreateWebhookDeliveryAttempt(ctx context.Context, arg CreateWebhookDeliveryAttemptParams) (WebhookDeliveryAttempt, error)
+	CreateWebhookEvent(ctx context.Context, arg CreateWebhookEventParams) (WebhookEvent, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
+	CreateWorkOrder(ctx context.Context, arg CreateWorkOrderParams) (WorkOrder, error)
+	CreateWorkOrderComponent(ctx context.Context, arg CreateWorkOrderComponentParams) (WorkOrderComponent, error)
+	CreateWorkOrderConsumption(ctx context.Context, arg CreateWorkOrderConsumptionParams) (WorkOrderConsumption, error)
+	CreateWorkOrderReservation(ctx context.Context, arg CreateWorkOrderReservationParams) (WorkOrderReservation, error)
 	DeactivateLocation(ctx context.Context, locationID int32) error
+	DeactivateProductUom(ctx context.Context, productUomID int32) error
+	DeactivatePutawayRule(ctx context.Context, ruleID int32) error
 	DeactivateSupplier(ctx context.Context, supplierID int32) error
 	DeactivateWarehouse(ctx context.Context, warehouseID int32) error
+	DeactivateWebhookSubscription(ctx context.Context, subscriptionID int32) error
 	DeleteCategory(ctx context.Context, categoryID int32) error
+	DeleteCategoryAttribute(ctx context.Context, attributeID int32) error
+	DeleteCategoryAttributesByCategory(ctx context.Context, categoryID int32) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
+	DeleteKitComponent(ctx context.Context, arg DeleteKitComponentParams) (int64, error)
+	DeleteOldWebhookEvents(ctx context.Context, before time.Time) (int64, error)
+	DeleteProductSupplier(ctx context.Context, productSupplierID int32) error
+	DeleteProductSupplierPrices(ctx context.Context, productSupplierID int32) error
+	DeleteProductVariantAxes(ctx context.Context, parentProductID int32) error
+	DeleteWebhookSubscriptionEvents(ctx context.Context, subscriptionID int32) error
+	EnsureCostBalance(ctx context.Context, arg EnsureCostBalanceParams) error
+	FinishWebhookDeliveryAttempt(ctx context.Context, arg FinishWebhookDeliveryAttemptParams) (WebhookDelivery, error)
 	GetActiveStocktakes(ctx context.Context) ([]GetActiveStocktakesRow, error)
+	GetBaseCurrency(ctx context.Context) (Currency, error)
 	GetCategory(ctx context.Context, categoryID int32) (Category, error)
+	GetCategoryAttribute(ctx context.Context, attributeID int32) (CategoryAttribute, error)
 	GetCategoryByCode(ctx context.Context, categoryCode string) (Category, error)
+	GetCategoryPath(ctx context.Context, categoryID int32) ([]Category, error)
+	GetCostBalanceForUpdate(ctx context.Context, arg GetCostBalanceForUpdateParams) (CostBalance, error)
+	GetCurrency(ctx context.Context, currencyCode string) (Currency, error)
+	GetDeviceFloorTask(ctx context.Context, arg GetDeviceFloorTaskParams) (FloorTask, error)
+	GetExchangeRateOn(ctx context.Context, arg GetExchangeRateOnParams) (ExchangeRate, error)
+	GetFloorTask(ctx context.Context, taskID int32) (FloorTask, error)
+	GetFloorTaskDetail(ctx context.Context, taskID int32) (GetFloorTaskDetailRow, error)
+	GetFloorTaskForUpdate(ctx context.Context, taskID int32) (FloorTask, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetImportBatch(ctx context.Context, batchID int32) (ImportBatch, error)
 	GetInventory(ctx context.Context, inventoryID int32) (Inventory, error)
+	GetInventoryAtLocationForUpdate(ctx context.Context, arg GetInventoryAtLocationForUpdateParams) (Inventory, error)
 	GetInventoryByLocation(ctx context.Context, arg GetInventoryByLocationParams) (Inventory, error)
 	GetInventoryByProductWarehouse(ctx context.Context, arg GetInventoryByProductWarehouseParams) (Inventory, error)
+	GetInventoryForUpdate(ctx context.Context, inventoryID int32) (Inventory, error)
+	GetKitAssembly(ctx context.Context, assemblyID int32) (KitAssembly, error)
+	GetKitAssemblyLine(ctx context.Context, lineID int32) (KitAssemblyLine, error)
+	GetLandedCost(ctx context.Context, landedCostID int32) (LandedCost, error)
 	GetLocation(ctx context.Context, locationID int32) (Location, error)
 	GetLocationByCode(ctx context.Context, arg GetLocationByCodeParams) (Location, error)
+	GetLocationForUpdate(ctx context.Context, locationID int32) (Location, error)
+	GetOpeningBalance(ctx context.Context, openingBalanceID int32) (OpeningBalance, error)
+	GetPickList(ctx context.Context, waveID int32) ([]GetPickListRow, error)
+	GetPickerProductivity(ctx context.Context, arg GetPickerProductivityParams) ([]GetPickerProductivityRow, error)
+	GetPickingRoute(ctx context.Context, routeID int32) (PickingRoute, error)
+	GetPickingWave(ctx context.Context, waveID int32) (PickingWafe, error)
+	GetPickingWaveForUpdate(ctx context.Context, waveID int32) (PickingWafe, error)
+	GetPickingWaveItemForUpdate(ctx context.Context, waveItemID int32) (PickingWaveItem, error)
 	GetProduct(ctx context.Context, productID int32) (Product, error)
+	GetProductAttributes(ctx context.Context, productID int32) (ProductAttribute, error)
+	GetProductAvailability(ctx context.Context, productID int32) (GetProductAvailabilityRow, error)
+	GetProductByGTIN(ctx context.Context, barcode string) (Product, error)
 	GetProductBySKU(ctx context.Context, sku string) (Product, error)
+	GetProductForUpdate(ctx context.Context, productID int32) (Product, error)
+	GetProductIdentifierForUpdate(ctx context.Context, arg GetProductIdentifierForUpdateParams) (ProductIdentifier, error)
 	GetProductMovementHistory(ctx context.Context, arg GetProductMovementHistoryParams) ([]GetProductMovementHistoryRow, error)
+	GetProductSupplier(ctx context.Context, productSupplierID int32) (ProductSupplier, error)
+	GetProductSupplierByProduct(ctx context.Context, arg GetProductSupplierByProductParams) (ProductSupplier, error)
+	GetProductUomByGTIN(ctx context.Context, barcode string) (ProductUom, error)
+	GetProductUomFactor(ctx context.Context, arg GetProductUomFactorParams) (GetProductUomFactorRow, error)
+	GetProductVariant(ctx context.Context, productID int32) (ProductVariant, error)
+	GetProductVariantByOptions(ctx context.Context, arg GetProductVariantByOptionsParams) (ProductVariant, error)
 	GetPurchaseOrder(ctx context.Context, poID int32) (GetPurchaseOrderRow, error)
+	GetPurchaseOrderDocumentLines(ctx context.Context, poID int32) ([]GetPurchaseOrderDocumentLinesRow, error)
+	GetPurchaseOrderForUpdate(ctx context.Context, poID int32) (PurchaseOrder, error)
+	GetPurchaseOrderItem(ctx context.Context, poItemID int32) (PurchaseOrderItem, error)
+	GetPurchaseOrderItemCurrency(ctx context.Context, poItemID int32) (sql.NullString, error)
 	GetPurchaseOrderItems(ctx context.Context, poID int32) ([]GetPurchaseOrderItemsRow, error)
 	GetStockMovement(ctx context.Context, movementID int32) (StockMovement, error)
+	GetStockTransferForUpdate(ctx context.Context, transferID int32) (StockTransfer, error)
+	GetStockTransferItem(ctx context.Context, transferItemID int32) (StockTransferItem, error)
 	GetStocktake(ctx context.Context, stocktakeID int32) (GetStocktakeRow, error)
+	GetStocktakeItem(ctx context.Context, stocktakeItemID int32) (StocktakeItem, error)
 	GetStocktakeItems(ctx context.Context, stocktakeID int32) ([]GetStocktakeItemsRow, error)
 	GetStocktakeVariances(ctx context.Context, stocktakeID int32) ([]GetStocktakeVariancesRow, error)
 	GetSupplier(ctx context.Context, supplierID int32) (Supplier, error)
 	GetSupplierByCode(ctx context.Context, code string) (Supplier, error)
+	GetSupplierForUpdate(ctx context.Context, supplierID int32) (Supplier, error)
 	GetSupplierPerformance(ctx context.Context, supplierID int32) (GetSupplierPerformanceRow, error)
 	GetSupplierProducts(ctx context.Context, arg GetSupplierProductsParams) ([]Product, error)
+	GetUnitOfMeasure(ctx context.Context, uomID int32) (UnitsOfMeasure, error)
+	GetUser(ctx context.Context, userID int32) (User, error)
+	GetValuationAsOf(ctx context.Context, arg GetValuationAsOfParams) ([]GetValuationAsOfRow, error)
+	GetValuationByCategory(ctx context.Context, warehouseID sql.NullInt32) ([]GetValuationByCategoryRow, error)
+	GetValuationByWarehouse(ctx context.Context) ([]GetValuationByWarehouseRow, error)
 	GetWarehouse(ctx context.Context, warehouseID int32) (Warehouse, error)
 	GetWarehouseByCode(ctx context.Context, code string) (Warehouse, error)
+	GetWarehouseDepth(ctx context.Context, warehouseID int32) (float64, error)
+	GetWarehouseForUpdate(ctx context.Context, warehouseID int32) (Warehouse, error)
 	GetWarehouseInventorySummary(ctx context.Context) ([]GetWarehouseInventorySummaryRow, error)
+	GetWarehouseLetterhead(ctx context.Context, warehouseID int32) (WarehouseLetterhead, error)
+	GetWarehouseZone(ctx context.Context, zoneID int32) (WarehouseZone, error)
+	GetWarehouseZoneByCode(ctx context.Context, arg GetWarehouseZoneByCodeParams) (WarehouseZone, error)
+	GetWebhookDelivery(ctx context.Context, deliveryID int32) (WebhookDelivery, error)
+	GetWebhookEvent(ctx context.Context, eventID int32) (WebhookEvent, error)
+	GetWebhookSubscription(ctx context.Context, subscriptionID int32) (WebhookSubscription, error)
+	GetWorkOrder(ctx context.Context, workOrderID int32) (WorkOrder, error)
+	GetWorkOrderForUpdate(ctx context.Context, workOrderID int32) (WorkOrder, error)
+	ListActivePoApprovalLevels(ctx context.Context) ([]PoApprovalLevel, error)
 	ListActiveSuppliers(ctx context.Context) ([]Supplier, error)
 	ListAllSuppliers(ctx context.Context, arg ListAllSuppliersParams) ([]Supplier, error)
 	ListAllWarehouses(ctx context.Context) ([]Warehouse, error)
+	ListAvailableInventoryForUpdate(ctx context.Context, arg ListAvailableInventoryForUpdateParams) ([]Inventory, error)
 	ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error)
+	ListCategoryAttributes(ctx context.Context, categoryID int32) ([]CategoryAttribute, error)
+	ListCategoryDescendantIDs(ctx context.Context, categoryID int32) ([]int32, error)
+	ListCategoryTree(ctx context.Context, rootID sql.NullInt32) ([]ListCategoryTreeRow, error)
+	ListContentsOfLocation(ctx context.Context, locationID sql.NullInt32) ([]ListContentsOfLocationRow, error)
+	ListCostBalancesByProductForUpdate(ctx context.Context, productID int32) ([]CostBalance, error)
+	ListCostEntriesByMovement(ctx context.Context, movementID sql.NullInt32) ([]CostEntry, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
+	ListEffectiveCategoryAttributes(ctx context.Context, categoryID int32) ([]ListEffectiveCategoryAttributesRow, error)
+	ListExchangeRates(ctx context.Context, arg ListExchangeRatesParams) ([]ExchangeRate, error)
 	ListExpiringInventory(ctx context.Context) ([]ListExpiringInventoryRow, error)
+	ListFloorTasks(ctx context.Context, arg ListFloorTasksParams) ([]FloorTask, error)
+	ListImportBatches(ctx context.Context, arg ListImportBatchesParams) ([]ImportBatch, error)
 	ListInventoryByProduct(ctx context.Context, productID int32) ([]ListInventoryByProductRow, error)
+	ListInventoryByProductBatch(ctx context.Context, arg ListInventoryByProductBatchParams) ([]Inventory, error)
 	ListInventoryByWarehouse(ctx context.Context, arg ListInventoryByWarehouseParams) ([]ListInventoryByWarehouseRow, error)
+	ListKitAssemblies(ctx context.Context, arg ListKitAssembliesParams) ([]KitAssembly, error)
+	ListKitAssemblyLines(ctx context.Context, assemblyID int32) ([]KitAssemblyLine, error)
+	ListKitComponentAvailability(ctx context.Context, arg ListKitComponentAvailabilityParams) ([]ListKitComponentAvailabilityRow, error)
+	ListKitComponentIDsDeep(ctx context.Context, kitProductID int32) ([]int32, error)
+	ListKitComponents(ctx context.Context, kitProductID int32) ([]ListKitComponentsRow, error)
+	ListKitsUsingComponent(ctx context.Context, componentProductID int32) ([]ListKitsUsingComponentRow, error)
+	ListLandedCostAllocations(ctx context.Context, landedCostID int32) ([]ListLandedCostAllocationsRow, error)
+	ListLandedCostsByPurchaseOrder(ctx context.Context, poID int32) ([]LandedCost, error)
+	ListLocationContents(ctx context.Context, warehouseID int32) ([]ListLocationContentsRow, error)
+	ListLocationHistoryByIdentifier(ctx context.Context, identifierID int32) ([]LocationHistory, error)
 	ListLocationsByWarehouse(ctx context.Context, warehouseID int32) ([]Location, error)
+	ListLocationsByZone(ctx context.Context, zoneID sql.NullInt32) ([]Location, error)
+	ListOpenCostLayers(ctx context.Context, arg ListOpenCostLayersParams) ([]CostLayer, error)
+	ListOpenCostLayersForUpdate(ctx context.Context, arg ListOpenCostLayersForUpdateParams) ([]CostLayer, error)
+	ListOpenPickingWaves(ctx context.Context, warehouseID int32) ([]PickingWafe, error)
+	ListOpenWorkOrderReservationsForUpdate(ctx context.Context, woComponentID int32) ([]WorkOrderReservation, error)
+	ListOpeningBalancesByBatch(ctx context.Context, batchID int32) ([]OpeningBalance, error)
+	ListPickingRoutesByWarehouse(ctx context.Context, warehouseID int32) ([]PickingRoute, error)
+	ListPickingWavesByWarehouse(ctx context.Context, arg ListPickingWavesByWarehouseParams) ([]PickingWafe, error)
+	ListPoApprovalLevels(ctx context.Context) ([]PoApprovalLevel, error)
+	ListProductIdentifiersByValue(ctx context.Context, arg ListProductIdentifiersByValueParams) ([]ProductIdentifier, error)
+	ListProductSupplierPrices(ctx context.Context, productSupplierID int32) ([]ListProductSupplierPricesRow, error)
+	ListProductSuppliersByProduct(ctx context.Context, productID int32) ([]ListProductSuppliersByProductRow, error)
+	ListProductSuppliersForUpdate(ctx context.Context, productID int32) ([]ProductSupplier, error)
+	ListProductUoms(ctx context.Context, productID int32) ([]ListProductUomsRow, error)
+	ListProductVariantAxes(ctx context.Context, parentProductID int32) ([]ProductVariantAxis, error)
+	ListProductVariants(ctx context.Context, parentProductID int32) ([]ListProductVariantsRow, error)
 	ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error)
 	ListProductsBelowReorderPoint(ctx context.Context) ([]ListProductsBelowReorderPointRow, error)
+	ListProductsByAttributes(ctx context.Context, arg ListProductsByAttributesParams) ([]Product, error)
 	ListProductsByCategory(ctx context.Context, arg ListProductsByCategoryParams) ([]Product, error)
+	ListProductsInCategoryTree(ctx context.Context, arg ListProductsInCategoryTreeParams) ([]Product, error)
+	ListProfitClassifications(ctx context.Context) ([]AbcClassification, error)
+	ListPurchaseOrderApprovals(ctx context.Context, poID int32) ([]ListPurchaseOrderApprovalsRow, error)
+	ListPurchaseOrderItemReceipts(ctx context.Context, referenceID sql.NullInt32) ([]ListPurchaseOrderItemReceiptsRow, error)
 	ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrdersParams) ([]ListPurchaseOrdersRow, error)
 	ListPurchaseOrdersByStatus(ctx context.Context, arg ListPurchaseOrdersByStatusParams) ([]ListPurchaseOrdersByStatusRow, error)
+	ListPurchasePriceVariances(ctx context.Context, arg ListPurchasePriceVariancesParams) ([]ListPurchasePriceVariancesRow, error)
+	ListPutawayRulesByWarehouse(ctx context.Context, warehouseID int32) ([]PutawayRule, error)
+	ListPutawayZonesForCategory(ctx context.Context, arg ListPutawayZonesForCategoryParams) ([]ListPutawayZonesForCategoryRow, error)
+	ListReceivedPurchaseOrderLines(ctx context.Context, poID int32) ([]ListReceivedPurchaseOrderLinesRow, error)
 	ListRootCategories(ctx context.Context) ([]Category, error)
+	ListSalesMarginLines(ctx context.Context, arg ListSalesMarginLinesParams) ([]ListSalesMarginLinesRow, error)
 	ListStockMovementsByProduct(ctx context.Context, arg ListStockMovementsByProductParams) ([]ListStockMovementsByProductRow, error)
 	ListStockMovementsByType(ctx context.Context, arg ListStockMovementsByTypeParams) ([]ListStockMovementsByTypeRow, error)
 	ListStockMovementsByWarehouse(ctx context.Context, arg ListStockMovementsByWarehouseParams) ([]ListStockMovementsByWarehouseRow, error)
+	ListStockTransferItems(ctx context.Context, transferID int32) ([]StockTransferItem, error)
 	ListStocktakes(ctx context.Context, arg ListStocktakesParams) ([]ListStocktakesRow, error)
 	ListStocktakesByWarehouse(ctx context.Context, warehouseID int32) ([]StockTake, error)
 	ListSubCategories(ctx context.Context, parentCategoryID sql.NullInt32) ([]Category, error)
+	ListSubmissionApprovals(ctx context.Context, poID int32) ([]PurchaseOrderApproval, error)
+	ListSupplierCatalogue(ctx context.Context, arg ListSupplierCatalogueParams) ([]ListSupplierCatalogueRow, error)
+	ListSupplierScorecardLines(ctx context.Context, arg ListSupplierScorecardLinesParams) ([]ListSupplierScorecardLinesRow, error)
 	ListSuppliers(ctx context.Context, arg ListSuppliersParams) ([]Supplier, error)
+	ListUnitsOfMeasure(ctx context.Context) ([]UnitsOfMeasure, error)
+	ListUnwavedReservations(ctx context.Context, arg ListUnwavedReservationsParams) ([]ListUnwavedReservationsRow, error)
+	ListWarehouseZones(ctx context.Context, warehouseID int32) ([]WarehouseZone, error)
 	ListWarehouses(ctx context.Context) ([]Warehouse, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]ListWebhookDeliveriesRow, error)
+	ListWebhookDeliveryAttempts(ctx context.Context, deliveryID int32) ([]WebhookDeliveryAttempt, error)
+	ListWebhookSubscriptionEvents(ctx context.Context, subscriptionID int32) ([]string, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	ListWorkOrderComponents(ctx context.Context, workOrderID int32) ([]WorkOrderComponent, error)
+	ListWorkOrderConsumptions(ctx context.Context, workOrderID int32) ([]WorkOrderConsumption, error)
+	ListWorkOrderReservations(ctx context.Context, workOrderID int32) ([]WorkOrderReservation, error)
+	ListWorkOrders(ctx context.Context, arg ListWorkOrdersParams) ([]WorkOrder, error)
+	LockCategoryTree(ctx context.Context) error
+	LockKitComponents(ctx context.Context) error
+	LockWarehousePicking(ctx context.Context, warehouseID int32) error
+	MoveCategory(ctx context.Context, arg MoveCategoryParams) (Category, error)
+	NotifyStockEvent(ctx context.Context, arg NotifyStockEventParams) error
+	PickInventory(ctx context.Context, arg PickInventoryParams) (Inventory, error)
+	RaisePurchaseOrderItemCostLayers(ctx context.Context, arg RaisePurchaseOrderItemCostLayersParams) (int32, error)
+	ReassignCategoryChildren(ctx context.Context, arg ReassignCategoryChildrenParams) (int64, error)
+	ReassignCategoryProducts(ctx context.Context, arg ReassignCategoryProductsParams) (int64, error)
+	RecalculatePurchaseOrderTotal(ctx context.Context, poID int32) (PurchaseOrder, error)
+	RedeliverWebhookDelivery(ctx context.Context, deliveryID int32) (WebhookDelivery, error)
+	ReduceWorkOrderReservation(ctx context.Context, arg ReduceWorkOrderReservationParams) (WorkOrderReservation, error)
+	ReleaseFloorTask(ctx context.Context, arg ReleaseFloorTaskParams) (FloorTask, error)
 	ReleaseInventoryReservation(ctx context.Context, arg ReleaseInventoryReservationParams) (Inventory, error)
+	RemoveInventoryQuantity(ctx context.Context, arg RemoveInventoryQuantityParams) (Inventory, error)
 	ReserveInventory(ctx context.Context, arg ReserveInventoryParams) (Inventory, error)
+	SaveIdempotencyResponse(ctx context.Context, arg SaveIdempotencyResponseParams) error
+	SearchProducts(ctx context.Context, arg SearchProductsParams) ([]SearchProductsRow, error)
 	SearchSuppliers(ctx context.Context, arg SearchSuppliersParams) ([]Supplier, error)
+	SetInventoryLocation(ctx context.Context, arg SetInventoryLocationParams) (Inventory, error)
+	SetKitAssemblyCost(ctx context.Context, arg SetKitAssemblyCostParams) (KitAssembly, error)
+	SetPickingWaveItemSequence(ctx context.Context, arg SetPickingWaveItemSequenceParams) error
+	SetPickingWaveNumber(ctx context.Context, arg SetPickingWaveNumberParams) (PickingWafe, error)
+	SetPickingWaveRoute(ctx context.Context, arg SetPickingWaveRouteParams) (PickingWafe, error)
+	SetProductCosting(ctx context.Context, arg SetProductCostingParams) (Product, error)
+	SetProductPrimarySupplier(ctx context.Context, arg SetProductPrimarySupplierParams) error
+	SetProductSupplierPriority(ctx context.Context, arg SetProductSupplierPriorityParams) error
+	SetProductSupplierPurchaseUom(ctx context.Context, arg SetProductSupplierPurchaseUomParams) (int32, error)
+	SetSimilarityThreshold(ctx context.Context, threshold float32) error
+	SetWorkOrderNumber(ctx context.Context, arg SetWorkOrderNumberParams) (WorkOrder, error)
+	SetWorkOrderStatus(ctx context.Context, arg SetWorkOrderStatusParams) (WorkOrder, error)
 	SoftDeleteProduct(ctx context.Context, productID int32) error
+	StartPickingWave(ctx context.Context, waveID int32) (PickingWafe, error)
+	SubmitPurchaseOrder(ctx context.Context, arg SubmitPurchaseOrderParams) (PurchaseOrder, error)
+	TraceComponentLot(ctx context.Context, arg TraceComponentLotParams) ([]TraceComponentLotRow, error)
+	TraceWorkOrderLot(ctx context.Context, arg TraceWorkOrderLotParams) ([]TraceWorkOrderLotRow, error)
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
+	UpdateCategoryAttribute(ctx context.Context, arg UpdateCategoryAttributeParams) (CategoryAttribute, error)
+	UpdateCostBalance(ctx context.Context, arg UpdateCostBalanceParams) (CostBalance, error)
 	UpdateInventoryQuantity(ctx context.Context, arg UpdateInventoryQuantityParams) (Inventory, error)
 	UpdateInventoryStatus(ctx context.Context, arg UpdateInventoryStatusParams) (Inventory, error)
 	UpdateLastReorderDate(ctx context.Context, productID int32) error
 	UpdateLocation(ctx context.Context, arg UpdateLocationParams) (Location, error)
+	UpdatePickingRoute(ctx context.Context, arg UpdatePickingRouteParams) (PickingRoute, error)
+	UpdatePickingWaveTotalItems(ctx context.Context, arg UpdatePickingWaveTotalItemsParams) (PickingWafe, error)
+	UpdatePoApprovalLevel(ctx context.Context, arg UpdatePoApprovalLevelParams) (PoApprovalLevel, error)
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error)
+	UpdateProductIdentifierLocation(ctx context.Context, arg UpdateProductIdentifierLocationParams) (ProductIdentifier, error)
+	UpdateProductSupplier(ctx context.Context, arg UpdateProductSupplierParams) (ProductSupplier, error)
+	UpdateProductSupplierRating(ctx context.Context, arg UpdateProductSupplierRatingParams) error
 	UpdatePurchaseOrderItemReceivedQty(ctx context.Context, arg UpdatePurchaseOrderItemReceivedQtyParams) (PurchaseOrderItem, error)
+	UpdatePurchaseOrderReceiptStatus(ctx context.Context, poID int32) error
 	UpdatePurchaseOrderStatus(ctx context.Context, arg UpdatePurchaseOrderStatusParams) (PurchaseOrder, error)
 	UpdateStockTransferItemQuantities(ctx context.Context, arg UpdateStockTransferItemQuantitiesParams) (StockTransferItem, error)
 	UpdateStockTransferStatus(ctx context.Context, arg UpdateStockTransferStatusParams) (StockTransfer, error)
 	UpdateStocktakeItemCount(ctx context.Context, arg UpdateStocktakeItemCountParams) (StocktakeItem, error)
 	UpdateStocktakeStatus(ctx context.Context, arg UpdateStocktakeStatusParams) (StockTake, error)
 	UpdateSupplier(ctx context.Context, arg UpdateSupplierParams) (Supplier, error)
+	UpdateSupplierRating(ctx context.Context, arg UpdateSupplierRatingParams) error
 	UpdateWarehouse(ctx context.Context, arg UpdateWarehouseParams) (Warehouse, error)
+	UpdateWarehouseZone(ctx context.Context, arg UpdateWarehouseZoneParams) (WarehouseZone, error)
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error)
+	UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) (ExchangeRate, error)
+	UpsertKitComponent(ctx context.Context, arg UpsertKitComponentParams) (KitComponent, error)
+	UpsertProductAttributes(ctx context.Context, arg UpsertProductAttributesParams) (ProductAttribute, error)
+	UpsertProductSupplierPrice(ctx context.Context, arg UpsertProductSupplierPriceParams) (ProductSupplierPrice, error)
+	UpsertWarehouseLetterhead(ctx context.Context, arg UpsertWarehouseLetterheadParams) (WarehouseLetterhead, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,30 @@
+package db
+
+import "context"
+
+type SearchProductsTxParams struct {
+	SearchProductsParams
+	// MinSimilarity is the trigram similarity, between 0 and 1, a name or
+	// SKU needs to match a misspelt search.
+	MinSimilarity float32
+}
+
+// SearchProductsTx runs SearchProducts with the pg_trgm thresholds set to
+// MinSimilarity for this transaction only. The query matches with the % and
+// <% operators, which read those settings, so the trigram indexes on name and
+// SKU can serve it where the similarity functions could not.
+func (store *SQLStore) SearchProductsTx(ctx context.Context, arg SearchProductsTxParams) ([]SearchProductsRow, error) {
+	var result []SearchProductsRow
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.SetSimilarityThreshold(ctx, arg.MinSimilarity); err != nil {
+			return err
+		}
+
+		var err error
+		result, err = q.SearchProducts(ctx, arg.SearchProductsParams)
+		return err
+	})
+
+	return result, err
+}
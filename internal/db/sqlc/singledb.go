@@ -2,11 +2,62 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 )
 
 type SingleDb interface {
 	Querier
+	CreatePickingWaveTx(ctx context.Context, arg CreatePickingWaveTxParams) (CreatePickingWaveTxResult, error)
+	ConfirmPickTx(ctx context.Context, arg ConfirmPickTxParams) (ConfirmPickTxResult, error)
+	SavePickPathTx(ctx context.Context, arg SavePickPathTxParams) (SavePickPathTxResult, error)
+	PutawayTx(ctx context.Context, arg PutawayTxParams) (PutawayTxResult, error)
+	SetInventoryQuantityTx(ctx context.Context, arg SetInventoryQuantityTxParams) (Inventory, error)
+	MoveStockTx(ctx context.Context, arg MoveStockTxParams) (MoveStockTxResult, error)
+	CreateStockMovementTx(ctx context.Context, arg CreateStockMovementParams) (CreateStockMovementTxResult, error)
+	SetProductCostingTx(ctx context.Context, arg SetProductCostingTxParams) (SetProductCostingTxResult, error)
+	CreateLandedCostTx(ctx context.Context, arg CreateLandedCostTxParams) (CreateLandedCostTxResult, error)
+	ChangePurchaseOrderStatusTx(ctx context.Context, arg ChangePurchaseOrderStatusTxParams) (PurchaseOrder, error)
+	DecidePurchaseOrderTx(ctx context.Context, arg DecidePurchaseOrderTxParams) (DecidePurchaseOrderTxResult, error)
+	CreatePurchaseOrderItemTx(ctx context.Context, arg CreatePurchaseOrderItemParams) (PurchaseOrderItem, error)
+	ReceivePurchaseOrderItemTx(ctx context.Context, arg ReceivePurchaseOrderItemTxParams) (PurchaseOrderItem, error)
+	ScoreSupplierTx(ctx context.Context, arg ScoreSupplierTxParams) (ScoreSupplierTxResult, error)
+	CreateProductSupplierTx(ctx context.Context, arg ProductSupplierTxParams) (ProductSupplier, error)
+	UpdateProductSupplierTx(ctx context.Context, arg ProductSupplierTxParams) (ProductSupplier, error)
+	DeleteProductSupplierTx(ctx context.Context, productSupplierID int32) error
+	ImportSupplierPriceListTx(ctx context.Context, arg ImportSupplierPriceListTxParams) (ImportSupplierPriceListTxResult, error)
+	ImportTx(ctx context.Context, arg ImportTxParams) (ImportTxResult, error)
+	MoveCategoryTx(ctx context.Context, arg MoveCategoryParams) (Category, error)
+	UpdateCategoryTx(ctx context.Context, arg UpdateCategoryParams) (Category, error)
+	DeleteCategoryTx(ctx context.Context, arg DeleteCategoryTxParams) (DeleteCategoryTxResult, error)
+	GenerateVariantsTx(ctx context.Context, arg GenerateVariantsTxParams) (GenerateVariantsTxResult, error)
+	SetKitComponentTx(ctx context.Context, arg SetKitComponentTxParams) (KitComponent, error)
+	KitAssemblyTx(ctx context.Context, arg KitAssemblyTxParams) (KitAssemblyTxResult, error)
+	CreateWorkOrderTx(ctx context.Context, arg CreateWorkOrderTxParams) (WorkOrderTxResult, error)
+	ReleaseWorkOrderTx(ctx context.Context, workOrderID int32) (WorkOrderTxResult, error)
+	ConsumeWorkOrderTx(ctx context.Context, arg ConsumeWorkOrderTxParams) (WorkOrderTxResult, error)
+	CompleteWorkOrderTx(ctx context.Context, arg CompleteWorkOrderTxParams) (WorkOrderTxResult, error)
+	CancelWorkOrderTx(ctx context.Context, workOrderID int32) (WorkOrder, error)
+	NextFloorTaskTx(ctx context.Context, arg NextFloorTaskTxParams) (FloorTask, error)
+	ConfirmFloorTaskTx(ctx context.Context, arg ConfirmFloorTaskTxParams) (FloorTaskTxResult, error)
+	ReportFloorTaskExceptionTx(ctx context.Context, arg ReportFloorTaskExceptionTxParams) (FloorTaskTxResult, error)
+	UpdateProductTx(ctx context.Context, arg UpdateProductTxParams) (Product, error)
+	DeleteProductTx(ctx context.Context, arg DeleteProductTxParams) error
+	UpdateWarehouseTx(ctx context.Context, arg UpdateWarehouseTxParams) (Warehouse, error)
+	DeactivateWarehouseTx(ctx context.Context, arg DeactivateWarehouseTxParams) error
+	UpdateSupplierTx(ctx context.Context, arg UpdateSupplierTxParams) (Supplier, error)
+	UpdateInventoryStatusTx(ctx context.Context, arg UpdateInventoryStatusTxParams) (Inventory, error)
+	SearchProductsTx(ctx context.Context, arg SearchProductsTxParams) ([]SearchProductsRow, error)
+	CreateWebhookSubscriptionTx(ctx context.Context, arg CreateWebhookSubscriptionTxParams) (WebhookSubscriptionTxResult, error)
+	UpdateWebhookSubscriptionTx(ctx context.Context, arg UpdateWebhookSubscriptionTxParams) (WebhookSubscriptionTxResult, error)
+	RecordWebhookAttemptTx(ctx context.Context, arg RecordWebhookAttemptTxParams) (RecordWebhookAttemptTxResult, error)
+	UpdateStockTransferStatusTx(ctx context.Context, arg UpdateStockTransferStatusParams) (StockTransfer, error)
+	ExportProducts(ctx context.Context, arg ExportProductsParams, fn func(ExportProductRow) error) error
+	ExportInventory(ctx context.Context, arg ExportInventoryParams, fn func(ExportInventoryRow) error) error
+	ExportStockMovements(ctx context.Context, arg ExportStockMovementsParams, fn func(ExportStockMovementRow) error) error
+	ExportPurchaseOrders(ctx context.Context, arg ExportPurchaseOrdersParams, fn func(ExportPurchaseOrderRow) error) error
+	ExportStocktakes(ctx context.Context, arg ExportStocktakesParams, fn func(ExportStocktakeRow) error) error
 }
 
 type SQLStore struct {
@@ -20,3 +71,21 @@ func NewStore(db *sql.DB) SingleDb {
 		db:      db,
 	}
 }
+
+// execTx runs fn inside a database transaction, rolling back on error.
+func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	q := New(tx)
+	if err := fn(q); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -9,31 +9,34 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 const createStockMovement = `-- name: CreateStockMovement :one
 INSERT INTO stock_movements (
     reference_number, product_id, warehouse_id, location_id,
     movement_type, quantity_before, quantity_change, quantity_after,
-    reference_id, reference_table, notes, created_by
+    reference_id, reference_table, notes, created_by, sale_price
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
-) RETURNING movement_id, reference_number, product_id, warehouse_id, location_id, movement_type, quantity_before, quantity_change, quantity_after, reference_id, reference_table, notes, movement_date, created_by
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING movement_id, reference_number, product_id, warehouse_id, location_id, movement_type, quantity_before, quantity_change, quantity_after, reference_id, reference_table, notes, movement_date, created_by, sale_price
 `
 
 type CreateStockMovementParams struct {
-	ReferenceNumber sql.NullString `json:"reference_number"`
-	ProductID       int32          `json:"product_id"`
-	WarehouseID     int32          `json:"warehouse_id"`
-	LocationID      sql.NullInt32  `json:"location_id"`
-	MovementType    MovementType   `json:"movement_type"`
-	QuantityBefore  sql.NullInt32  `json:"quantity_before"`
-	QuantityChange  int32          `json:"quantity_change"`
-	QuantityAfter   sql.NullInt32  `json:"quantity_after"`
-	ReferenceID     sql.NullInt32  `json:"reference_id"`
-	ReferenceTable  sql.NullString `json:"reference_table"`
-	Notes           sql.NullString `json:"notes"`
-	CreatedBy       sql.NullInt32  `json:"created_by"`
+	ReferenceNumber sql.NullString      `json:"reference_number"`
+	ProductID       int32               `json:"product_id"`
+	WarehouseID     int32               `json:"warehouse_id"`
+	LocationID      sql.NullInt32       `json:"location_id"`
+	MovementType    MovementType        `json:"movement_type"`
+	QuantityBefore  sql.NullInt32       `json:"quantity_before"`
+	QuantityChange  int32               `json:"quantity_change"`
+	QuantityAfter   sql.NullInt32       `json:"quantity_after"`
+	ReferenceID     sql.NullInt32       `json:"reference_id"`
+	ReferenceTable  sql.NullString      `json:"reference_table"`
+	Notes           sql.NullString      `json:"notes"`
+	CreatedBy       sql.NullInt32       `json:"created_by"`
+	SalePrice       decimal.NullDecimal `json:"sale_price"`
 }
 
 func (q *Queries) CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) (StockMovement, error) {
@@ -50,6 +53,7 @@ func (q *Queries) CreateStockMovement(ctx context.Context, arg CreateStockMoveme
 		arg.ReferenceTable,
 		arg.Notes,
 		arg.CreatedBy,
+		arg.SalePrice,
 	)
 	var i StockMovement
 	err := row.Scan(
@@ -67,12 +71,13 @@ func (q *Queries) CreateStockMovement(ctx context.Context, arg CreateStockMoveme
 		&i.Notes,
 		&i.MovementDate,
 		&i.CreatedBy,
+		&i.SalePrice,
 	)
 	return i, err
 }
 
 const getProductMovementHistory = `-- name: GetProductMovementHistory :many
-SELECT sm.movement_id, sm.reference_number, sm.product_id, sm.warehouse_id, sm.location_id, sm.movement_type, sm.quantity_before, sm.quantity_change, sm.quantity_after, sm.reference_id, sm.reference_table, sm.notes, sm.movement_date, sm.created_by, w.name as warehouse_name
+SELECT sm.movement_id, sm.reference_number, sm.product_id, sm.warehouse_id, sm.location_id, sm.movement_type, sm.quantity_before, sm.quantity_change, sm.quantity_after, sm.reference_id, sm.reference_table, sm.notes, sm.movement_date, sm.created_by, sm.sale_price, w.name as warehouse_name
 FROM stock_movements sm
 JOIN warehouses w ON sm.warehouse_id = w.warehouse_id
 WHERE sm.product_id = $1 AND sm.warehouse_id = $2
@@ -88,21 +93,22 @@ type GetProductMovementHistoryParams struct {
 }
 
 type GetProductMovementHistoryRow struct {
-	MovementID      int32          `json:"movement_id"`
-	ReferenceNumber sql.NullString `json:"reference_number"`
-	ProductID       int32          `json:"product_id"`
-	WarehouseID     int32          `json:"warehouse_id"`
-	LocationID      sql.NullInt32  `json:"location_id"`
-	MovementType    MovementType   `json:"movement_type"`
-	QuantityBefore  sql.NullInt32  `json:"quantity_before"`
-	QuantityChange  int32          `json:"quantity_change"`
-	QuantityAfter   sql.NullInt32  `json:"quantity_after"`
-	ReferenceID     sql.NullInt32  `json:"reference_id"`
-	ReferenceTable  sql.NullString `json:"reference_table"`
-	Notes           sql.NullString `json:"notes"`
-	MovementDate    time.Time      `json:"movement_date"`
-	CreatedBy       sql.NullInt32  `json:"created_by"`
-	WarehouseName   string         `json:"warehouse_name"`
+	MovementID      int32               `json:"movement_id"`
+	ReferenceNumber sql.NullString      `json:"reference_number"`
+	ProductID       int32               `json:"product_id"`
+	WarehouseID     int32               `json:"warehouse_id"`
+	LocationID      sql.NullInt32       `json:"location_id"`
+	MovementType    MovementType        `json:"movement_type"`
+	QuantityBefore  sql.NullInt32       `json:"quantity_before"`
+	QuantityChange  int32               `json:"quantity_change"`
+	QuantityAfter   sql.NullInt32       `json:"quantity_after"`
+	ReferenceID     sql.NullInt32       `json:"reference_id"`
+	ReferenceTable  sql.NullString      `json:"reference_table"`
+	Notes           sql.NullString      `json:"notes"`
+	MovementDate    time.Time           `json:"movement_date"`
+	CreatedBy       sql.NullInt32       `json:"created_by"`
+	SalePrice       decimal.NullDecimal `json:"sale_price"`
+	WarehouseName   string              `json:"warehouse_name"`
 }
 
 func (q *Queries) GetProductMovementHistory(ctx context.Context, arg GetProductMovementHistoryParams) ([]GetProductMovementHistoryRow, error) {
@@ -134,6 +140,7 @@ func (q *Queries) GetProductMovementHistory(ctx context.Context, arg GetProductM
 			&i.Notes,
 			&i.MovementDate,
 			&i.CreatedBy,
+			&i.SalePrice,
 			&i.WarehouseName,
 		); err != nil {
 			return nil, err
@@ -150,7 +157,7 @@ func (q *Queries) GetProductMovementHistory(ctx context.Context, arg GetProductM
 }
 
 const getStockMovement = `-- name: GetStockMovement :one
-SELECT movement_id, reference_number, product_id, warehouse_id, location_id, movement_type, quantity_before, quantity_change, quantity_after, reference_id, reference_table, notes, movement_date, created_by FROM stock_movements 
+SELECT movement_id, reference_number, product_id, warehouse_id, location_id, movement_type, quantity_before, quantity_change, quantity_after, reference_id, reference_table, notes, movement_date, created_by, sale_price FROM stock_movements 
 WHERE movement_id = $1
 `
 
@@ -172,12 +179,13 @@ func (q *Queries) GetStockMovement(ctx context.Context, movementID int32) (Stock
 		&i.Notes,
 		&i.MovementDate,
 		&i.CreatedBy,
+		&i.SalePrice,
 	)
 	return i, err
 }
 
 const listStockMovementsByProduct = `-- name: ListStockMovementsByProduct :many
-SELECT sm.movement_id, sm.reference_number, sm.product_id, sm.warehouse_id, sm.location_id, sm.movement_type, sm.quantity_before, sm.quantity_change, sm.quantity_after, sm.reference_id, sm.reference_table, sm.notes, sm.movement_date, sm.created_by, p.name as product_name, p.sku, w.name as warehouse_name
+SELECT sm.movement_id, sm.reference_number, sm.product_id, sm.warehouse_id, sm.location_id, sm.movement_type, sm.quantity_before, sm.quantity_change, sm.quantity_after, sm.reference_id, sm.reference_table, sm.notes, sm.movement_date, sm.created_by, sm.sale_price, p.name as product_name, p.sku, w.name as warehouse_name
 FROM stock_movements sm
 JOIN products p ON sm.product_id = p.product_id
 JOIN warehouses w ON sm.warehouse_id = w.warehouse_id
@@ -193,23 +201,24 @@ type ListStockMovementsByProductParams struct {
 }
 
 type ListStockMovementsByProductRow struct {
-	MovementID      int32          `json:"movement_id"`
-	ReferenceNumber sql.NullString `json:"reference_number"`
-	ProductID       int32          `json:"product_id"`
-	WarehouseID     int32          `json:"warehouse_id"`
-	LocationID      sql.NullInt32  `json:"location_id"`
-	MovementType    MovementType   `json:"movement_type"`
-	QuantityBefore  sql.NullInt32  `json:"quantity_before"`
-	QuantityChange  int32          `json:"quantity_change"`
-	QuantityAfter   sql.NullInt32  `json:"quantity_after"`
-	ReferenceID     sql.NullInt32  `json:"reference_id"`
-	ReferenceTable  sql.NullString `json:"reference_table"`
-	Notes           sql.NullString `json:"notes"`
-	MovementDate    time.Time      `json:"movement_date"`
-	CreatedBy       sql.NullInt32  `json:"created_by"`
-	ProductName     string         `json:"product_name"`
-	Sku             string         `json:"sku"`
-	WarehouseName   string         `json:"warehouse_name"`
+	MovementID      int32               `json:"movement_id"`
+	ReferenceNumber sql.NullString      `json:"reference_number"`
+	ProductID       int32               `json:"product_id"`
+	WarehouseID     int32               `json:"warehouse_id"`
+	LocationID      sql.NullInt32       `json:"location_id"`
+	MovementType    MovementType        `json:"movement_type"`
+	QuantityBefore  sql.NullInt32       `json:"quantity_before"`
+	QuantityChange  int32               `json:"quantity_change"`
+	QuantityAfter   sql.NullInt32       `json:"quantity_after"`
+	ReferenceID     sql.NullInt32       `json:"reference_id"`
+	ReferenceTable  sql.NullString      `json:"reference_table"`
+	Notes           sql.NullString      `json:"notes"`
+	MovementDate    time.Time           `json:"movement_date"`
+	CreatedBy       sql.NullInt32       `json:"created_by"`
+	SalePrice       decimal.NullDecimal `json:"sale_price"`
+	ProductName     string              `json:"product_name"`
+	Sku             string              `json:"sku"`
+	WarehouseName   string              `json:"warehouse_name"`
 }
 
 func (q *Queries) ListStockMovementsByProduct(ctx context.Context, arg ListStockMovementsByProductParams) ([]ListStockMovementsByProductRow, error) {
@@ -236,6 +245,7 @@ func (q *Queries) ListStockMovementsByProduct(ctx context.Context, arg ListStock
 			&i.Notes,
 			&i.MovementDate,
 			&i.CreatedBy,
+			&i.SalePrice,
 			&i.ProductName,
 			&i.Sku,
 			&i.WarehouseName,
@@ -254,7 +264,7 @@ func (q *Queries) ListStockMovementsByProduct(ctx context.Context, arg ListStock
 }
 
 const listStockMovementsByType = `-- name: ListStockMovementsByType :many
-SELECT sm.movement_id, sm.reference_number, sm.product_id, sm.warehouse_id, sm.location_id, sm.movement_type, sm.quantity_before, sm.quantity_change, sm.quantity_after, sm.reference_id, sm.reference_table, sm.notes, sm.movement_date, sm.created_by, p.name as product_name, p.sku, w.name as warehouse_name
+SELECT sm.movement_id, sm.reference_number, sm.product_id, sm.warehouse_id, sm.location_id, sm.movement_type, sm.quantity_before, sm.quantity_change, sm.quantity_after, sm.reference_id, sm.reference_table, sm.notes, sm.movement_date, sm.created_by, sm.sale_price, p.name as product_name, p.sku, w.name as warehouse_name
 FROM stock_movements sm
 JOIN products p ON sm.product_id = p.product_id
 JOIN warehouses w ON sm.warehouse_id = w.warehouse_id
@@ -270,23 +280,24 @@ type ListStockMovementsByTypeParams struct {
 }
 
 type ListStockMovementsByTypeRow struct {
-	MovementID      int32          `json:"movement_id"`
-	ReferenceNumber sql.NullString `json:"reference_number"`
-	ProductID       int32          `json:"product_id"`
-	WarehouseID     int32          `json:"warehouse_id"`
-	LocationID      sql.NullInt32  `json:"location_id"`
-	MovementType    MovementType   `json:"movement_type"`
-	QuantityBefore  sql.NullInt32  `json:"quantity_before"`
-	QuantityChange  int32          `json:"quantity_change"`
-	QuantityAfter   sql.NullInt32  `json:"quantity_after"`
-	ReferenceID     sql.NullInt32  `json:"reference_id"`
-	ReferenceTable  sql.NullString `json:"reference_table"`
-	Notes           sql.NullString `json:"notes"`
-	MovementDate    time.Time      `json:"movement_date"`
-	CreatedBy       sql.NullInt32  `json:"created_by"`
-	ProductName     string         `json:"product_name"`
-	Sku             string         `json:"sku"`
-	WarehouseName   string         `json:"warehouse_name"`
+	MovementID      int32               `json:"movement_id"`
+	ReferenceNumber sql.NullString      `json:"reference_number"`
+	ProductID       int32               `json:"product_id"`
+	WarehouseID     int32               `json:"warehouse_id"`
+	LocationID      sql.NullInt32       `json:"location_id"`
+	MovementType    MovementType        `json:"movement_type"`
+	QuantityBefore  sql.NullInt32       `json:"quantity_before"`
+	QuantityChange  int32               `json:"quantity_change"`
+	QuantityAfter   sql.NullInt32       `json:"quantity_after"`
+	ReferenceID     sql.NullInt32       `json:"reference_id"`
+	ReferenceTable  sql.NullString      `json:"reference_table"`
+	Notes           sql.NullString      `json:"notes"`
+	MovementDate    time.Time           `json:"movement_date"`
+	CreatedBy       sql.NullInt32       `json:"created_by"`
+	SalePrice       decimal.NullDecimal `json:"sale_price"`
+	ProductName     string              `json:"product_name"`
+	Sku             string              `json:"sku"`
+	WarehouseName   string              `json:"warehouse_name"`
 }
 
 func (q *Queries) ListStockMovementsByType(ctx context.Context, arg ListStockMovementsByTypeParams) ([]ListStockMovementsByTypeRow, error) {
@@ -313,6 +324,7 @@ func (q *Queries) ListStockMovementsByType(ctx context.Context, arg ListStockMov
 			&i.Notes,
 			&i.MovementDate,
 			&i.CreatedBy,
+			&i.SalePrice,
 			&i.ProductName,
 			&i.Sku,
 			&i.WarehouseName,
@@ -331,7 +343,7 @@ func (q *Queries) ListStockMovementsByType(ctx context.Context, arg ListStockMov
 }
 
 const listStockMovementsByWarehouse = `-- name: ListStockMovementsByWarehouse :many
-SELECT sm.movement_id, sm.reference_number, sm.product_id, sm.warehouse_id, sm.location_id, sm.movement_type, sm.quantity_before, sm.quantity_change, sm.quantity_after, sm.reference_id, sm.reference_table, sm.notes, sm.movement_date, sm.created_by, p.name as product_name, p.sku
+SELECT sm.movement_id, sm.reference_number, sm.product_id, sm.warehouse_id, sm.location_id, sm.movement_type, sm.quantity_before, sm.quantity_change, sm.quantity_after, sm.reference_id, sm.reference_table, sm.notes, sm.movement_date, sm.created_by, sm.sale_price, p.name as product_name, p.sku
 FROM stock_movements sm
 JOIN products p ON sm.product_id = p.product_id
 WHERE sm.warehouse_id = $1
@@ -346,22 +358,23 @@ type ListStockMovementsByWarehouseParams struct {
 }
 
 type ListStockMovementsByWarehouseRow struct {
-	MovementID      int32          `json:"movement_id"`
-	ReferenceNumber sql.NullString `json:"reference_number"`
-	ProductID       int32          `json:"product_id"`
-	WarehouseID     int32          `json:"warehouse_id"`
-	LocationID      sql.NullInt32  `json:"location_id"`
-	MovementType    MovementType   `json:"movement_type"`
-	QuantityBefore  sql.NullInt32  `json:"quantity_before"`
-	QuantityChange  int32          `json:"quantity_change"`
-	QuantityAfter   sql.NullInt32  `json:"quantity_after"`
-	ReferenceID     sql.NullInt32  `json:"reference_id"`
-	ReferenceTable  sql.NullString `json:"reference_table"`
-	Notes           sql.NullString `json:"notes"`
-	MovementDate    time.Time      `json:"movement_date"`
-	CreatedBy       sql.NullInt32  `json:"created_by"`
-	ProductName     string         `json:"product_name"`
-	Sku             string         `json:"sku"`
+	MovementID      int32               `json:"movement_id"`
+	ReferenceNumber sql.NullString      `json:"reference_number"`
+	ProductID       int32               `json:"product_id"`
+	WarehouseID     int32               `json:"warehouse_id"`
+	LocationID      sql.NullInt32       `json:"location_id"`
+	MovementType    MovementType        `json:"movement_type"`
+	QuantityBefore  sql.NullInt32       `json:"quantity_before"`
+	QuantityChange  int32               `json:"quantity_change"`
+	QuantityAfter   sql.NullInt32       `json:"quantity_after"`
+	ReferenceID     sql.NullInt32       `json:"reference_id"`
+	ReferenceTable  sql.NullString      `json:"reference_table"`
+	Notes           sql.NullString      `json:"notes"`
+	MovementDate    time.Time           `json:"movement_date"`
+	CreatedBy       sql.NullInt32       `json:"created_by"`
+	SalePrice       decimal.NullDecimal `json:"sale_price"`
+	ProductName     string              `json:"product_name"`
+	Sku             string              `json:"sku"`
 }
 
 func (q *Queries) ListStockMovementsByWarehouse(ctx context.Context, arg ListStockMovementsByWarehouseParams) ([]ListStockMovementsByWarehouseRow, error) {
@@ -388,6 +401,7 @@ func (q *Queries) ListStockMovementsByWarehouse(ctx context.Context, arg ListSto
 			&i.Notes,
 			&i.MovementDate,
 			&i.CreatedBy,
+			&i.SalePrice,
 			&i.ProductName,
 			&i.Sku,
 		); err != nil {
@@ -403,3 +417,17 @@ func (q *Queries) ListStockMovementsByWarehouse(ctx context.Context, arg ListSto
 	}
 	return items, nil
 }
+
+const notifyStockEvent = `-- name: NotifyStockEvent :exec
+SELECT pg_notify($1::text, $2::text)
+`
+
+type NotifyStockEventParams struct {
+	Channel string `json:"channel"`
+	Payload string `json:"payload"`
+}
+
+func (q *Queries) NotifyStockEvent(ctx context.Context, arg NotifyStockEventParams) error {
+	_, err := q.db.ExecContext(ctx, notifyStockEvent, arg.Channel, arg.Payload)
+	return err
+}
@@ -198,6 +198,29 @@ func (q *Queries) GetStocktake(ctx context.Context, stocktakeID int32) (GetStock
 	return i, err
 }
 
+const getStocktakeItem = `-- name: GetStocktakeItem :one
+SELECT stocktake_item_id, stocktake_id, product_id, location_id, system_quantity, counted_quantity, variance, counted_by, counted_at, notes FROM stocktake_items
+WHERE stocktake_item_id = $1
+`
+
+func (q *Queries) GetStocktakeItem(ctx context.Context, stocktakeItemID int32) (StocktakeItem, error) {
+	row := q.db.QueryRowContext(ctx, getStocktakeItem, stocktakeItemID)
+	var i StocktakeItem
+	err := row.Scan(
+		&i.StocktakeItemID,
+		&i.StocktakeID,
+		&i.ProductID,
+		&i.LocationID,
+		&i.SystemQuantity,
+		&i.CountedQuantity,
+		&i.Variance,
+		&i.CountedBy,
+		&i.CountedAt,
+		&i.Notes,
+	)
+	return i, err
+}
+
 const getStocktakeItems = `-- name: GetStocktakeItems :many
 SELECT si.stocktake_item_id, si.stocktake_id, si.product_id, si.location_id, si.system_quantity, si.counted_quantity, si.variance, si.counted_by, si.counted_at, si.notes, p.name as product_name, p.sku, l.location_code
 FROM stocktake_items si
@@ -0,0 +1,31 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/molu/stock-management-system/internal/stream"
+)
+
+// streamStockEvent sends a stock event to the live stream. PostgreSQL holds
+// the notification until the transaction commits and drops it on rollback.
+func streamStockEvent(ctx context.Context, q *Queries, eventType string, warehouseID, productID int32, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(stream.Event{
+		Type:        eventType,
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		Data:        raw,
+	})
+	if err != nil {
+		return err
+	}
+
+	return q.NotifyStockEvent(ctx, NotifyStockEventParams{
+		Channel: stream.Channel,
+		Payload: string(payload),
+	})
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/molu/stock-management-system/internal/scorecard"
+)
+
+type ScoreSupplierTxParams struct {
+	SupplierID int32
+	// Orders placed on or after Since are scored.
+	Since time.Time
+	AsOf  time.Time
+	// SaveRatings writes the ratings into suppliers.rating and
+	// product_suppliers.performance_rating.
+	SaveRatings bool
+}
+
+type ProductScorecard struct {
+	ProductID int32 `json:"product_id"`
+	scorecard.Scorecard
+}
+
+type ScoreSupplierTxResult struct {
+	SupplierID int32               `json:"supplier_id"`
+	Since      time.Time           `json:"since"`
+	Scorecard  scorecard.Scorecard `json:"scorecard"`
+	Products   []ProductScorecard  `json:"products"`
+}
+
+// ScoreSupplierTx builds the scorecard of a supplier's purchase orders, as a
+// whole and per product, and optionally saves the ratings. Ratings are left
+// as they were when there is nothing to measure them on.
+func (store *SQLStore) ScoreSupplierTx(ctx context.Context, arg ScoreSupplierTxParams) (ScoreSupplierTxResult, error) {
+	result := ScoreSupplierTxResult{
+		SupplierID: arg.SupplierID,
+		Since:      arg.Since,
+		Products:   []ProductScorecard{},
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		rows, err := q.ListSupplierScorecardLines(ctx, ListSupplierScorecardLinesParams{
+			SupplierID: arg.SupplierID,
+			OrderDate:  arg.Since,
+		})
+		if err != nil {
+			return err
+		}
+
+		lines := make([]scorecard.Line, len(rows))
+		for i, row := range rows {
+			lines[i] = scorecard.Line{
+				ProductID:        row.ProductID,
+				OrderDate:        row.OrderDate,
+				PromisedDate:     row.PromisedDate.Time,
+				PromisedLeadDays: int(row.PromisedLeadTimeDays.Int32),
+				QuantityOrdered:  row.QuantityOrdered,
+				QuantityReceived: row.QuantityReceived,
+				QuantityReturned: row.QuantityReturned,
+				UnitPrice:        row.UnitPrice,
+				LastReceivedAt:   row.LastReceivedAt.Time,
+			}
+		}
+
+		result.Scorecard = scorecard.Score(lines, arg.AsOf)
+		for productID, sc := range scorecard.ByProduct(lines, arg.AsOf) {
+			result.Products = append(result.Products, ProductScorecard{ProductID: productID, Scorecard: sc})
+		}
+		sort.Slice(result.Products, func(i, j int) bool {
+			return result.Products[i].ProductID < result.Products[j].ProductID
+		})
+
+		if !arg.SaveRatings {
+			return nil
+		}
+		if result.Scorecard.Rating.Valid {
+			if err := q.UpdateSupplierRating(ctx, UpdateSupplierRatingParams{
+				SupplierID: arg.SupplierID,
+				Rating:     result.Scorecard.Rating.Decimal,
+			}); err != nil {
+				return err
+			}
+		}
+		for _, p := range result.Products {
+			if !p.Rating.Valid {
+				continue
+			}
+			if err := q.UpdateProductSupplierRating(ctx, UpdateProductSupplierRatingParams{
+				SupplierID:        arg.SupplierID,
+				ProductID:         p.ProductID,
+				PerformanceRating: p.Rating,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
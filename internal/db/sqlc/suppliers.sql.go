@@ -8,13 +8,14 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
 
 const activateSupplier = `-- name: ActivateSupplier :exec
 UPDATE suppliers 
-SET is_active = true 
+SET is_active = true, updated_at = CURRENT_TIMESTAMP
 WHERE supplier_id = $1
 `
 
@@ -26,10 +27,10 @@ func (q *Queries) ActivateSupplier(ctx context.Context, supplierID int32) error
 const createSupplier = `-- name: CreateSupplier :one
 INSERT INTO suppliers (
     code, name, contact_person, email, phone, address, 
-    tax_id, payment_terms, lead_time_days, rating, is_active
+    tax_id, payment_terms, lead_time_days, rating, is_active, currency_code
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
-) RETURNING supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at, currency_code, rated_at, updated_at
 `
 
 type CreateSupplierParams struct {
@@ -44,6 +45,7 @@ type CreateSupplierParams struct {
 	LeadTimeDays  sql.NullInt32   `json:"lead_time_days"`
 	Rating        decimal.Decimal `json:"rating"`
 	IsActive      bool            `json:"is_active"`
+	CurrencyCode  sql.NullString  `json:"currency_code"`
 }
 
 func (q *Queries) CreateSupplier(ctx context.Context, arg CreateSupplierParams) (Supplier, error) {
@@ -59,6 +61,7 @@ func (q *Queries) CreateSupplier(ctx context.Context, arg CreateSupplierParams)
 		arg.LeadTimeDays,
 		arg.Rating,
 		arg.IsActive,
+		arg.CurrencyCode,
 	)
 	var i Supplier
 	err := row.Scan(
@@ -75,13 +78,16 @@ func (q *Queries) CreateSupplier(ctx context.Context, arg CreateSupplierParams)
 		&i.Rating,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.CurrencyCode,
+		&i.RatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const deactivateSupplier = `-- name: DeactivateSupplier :exec
 UPDATE suppliers 
-SET is_active = false 
+SET is_active = false, updated_at = CURRENT_TIMESTAMP
 WHERE supplier_id = $1
 `
 
@@ -91,7 +97,7 @@ func (q *Queries) DeactivateSupplier(ctx context.Context, supplierID int32) erro
 }
 
 const getSupplier = `-- name: GetSupplier :one
-SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at FROM suppliers 
+SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at, currency_code, rated_at, updated_at FROM suppliers 
 WHERE supplier_id = $1
 `
 
@@ -112,12 +118,15 @@ func (q *Queries) GetSupplier(ctx context.Context, supplierID int32) (Supplier,
 		&i.Rating,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.CurrencyCode,
+		&i.RatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getSupplierByCode = `-- name: GetSupplierByCode :one
-SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at FROM suppliers 
+SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at, currency_code, rated_at, updated_at FROM suppliers 
 WHERE code = $1
 `
 
@@ -138,26 +147,60 @@ func (q *Queries) GetSupplierByCode(ctx context.Context, code string) (Supplier,
 		&i.Rating,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.CurrencyCode,
+		&i.RatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSupplierForUpdate = `-- name: GetSupplierForUpdate :one
+SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at, currency_code, rated_at, updated_at FROM suppliers
+WHERE supplier_id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetSupplierForUpdate(ctx context.Context, supplierID int32) (Supplier, error) {
+	row := q.db.QueryRowContext(ctx, getSupplierForUpdate, supplierID)
+	var i Supplier
+	err := row.Scan(
+		&i.SupplierID,
+		&i.Code,
+		&i.Name,
+		&i.ContactPerson,
+		&i.Email,
+		&i.Phone,
+		&i.Address,
+		&i.TaxID,
+		&i.PaymentTerms,
+		&i.LeadTimeDays,
+		&i.Rating,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.CurrencyCode,
+		&i.RatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getSupplierPerformance = `-- name: GetSupplierPerformance :one
 SELECT 
-    COUNT(DISTINCT po.po_id) as total_orders,
-    COUNT(DISTINCT po.product_id) as unique_products,
-    AVG(po.unit_price) as avg_unit_price,
-    MAX(po.order_date) as last_order_date
-FROM purchase_order_items po
-INNER JOIN purchase_orders p ON po.po_id = p.po_id
+    COUNT(DISTINCT p.po_id) as total_orders,
+    COUNT(DISTINCT poi.product_id) as unique_products,
+    ROUND(COALESCE(AVG(poi.unit_price), 0), 4)::numeric as avg_unit_price,
+    MAX(p.order_date)::timestamp as last_order_date
+FROM purchase_orders p
+INNER JOIN purchase_order_items poi ON poi.po_id = p.po_id
 WHERE p.supplier_id = $1
+    AND p.status <> 'cancelled'
 `
 
 type GetSupplierPerformanceRow struct {
-	TotalOrders    int64       `json:"total_orders"`
-	UniqueProducts int64       `json:"unique_products"`
-	AvgUnitPrice   float64     `json:"avg_unit_price"`
-	LastOrderDate  interface{} `json:"last_order_date"`
+	TotalOrders    int64           `json:"total_orders"`
+	UniqueProducts int64           `json:"unique_products"`
+	AvgUnitPrice   decimal.Decimal `json:"avg_unit_price"`
+	LastOrderDate  sql.NullTime    `json:"last_order_date"`
 }
 
 func (q *Queries) GetSupplierPerformance(ctx context.Context, supplierID int32) (GetSupplierPerformanceRow, error) {
@@ -173,7 +216,7 @@ func (q *Queries) GetSupplierPerformance(ctx context.Context, supplierID int32)
 }
 
 const getSupplierProducts = `-- name: GetSupplierProducts :many
-SELECT p.product_id, p.sku, p.name, p.description, p.category_id, p.unit_price, p.cost_price, p.barcode, p.weight, p.dimensions, p.supplier_id, p.min_stock_level, p.max_stock_level, p.reorder_point, p.safety_stock, p.lead_time_days, p.auto_reorder, p.last_reorder_date, p.is_active, p.created_at, p.updated_at 
+SELECT p.product_id, p.sku, p.name, p.description, p.category_id, p.unit_price, p.cost_price, p.barcode, p.weight, p.dimensions, p.supplier_id, p.min_stock_level, p.max_stock_level, p.reorder_point, p.safety_stock, p.lead_time_days, p.auto_reorder, p.last_reorder_date, p.is_active, p.created_at, p.updated_at, p.base_uom_id, p.costing_method, p.standard_cost 
 FROM products p
 INNER JOIN product_suppliers ps ON p.product_id = ps.product_id
 WHERE ps.supplier_id = $1 
@@ -219,6 +262,9 @@ func (q *Queries) GetSupplierProducts(ctx context.Context, arg GetSupplierProduc
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BaseUomID,
+			&i.CostingMethod,
+			&i.StandardCost,
 		); err != nil {
 			return nil, err
 		}
@@ -234,7 +280,7 @@ func (q *Queries) GetSupplierProducts(ctx context.Context, arg GetSupplierProduc
 }
 
 const listActiveSuppliers = `-- name: ListActiveSuppliers :many
-SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at FROM suppliers 
+SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at, currency_code, rated_at, updated_at FROM suppliers 
 WHERE is_active = true 
 ORDER BY name
 `
@@ -262,6 +308,9 @@ func (q *Queries) ListActiveSuppliers(ctx context.Context) ([]Supplier, error) {
 			&i.Rating,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.CurrencyCode,
+			&i.RatedAt,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -277,7 +326,7 @@ func (q *Queries) ListActiveSuppliers(ctx context.Context) ([]Supplier, error) {
 }
 
 const listAllSuppliers = `-- name: ListAllSuppliers :many
-SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at FROM suppliers 
+SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at, currency_code, rated_at, updated_at FROM suppliers 
 ORDER BY name
 LIMIT $1 OFFSET $2
 `
@@ -310,6 +359,89 @@ func (q *Queries) ListAllSuppliers(ctx context.Context, arg ListAllSuppliersPara
 			&i.Rating,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.CurrencyCode,
+			&i.RatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSupplierScorecardLines = `-- name: ListSupplierScorecardLines :many
+SELECT
+    poi.po_item_id,
+    poi.product_id,
+    p.order_date,
+    p.expected_delivery_date::timestamp AS promised_date,
+    COALESCE(ps.lead_time_days, s.lead_time_days) AS promised_lead_time_days,
+    poi.quantity_ordered,
+    poi.quantity_received,
+    COALESCE((
+        SELECT SUM(ABS(sm.quantity_change))
+        FROM stock_movements sm
+        WHERE sm.reference_table = 'purchase_order_items'
+            AND sm.reference_id = poi.po_item_id
+            AND sm.movement_type = 'return'
+    ), 0)::int AS quantity_returned,
+    poi.unit_price,
+    (SELECT MAX(r.received_at) FROM purchase_order_receipts r WHERE r.po_item_id = poi.po_item_id)::timestamp AS last_received_at
+FROM purchase_order_items poi
+INNER JOIN purchase_orders p ON p.po_id = poi.po_id
+INNER JOIN suppliers s ON s.supplier_id = p.supplier_id
+LEFT JOIN product_suppliers ps ON ps.supplier_id = p.supplier_id AND ps.product_id = poi.product_id
+WHERE p.supplier_id = $1
+    AND p.order_date >= $2
+    AND p.status IN ('approved', 'partially_received', 'completed')
+ORDER BY p.order_date, poi.po_item_id
+`
+
+type ListSupplierScorecardLinesParams struct {
+	SupplierID int32     `json:"supplier_id"`
+	OrderDate  time.Time `json:"order_date"`
+}
+
+type ListSupplierScorecardLinesRow struct {
+	PoItemID             int32           `json:"po_item_id"`
+	ProductID            int32           `json:"product_id"`
+	OrderDate            time.Time       `json:"order_date"`
+	PromisedDate         sql.NullTime    `json:"promised_date"`
+	PromisedLeadTimeDays sql.NullInt32   `json:"promised_lead_time_days"`
+	QuantityOrdered      int32           `json:"quantity_ordered"`
+	QuantityReceived     int32           `json:"quantity_received"`
+	QuantityReturned     int32           `json:"quantity_returned"`
+	UnitPrice            decimal.Decimal `json:"unit_price"`
+	LastReceivedAt       sql.NullTime    `json:"last_received_at"`
+}
+
+func (q *Queries) ListSupplierScorecardLines(ctx context.Context, arg ListSupplierScorecardLinesParams) ([]ListSupplierScorecardLinesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSupplierScorecardLines, arg.SupplierID, arg.OrderDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSupplierScorecardLinesRow
+	for rows.Next() {
+		var i ListSupplierScorecardLinesRow
+		if err := rows.Scan(
+			&i.PoItemID,
+			&i.ProductID,
+			&i.OrderDate,
+			&i.PromisedDate,
+			&i.PromisedLeadTimeDays,
+			&i.QuantityOrdered,
+			&i.QuantityReceived,
+			&i.QuantityReturned,
+			&i.UnitPrice,
+			&i.LastReceivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -325,7 +457,7 @@ func (q *Queries) ListAllSuppliers(ctx context.Context, arg ListAllSuppliersPara
 }
 
 const listSuppliers = `-- name: ListSuppliers :many
-SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at FROM suppliers 
+SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at, currency_code, rated_at, updated_at FROM suppliers 
 WHERE is_active = true
 ORDER BY name
 LIMIT $1 OFFSET $2
@@ -359,6 +491,9 @@ func (q *Queries) ListSuppliers(ctx context.Context, arg ListSuppliersParams) ([
 			&i.Rating,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.CurrencyCode,
+			&i.RatedAt,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -374,7 +509,7 @@ func (q *Queries) ListSuppliers(ctx context.Context, arg ListSuppliersParams) ([
 }
 
 const searchSuppliers = `-- name: SearchSuppliers :many
-SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at FROM suppliers 
+SELECT supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at, currency_code, rated_at, updated_at FROM suppliers 
 WHERE is_active = true 
     AND (
         name ILIKE '%' || $1 || '%' 
@@ -414,6 +549,9 @@ func (q *Queries) SearchSuppliers(ctx context.Context, arg SearchSuppliersParams
 			&i.Rating,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.CurrencyCode,
+			&i.RatedAt,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -428,6 +566,43 @@ func (q *Queries) SearchSuppliers(ctx context.Context, arg SearchSuppliersParams
 	return items, nil
 }
 
+const setProductSupplierPurchaseUom = `-- name: SetProductSupplierPurchaseUom :one
+UPDATE product_suppliers
+SET purchase_uom_id = $3
+WHERE supplier_id = $1 AND product_id = $2
+RETURNING product_supplier_id
+`
+
+type SetProductSupplierPurchaseUomParams struct {
+	SupplierID    int32         `json:"supplier_id"`
+	ProductID     int32         `json:"product_id"`
+	PurchaseUomID sql.NullInt32 `json:"purchase_uom_id"`
+}
+
+func (q *Queries) SetProductSupplierPurchaseUom(ctx context.Context, arg SetProductSupplierPurchaseUomParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, setProductSupplierPurchaseUom, arg.SupplierID, arg.ProductID, arg.PurchaseUomID)
+	var product_supplier_id int32
+	err := row.Scan(&product_supplier_id)
+	return product_supplier_id, err
+}
+
+const updateProductSupplierRating = `-- name: UpdateProductSupplierRating :exec
+UPDATE product_suppliers
+SET performance_rating = $3
+WHERE supplier_id = $1 AND product_id = $2
+`
+
+type UpdateProductSupplierRatingParams struct {
+	SupplierID        int32               `json:"supplier_id"`
+	ProductID         int32               `json:"product_id"`
+	PerformanceRating decimal.NullDecimal `json:"performance_rating"`
+}
+
+func (q *Queries) UpdateProductSupplierRating(ctx context.Context, arg UpdateProductSupplierRatingParams) error {
+	_, err := q.db.ExecContext(ctx, updateProductSupplierRating, arg.SupplierID, arg.ProductID, arg.PerformanceRating)
+	return err
+}
+
 const updateSupplier = `-- name: UpdateSupplier :one
 UPDATE suppliers 
 SET 
@@ -440,9 +615,11 @@ SET
     payment_terms = COALESCE($8, payment_terms),
     lead_time_days = COALESCE($9, lead_time_days),
     rating = COALESCE($10, rating),
-    is_active = COALESCE($11, is_active)
+    is_active = COALESCE($11, is_active),
+    currency_code = COALESCE($12, currency_code),
+    updated_at = CURRENT_TIMESTAMP
 WHERE supplier_id = $1
-RETURNING supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at
+RETURNING supplier_id, code, name, contact_person, email, phone, address, tax_id, payment_terms, lead_time_days, rating, is_active, created_at, currency_code, rated_at, updated_at
 `
 
 type UpdateSupplierParams struct {
@@ -457,6 +634,7 @@ type UpdateSupplierParams struct {
 	LeadTimeDays  sql.NullInt32   `json:"lead_time_days"`
 	Rating        decimal.Decimal `json:"rating"`
 	IsActive      bool            `json:"is_active"`
+	CurrencyCode  sql.NullString  `json:"currency_code"`
 }
 
 func (q *Queries) UpdateSupplier(ctx context.Context, arg UpdateSupplierParams) (Supplier, error) {
@@ -472,6 +650,7 @@ func (q *Queries) UpdateSupplier(ctx context.Context, arg UpdateSupplierParams)
 		arg.LeadTimeDays,
 		arg.Rating,
 		arg.IsActive,
+		arg.CurrencyCode,
 	)
 	var i Supplier
 	err := row.Scan(
@@ -488,6 +667,25 @@ func (q *Queries) UpdateSupplier(ctx context.Context, arg UpdateSupplierParams)
 		&i.Rating,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.CurrencyCode,
+		&i.RatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
+
+const updateSupplierRating = `-- name: UpdateSupplierRating :exec
+UPDATE suppliers
+SET rating = $2, rated_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE supplier_id = $1
+`
+
+type UpdateSupplierRatingParams struct {
+	SupplierID int32           `json:"supplier_id"`
+	Rating     decimal.Decimal `json:"rating"`
+}
+
+func (q *Queries) UpdateSupplierRating(ctx context.Context, arg UpdateSupplierRatingParams) error {
+	_, err := q.db.ExecContext(ctx, updateSupplierRating, arg.SupplierID, arg.Rating)
+	return err
+}
@@ -98,12 +98,94 @@ func (q *Queries) CreateStockTransferItem(ctx context.Context, arg CreateStockTr
 	return i, err
 }
 
+const getStockTransferForUpdate = `-- name: GetStockTransferForUpdate :one
+SELECT transfer_id, transfer_number, from_warehouse_id, to_warehouse_id, status, transfer_date, expected_completion_date, notes, created_by, created_at FROM stock_transfers
+WHERE transfer_id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetStockTransferForUpdate(ctx context.Context, transferID int32) (StockTransfer, error) {
+	row := q.db.QueryRowContext(ctx, getStockTransferForUpdate, transferID)
+	var i StockTransfer
+	err := row.Scan(
+		&i.TransferID,
+		&i.TransferNumber,
+		&i.FromWarehouseID,
+		&i.ToWarehouseID,
+		&i.Status,
+		&i.TransferDate,
+		&i.ExpectedCompletionDate,
+		&i.Notes,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getStockTransferItem = `-- name: GetStockTransferItem :one
+SELECT transfer_item_id, transfer_id, product_id, quantity, quantity_sent, quantity_received, from_location_id, to_location_id FROM stock_transfer_items
+WHERE transfer_item_id = $1
+`
+
+func (q *Queries) GetStockTransferItem(ctx context.Context, transferItemID int32) (StockTransferItem, error) {
+	row := q.db.QueryRowContext(ctx, getStockTransferItem, transferItemID)
+	var i StockTransferItem
+	err := row.Scan(
+		&i.TransferItemID,
+		&i.TransferID,
+		&i.ProductID,
+		&i.Quantity,
+		&i.QuantitySent,
+		&i.QuantityReceived,
+		&i.FromLocationID,
+		&i.ToLocationID,
+	)
+	return i, err
+}
+
+const listStockTransferItems = `-- name: ListStockTransferItems :many
+SELECT transfer_item_id, transfer_id, product_id, quantity, quantity_sent, quantity_received, from_location_id, to_location_id FROM stock_transfer_items
+WHERE transfer_id = $1
+ORDER BY transfer_item_id
+`
+
+func (q *Queries) ListStockTransferItems(ctx context.Context, transferID int32) ([]StockTransferItem, error) {
+	rows, err := q.db.QueryContext(ctx, listStockTransferItems, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StockTransferItem
+	for rows.Next() {
+		var i StockTransferItem
+		if err := rows.Scan(
+			&i.TransferItemID,
+			&i.TransferID,
+			&i.ProductID,
+			&i.Quantity,
+			&i.QuantitySent,
+			&i.QuantityReceived,
+			&i.FromLocationID,
+			&i.ToLocationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateStockTransferItemQuantities = `-- name: UpdateStockTransferItemQuantities :one
 UPDATE stock_transfer_items 
 SET 
     quantity_sent = $2,
-    quantity_received = $3,
-    updated_at = CURRENT_TIMESTAMP
+    quantity_received = $3
 WHERE transfer_item_id = $1
 RETURNING transfer_item_id, transfer_id, product_id, quantity, quantity_sent, quantity_received, from_location_id, to_location_id
 `
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/molu/stock-management-system/internal/variants"
+)
+
+var (
+	ErrVariantOfVariant = errors.New("a variant cannot have variants of its own")
+	ErrVariantSKUTaken  = errors.New("variant SKU is already used by another product")
+	ErrVariantSKULength = errors.New("variant SKU is longer than 50 characters")
+)
+
+// maxSKULength is the width of products.sku.
+const maxSKULength = 50
+
+type GenerateVariantsTxParams struct {
+	ParentProductID int32
+	Axes            []variants.Axis
+}
+
+type GenerateVariantsTxResult struct {
+	Axes     []ProductVariantAxis `json:"axes"`
+	Created  []Product            `json:"created"`
+	Existing int                  `json:"existing"`
+}
+
+// GenerateVariantsTx sets a parent product's option axes and creates a child
+// product for every combination that has no variant yet, copying the
+// parent's category, prices, stock levels and costing. Variants of values
+// dropped from the axes are kept; deactivate them to retire them.
+func (store *SQLStore) GenerateVariantsTx(ctx context.Context, arg GenerateVariantsTxParams) (GenerateVariantsTxResult, error) {
+	var result GenerateVariantsTxResult
+
+	if err := variants.Check(arg.Axes); err != nil {
+		return result, err
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		parent, err := q.GetProduct(ctx, arg.ParentProductID)
+		if err != nil {
+			return err
+		}
+		if _, err := q.GetProductVariant(ctx, parent.ProductID); err == nil {
+			return ErrVariantOfVariant
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if err := q.DeleteProductVariantAxes(ctx, parent.ProductID); err != nil {
+			return err
+		}
+		for i, a := range arg.Axes {
+			values, err := json.Marshal(a.Values)
+			if err != nil {
+				return err
+			}
+			axis, err := q.CreateProductVariantAxis(ctx, CreateProductVariantAxisParams{
+				ParentProductID: parent.ProductID,
+				Name:            a.Name,
+				Position:        int32(i),
+				AxisValues:      values,
+			})
+			if err != nil {
+				return err
+			}
+			result.Axes = append(result.Axes, axis)
+		}
+
+		for _, combo := range variants.Combinations(arg.Axes) {
+			options, err := json.Marshal(variants.Map(combo))
+			if err != nil {
+				return err
+			}
+			_, err = q.GetProductVariantByOptions(ctx, GetProductVariantByOptionsParams{
+				ParentProductID: parent.ProductID,
+				Options:         options,
+			})
+			if err == nil {
+				result.Existing++
+				continue
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+
+			child, err := createVariantProduct(ctx, q, parent, combo)
+			if err != nil {
+				return err
+			}
+			if _, err := q.CreateProductVariant(ctx, CreateProductVariantParams{
+				ProductID:       child.ProductID,
+				ParentProductID: parent.ProductID,
+				Options:         options,
+			}); err != nil {
+				return err
+			}
+			result.Created = append(result.Created, child)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// createVariantProduct creates the child product of one combination.
+func createVariantProduct(ctx context.Context, q *Queries, parent Product, combo []variants.Option) (Product, error) {
+	sku := variants.SKU(parent.Sku, combo)
+	if len(sku) > maxSKULength {
+		return Product{}, fmt.Errorf("%w: %s", ErrVariantSKULength, sku)
+	}
+	if _, err := q.GetProductBySKU(ctx, sku); err == nil {
+		return Product{}, fmt.Errorf("%w: %s", ErrVariantSKUTaken, sku)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return Product{}, err
+	}
+
+	child, err := q.CreateProduct(ctx, CreateProductParams{
+		Sku:           sku,
+		Name:          variants.Name(parent.Name, combo),
+		Description:   parent.Description,
+		CategoryID:    parent.CategoryID,
+		UnitPrice:     parent.UnitPrice,
+		CostPrice:     parent.CostPrice,
+		Weight:        parent.Weight,
+		Dimensions:    parent.Dimensions,
+		SupplierID:    parent.SupplierID,
+		MinStockLevel: parent.MinStockLevel,
+		MaxStockLevel: parent.MaxStockLevel,
+		ReorderPoint:  parent.ReorderPoint,
+		SafetyStock:   parent.SafetyStock,
+		LeadTimeDays:  parent.LeadTimeDays,
+		AutoReorder:   parent.AutoReorder,
+		IsActive:      parent.IsActive,
+		BaseUomID:     parent.BaseUomID,
+	})
+	if err != nil {
+		return child, err
+	}
+	if child.CostingMethod == parent.CostingMethod && !parent.StandardCost.Valid {
+		return child, nil
+	}
+	return q.SetProductCosting(ctx, SetProductCostingParams{
+		ProductID:     child.ProductID,
+		CostingMethod: parent.CostingMethod,
+		StandardCost:  parent.StandardCost,
+	})
+}
@@ -8,24 +8,33 @@ package db
 import (
 	"context"
 	"database/sql"
+
+	"github.com/shopspring/decimal"
 )
 
 const createLocation = `-- name: CreateLocation :one
 INSERT INTO locations (
-  warehouse_id, location_code, aisle, shelf, bin, max_capacity
+  warehouse_id, location_code, aisle, shelf, bin, max_capacity,
+  zone_id, x_coord, y_coord, pick_sequence, max_weight, max_volume
 ) VALUES (
-  $1, $2, $3, $4, $5, $6
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 )
-RETURNING location_id, warehouse_id, location_code, aisle, shelf, bin, max_capacity, is_active
+RETURNING location_id, warehouse_id, location_code, aisle, shelf, bin, max_capacity, is_active, zone_id, x_coord, y_coord, pick_sequence, max_weight, max_volume
 `
 
 type CreateLocationParams struct {
-	WarehouseID  int32          `json:"warehouse_id"`
-	LocationCode string         `json:"location_code"`
-	Aisle        sql.NullString `json:"aisle"`
-	Shelf        sql.NullString `json:"shelf"`
-	Bin          sql.NullString `json:"bin"`
-	MaxCapacity  sql.NullInt32  `json:"max_capacity"`
+	WarehouseID  int32               `json:"warehouse_id"`
+	LocationCode string              `json:"location_code"`
+	Aisle        sql.NullString      `json:"aisle"`
+	Shelf        sql.NullString      `json:"shelf"`
+	Bin          sql.NullString      `json:"bin"`
+	MaxCapacity  sql.NullInt32       `json:"max_capacity"`
+	ZoneID       sql.NullInt32       `json:"zone_id"`
+	XCoord       decimal.NullDecimal `json:"x_coord"`
+	YCoord       decimal.NullDecimal `json:"y_coord"`
+	PickSequence sql.NullInt32       `json:"pick_sequence"`
+	MaxWeight    decimal.NullDecimal `json:"max_weight"`
+	MaxVolume    decimal.NullDecimal `json:"max_volume"`
 }
 
 func (q *Queries) CreateLocation(ctx context.Context, arg CreateLocationParams) (Location, error) {
@@ -36,6 +45,12 @@ func (q *Queries) CreateLocation(ctx context.Context, arg CreateLocationParams)
 		arg.Shelf,
 		arg.Bin,
 		arg.MaxCapacity,
+		arg.ZoneID,
+		arg.XCoord,
+		arg.YCoord,
+		arg.PickSequence,
+		arg.MaxWeight,
+		arg.MaxVolume,
 	)
 	var i Location
 	err := row.Scan(
@@ -47,6 +62,12 @@ func (q *Queries) CreateLocation(ctx context.Context, arg CreateLocationParams)
 		&i.Bin,
 		&i.MaxCapacity,
 		&i.IsActive,
+		&i.ZoneID,
+		&i.XCoord,
+		&i.YCoord,
+		&i.PickSequence,
+		&i.MaxWeight,
+		&i.MaxVolume,
 	)
 	return i, err
 }
@@ -57,7 +78,7 @@ INSERT INTO warehouses (
 ) VALUES (
   $1, $2, $3, $4, $5, $6
 )
-RETURNING warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at
+RETURNING warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at, updated_at
 `
 
 type CreateWarehouseParams struct {
@@ -89,6 +110,43 @@ func (q *Queries) CreateWarehouse(ctx context.Context, arg CreateWarehouseParams
 		&i.ContactEmail,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createWarehouseZone = `-- name: CreateWarehouseZone :one
+INSERT INTO warehouse_zones (
+  warehouse_id, zone_code, name, description
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING zone_id, warehouse_id, zone_code, name, description, is_active, created_at
+`
+
+type CreateWarehouseZoneParams struct {
+	WarehouseID int32          `json:"warehouse_id"`
+	ZoneCode    string         `json:"zone_code"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+}
+
+func (q *Queries) CreateWarehouseZone(ctx context.Context, arg CreateWarehouseZoneParams) (WarehouseZone, error) {
+	row := q.db.QueryRowContext(ctx, createWarehouseZone,
+		arg.WarehouseID,
+		arg.ZoneCode,
+		arg.Name,
+		arg.Description,
+	)
+	var i WarehouseZone
+	err := row.Scan(
+		&i.ZoneID,
+		&i.WarehouseID,
+		&i.ZoneCode,
+		&i.Name,
+		&i.Description,
+		&i.IsActive,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -106,7 +164,7 @@ func (q *Queries) DeactivateLocation(ctx context.Context, locationID int32) erro
 
 const deactivateWarehouse = `-- name: DeactivateWarehouse :exec
 UPDATE warehouses
-SET is_active = false
+SET is_active = false, updated_at = CURRENT_TIMESTAMP
 WHERE warehouse_id = $1
 `
 
@@ -116,7 +174,7 @@ func (q *Queries) DeactivateWarehouse(ctx context.Context, warehouseID int32) er
 }
 
 const getLocation = `-- name: GetLocation :one
-SELECT location_id, warehouse_id, location_code, aisle, shelf, bin, max_capacity, is_active FROM locations WHERE location_id = $1
+SELECT location_id, warehouse_id, location_code, aisle, shelf, bin, max_capacity, is_active, zone_id, x_coord, y_coord, pick_sequence, max_weight, max_volume FROM locations WHERE location_id = $1
 `
 
 func (q *Queries) GetLocation(ctx context.Context, locationID int32) (Location, error) {
@@ -131,12 +189,18 @@ func (q *Queries) GetLocation(ctx context.Context, locationID int32) (Location,
 		&i.Bin,
 		&i.MaxCapacity,
 		&i.IsActive,
+		&i.ZoneID,
+		&i.XCoord,
+		&i.YCoord,
+		&i.PickSequence,
+		&i.MaxWeight,
+		&i.MaxVolume,
 	)
 	return i, err
 }
 
 const getLocationByCode = `-- name: GetLocationByCode :one
-SELECT location_id, warehouse_id, location_code, aisle, shelf, bin, max_capacity, is_active FROM locations
+SELECT location_id, warehouse_id, location_code, aisle, shelf, bin, max_capacity, is_active, zone_id, x_coord, y_coord, pick_sequence, max_weight, max_volume FROM locations
 WHERE warehouse_id = $1 AND location_code = $2
 `
 
@@ -157,12 +221,46 @@ func (q *Queries) GetLocationByCode(ctx context.Context, arg GetLocationByCodePa
 		&i.Bin,
 		&i.MaxCapacity,
 		&i.IsActive,
+		&i.ZoneID,
+		&i.XCoord,
+		&i.YCoord,
+		&i.PickSequence,
+		&i.MaxWeight,
+		&i.MaxVolume,
+	)
+	return i, err
+}
+
+const getLocationForUpdate = `-- name: GetLocationForUpdate :one
+SELECT location_id, warehouse_id, location_code, aisle, shelf, bin, max_capacity, is_active, zone_id, x_coord, y_coord, pick_sequence, max_weight, max_volume FROM locations
+WHERE location_id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetLocationForUpdate(ctx context.Context, locationID int32) (Location, error) {
+	row := q.db.QueryRowContext(ctx, getLocationForUpdate, locationID)
+	var i Location
+	err := row.Scan(
+		&i.LocationID,
+		&i.WarehouseID,
+		&i.LocationCode,
+		&i.Aisle,
+		&i.Shelf,
+		&i.Bin,
+		&i.MaxCapacity,
+		&i.IsActive,
+		&i.ZoneID,
+		&i.XCoord,
+		&i.YCoord,
+		&i.PickSequence,
+		&i.MaxWeight,
+		&i.MaxVolume,
 	)
 	return i, err
 }
 
 const getWarehouse = `-- name: GetWarehouse :one
-SELECT warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at FROM warehouses WHERE warehouse_id = $1
+SELECT warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at, updated_at FROM warehouses WHERE warehouse_id = $1
 `
 
 func (q *Queries) GetWarehouse(ctx context.Context, warehouseID int32) (Warehouse, error) {
@@ -178,12 +276,13 @@ func (q *Queries) GetWarehouse(ctx context.Context, warehouseID int32) (Warehous
 		&i.ContactEmail,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getWarehouseByCode = `-- name: GetWarehouseByCode :one
-SELECT warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at FROM warehouses WHERE code = $1
+SELECT warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at, updated_at FROM warehouses WHERE code = $1
 `
 
 func (q *Queries) GetWarehouseByCode(ctx context.Context, code string) (Warehouse, error) {
@@ -199,6 +298,44 @@ func (q *Queries) GetWarehouseByCode(ctx context.Context, code string) (Warehous
 		&i.ContactEmail,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getWarehouseDepth = `-- name: GetWarehouseDepth :one
+SELECT COALESCE(MAX(y_coord), 0)::float8 as depth
+FROM locations
+WHERE warehouse_id = $1 AND is_active = true
+`
+
+func (q *Queries) GetWarehouseDepth(ctx context.Context, warehouseID int32) (float64, error) {
+	row := q.db.QueryRowContext(ctx, getWarehouseDepth, warehouseID)
+	var depth float64
+	err := row.Scan(&depth)
+	return depth, err
+}
+
+const getWarehouseForUpdate = `-- name: GetWarehouseForUpdate :one
+SELECT warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at, updated_at FROM warehouses
+WHERE warehouse_id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetWarehouseForUpdate(ctx context.Context, warehouseID int32) (Warehouse, error) {
+	row := q.db.QueryRowContext(ctx, getWarehouseForUpdate, warehouseID)
+	var i Warehouse
+	err := row.Scan(
+		&i.WarehouseID,
+		&i.Code,
+		&i.Name,
+		&i.Address,
+		&i.ContactPerson,
+		&i.ContactPhone,
+		&i.ContactEmail,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -254,8 +391,52 @@ func (q *Queries) GetWarehouseInventorySummary(ctx context.Context) ([]GetWareho
 	return items, nil
 }
 
+const getWarehouseZone = `-- name: GetWarehouseZone :one
+SELECT zone_id, warehouse_id, zone_code, name, description, is_active, created_at FROM warehouse_zones WHERE zone_id = $1
+`
+
+func (q *Queries) GetWarehouseZone(ctx context.Context, zoneID int32) (WarehouseZone, error) {
+	row := q.db.QueryRowContext(ctx, getWarehouseZone, zoneID)
+	var i WarehouseZone
+	err := row.Scan(
+		&i.ZoneID,
+		&i.WarehouseID,
+		&i.ZoneCode,
+		&i.Name,
+		&i.Description,
+		&i.IsActive,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWarehouseZoneByCode = `-- name: GetWarehouseZoneByCode :one
+SELECT zone_id, warehouse_id, zone_code, name, description, is_active, created_at FROM warehouse_zones
+WHERE warehouse_id = $1 AND zone_code = $2
+`
+
+type GetWarehouseZoneByCodeParams struct {
+	WarehouseID int32  `json:"warehouse_id"`
+	ZoneCode    string `json:"zone_code"`
+}
+
+func (q *Queries) GetWarehouseZoneByCode(ctx context.Context, arg GetWarehouseZoneByCodeParams) (WarehouseZone, error) {
+	row := q.db.QueryRowContext(ctx, getWarehouseZoneByCode, arg.WarehouseID, arg.ZoneCode)
+	var i WarehouseZone
+	err := row.Scan(
+		&i.ZoneID,
+		&i.WarehouseID,
+		&i.ZoneCode,
+		&i.Name,
+		&i.Description,
+		&i.IsActive,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const listAllWarehouses = `-- name: ListAllWarehouses :many
-SELECT warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at FROM warehouses
+SELECT warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at, updated_at FROM warehouses
 ORDER BY name
 `
 
@@ -278,6 +459,7 @@ func (q *Queries) ListAllWarehouses(ctx context.Context) ([]Warehouse, error) {
 			&i.ContactEmail,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -293,7 +475,7 @@ func (q *Queries) ListAllWarehouses(ctx context.Context) ([]Warehouse, error) {
 }
 
 const listLocationsByWarehouse = `-- name: ListLocationsByWarehouse :many
-SELECT location_id, warehouse_id, location_code, aisle, shelf, bin, max_capacity, is_active FROM locations
+SELECT location_id, warehouse_id, location_code, aisle, shelf, bin, max_capacity, is_active, zone_id, x_coord, y_coord, pick_sequence, max_weight, max_volume FROM locations
 WHERE warehouse_id = $1 AND is_active = true
 ORDER BY location_code
 `
@@ -316,6 +498,93 @@ func (q *Queries) ListLocationsByWarehouse(ctx context.Context, warehouseID int3
 			&i.Bin,
 			&i.MaxCapacity,
 			&i.IsActive,
+			&i.ZoneID,
+			&i.XCoord,
+			&i.YCoord,
+			&i.PickSequence,
+			&i.MaxWeight,
+			&i.MaxVolume,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLocationsByZone = `-- name: ListLocationsByZone :many
+SELECT location_id, warehouse_id, location_code, aisle, shelf, bin, max_capacity, is_active, zone_id, x_coord, y_coord, pick_sequence, max_weight, max_volume FROM locations
+WHERE zone_id = $1 AND is_active = true
+ORDER BY pick_sequence NULLS LAST, location_code
+`
+
+func (q *Queries) ListLocationsByZone(ctx context.Context, zoneID sql.NullInt32) ([]Location, error) {
+	rows, err := q.db.QueryContext(ctx, listLocationsByZone, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Location
+	for rows.Next() {
+		var i Location
+		if err := rows.Scan(
+			&i.LocationID,
+			&i.WarehouseID,
+			&i.LocationCode,
+			&i.Aisle,
+			&i.Shelf,
+			&i.Bin,
+			&i.MaxCapacity,
+			&i.IsActive,
+			&i.ZoneID,
+			&i.XCoord,
+			&i.YCoord,
+			&i.PickSequence,
+			&i.MaxWeight,
+			&i.MaxVolume,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWarehouseZones = `-- name: ListWarehouseZones :many
+SELECT zone_id, warehouse_id, zone_code, name, description, is_active, created_at FROM warehouse_zones
+WHERE warehouse_id = $1 AND is_active = true
+ORDER BY zone_code
+`
+
+func (q *Queries) ListWarehouseZones(ctx context.Context, warehouseID int32) ([]WarehouseZone, error) {
+	rows, err := q.db.QueryContext(ctx, listWarehouseZones, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WarehouseZone
+	for rows.Next() {
+		var i WarehouseZone
+		if err := rows.Scan(
+			&i.ZoneID,
+			&i.WarehouseID,
+			&i.ZoneCode,
+			&i.Name,
+			&i.Description,
+			&i.IsActive,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -331,7 +600,7 @@ func (q *Queries) ListLocationsByWarehouse(ctx context.Context, warehouseID int3
 }
 
 const listWarehouses = `-- name: ListWarehouses :many
-SELECT warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at FROM warehouses
+SELECT warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at, updated_at FROM warehouses
 WHERE is_active = true
 ORDER BY name
 `
@@ -355,6 +624,7 @@ func (q *Queries) ListWarehouses(ctx context.Context) ([]Warehouse, error) {
 			&i.ContactEmail,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -374,17 +644,29 @@ UPDATE locations
 SET aisle = $2,
     shelf = $3,
     bin = $4,
-    max_capacity = $5
+    max_capacity = $5,
+    zone_id = $6,
+    x_coord = $7,
+    y_coord = $8,
+    pick_sequence = $9,
+    max_weight = $10,
+    max_volume = $11
 WHERE location_id = $1
-RETURNING location_id, warehouse_id, location_code, aisle, shelf, bin, max_capacity, is_active
+RETURNING location_id, warehouse_id, location_code, aisle, shelf, bin, max_capacity, is_active, zone_id, x_coord, y_coord, pick_sequence, max_weight, max_volume
 `
 
 type UpdateLocationParams struct {
-	LocationID  int32          `json:"location_id"`
-	Aisle       sql.NullString `json:"aisle"`
-	Shelf       sql.NullString `json:"shelf"`
-	Bin         sql.NullString `json:"bin"`
-	MaxCapacity sql.NullInt32  `json:"max_capacity"`
+	LocationID   int32               `json:"location_id"`
+	Aisle        sql.NullString      `json:"aisle"`
+	Shelf        sql.NullString      `json:"shelf"`
+	Bin          sql.NullString      `json:"bin"`
+	MaxCapacity  sql.NullInt32       `json:"max_capacity"`
+	ZoneID       sql.NullInt32       `json:"zone_id"`
+	XCoord       decimal.NullDecimal `json:"x_coord"`
+	YCoord       decimal.NullDecimal `json:"y_coord"`
+	PickSequence sql.NullInt32       `json:"pick_sequence"`
+	MaxWeight    decimal.NullDecimal `json:"max_weight"`
+	MaxVolume    decimal.NullDecimal `json:"max_volume"`
 }
 
 func (q *Queries) UpdateLocation(ctx context.Context, arg UpdateLocationParams) (Location, error) {
@@ -394,6 +676,12 @@ func (q *Queries) UpdateLocation(ctx context.Context, arg UpdateLocationParams)
 		arg.Shelf,
 		arg.Bin,
 		arg.MaxCapacity,
+		arg.ZoneID,
+		arg.XCoord,
+		arg.YCoord,
+		arg.PickSequence,
+		arg.MaxWeight,
+		arg.MaxVolume,
 	)
 	var i Location
 	err := row.Scan(
@@ -405,6 +693,12 @@ func (q *Queries) UpdateLocation(ctx context.Context, arg UpdateLocationParams)
 		&i.Bin,
 		&i.MaxCapacity,
 		&i.IsActive,
+		&i.ZoneID,
+		&i.XCoord,
+		&i.YCoord,
+		&i.PickSequence,
+		&i.MaxWeight,
+		&i.MaxVolume,
 	)
 	return i, err
 }
@@ -415,9 +709,10 @@ SET name = $2,
     address = $3,
     contact_person = $4,
     contact_phone = $5,
-    contact_email = $6
+    contact_email = $6,
+    updated_at = CURRENT_TIMESTAMP
 WHERE warehouse_id = $1
-RETURNING warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at
+RETURNING warehouse_id, code, name, address, contact_person, contact_phone, contact_email, is_active, created_at, updated_at
 `
 
 type UpdateWarehouseParams struct {
@@ -449,6 +744,43 @@ func (q *Queries) UpdateWarehouse(ctx context.Context, arg UpdateWarehouseParams
 		&i.ContactEmail,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateWarehouseZone = `-- name: UpdateWarehouseZone :one
+UPDATE warehouse_zones
+SET name = $2,
+    description = $3,
+    is_active = $4
+WHERE zone_id = $1
+RETURNING zone_id, warehouse_id, zone_code, name, description, is_active, created_at
+`
+
+type UpdateWarehouseZoneParams struct {
+	ZoneID      int32          `json:"zone_id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	IsActive    bool           `json:"is_active"`
+}
+
+func (q *Queries) UpdateWarehouseZone(ctx context.Context, arg UpdateWarehouseZoneParams) (WarehouseZone, error) {
+	row := q.db.QueryRowContext(ctx, updateWarehouseZone,
+		arg.ZoneID,
+		arg.Name,
+		arg.Description,
+		arg.IsActive,
+	)
+	var i WarehouseZone
+	err := row.Scan(
+		&i.ZoneID,
+		&i.WarehouseID,
+		&i.ZoneCode,
+		&i.Name,
+		&i.Description,
+		&i.IsActive,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,293 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/molu/stock-management-system/internal/stream"
+	"github.com/molu/stock-management-system/internal/webhook"
+)
+
+var (
+	ErrWebhookEventInvalid  = errors.New("event type must be inventory.changed, po.received, transfer.completed or stock.below_reorder")
+	ErrWebhookEventRequired = errors.New("a subscription needs at least one event type")
+)
+
+// enqueueWebhookEvent writes an event to the outbox with a delivery for
+// every active subscription to its type. It runs in the transaction of the
+// change the event describes, so the event is sent if and only if the change
+// is committed. Without subscribers nothing is written.
+func enqueueWebhookEvent(ctx context.Context, q *Queries, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	event, err := q.CreateWebhookEvent(ctx, CreateWebhookEventParams{
+		EventType: eventType,
+		Payload:   payload,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateWebhookDeliveries(ctx, CreateWebhookDeliveriesParams{
+		EventID:   event.EventID,
+		EventType: eventType,
+	})
+	return err
+}
+
+// postStockMovement records a stock movement, streams it and publishes the
+// inventory change it describes. Every movement is written through here.
+func postStockMovement(ctx context.Context, q *Queries, arg CreateStockMovementParams) (StockMovement, error) {
+	m, err := q.CreateStockMovement(ctx, arg)
+	if err != nil {
+		return m, err
+	}
+	if err := streamStockEvent(ctx, q, stream.EventMovementCreated, m.WarehouseID, m.ProductID, m); err != nil {
+		return m, err
+	}
+
+	change := webhook.InventoryChanged{
+		ProductID:      m.ProductID,
+		WarehouseID:    m.WarehouseID,
+		LocationID:     m.LocationID.Int32,
+		MovementID:     m.MovementID,
+		MovementType:   string(m.MovementType),
+		QuantityChange: m.QuantityChange,
+		ReferenceTable: m.ReferenceTable.String,
+		ReferenceID:    m.ReferenceID.Int32,
+	}
+	if m.QuantityAfter.Valid {
+		change.QuantityAfter = &m.QuantityAfter.Int32
+	}
+	// A move between locations leaves the product's total alone.
+	checkReorder := m.MovementType != MovementTypeStockTransfer
+	return m, publishInventoryChange(ctx, q, change, checkReorder)
+}
+
+// publishInventoryChange sends inventory.changed for a change already
+// applied to inventory and, when checkReorder is set, stock.below_reorder if
+// it took the product down to its reorder point, both to webhook
+// subscribers and to the live stream.
+func publishInventoryChange(ctx context.Context, q *Queries, change webhook.InventoryChanged, checkReorder bool) error {
+	if change.QuantityChange == 0 {
+		return nil
+	}
+
+	product, err := q.GetProductAvailability(ctx, change.ProductID)
+	if err != nil {
+		return err
+	}
+	change.Sku = product.Sku
+	change.Available = product.AvailableQty
+
+	if err := enqueueWebhookEvent(ctx, q, webhook.EventInventoryChanged, change); err != nil {
+		return err
+	}
+	if err := streamStockEvent(ctx, q, stream.EventInventoryChanged, change.WarehouseID, change.ProductID, change); err != nil {
+		return err
+	}
+
+	before := product.AvailableQty - change.QuantityChange
+	if !checkReorder || !product.ReorderPoint.Valid ||
+		!webhook.BelowReorder(before, product.AvailableQty, product.ReorderPoint.Int32) {
+		return nil
+	}
+	alert := webhook.StockBelowReorder{
+		ProductID:    product.ProductID,
+		Sku:          product.Sku,
+		WarehouseID:  change.WarehouseID,
+		ReorderPoint: product.ReorderPoint.Int32,
+		Available:    product.AvailableQty,
+	}
+	if err := enqueueWebhookEvent(ctx, q, webhook.EventStockBelowReorder, alert); err != nil {
+		return err
+	}
+	return streamStockEvent(ctx, q, stream.EventStockBelowReorder, alert.WarehouseID, alert.ProductID, alert)
+}
+
+// setWebhookSubscriptionEvents replaces the event types of a subscription.
+func setWebhookSubscriptionEvents(ctx context.Context, q *Queries, subscriptionID int32, eventTypes []string) ([]string, error) {
+	if len(eventTypes) == 0 {
+		return nil, ErrWebhookEventRequired
+	}
+	for _, e := range eventTypes {
+		if !webhook.ValidEvent(e) {
+			return nil, ErrWebhookEventInvalid
+		}
+	}
+
+	if err := q.DeleteWebhookSubscriptionEvents(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	for _, e := range eventTypes {
+		if err := q.AddWebhookSubscriptionEvent(ctx, AddWebhookSubscriptionEventParams{
+			SubscriptionID: subscriptionID,
+			EventType:      e,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return q.ListWebhookSubscriptionEvents(ctx, subscriptionID)
+}
+
+type CreateWebhookSubscriptionTxParams struct {
+	CreateWebhookSubscriptionParams
+	EventTypes []string
+}
+
+type UpdateWebhookSubscriptionTxParams struct {
+	UpdateWebhookSubscriptionParams
+	EventTypes []string
+}
+
+type WebhookSubscriptionTxResult struct {
+	Subscription WebhookSubscription
+	EventTypes   []string
+}
+
+// CreateWebhookSubscriptionTx creates a subscription to the event types.
+func (store *SQLStore) CreateWebhookSubscriptionTx(ctx context.Context, arg CreateWebhookSubscriptionTxParams) (WebhookSubscriptionTxResult, error) {
+	var result WebhookSubscriptionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Subscription, err = q.CreateWebhookSubscription(ctx, arg.CreateWebhookSubscriptionParams)
+		if err != nil {
+			return err
+		}
+
+		result.EventTypes, err = setWebhookSubscriptionEvents(ctx, q, result.Subscription.SubscriptionID, arg.EventTypes)
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateWebhookSubscriptionTx updates a subscription and replaces its event
+// types. Events already in the outbox are still delivered to it.
+func (store *SQLStore) UpdateWebhookSubscriptionTx(ctx context.Context, arg UpdateWebhookSubscriptionTxParams) (WebhookSubscriptionTxResult, error) {
+	var result WebhookSubscriptionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Subscription, err = q.UpdateWebhookSubscription(ctx, arg.UpdateWebhookSubscriptionParams)
+		if err != nil {
+			return err
+		}
+
+		result.EventTypes, err = setWebhookSubscriptionEvents(ctx, q, result.Subscription.SubscriptionID, arg.EventTypes)
+		return err
+	})
+
+	return result, err
+}
+
+type RecordWebhookAttemptTxParams struct {
+	DeliveryID int32
+	// Attempts made before this one.
+	Attempts int32
+	Result   webhook.Result
+}
+
+type RecordWebhookAttemptTxResult struct {
+	Delivery WebhookDelivery
+	Attempt  WebhookDeliveryAttempt
+}
+
+// RecordWebhookAttemptTx logs an attempt and moves its delivery on:
+// delivered on a 2xx answer, failed once MaxAttempts have failed, and
+// otherwise due again after the backoff.
+func (store *SQLStore) RecordWebhookAttemptTx(ctx context.Context, arg RecordWebhookAttemptTxParams) (RecordWebhookAttemptTxResult, error) {
+	var result RecordWebhookAttemptTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		res := arg.Result
+		responseStatus := sql.NullInt32{Int32: int32(res.StatusCode), Valid: res.StatusCode != 0}
+		lastError := sql.NullString{String: res.Error, Valid: res.Error != ""}
+
+		result.Attempt, err = q.CreateWebhookDeliveryAttempt(ctx, CreateWebhookDeliveryAttemptParams{
+			DeliveryID:     arg.DeliveryID,
+			ResponseStatus: responseStatus,
+			Error:          lastError,
+			DurationMs:     int32(res.Duration.Milliseconds()),
+		})
+		if err != nil {
+			return err
+		}
+
+		failed := int(arg.Attempts) + 1
+		status := WebhookDeliveryStatusPending
+		switch {
+		case res.OK():
+			status = WebhookDeliveryStatusDelivered
+		case failed >= webhook.MaxAttempts:
+			status = WebhookDeliveryStatusFailed
+		}
+
+		// The backoff runs on the database clock, which the claim query
+		// compares next_attempt_at with.
+		result.Delivery, err = q.FinishWebhookDeliveryAttempt(ctx, FinishWebhookDeliveryAttemptParams{
+			Status:         status,
+			BackoffSeconds: int32(webhook.Backoff(failed).Seconds()),
+			ResponseStatus: responseStatus,
+			LastError:      lastError,
+			DeliveryID:     arg.DeliveryID,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateStockTransferStatusTx changes the status of a transfer and sends
+// transfer.completed when it becomes completed.
+func (store *SQLStore) UpdateStockTransferStatusTx(ctx context.Context, arg UpdateStockTransferStatusParams) (StockTransfer, error) {
+	var result StockTransfer
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetStockTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.UpdateStockTransferStatus(ctx, arg)
+		if err != nil {
+			return err
+		}
+		if result.Status != TransferStatusCompleted || before.Status == TransferStatusCompleted {
+			return nil
+		}
+
+		items, err := q.ListStockTransferItems(ctx, result.TransferID)
+		if err != nil {
+			return err
+		}
+		event := webhook.TransferCompleted{
+			TransferID:      result.TransferID,
+			TransferNumber:  result.TransferNumber,
+			FromWarehouseID: result.FromWarehouseID,
+			ToWarehouseID:   result.ToWarehouseID,
+			Items:           make([]webhook.TransferItem, len(items)),
+		}
+		for i, item := range items {
+			event.Items[i] = webhook.TransferItem{
+				ProductID:        item.ProductID,
+				Quantity:         item.Quantity,
+				QuantitySent:     item.QuantitySent,
+				QuantityReceived: item.QuantityReceived,
+			}
+		}
+		return enqueueWebhookEvent(ctx, q, webhook.EventTransferCompleted, event)
+	})
+
+	return result, err
+}
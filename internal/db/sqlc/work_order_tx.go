@@ -0,0 +1,535 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/molu/stock-management-system/internal/kitting"
+	"github.com/molu/stock-management-system/internal/manufacturing"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidWorkOrderQuantity = errors.New("work order quantities cannot be negative, and the planned quantity must be positive")
+	ErrNotWorkOrderComponent    = errors.New("product is not a component of this work order")
+	ErrNothingConsumed          = errors.New("no components have been consumed on this work order")
+)
+
+type WorkOrderTxResult struct {
+	WorkOrder    WorkOrder              `json:"work_order"`
+	Components   []WorkOrderComponent   `json:"components,omitempty"`
+	Reservations []WorkOrderReservation `json:"reservations,omitempty"`
+	Consumptions []WorkOrderConsumption `json:"consumptions,omitempty"`
+	Movements    []StockMovement        `json:"movements,omitempty"`
+}
+
+type CreateWorkOrderTxParams struct {
+	ProductID int32
+	// OutputLocationID receives the finished goods; its warehouse supplies
+	// the components.
+	OutputLocationID int32
+	PlannedQuantity  int32
+	BatchNumber      sql.NullString
+	ExpiryDate       sql.NullTime
+	Notes            sql.NullString
+	CreatedBy        sql.NullInt32
+}
+
+// CreateWorkOrderTx opens a draft work order for a product with a bill of
+// materials, copying the bill so later changes to it leave the order alone.
+func (store *SQLStore) CreateWorkOrderTx(ctx context.Context, arg CreateWorkOrderTxParams) (WorkOrderTxResult, error) {
+	var result WorkOrderTxResult
+
+	if arg.PlannedQuantity <= 0 {
+		return result, ErrInvalidWorkOrderQuantity
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		loc, err := q.GetLocation(ctx, arg.OutputLocationID)
+		if err != nil {
+			return err
+		}
+		if _, err := q.GetProduct(ctx, arg.ProductID); err != nil {
+			return err
+		}
+		bom, err := q.ListKitComponents(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+		if len(bom) == 0 {
+			return kitting.ErrNoComponents
+		}
+
+		wo, err := q.CreateWorkOrder(ctx, CreateWorkOrderParams{
+			ProductID:         arg.ProductID,
+			WarehouseID:       loc.WarehouseID,
+			OutputLocationID:  loc.LocationID,
+			PlannedQuantity:   arg.PlannedQuantity,
+			OutputBatchNumber: arg.BatchNumber,
+			ExpiryDate:        arg.ExpiryDate,
+			Notes:             arg.Notes,
+			CreatedBy:         arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		result.WorkOrder, err = q.SetWorkOrderNumber(ctx, SetWorkOrderNumberParams{
+			WorkOrderID:     wo.WorkOrderID,
+			WorkOrderNumber: sql.NullString{String: fmt.Sprintf("WO-%06d", wo.WorkOrderID), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, c := range bom {
+			component, err := q.CreateWorkOrderComponent(ctx, CreateWorkOrderComponentParams{
+				WorkOrderID:        wo.WorkOrderID,
+				ComponentProductID: c.ComponentProductID,
+				QuantityPer:        c.Quantity,
+				PlannedQuantity:    c.Quantity * arg.PlannedQuantity,
+			})
+			if err != nil {
+				return err
+			}
+			result.Components = append(result.Components, component)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// lockWorkOrder locks a work order and checks it may move to the status.
+func lockWorkOrder(ctx context.Context, q *Queries, workOrderID int32, to WorkOrderStatus) (WorkOrder, error) {
+	wo, err := q.GetWorkOrderForUpdate(ctx, workOrderID)
+	if err != nil {
+		return wo, err
+	}
+	return wo, manufacturing.Check(manufacturing.Status(wo.Status), manufacturing.Status(to))
+}
+
+// ReleaseWorkOrderTx releases a draft work order to the floor, reserving
+// its planned components first expiring first so nothing else picks them.
+func (store *SQLStore) ReleaseWorkOrderTx(ctx context.Context, workOrderID int32) (WorkOrderTxResult, error) {
+	var result WorkOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		wo, err := lockWorkOrder(ctx, q, workOrderID, WorkOrderStatusReleased)
+		if err != nil {
+			return err
+		}
+		result.Components, err = q.ListWorkOrderComponents(ctx, wo.WorkOrderID)
+		if err != nil {
+			return err
+		}
+		for _, c := range result.Components {
+			reservations, err := reserveComponent(ctx, q, wo, c)
+			if err != nil {
+				return err
+			}
+			result.Reservations = append(result.Reservations, reservations...)
+		}
+
+		result.WorkOrder, err = q.SetWorkOrderStatus(ctx, SetWorkOrderStatusParams{
+			WorkOrderID: wo.WorkOrderID,
+			Status:      WorkOrderStatusReleased,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// reserveComponent reserves a component's planned quantity across the
+// unreserved inventory rows of the work order's warehouse.
+func reserveComponent(ctx context.Context, q *Queries, wo WorkOrder, c WorkOrderComponent) ([]WorkOrderReservation, error) {
+	rows, err := q.ListAvailableInventoryForUpdate(ctx, ListAvailableInventoryForUpdateParams{
+		ProductID:   c.ComponentProductID,
+		WarehouseID: wo.WarehouseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var reservations []WorkOrderReservation
+	remaining := c.PlannedQuantity
+	for _, row := range rows {
+		if remaining == 0 {
+			break
+		}
+		take := row.Quantity - row.ReservedQuantity
+		if take > remaining {
+			take = remaining
+		}
+		if _, err := q.ReserveInventory(ctx, ReserveInventoryParams{
+			InventoryID:      row.InventoryID,
+			ReservedQuantity: take,
+		}); err != nil {
+			return nil, err
+		}
+		reservation, err := q.CreateWorkOrderReservation(ctx, CreateWorkOrderReservationParams{
+			WorkOrderID:   wo.WorkOrderID,
+			WoComponentID: c.WoComponentID,
+			InventoryID:   row.InventoryID,
+			Quantity:      take,
+		})
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+		remaining -= take
+	}
+	if remaining > 0 {
+		product, err := q.GetProduct(ctx, c.ComponentProductID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w of component %s: %d needed, %d available", ErrInsufficientStock, product.Sku, c.PlannedQuantity, c.PlannedQuantity-remaining)
+	}
+	return reservations, nil
+}
+
+// releaseWorkOrderReservations gives back whatever a work order still holds
+// reserved.
+func releaseWorkOrderReservations(ctx context.Context, q *Queries, workOrderID int32) error {
+	reservations, err := q.ListWorkOrderReservations(ctx, workOrderID)
+	if err != nil {
+		return err
+	}
+	for _, r := range reservations {
+		if r.OpenQuantity == 0 {
+			continue
+		}
+		if _, err := q.ReleaseInventoryReservation(ctx, ReleaseInventoryReservationParams{
+			InventoryID:      r.InventoryID,
+			ReservedQuantity: r.OpenQuantity,
+		}); err != nil {
+			return err
+		}
+		if _, err := q.ReduceWorkOrderReservation(ctx, ReduceWorkOrderReservationParams{
+			ReservationID: r.ReservationID,
+			OpenQuantity:  r.OpenQuantity,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type WorkOrderConsumptionLine struct {
+	ComponentProductID int32
+	// Quantity went into the output; ScrapQuantity was wasted on the way.
+	Quantity      int32
+	ScrapQuantity int32
+	// BatchNumber, when valid, is the only component lot drawn from.
+	BatchNumber sql.NullString
+}
+
+type ConsumeWorkOrderTxParams struct {
+	WorkOrderID int32
+	Lines       []WorkOrderConsumptionLine
+	CreatedBy   sql.NullInt32
+}
+
+// ConsumeWorkOrderTx records what was actually used on a work order,
+// issuing the components from its reservations first and then from
+// unreserved stock, and starts the work order if it had not started.
+func (store *SQLStore) ConsumeWorkOrderTx(ctx context.Context, arg ConsumeWorkOrderTxParams) (WorkOrderTxResult, error) {
+	var result WorkOrderTxResult
+
+	for _, l := range arg.Lines {
+		if l.Quantity < 0 || l.ScrapQuantity < 0 || l.Quantity+l.ScrapQuantity == 0 {
+			return result, ErrInvalidWorkOrderQuantity
+		}
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		wo, err := q.GetWorkOrderForUpdate(ctx, arg.WorkOrderID)
+		if err != nil {
+			return err
+		}
+		if wo.Status != WorkOrderStatusInProgress {
+			if err := manufacturing.Check(manufacturing.Status(wo.Status), manufacturing.InProgress); err != nil {
+				return err
+			}
+		}
+		components, err := q.ListWorkOrderComponents(ctx, wo.WorkOrderID)
+		if err != nil {
+			return err
+		}
+		byProduct := make(map[int32]WorkOrderComponent, len(components))
+		for _, c := range components {
+			byProduct[c.ComponentProductID] = c
+		}
+
+		for _, l := range arg.Lines {
+			c, ok := byProduct[l.ComponentProductID]
+			if !ok {
+				return fmt.Errorf("%w: product %d", ErrNotWorkOrderComponent, l.ComponentProductID)
+			}
+			if err := consumeComponent(ctx, q, wo, c, l.Quantity, false, l.BatchNumber, arg.CreatedBy, &result); err != nil {
+				return err
+			}
+			if err := consumeComponent(ctx, q, wo, c, l.ScrapQuantity, true, l.BatchNumber, arg.CreatedBy, &result); err != nil {
+				return err
+			}
+		}
+
+		if result.Components, err = q.ListWorkOrderComponents(ctx, wo.WorkOrderID); err != nil {
+			return err
+		}
+		result.WorkOrder = wo
+		if wo.Status != WorkOrderStatusInProgress {
+			result.WorkOrder, err = q.SetWorkOrderStatus(ctx, SetWorkOrderStatusParams{
+				WorkOrderID: wo.WorkOrderID,
+				Status:      WorkOrderStatusInProgress,
+			})
+		}
+		return err
+	})
+
+	return result, err
+}
+
+// consumeComponent issues quantity units of a component to a work order as
+// production movements, recording the lot and cost of each draw.
+func consumeComponent(ctx context.Context, q *Queries, wo WorkOrder, c WorkOrderComponent, quantity int32, scrap bool, batch sql.NullString, createdBy sql.NullInt32, result *WorkOrderTxResult) error {
+	if quantity == 0 {
+		return nil
+	}
+	issue := CreateStockMovementParams{
+		MovementType:   MovementTypeProduction,
+		ReferenceID:    sql.NullInt32{Int32: wo.WorkOrderID, Valid: true},
+		ReferenceTable: sql.NullString{String: "work_orders", Valid: true},
+		CreatedBy:      createdBy,
+	}
+
+	var lots []issuedLot
+	remaining := quantity
+
+	reservations, err := q.ListOpenWorkOrderReservationsForUpdate(ctx, c.WoComponentID)
+	if err != nil {
+		return err
+	}
+	for _, r := range reservations {
+		if remaining == 0 {
+			break
+		}
+		before, err := q.GetInventoryForUpdate(ctx, r.InventoryID)
+		if err != nil {
+			return err
+		}
+		if batch.Valid && before.BatchNumber != batch {
+			continue
+		}
+		take := r.OpenQuantity
+		if take > remaining {
+			take = remaining
+		}
+		after, err := q.PickInventory(ctx, PickInventoryParams{
+			Quantity:    take,
+			InventoryID: r.InventoryID,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := q.ReduceWorkOrderReservation(ctx, ReduceWorkOrderReservationParams{
+			ReservationID: r.ReservationID,
+			OpenQuantity:  take,
+		}); err != nil {
+			return err
+		}
+		lot, err := postIssue(ctx, q, before, after, take, issue)
+		if err != nil {
+			return err
+		}
+		lots = append(lots, lot)
+		remaining -= take
+	}
+
+	if remaining > 0 {
+		free, err := issueLots(ctx, q, c.ComponentProductID, wo.WarehouseID, remaining, batch, issue)
+		if err != nil {
+			if errors.Is(err, ErrInsufficientStock) {
+				product, perr := q.GetProduct(ctx, c.ComponentProductID)
+				if perr != nil {
+					return perr
+				}
+				return fmt.Errorf("%w of component %s: %d more needed", ErrInsufficientStock, product.Sku, remaining)
+			}
+			return err
+		}
+		lots = append(lots, free...)
+	}
+
+	for _, lot := range lots {
+		consumption, err := q.CreateWorkOrderConsumption(ctx, CreateWorkOrderConsumptionParams{
+			WorkOrderID:        wo.WorkOrderID,
+			WoComponentID:      c.WoComponentID,
+			ComponentProductID: c.ComponentProductID,
+			InventoryID:        lot.Inventory.InventoryID,
+			MovementID:         lot.Movement.MovementID,
+			BatchNumber:        lot.Inventory.BatchNumber,
+			Quantity:           lot.Quantity,
+			IsScrap:            scrap,
+			TotalCost:          lot.Cost,
+			CreatedBy:          createdBy,
+		})
+		if err != nil {
+			return err
+		}
+		result.Consumptions = append(result.Consumptions, consumption)
+		result.Movements = append(result.Movements, lot.Movement)
+	}
+
+	var scrapped int32
+	if scrap {
+		scrapped = quantity
+	}
+	_, err = q.AddWorkOrderComponentConsumption(ctx, AddWorkOrderComponentConsumptionParams{
+		WoComponentID:    c.WoComponentID,
+		ConsumedQuantity: quantity,
+		ScrapQuantity:    scrapped,
+	})
+	return err
+}
+
+type CompleteWorkOrderTxParams struct {
+	WorkOrderID      int32
+	ProducedQuantity int32
+	ScrapQuantity    int32
+	// BatchNumber and ExpiryDate override the ones set on the work order;
+	// the output lot falls back to the work order number.
+	BatchNumber sql.NullString
+	ExpiryDate  sql.NullTime
+	// Backflush consumes whatever the standard usage of the output still
+	// calls for beyond the recorded consumption.
+	Backflush bool
+	CreatedBy sql.NullInt32
+}
+
+// CompleteWorkOrderTx records a work order's yield and receives the good
+// output into its location as one lot, costed at everything consumed over
+// the good units. Reservations left over are released.
+func (store *SQLStore) CompleteWorkOrderTx(ctx context.Context, arg CompleteWorkOrderTxParams) (WorkOrderTxResult, error) {
+	var result WorkOrderTxResult
+
+	if arg.ProducedQuantity < 0 || arg.ScrapQuantity < 0 {
+		return result, ErrInvalidWorkOrderQuantity
+	}
+	if arg.ProducedQuantity+arg.ScrapQuantity == 0 {
+		return result, manufacturing.ErrNothingProduced
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		wo, err := lockWorkOrder(ctx, q, arg.WorkOrderID, WorkOrderStatusCompleted)
+		if err != nil {
+			return err
+		}
+		if arg.Backflush {
+			components, err := q.ListWorkOrderComponents(ctx, wo.WorkOrderID)
+			if err != nil {
+				return err
+			}
+			for _, c := range components {
+				n := manufacturing.Backflush(c.QuantityPer, arg.ProducedQuantity, arg.ScrapQuantity, c.ConsumedQuantity)
+				if err := consumeComponent(ctx, q, wo, c, n, false, sql.NullString{}, arg.CreatedBy, &result); err != nil {
+					return err
+				}
+			}
+		}
+		if err := releaseWorkOrderReservations(ctx, q, wo.WorkOrderID); err != nil {
+			return err
+		}
+
+		consumptions, err := q.ListWorkOrderConsumptions(ctx, wo.WorkOrderID)
+		if err != nil {
+			return err
+		}
+		if len(consumptions) == 0 {
+			return ErrNothingConsumed
+		}
+		total := decimal.Zero
+		for _, c := range consumptions {
+			total = total.Add(c.TotalCost)
+		}
+
+		batch := wo.OutputBatchNumber
+		if arg.BatchNumber.Valid {
+			batch = arg.BatchNumber
+		}
+		if !batch.Valid {
+			batch = wo.WorkOrderNumber
+		}
+		expiry := wo.ExpiryDate
+		if arg.ExpiryDate.Valid {
+			expiry = arg.ExpiryDate
+		}
+
+		result.WorkOrder, err = q.CompleteWorkOrder(ctx, CompleteWorkOrderParams{
+			WorkOrderID:       wo.WorkOrderID,
+			ProducedQuantity:  arg.ProducedQuantity,
+			ScrapQuantity:     arg.ScrapQuantity,
+			OutputBatchNumber: batch,
+			ExpiryDate:        expiry,
+			UnitCost:          manufacturing.UnitCost(total, arg.ProducedQuantity),
+			TotalCost:         total,
+		})
+		if err != nil {
+			return err
+		}
+		if result.Components, err = q.ListWorkOrderComponents(ctx, wo.WorkOrderID); err != nil {
+			return err
+		}
+		if arg.ProducedQuantity == 0 {
+			return nil
+		}
+
+		// The output is put away only now, so its receipt is costed at the
+		// unit cost just recorded on the work order.
+		put, err := putawayStock(ctx, q, PutawayTxParams{
+			ProductID:      wo.ProductID,
+			LocationID:     wo.OutputLocationID,
+			Quantity:       arg.ProducedQuantity,
+			BatchNumber:    batch,
+			ExpiryDate:     expiry,
+			ReferenceID:    sql.NullInt32{Int32: wo.WorkOrderID, Valid: true},
+			ReferenceTable: sql.NullString{String: "work_orders", Valid: true},
+			Notes:          wo.Notes,
+			CreatedBy:      arg.CreatedBy,
+			MovementType:   MovementTypeProduction,
+		})
+		if err != nil {
+			return err
+		}
+		result.Movements = append(result.Movements, put.Movement)
+		return nil
+	})
+
+	return result, err
+}
+
+// CancelWorkOrderTx cancels a work order nothing has been consumed on yet,
+// releasing its reservations.
+func (store *SQLStore) CancelWorkOrderTx(ctx context.Context, workOrderID int32) (WorkOrder, error) {
+	var result WorkOrder
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		wo, err := lockWorkOrder(ctx, q, workOrderID, WorkOrderStatusCancelled)
+		if err != nil {
+			return err
+		}
+		if err := releaseWorkOrderReservations(ctx, q, wo.WorkOrderID); err != nil {
+			return err
+		}
+		result, err = q.SetWorkOrderStatus(ctx, SetWorkOrderStatusParams{
+			WorkOrderID: wo.WorkOrderID,
+			Status:      WorkOrderStatusCancelled,
+		})
+		return err
+	})
+
+	return result, err
+}
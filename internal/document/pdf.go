@@ -0,0 +1,206 @@
+package document
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 page size in points.
+const (
+	PageWidth  = 595.0
+	PageHeight = 842.0
+)
+
+// PDF is a minimal PDF 1.4 writer for text documents. It draws text in the
+// standard Helvetica fonts, which every viewer has, so nothing needs to be
+// embedded. Coordinates are in points from the top-left corner of the page.
+type PDF struct {
+	title string
+	pages []*bytes.Buffer
+}
+
+func NewPDF(title string) *PDF {
+	return &PDF{title: title}
+}
+
+// AddPage starts a new page; later drawing goes to it.
+func (p *PDF) AddPage() {
+	p.pages = append(p.pages, &bytes.Buffer{})
+}
+
+// Pages is the number of pages added so far.
+func (p *PDF) Pages() int {
+	return len(p.pages)
+}
+
+func (p *PDF) page() *bytes.Buffer {
+	if len(p.pages) == 0 {
+		p.AddPage()
+	}
+	return p.pages[len(p.pages)-1]
+}
+
+// Text draws s with its baseline at y, starting at x.
+func (p *PDF) Text(x, y, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(p.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, PageHeight-y, escape(s))
+}
+
+// TextRight draws s so that it ends at x.
+func (p *PDF) TextRight(x, y, size float64, bold bool, s string) {
+	p.Text(x-TextWidth(s, size, bold), y, size, bold, s)
+}
+
+// Line draws a straight line of the given width.
+func (p *PDF) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(p.page(), "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// WriteTo writes the document.
+func (p *PDF) WriteTo(w io.Writer) (int64, error) {
+	if len(p.pages) == 0 {
+		p.AddPage()
+	}
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	var offsets []int64
+	obj := func(body string) {
+		offsets = append(offsets, cw.n)
+		fmt.Fprintf(cw, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	io.WriteString(cw, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-5 are fixed; each page then takes a page and a content object.
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title (%s) >>", escape(p.title)))
+	for i, content := range p.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", PageWidth, PageHeight, 7+2*i))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.Bytes()))
+	}
+
+	xref := cw.n
+	fmt.Fprintf(cw, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(cw, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(cw, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
+
+// escape encodes s as the body of a PDF string in WinAnsiEncoding.
+// Characters outside Latin-1 are printed as '?'.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r == '\t':
+			b.WriteByte(' ')
+		case r < 32:
+			continue
+		case r < 127 || (r >= 160 && r < 256):
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// TextWidth is the width of s in points when drawn at size.
+func TextWidth(s string, size float64, bold bool) float64 {
+	widths := helveticaWidths
+	if bold {
+		widths = helveticaBoldWidths
+	}
+	units := 0
+	for _, r := range s {
+		if r >= 32 && r < 127 {
+			units += widths[r-32]
+		} else {
+			units += 556
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// Wrap breaks s into lines no wider than width, at spaces where possible.
+// Newlines in s always start a new line.
+func Wrap(s string, size float64, bold bool, width float64) []string {
+	var lines []string
+	for _, para := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		words := strings.Fields(para)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		line := words[0]
+		for _, word := range words[1:] {
+			if TextWidth(line+" "+word, size, bold) > width {
+				lines = append(lines, line)
+				line = word
+				continue
+			}
+			line += " " + word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Glyph widths of ASCII 32-126 from the standard Helvetica font metrics, in
+// thousandths of the font size.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
@@ -0,0 +1,383 @@
+// Package document renders business documents, such as purchase orders to
+// send to suppliers, as PDF and CSV. It has no database dependencies and no
+// dependencies outside the standard library.
+package document
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultHeaderTemplate is the letterhead of warehouses without their own.
+const DefaultHeaderTemplate = `{{.Warehouse.Name}}
+{{.Warehouse.Address}}
+{{with .Warehouse.ContactPhone}}Tel: {{.}}{{end}}{{with .Warehouse.ContactEmail}}  Email: {{.}}{{end}}`
+
+// Party is a company or site named on a document.
+type Party struct {
+	Code          string
+	Name          string
+	Address       string
+	ContactPerson string
+	ContactPhone  string
+	ContactEmail  string
+}
+
+// LetterheadData is what letterhead templates are executed with.
+type LetterheadData struct {
+	Warehouse Party
+	PoNumber  string
+	OrderDate time.Time
+}
+
+// Letterhead is the rendered top and foot of every page.
+type Letterhead struct {
+	Header []string
+	Footer []string
+}
+
+// RenderLetterhead executes the header and footer templates. Blank lines
+// are dropped so optional fields leave no gaps.
+func RenderLetterhead(header, footer string, data LetterheadData) (Letterhead, error) {
+	var lh Letterhead
+	var err error
+	if lh.Header, err = renderLines("header", header, data); err != nil {
+		return lh, err
+	}
+	if lh.Footer, err = renderLines("footer", footer, data); err != nil {
+		return lh, err
+	}
+	return lh, nil
+}
+
+func renderLines(name, text string, data LetterheadData) ([]string, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s template: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("%s template: %w", name, err)
+	}
+	var lines []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// Supplier is who the order is sent to.
+type Supplier struct {
+	Party
+	TaxID        string
+	PaymentTerms string
+}
+
+// Line is one ordered product. Quantity and UnitPrice are in Unit, the unit
+// the line was ordered in.
+type Line struct {
+	Number      int
+	SKU         string
+	Description string
+	Quantity    int32
+	Unit        string
+	UnitPrice   decimal.Decimal
+	// ListPrice is the supplier's catalogue price for one Unit, when known.
+	ListPrice decimal.NullDecimal
+	Total     decimal.Decimal
+}
+
+// PurchaseOrder is everything printed on a purchase order.
+type PurchaseOrder struct {
+	Number       string
+	Status       string
+	OrderDate    time.Time
+	ExpectedDate time.Time
+	Currency     string
+	Notes        string
+	Letterhead   Letterhead
+	Supplier     Supplier
+	// ShipTo is the delivery warehouse; empty when the order has none.
+	ShipTo Party
+	Lines  []Line
+}
+
+// Total is the sum of the line totals.
+func (po PurchaseOrder) Total() decimal.Decimal {
+	total := decimal.Zero
+	for _, l := range po.Lines {
+		total = total.Add(l.Total)
+	}
+	return total
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// WriteCSV writes the order as CSV: a block of header fields, then the
+// lines and a total row.
+func (po PurchaseOrder) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	fields := [][]string{
+		{"po_number", po.Number},
+		{"status", po.Status},
+		{"order_date", formatDate(po.OrderDate)},
+		{"expected_delivery_date", formatDate(po.ExpectedDate)},
+		{"currency", po.Currency},
+		{"supplier_code", po.Supplier.Code},
+		{"supplier_name", po.Supplier.Name},
+		{"supplier_address", po.Supplier.Address},
+		{"supplier_tax_id", po.Supplier.TaxID},
+		{"payment_terms", po.Supplier.PaymentTerms},
+		{"ship_to", po.ShipTo.Name},
+		{"ship_to_address", po.ShipTo.Address},
+		{"notes", po.Notes},
+	}
+	for _, f := range fields {
+		cw.Write(f)
+	}
+	cw.Write(nil)
+
+	cw.Write([]string{"line", "sku", "description", "quantity", "unit", "unit_price", "list_price", "line_total"})
+	for _, l := range po.Lines {
+		listPrice := ""
+		if l.ListPrice.Valid {
+			listPrice = l.ListPrice.Decimal.StringFixed(2)
+		}
+		cw.Write([]string{
+			strconv.Itoa(l.Number), l.SKU, l.Description, strconv.Itoa(int(l.Quantity)), l.Unit,
+			l.UnitPrice.StringFixed(2), listPrice, l.Total.StringFixed(2),
+		})
+	}
+	cw.Write([]string{"total", "", "", "", "", "", "", po.Total().StringFixed(2)})
+	cw.Flush()
+	return cw.Error()
+}
+
+// Layout of the PDF page, in points.
+const (
+	marginX      = 40.0
+	marginTop    = 50.0
+	marginBottom = 60.0
+	bodySize     = 9.0
+	lineHeight   = 12.0
+)
+
+// Columns of the line table; amounts are right-aligned at their x.
+var (
+	colNumber      = marginX
+	colSKU         = marginX + 25
+	colDescription = marginX + 110
+	colQuantity    = 360.0
+	colUnit        = 366.0
+	colUnitPrice   = 455.0
+	colTotal       = PageWidth - marginX
+)
+
+// WritePDF writes the order as an A4 PDF with the letterhead on every page.
+func (po PurchaseOrder) WritePDF(w io.Writer) error {
+	r := &pdfRenderer{pdf: NewPDF("Purchase Order " + po.Number), po: po}
+	r.newPage()
+	r.parties()
+	r.tableHeader()
+	for _, l := range po.Lines {
+		r.line(l)
+	}
+	r.totals()
+	_, err := r.pdf.WriteTo(w)
+	return err
+}
+
+type pdfRenderer struct {
+	pdf *PDF
+	po  PurchaseOrder
+	y   float64
+}
+
+func (r *pdfRenderer) newPage() {
+	r.pdf.AddPage()
+	r.y = marginTop
+	for i, text := range r.po.Letterhead.Header {
+		if i == 0 {
+			r.pdf.Text(marginX, r.y, 14, true, text)
+			r.y += 18
+			continue
+		}
+		r.pdf.Text(marginX, r.y, bodySize, false, text)
+		r.y += lineHeight
+	}
+
+	title := "PURCHASE ORDER"
+	r.pdf.TextRight(colTotal, marginTop, 16, true, title)
+	r.pdf.TextRight(colTotal, marginTop+18, 10, true, r.po.Number)
+	r.pdf.TextRight(colTotal, marginTop+30, bodySize, false, fmt.Sprintf("Page %d", r.pdf.Pages()))
+	if r.y < marginTop+36 {
+		r.y = marginTop + 36
+	}
+	r.y += 6
+	r.pdf.Line(marginX, r.y, colTotal, r.y, 1)
+	r.y += 18
+
+	footerY := PageHeight - marginBottom + 20
+	for _, text := range r.po.Letterhead.Footer {
+		r.pdf.Text(marginX, footerY, 7.5, false, text)
+		footerY += 10
+	}
+}
+
+// ensure starts a new page, with the table header when inTable, unless
+// height points still fit above the footer.
+func (r *pdfRenderer) ensure(height float64, inTable bool) {
+	if r.y+height <= PageHeight-marginBottom {
+		return
+	}
+	r.newPage()
+	if inTable {
+		r.tableHeader()
+	}
+}
+
+func (r *pdfRenderer) block(x, y float64, heading string, lines []string) float64 {
+	r.pdf.Text(x, y, bodySize, true, heading)
+	y += lineHeight
+	for _, l := range lines {
+		for _, wrapped := range Wrap(l, bodySize, false, 240) {
+			r.pdf.Text(x, y, bodySize, false, wrapped)
+			y += lineHeight
+		}
+	}
+	return y
+}
+
+func partyLines(p Party) []string {
+	lines := []string{p.Name}
+	if p.Address != "" {
+		lines = append(lines, strings.Split(p.Address, "\n")...)
+	}
+	if p.ContactPerson != "" {
+		lines = append(lines, "Attn: "+p.ContactPerson)
+	}
+	if p.ContactPhone != "" {
+		lines = append(lines, "Tel: "+p.ContactPhone)
+	}
+	if p.ContactEmail != "" {
+		lines = append(lines, "Email: "+p.ContactEmail)
+	}
+	return lines
+}
+
+func (r *pdfRenderer) parties() {
+	po := r.po
+	supplier := partyLines(po.Supplier.Party)
+	if po.Supplier.TaxID != "" {
+		supplier = append(supplier, "Tax ID: "+po.Supplier.TaxID)
+	}
+	left := r.block(marginX, r.y, "Supplier", supplier)
+
+	right := r.y
+	if po.ShipTo.Name != "" {
+		right = r.block(320, r.y, "Ship To", partyLines(po.ShipTo))
+	}
+
+	r.y = max(left, right) + 8
+	details := [][2]string{
+		{"Order date", formatDate(po.OrderDate)},
+		{"Expected delivery", formatDate(po.ExpectedDate)},
+		{"Payment terms", po.Supplier.PaymentTerms},
+		{"Currency", po.Currency},
+		{"Status", po.Status},
+	}
+	for _, d := range details {
+		if d[1] == "" {
+			continue
+		}
+		r.pdf.Text(marginX, r.y, bodySize, true, d[0]+":")
+		r.pdf.Text(marginX+90, r.y, bodySize, false, d[1])
+		r.y += lineHeight
+	}
+	r.y += 10
+}
+
+func (r *pdfRenderer) tableHeader() {
+	r.pdf.Text(colNumber, r.y, bodySize, true, "#")
+	r.pdf.Text(colSKU, r.y, bodySize, true, "SKU")
+	r.pdf.Text(colDescription, r.y, bodySize, true, "Description")
+	r.pdf.TextRight(colQuantity, r.y, bodySize, true, "Qty")
+	r.pdf.Text(colUnit, r.y, bodySize, true, "Unit")
+	r.pdf.TextRight(colUnitPrice, r.y, bodySize, true, "Unit price")
+	r.pdf.TextRight(colTotal, r.y, bodySize, true, "Amount")
+	r.y += 4
+	r.pdf.Line(marginX, r.y, colTotal, r.y, 0.5)
+	r.y += lineHeight
+}
+
+func (r *pdfRenderer) line(l Line) {
+	description := Wrap(l.Description, bodySize, false, colQuantity-colDescription-40)
+	sku := Wrap(l.SKU, bodySize, false, colDescription-colSKU-6)
+	rows := max(len(description), len(sku))
+	if l.ListPrice.Valid && !l.ListPrice.Decimal.Equal(l.UnitPrice) {
+		rows++
+	}
+	r.ensure(float64(rows)*lineHeight, true)
+
+	r.pdf.Text(colNumber, r.y, bodySize, false, strconv.Itoa(l.Number))
+	r.pdf.TextRight(colQuantity, r.y, bodySize, false, strconv.Itoa(int(l.Quantity)))
+	r.pdf.Text(colUnit, r.y, bodySize, false, l.Unit)
+	r.pdf.TextRight(colUnitPrice, r.y, bodySize, false, l.UnitPrice.StringFixed(2))
+	r.pdf.TextRight(colTotal, r.y, bodySize, false, l.Total.StringFixed(2))
+	for i := 0; i < rows; i++ {
+		y := r.y + float64(i)*lineHeight
+		if i < len(sku) {
+			r.pdf.Text(colSKU, y, bodySize, false, sku[i])
+		}
+		if i < len(description) {
+			r.pdf.Text(colDescription, y, bodySize, false, description[i])
+		}
+	}
+	if l.ListPrice.Valid && !l.ListPrice.Decimal.Equal(l.UnitPrice) {
+		r.pdf.TextRight(colUnitPrice, r.y+float64(rows-1)*lineHeight, 7.5, false,
+			"list "+l.ListPrice.Decimal.StringFixed(2))
+	}
+	r.y += float64(rows)*lineHeight + 2
+}
+
+func (r *pdfRenderer) totals() {
+	r.ensure(3*lineHeight, false)
+	r.pdf.Line(marginX, r.y, colTotal, r.y, 0.5)
+	r.y += lineHeight + 2
+	label := "Total"
+	if r.po.Currency != "" {
+		label += " (" + r.po.Currency + ")"
+	}
+	r.pdf.TextRight(colUnitPrice, r.y, 10, true, label)
+	r.pdf.TextRight(colTotal, r.y, 10, true, r.po.Total().StringFixed(2))
+	r.y += 2 * lineHeight
+
+	if r.po.Notes != "" {
+		notes := Wrap(r.po.Notes, bodySize, false, colTotal-marginX)
+		r.ensure(float64(len(notes)+1)*lineHeight, false)
+		r.pdf.Text(marginX, r.y, bodySize, true, "Notes")
+		r.y += lineHeight
+		for _, n := range notes {
+			r.pdf.Text(marginX, r.y, bodySize, false, n)
+			r.y += lineHeight
+		}
+	}
+}
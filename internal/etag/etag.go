@@ -0,0 +1,29 @@
+// Package etag builds entity tags for rows from their id and last update
+// time, and checks them against If-Match headers for optimistic
+// concurrency control.
+package etag
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Of returns the strong entity tag, quotes included, of a row version. Any
+// update bumps updated_at, so the tag changes with every write.
+func Of(id int32, updatedAt time.Time) string {
+	return fmt.Sprintf("\"%x-%x\"", id, updatedAt.UnixMicro())
+}
+
+// Match reports whether an If-Match header value matches the current tag.
+// The value is "*" or a comma-separated list of tags; weak tags never match,
+// as If-Match uses strong comparison.
+func Match(ifMatch, current string) bool {
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,448 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/molu/stock-management-system/internal/attributes"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/sqlc-dev/pqtype"
+)
+
+type AttributeHandler struct {
+	queries db.SingleDb
+}
+
+func NewAttributeHandler(queries db.SingleDb) *AttributeHandler {
+	return &AttributeHandler{queries: queries}
+}
+
+type CategoryAttributeRequest struct {
+	AttributeCode string   `json:"attribute_code"`
+	Name          string   `json:"name"`
+	DataType      string   `json:"data_type"`
+	IsRequired    bool     `json:"is_required"`
+	AllowedValues []string `json:"allowed_values"`
+	Unit          *string  `json:"unit"`
+}
+
+func (req CategoryAttributeRequest) definition() attributes.Definition {
+	return attributes.Definition{
+		Code:     req.AttributeCode,
+		Type:     attributes.Type(req.DataType),
+		Required: req.IsRequired,
+		Options:  req.AllowedValues,
+	}
+}
+
+func (req CategoryAttributeRequest) allowedValues() pqtype.NullRawMessage {
+	if len(req.AllowedValues) == 0 {
+		return pqtype.NullRawMessage{}
+	}
+	raw, _ := json.Marshal(req.AllowedValues)
+	return pqtype.NullRawMessage{RawMessage: raw, Valid: true}
+}
+
+// categorySchema returns the attributes a category's products take, its own
+// and inherited ones, as definitions the validator understands. A product
+// without a category has none.
+func categorySchema(ctx context.Context, queries db.SingleDb, categoryID sql.NullInt32) ([]db.ListEffectiveCategoryAttributesRow, []attributes.Definition, error) {
+	if !categoryID.Valid {
+		return []db.ListEffectiveCategoryAttributesRow{}, nil, nil
+	}
+	rows, err := queries.ListEffectiveCategoryAttributes(ctx, categoryID.Int32)
+	if err != nil {
+		return nil, nil, err
+	}
+	defs := make([]attributes.Definition, len(rows))
+	for i, row := range rows {
+		defs[i] = attributes.Definition{
+			Code:     row.AttributeCode,
+			Type:     attributes.Type(row.DataType),
+			Required: row.IsRequired,
+		}
+		if row.AllowedValues.Valid {
+			if err := json.Unmarshal(row.AllowedValues.RawMessage, &defs[i].Options); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return rows, defs, nil
+}
+
+// ListForCategory returns a category's attribute schema including the
+// attributes it inherits, nearest definition first; inherited=false returns
+// only those defined on the category itself.
+func (h *AttributeHandler) ListForCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	if _, err := h.queries.GetCategory(ctx, int32(id)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Category not found")
+			return
+		}
+		log.Printf("Error getting category: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch category")
+		return
+	}
+
+	if r.URL.Query().Get("inherited") == "false" {
+		attrs, err := h.queries.ListCategoryAttributes(ctx, int32(id))
+		if err != nil {
+			log.Printf("Error listing category attributes: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to fetch category attributes")
+			return
+		}
+		respondJSON(w, http.StatusOK, attrs)
+		return
+	}
+
+	attrs, err := h.queries.ListEffectiveCategoryAttributes(ctx, int32(id))
+	if err != nil {
+		log.Printf("Error listing category attributes: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch category attributes")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, attrs)
+}
+
+// Create adds an attribute to a category's schema. Subcategories inherit
+// it unless they define the same code themselves.
+func (h *AttributeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	var req CategoryAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Name == "" {
+		respondError(w, http.StatusBadRequest, "Attribute name is required")
+		return
+	}
+	if err := req.definition().Check(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err := h.queries.GetCategory(ctx, int32(id)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Category not found")
+			return
+		}
+		log.Printf("Error getting category: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to create category attribute")
+		return
+	}
+
+	existing, err := h.queries.ListCategoryAttributes(ctx, int32(id))
+	if err != nil {
+		log.Printf("Error listing category attributes: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to create category attribute")
+		return
+	}
+	for _, a := range existing {
+		if a.AttributeCode == req.AttributeCode {
+			respondError(w, http.StatusConflict, "Category already has an attribute with this code")
+			return
+		}
+	}
+
+	attr, err := h.queries.CreateCategoryAttribute(ctx, db.CreateCategoryAttributeParams{
+		CategoryID:    int32(id),
+		AttributeCode: req.AttributeCode,
+		Name:          req.Name,
+		DataType:      db.AttributeDataType(req.DataType),
+		IsRequired:    req.IsRequired,
+		AllowedValues: req.allowedValues(),
+		Unit:          toNullString(req.Unit),
+	})
+	if err != nil {
+		log.Printf("Error creating category attribute: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to create category attribute")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, attr)
+}
+
+func (h *AttributeHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid attribute ID")
+		return
+	}
+
+	attr, err := h.queries.GetCategoryAttribute(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Category attribute not found")
+			return
+		}
+		log.Printf("Error getting category attribute: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch category attribute")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, attr)
+}
+
+// Update replaces an attribute's definition; its code cannot change.
+// Values already stored are not rewritten, so products whose values no
+// longer fit show the problems in their attribute errors.
+func (h *AttributeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid attribute ID")
+		return
+	}
+
+	var req CategoryAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	current, err := h.queries.GetCategoryAttribute(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Category attribute not found")
+			return
+		}
+		log.Printf("Error getting category attribute: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to update category attribute")
+		return
+	}
+	if req.AttributeCode != "" && req.AttributeCode != current.AttributeCode {
+		respondError(w, http.StatusBadRequest, "Attribute code cannot be changed")
+		return
+	}
+	req.AttributeCode = current.AttributeCode
+	if req.Name == "" {
+		respondError(w, http.StatusBadRequest, "Attribute name is required")
+		return
+	}
+	if err := req.definition().Check(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attr, err := h.queries.UpdateCategoryAttribute(ctx, db.UpdateCategoryAttributeParams{
+		AttributeID:   current.AttributeID,
+		Name:          req.Name,
+		DataType:      db.AttributeDataType(req.DataType),
+		IsRequired:    req.IsRequired,
+		AllowedValues: req.allowedValues(),
+		Unit:          toNullString(req.Unit),
+	})
+	if err != nil {
+		log.Printf("Error updating category attribute: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to update category attribute")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, attr)
+}
+
+// Delete removes an attribute from its category's schema. Stored values
+// are kept and reported as unknown attributes until the product is saved.
+func (h *AttributeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid attribute ID")
+		return
+	}
+
+	if err := h.queries.DeleteCategoryAttribute(ctx, int32(id)); err != nil {
+		log.Printf("Error deleting category attribute: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to delete category attribute")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type ProductAttributesResponse struct {
+	ProductID  int32                                   `json:"product_id"`
+	CategoryID sql.NullInt32                           `json:"category_id"`
+	Attributes json.RawMessage                         `json:"attributes"`
+	Schema     []db.ListEffectiveCategoryAttributesRow `json:"schema"`
+	// Errors lists stored values that no longer fit the schema, after the
+	// product changed category or the schema changed.
+	Errors []attributes.FieldError `json:"errors"`
+}
+
+// GetForProduct returns a product's attribute values with the schema of its
+// category.
+func (h *AttributeHandler) GetForProduct(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	product, err := h.queries.GetProduct(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Product not found")
+			return
+		}
+		log.Printf("Error getting product: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch product attributes")
+		return
+	}
+
+	schema, defs, err := categorySchema(ctx, h.queries, product.CategoryID)
+	if err != nil {
+		log.Printf("Error getting attribute schema: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch product attributes")
+		return
+	}
+
+	stored := json.RawMessage(`{}`)
+	attrs, err := h.queries.GetProductAttributes(ctx, product.ProductID)
+	switch {
+	case err == nil:
+		stored = attrs.Attributes
+	case !errors.Is(err, sql.ErrNoRows):
+		log.Printf("Error getting product attributes: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch product attributes")
+		return
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(stored, &values); err != nil {
+		log.Printf("Error decoding product attributes: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch product attributes")
+		return
+	}
+	_, problems := attributes.Validate(defs, values)
+	if problems == nil {
+		problems = []attributes.FieldError{}
+	}
+
+	respondJSON(w, http.StatusOK, ProductAttributesResponse{
+		ProductID:  product.ProductID,
+		CategoryID: product.CategoryID,
+		Attributes: stored,
+		Schema:     schema,
+		Errors:     problems,
+	})
+}
+
+// SetForProduct replaces a product's attribute values. The body is an
+// object of attribute code to value, checked against the schema of the
+// product's category; any problem returns 422 and nothing is saved.
+func (h *AttributeHandler) SetForProduct(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&values); err != nil {
+		respondError(w, http.StatusBadRequest, "Body must be an object of attribute values")
+		return
+	}
+
+	product, err := h.queries.GetProduct(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Product not found")
+			return
+		}
+		log.Printf("Error getting product: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to save product attributes")
+		return
+	}
+
+	_, defs, err := categorySchema(ctx, h.queries, product.CategoryID)
+	if err != nil {
+		log.Printf("Error getting attribute schema: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to save product attributes")
+		return
+	}
+
+	normalised, problems := attributes.Validate(defs, values)
+	if len(problems) > 0 {
+		respondJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"error":  "Attribute values do not match the category schema",
+			"errors": problems,
+		})
+		return
+	}
+
+	raw, err := json.Marshal(normalised)
+	if err != nil {
+		log.Printf("Error encoding product attributes: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to save product attributes")
+		return
+	}
+
+	attrs, err := h.queries.UpsertProductAttributes(ctx, db.UpsertProductAttributesParams{
+		ProductID:  product.ProductID,
+		Attributes: raw,
+	})
+	if err != nil {
+		log.Printf("Error saving product attributes: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to save product attributes")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, attrs)
+}
+
+// attributeFilter collects attr.<code>=value query parameters into the JSON
+// object ListProductsByAttributes matches against; ok is false when there
+// are none.
+func attributeFilter(r *http.Request) (json.RawMessage, bool) {
+	filter := map[string]string{}
+	for key, values := range r.URL.Query() {
+		if code, found := strings.CutPrefix(key, "attr."); found && code != "" && len(values) > 0 {
+			filter[code] = values[0]
+		}
+	}
+	if len(filter) == 0 {
+		return nil, false
+	}
+	raw, _ := json.Marshal(filter)
+	return raw, true
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
@@ -262,10 +263,12 @@ func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	category, err := h.queries.UpdateCategory(ctx, params)
+	category, err := h.queries.UpdateCategoryTx(ctx, params)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			respondError(w, http.StatusNotFound, "Category not found")
+		} else if errors.Is(err, db.ErrCategoryCycle) || errors.Is(err, db.ErrParentCategoryNotFound) {
+			respondError(w, http.StatusUnprocessableEntity, err.Error())
 		} else {
 			log.Printf("Error updating category: %v", err)
 			respondError(w, http.StatusInternalServerError, "Failed to update category")
@@ -276,7 +279,9 @@ func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, category)
 }
 
-// Delete deletes a category
+// Delete deletes a category. A category with subcategories or products is
+// refused with 409 unless reassign_to is given: a category ID to move them
+// to, or "parent" for the deleted category's own parent.
 func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
@@ -287,12 +292,165 @@ func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.queries.DeleteCategory(ctx, int32(id))
+	params := db.DeleteCategoryTxParams{CategoryID: int32(id)}
+	if v := r.URL.Query().Get("reassign_to"); v != "" {
+		params.Reassign = true
+		if v != "parent" {
+			target, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "reassign_to must be a category ID or parent")
+				return
+			}
+			params.ReassignTo = sql.NullInt32{Int32: int32(target), Valid: true}
+		}
+	}
+
+	result, err := h.queries.DeleteCategoryTx(ctx, params)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "Category not found")
+		case errors.Is(err, db.ErrCategoryInUse):
+			respondError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, db.ErrCategoryCycle), errors.Is(err, db.ErrParentCategoryNotFound):
+			respondError(w, http.StatusUnprocessableEntity, "Cannot reassign to a missing category or one inside the deleted subtree")
+		default:
+			log.Printf("Error deleting category: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to delete category")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message":             "Category deleted successfully",
+		"reassigned_to":       result.ReassignedTo,
+		"moved_subcategories": result.MovedSubcategories,
+		"moved_products":      result.MovedProducts,
+	})
+}
+
+// CategoryNode is a category with its subtree, as returned by Tree.
+type CategoryNode struct {
+	db.ListCategoryTreeRow
+	Children []*CategoryNode `json:"children"`
+}
+
+// buildCategoryTree nests the rows of ListCategoryTree, which come parents
+// first, under their parents.
+func buildCategoryTree(rows []db.ListCategoryTreeRow) []*CategoryNode {
+	nodes := make(map[int32]*CategoryNode, len(rows))
+	roots := []*CategoryNode{}
+	for _, row := range rows {
+		node := &CategoryNode{ListCategoryTreeRow: row, Children: []*CategoryNode{}}
+		nodes[row.CategoryID] = node
+		if parent, ok := nodes[row.ParentCategoryID.Int32]; ok && row.Depth > 0 {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots
+}
+
+// Tree returns the whole category tree, or with an id the subtree under
+// that category, nested with depths and active product counts.
+func (h *CategoryHandler) Tree(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	var root sql.NullInt32
+	if v, ok := vars["id"]; ok {
+		id, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid category ID")
+			return
+		}
+		root = sql.NullInt32{Int32: int32(id), Valid: true}
+	}
+
+	rows, err := h.queries.ListCategoryTree(ctx, root)
+	if err != nil {
+		log.Printf("Error listing category tree: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch category tree")
+		return
+	}
+	if root.Valid && len(rows) == 0 {
+		respondError(w, http.StatusNotFound, "Category not found")
+		return
+	}
+
+	tree := buildCategoryTree(rows)
+	if root.Valid {
+		respondJSON(w, http.StatusOK, tree[0])
+		return
+	}
+	respondJSON(w, http.StatusOK, tree)
+}
+
+// Path returns the breadcrumb of a category: its ancestors from the root
+// down, ending with the category itself.
+func (h *CategoryHandler) Path(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	path, err := h.queries.GetCategoryPath(ctx, int32(id))
+	if err != nil {
+		log.Printf("Error getting category path: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch category path")
+		return
+	}
+	if len(path) == 0 {
+		respondError(w, http.StatusNotFound, "Category not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, path)
+}
+
+type MoveCategoryRequest struct {
+	ParentCategoryID *int64 `json:"parent_category_id"`
+}
+
+// Move moves a category and its subtree under another parent, or to the
+// root when parent_category_id is null.
+func (h *CategoryHandler) Move(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	var req MoveCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	category, err := h.queries.MoveCategoryTx(ctx, db.MoveCategoryParams{
+		CategoryID:       int32(id),
+		ParentCategoryID: toNullInt32FromInt64(req.ParentCategoryID),
+	})
 	if err != nil {
-		log.Printf("Error deleting category: %v", err)
-		respondError(w, http.StatusInternalServerError, "Failed to delete category")
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "Category not found")
+		case errors.Is(err, db.ErrCategoryCycle), errors.Is(err, db.ErrParentCategoryNotFound):
+			respondError(w, http.StatusUnprocessableEntity, err.Error())
+		default:
+			log.Printf("Error moving category: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to move category")
+		}
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]string{"message": "Category deleted successfully"})
+	respondJSON(w, http.StatusOK, category)
 }
@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/molu/stock-management-system/internal/costing"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/shopspring/decimal"
+)
+
+type CostingHandler struct {
+	queries db.SingleDb
+}
+
+func NewCostingHandler(queries db.SingleDb) *CostingHandler {
+	return &CostingHandler{queries: queries}
+}
+
+// parseDateRange reads the from and to query parameters (YYYY-MM-DD, both
+// inclusive) into a half-open range. Without from, the range covers the
+// given number of days up to to; without to, it ends today.
+func parseDateRange(query url.Values, days int) (time.Time, time.Time, error) {
+	to := time.Now().AddDate(0, 0, 1).Truncate(24 * time.Hour)
+	if t := query.Get("to"); t != "" {
+		parsed, err := time.Parse("2006-01-02", t)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("Invalid to date")
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	from := to.AddDate(0, 0, -days)
+	if f := query.Get("from"); f != "" {
+		parsed, err := time.Parse("2006-01-02", f)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("Invalid from date")
+		}
+		from = parsed
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+	return from, to, nil
+}
+
+// optionalID reads an optional integer query parameter.
+func optionalID(query url.Values, name string) (sql.NullInt32, error) {
+	v := query.Get(name)
+	if v == "" {
+		return sql.NullInt32{}, nil
+	}
+	id, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return sql.NullInt32{}, err
+	}
+	return sql.NullInt32{Int32: int32(id), Valid: true}, nil
+}
+
+type SetCostingRequest struct {
+	CostingMethod string           `json:"costing_method"`
+	StandardCost  *decimal.Decimal `json:"standard_cost"`
+}
+
+// SetCosting changes a product's costing method and standard cost. Stock on
+// hand is revalued when a standard cost is introduced or changed.
+func (h *CostingHandler) SetCosting(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req SetCostingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.StandardCost != nil && req.StandardCost.IsNegative() {
+		respondError(w, http.StatusBadRequest, "standard_cost must not be negative")
+		return
+	}
+
+	result, err := h.queries.SetProductCostingTx(ctx, db.SetProductCostingTxParams{
+		ProductID:     int32(id),
+		CostingMethod: db.CostingMethod(req.CostingMethod),
+		StandardCost:  toNullDecimal(req.StandardCost),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "Product not found")
+		case errors.Is(err, db.ErrCostingMethodInvalid), errors.Is(err, costing.ErrNoStandardCost):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			log.Printf("Error setting costing of product %d: %v", id, err)
+			respondError(w, http.StatusInternalServerError, "Failed to update costing")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// ListCostLayers lists the open FIFO layers of a product in a warehouse,
+// oldest first. Query parameter: warehouse_id.
+func (h *CostingHandler) ListCostLayers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	warehouseID, err := strconv.ParseInt(r.URL.Query().Get("warehouse_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	layers, err := h.queries.ListOpenCostLayers(ctx, db.ListOpenCostLayersParams{
+		ProductID:   int32(id),
+		WarehouseID: int32(warehouseID),
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch cost layers")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, layers)
+}
+
+// MovementCost returns the cost entries booked for a stock movement.
+func (h *CostingHandler) MovementCost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid movement ID")
+		return
+	}
+
+	entries, err := h.queries.ListCostEntriesByMovement(ctx, sql.NullInt32{Int32: int32(id), Valid: true})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch cost entries")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entries)
+}
+
+func (h *CostingHandler) ValuationByWarehouse(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rows, err := h.queries.GetValuationByWarehouse(ctx)
+	if err != nil {
+		log.Printf("Error valuing warehouses: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch valuation")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rows)
+}
+
+// ValuationByCategory totals the book value per category, optionally for one
+// warehouse. Query parameter: warehouse_id.
+func (h *CostingHandler) ValuationByCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	warehouseID, err := optionalID(r.URL.Query(), "warehouse_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	rows, err := h.queries.GetValuationByCategory(ctx, warehouseID)
+	if err != nil {
+		log.Printf("Error valuing categories: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch valuation")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rows)
+}
+
+type ValuationReport struct {
+	AsOf       string                   `json:"as_of"`
+	Quantity   int64                    `json:"quantity"`
+	TotalValue decimal.Decimal          `json:"total_value"`
+	Lines      []db.GetValuationAsOfRow `json:"lines"`
+}
+
+// ValuationReport rebuilds the book value per product and warehouse at the
+// end of a day from the cost entries, for month-end close. Query parameters:
+// as_of (YYYY-MM-DD, default today), warehouse_id and category_id.
+func (h *CostingHandler) ValuationReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	asOf := time.Now().Truncate(24 * time.Hour)
+	if a := query.Get("as_of"); a != "" {
+		parsed, err := time.Parse("2006-01-02", a)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid as_of date")
+			return
+		}
+		asOf = parsed
+	}
+
+	warehouseID, err := optionalID(query, "warehouse_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	categoryID, err := optionalID(query, "category_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	rows, err := h.queries.GetValuationAsOf(ctx, db.GetValuationAsOfParams{
+		Before:      asOf.AddDate(0, 0, 1),
+		WarehouseID: warehouseID,
+		CategoryID:  categoryID,
+	})
+	if err != nil {
+		log.Printf("Error building valuation report: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to build valuation report")
+		return
+	}
+
+	report := ValuationReport{AsOf: asOf.Format("2006-01-02"), Lines: rows}
+	if report.Lines == nil {
+		report.Lines = []db.GetValuationAsOfRow{}
+	}
+	for _, row := range rows {
+		report.Quantity += int64(row.Quantity)
+		report.TotalValue = report.TotalValue.Add(row.TotalValue)
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// ListVariances lists purchase price variances of standard-costed receipts.
+// Query parameters: from and to (YYYY-MM-DD), default the last 30 days.
+func (h *CostingHandler) ListVariances(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	from, to, err := parseDateRange(r.URL.Query(), 30)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rows, err := h.queries.ListPurchasePriceVariances(ctx, db.ListPurchasePriceVariancesParams{
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		log.Printf("Error listing purchase price variances: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch variances")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rows)
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/shopspring/decimal"
+)
+
+var errUnknownCurrency = errors.New("currency is not set up")
+
+type CurrencyHandler struct {
+	queries db.SingleDb
+}
+
+func NewCurrencyHandler(queries db.SingleDb) *CurrencyHandler {
+	return &CurrencyHandler{queries: queries}
+}
+
+// currencyCode upper-cases an optional ISO 4217 code and checks that the
+// currency is set up. An empty code means the base currency.
+func currencyCode(ctx context.Context, queries db.SingleDb, code *string) (sql.NullString, error) {
+	if code == nil || strings.TrimSpace(*code) == "" {
+		return sql.NullString{}, nil
+	}
+
+	c := strings.ToUpper(strings.TrimSpace(*code))
+	if _, err := queries.GetCurrency(ctx, c); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.NullString{}, fmt.Errorf("%w: %s", errUnknownCurrency, c)
+		}
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: c, Valid: true}, nil
+}
+
+// respondCurrencyError reports a failed currency lookup, treating unknown
+// currencies as client errors.
+func respondCurrencyError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errUnknownCurrency) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	log.Printf("Error looking up currency: %v", err)
+	respondError(w, http.StatusInternalServerError, "Failed to look up currency")
+}
+
+type CreateCurrencyRequest struct {
+	CurrencyCode string `json:"currency_code"`
+	Name         string `json:"name"`
+	IsBase       bool   `json:"is_base"`
+}
+
+// Create sets up a currency. Exactly one currency should be the base
+// currency inventory is valued in; it cannot be changed once set.
+func (h *CurrencyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreateCurrencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(req.CurrencyCode))
+	if len(code) != 3 || req.Name == "" {
+		respondError(w, http.StatusBadRequest, "A three-letter currency_code and a name are required")
+		return
+	}
+
+	if req.IsBase {
+		if _, err := h.queries.GetBaseCurrency(ctx); err == nil {
+			respondError(w, http.StatusConflict, "A base currency is already set up")
+			return
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusInternalServerError, "Failed to create currency")
+			return
+		}
+	}
+
+	currency, err := h.queries.CreateCurrency(ctx, db.CreateCurrencyParams{
+		CurrencyCode: code,
+		Name:         req.Name,
+		IsBase:       req.IsBase,
+	})
+	if err != nil {
+		log.Printf("Error creating currency %s: %v", code, err)
+		respondError(w, http.StatusInternalServerError, "Failed to create currency")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, currency)
+}
+
+func (h *CurrencyHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	currencies, err := h.queries.ListCurrencies(ctx)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch currencies")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, currencies)
+}
+
+type SetExchangeRateRequest struct {
+	// RateDate is YYYY-MM-DD; empty means today.
+	RateDate string          `json:"rate_date"`
+	Rate     decimal.Decimal `json:"rate"`
+}
+
+// SetRate records how many units of the base currency one unit of the
+// currency buys on a day, replacing the rate already recorded for that day.
+func (h *CurrencyHandler) SetRate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	code := strings.ToUpper(vars["code"])
+	currency, err := h.queries.GetCurrency(ctx, code)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Currency not found")
+		return
+	}
+	if currency.IsBase {
+		respondError(w, http.StatusBadRequest, "The base currency has no exchange rate")
+		return
+	}
+
+	var req SetExchangeRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !req.Rate.IsPositive() {
+		respondError(w, http.StatusBadRequest, "rate must be positive")
+		return
+	}
+
+	rateDate := time.Now().Truncate(24 * time.Hour)
+	if req.RateDate != "" {
+		rateDate, err = time.Parse("2006-01-02", req.RateDate)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid rate_date")
+			return
+		}
+	}
+
+	rate, err := h.queries.UpsertExchangeRate(ctx, db.UpsertExchangeRateParams{
+		CurrencyCode: code,
+		RateDate:     rateDate,
+		Rate:         req.Rate,
+	})
+	if err != nil {
+		log.Printf("Error setting exchange rate of %s: %v", code, err)
+		respondError(w, http.StatusInternalServerError, "Failed to set exchange rate")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rate)
+}
+
+// ListRates lists the rates recorded for a currency, newest first.
+func (h *CurrencyHandler) ListRates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	limit := int32(100)
+	offset := int32(0)
+
+	rates, err := h.queries.ListExchangeRates(ctx, db.ListExchangeRatesParams{
+		CurrencyCode: strings.ToUpper(vars["code"]),
+		Limit:        limit,
+		Offset:       offset,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch exchange rates")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rates)
+}
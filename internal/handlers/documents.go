@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/document"
+	"github.com/shopspring/decimal"
+)
+
+func warehouseParty(w db.Warehouse) document.Party {
+	return document.Party{
+		Code:          w.Code,
+		Name:          w.Name,
+		Address:       w.Address.String,
+		ContactPerson: w.ContactPerson.String,
+		ContactPhone:  w.ContactPhone.String,
+		ContactEmail:  w.ContactEmail.String,
+	}
+}
+
+// letterhead renders the warehouse's letterhead templates, or the default
+// letterhead when it has none.
+func letterhead(ctx context.Context, queries db.SingleDb, warehouse db.Warehouse, data document.LetterheadData) (document.Letterhead, error) {
+	header, footer := document.DefaultHeaderTemplate, ""
+	lh, err := queries.GetWarehouseLetterhead(ctx, warehouse.WarehouseID)
+	switch {
+	case err == nil:
+		header, footer = lh.HeaderTemplate, lh.FooterTemplate.String
+	case !errors.Is(err, sql.ErrNoRows):
+		return document.Letterhead{}, err
+	}
+	return document.RenderLetterhead(header, footer, data)
+}
+
+// Document renders a purchase order to send to the supplier. Query
+// parameters: format (pdf, the default, or csv) and warehouse_id, whose
+// letterhead to use instead of the delivery warehouse's.
+func (h *PurchaseOrderHandler) Document(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid PO ID")
+		return
+	}
+
+	format := query.Get("format")
+	if format == "" {
+		format = "pdf"
+	}
+	if format != "pdf" && format != "csv" {
+		respondError(w, http.StatusBadRequest, "format must be pdf or csv")
+		return
+	}
+
+	letterheadID, err := optionalID(query, "warehouse_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	po, err := h.queries.GetPurchaseOrder(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Purchase order not found")
+		return
+	}
+
+	supplier, err := h.queries.GetSupplier(ctx, po.SupplierID)
+	if err != nil {
+		log.Printf("Error fetching supplier %d of PO %d: %v", po.SupplierID, id, err)
+		respondError(w, http.StatusInternalServerError, "Failed to build purchase order document")
+		return
+	}
+
+	rows, err := h.queries.GetPurchaseOrderDocumentLines(ctx, po.PoID)
+	if err != nil {
+		log.Printf("Error fetching lines of PO %d: %v", id, err)
+		respondError(w, http.StatusInternalServerError, "Failed to build purchase order document")
+		return
+	}
+
+	doc := document.PurchaseOrder{
+		Number:       po.PoNumber,
+		Status:       string(po.Status),
+		OrderDate:    po.OrderDate,
+		ExpectedDate: po.ExpectedDeliveryDate,
+		Currency:     po.CurrencyCode.String,
+		Notes:        po.Notes.String,
+		Supplier: document.Supplier{
+			Party: document.Party{
+				Code:          supplier.Code,
+				Name:          supplier.Name,
+				Address:       supplier.Address.String,
+				ContactPerson: supplier.ContactPerson.String,
+				ContactPhone:  supplier.Phone.String,
+				ContactEmail:  supplier.Email.String,
+			},
+			TaxID:        supplier.TaxID.String,
+			PaymentTerms: supplier.PaymentTerms.String,
+		},
+		Lines: make([]document.Line, len(rows)),
+	}
+	if doc.Currency == "" {
+		if base, err := h.queries.GetBaseCurrency(ctx); err == nil {
+			doc.Currency = base.CurrencyCode
+		}
+	}
+
+	for i, row := range rows {
+		line := document.Line{
+			Number:      i + 1,
+			SKU:         row.Sku,
+			Description: row.ProductName,
+			Quantity:    row.QuantityOrdered,
+			UnitPrice:   row.UnitPrice,
+			Total:       row.TotalPrice,
+		}
+		// Lines are stored in base units; print them in the unit ordered
+		// when the quantity is a whole number of it.
+		if row.UomCode.Valid && row.ConversionFactor > 0 && row.QuantityOrdered%row.ConversionFactor == 0 {
+			line.Quantity = row.QuantityOrdered / row.ConversionFactor
+			line.UnitPrice = row.UnitPrice.Mul(decimal.NewFromInt32(row.ConversionFactor))
+			line.Unit = row.UomCode.String
+		}
+		if row.ListPrice.Valid {
+			listPrice := row.ListPrice.Decimal
+			if line.Unit == "" {
+				listPrice = listPrice.Div(decimal.NewFromInt32(max(row.ConversionFactor, 1)))
+			}
+			line.ListPrice = decimal.NullDecimal{Decimal: listPrice.Round(2), Valid: true}
+		}
+		doc.Lines[i] = line
+	}
+
+	if po.WarehouseID.Valid {
+		if shipTo, err := h.queries.GetWarehouse(ctx, po.WarehouseID.Int32); err == nil {
+			doc.ShipTo = warehouseParty(shipTo)
+		}
+	}
+
+	warehouseID := po.WarehouseID
+	if letterheadID.Valid {
+		warehouseID = letterheadID
+	}
+	if warehouseID.Valid {
+		warehouse, err := h.queries.GetWarehouse(ctx, warehouseID.Int32)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Warehouse not found")
+			return
+		}
+
+		doc.Letterhead, err = letterhead(ctx, h.queries, warehouse, document.LetterheadData{
+			Warehouse: warehouseParty(warehouse),
+			PoNumber:  po.PoNumber,
+			OrderDate: po.OrderDate,
+		})
+		if err != nil {
+			log.Printf("Error rendering letterhead of warehouse %d: %v", warehouse.WarehouseID, err)
+			respondError(w, http.StatusInternalServerError, "Failed to render letterhead")
+			return
+		}
+	}
+
+	var buf bytes.Buffer
+	contentType := "application/pdf"
+	if format == "csv" {
+		contentType = "text/csv"
+		err = doc.WriteCSV(&buf)
+	} else {
+		err = doc.WritePDF(&buf)
+	}
+	if err != nil {
+		log.Printf("Error rendering PO %d as %s: %v", id, format, err)
+		respondError(w, http.StatusInternalServerError, "Failed to build purchase order document")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="PO-%s.%s"`, po.PoNumber, format))
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("Error writing PO %d document: %v", id, err)
+	}
+}
+
+type SetLetterheadRequest struct {
+	HeaderTemplate string  `json:"header_template"`
+	FooterTemplate *string `json:"footer_template"`
+}
+
+// GetLetterhead returns the warehouse's letterhead templates, or the default
+// ones when it has none of its own.
+func (h *WarehouseHandler) GetLetterhead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	lh, err := h.queries.GetWarehouseLetterhead(ctx, int32(id))
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch letterhead")
+			return
+		}
+		lh = db.WarehouseLetterhead{WarehouseID: int32(id), HeaderTemplate: document.DefaultHeaderTemplate}
+	}
+
+	respondJSON(w, http.StatusOK, lh)
+}
+
+// SetLetterhead stores the warehouse's letterhead. Templates use Go
+// text/template syntax with .Warehouse (Code, Name, Address, ContactPerson,
+// ContactPhone, ContactEmail), .PoNumber and .OrderDate, and are checked by
+// rendering them against the warehouse before they are saved.
+func (h *WarehouseHandler) SetLetterhead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	var req SetLetterheadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.HeaderTemplate == "" {
+		respondError(w, http.StatusBadRequest, "header_template is required")
+		return
+	}
+
+	warehouse, err := h.queries.GetWarehouse(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Warehouse not found")
+		return
+	}
+
+	footer := toNullString(req.FooterTemplate)
+	if _, err := document.RenderLetterhead(req.HeaderTemplate, footer.String, document.LetterheadData{
+		Warehouse: warehouseParty(warehouse),
+		PoNumber:  "PO-0001",
+	}); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lh, err := h.queries.UpsertWarehouseLetterhead(ctx, db.UpsertWarehouseLetterheadParams{
+		WarehouseID:    warehouse.WarehouseID,
+		HeaderTemplate: req.HeaderTemplate,
+		FooterTemplate: footer,
+	})
+	if err != nil {
+		log.Printf("Error saving letterhead of warehouse %d: %v", id, err)
+		respondError(w, http.StatusInternalServerError, "Failed to save letterhead")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, lh)
+}
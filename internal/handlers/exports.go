@@ -0,0 +1,402 @@
+package handlers
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+)
+
+const (
+	// exportFlushRows is how many rows are written between flushes.
+	exportFlushRows = 500
+	// exportWriteTimeout replaces the server's write timeout for exports:
+	// each flush must reach the client within it, however long the whole
+	// export takes.
+	exportWriteTimeout = time.Minute
+)
+
+type ExportHandler struct {
+	queries db.SingleDb
+}
+
+func NewExportHandler(queries db.SingleDb) *ExportHandler {
+	return &ExportHandler{queries: queries}
+}
+
+// exportWriter writes rows as CSV, with a header taken from the row type's
+// json tags, or as newline-delimited JSON. Rows are flushed to the client
+// as they are written.
+type exportWriter struct {
+	w    http.ResponseWriter
+	rc   *http.ResponseController
+	csv  *csv.Writer
+	json *json.Encoder
+	rows int
+}
+
+func newExportWriter(w http.ResponseWriter, format, name string) (*exportWriter, error) {
+	ew := &exportWriter{w: w, rc: http.NewResponseController(w)}
+	switch format {
+	case "csv":
+		ew.csv = csv.NewWriter(w)
+		w.Header().Set("Content-Type", "text/csv")
+	case "jsonl":
+		ew.json = json.NewEncoder(w)
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	default:
+		return nil, errors.New("format must be csv or jsonl")
+	}
+	stamp := time.Now().Format("20060102")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s_%s.%s"`, name, stamp, format))
+	return ew, nil
+}
+
+func (ew *exportWriter) write(row interface{}) error {
+	if ew.rows == 0 {
+		if err := ew.extendDeadline(); err != nil {
+			return err
+		}
+		if ew.csv != nil {
+			if err := ew.csv.Write(csvHeader(reflect.TypeOf(row))); err != nil {
+				return err
+			}
+		}
+	}
+
+	var err error
+	if ew.csv != nil {
+		err = ew.csv.Write(csvRecord(reflect.ValueOf(row)))
+	} else {
+		err = ew.json.Encode(row)
+	}
+	if err != nil {
+		return err
+	}
+
+	ew.rows++
+	if ew.rows%exportFlushRows == 0 {
+		return ew.flush()
+	}
+	return nil
+}
+
+func (ew *exportWriter) flush() error {
+	if ew.csv != nil {
+		ew.csv.Flush()
+		if err := ew.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := ew.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return ew.extendDeadline()
+}
+
+func (ew *exportWriter) extendDeadline() error {
+	err := ew.rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+	if errors.Is(err, http.ErrNotSupported) {
+		return nil
+	}
+	return err
+}
+
+// finish flushes the last rows. An export without rows still gets its CSV
+// header.
+func (ew *exportWriter) finish(empty interface{}) error {
+	if ew.rows == 0 && ew.csv != nil {
+		if err := ew.csv.Write(csvHeader(reflect.TypeOf(empty))); err != nil {
+			return err
+		}
+	}
+	return ew.flush()
+}
+
+// stream runs an export and logs a failure. Once the first row is out the
+// status can no longer change, so an error part way leaves the file cut
+// short; before that it is reported as usual.
+func (ew *exportWriter) stream(name string, empty interface{}, export func() error) {
+	err := export()
+	if err == nil {
+		err = ew.finish(empty)
+	}
+	if err == nil {
+		return
+	}
+	log.Printf("Error exporting %s after %d rows: %v", name, ew.rows, err)
+	if ew.rows == 0 {
+		ew.w.Header().Del("Content-Disposition")
+		respondError(ew.w, http.StatusInternalServerError, "Failed to export "+name)
+	}
+}
+
+func csvHeader(t reflect.Type) []string {
+	header := make([]string, t.NumField())
+	for i := range header {
+		header[i] = t.Field(i).Tag.Get("json")
+	}
+	return header
+}
+
+// csvRecord formats each field of a row: NULLs as empty cells, times in
+// RFC 3339 and everything else as its database value.
+func csvRecord(v reflect.Value) []string {
+	record := make([]string, v.NumField())
+	for i := range record {
+		record[i] = csvValue(v.Field(i).Interface())
+	}
+	return record
+}
+
+func csvValue(v interface{}) string {
+	if valuer, ok := v.(driver.Valuer); ok {
+		val, err := valuer.Value()
+		if err != nil || val == nil {
+			return ""
+		}
+		v = val
+	}
+	switch x := v.(type) {
+	case time.Time:
+		return x.Format(time.RFC3339)
+	case []byte:
+		return string(x)
+	}
+	return fmt.Sprint(v)
+}
+
+// exportFormat reads the format query parameter; CSV by default.
+func exportFormat(query url.Values) string {
+	if f := query.Get("format"); f != "" {
+		return f
+	}
+	return "csv"
+}
+
+// optionalDate reads an optional YYYY-MM-DD query parameter. An inclusive
+// end date is returned as the start of the following day.
+func optionalDate(query url.Values, name string, inclusiveEnd bool) (sql.NullTime, error) {
+	v := query.Get(name)
+	if v == "" {
+		return sql.NullTime{}, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return sql.NullTime{}, fmt.Errorf("Invalid %s date", name)
+	}
+	if inclusiveEnd {
+		t = t.AddDate(0, 0, 1)
+	}
+	return sql.NullTime{Time: t, Valid: true}, nil
+}
+
+// optionalIDs reads several optional integer query parameters, reporting the
+// first that is not a number.
+func optionalIDs(query url.Values, names ...string) ([]sql.NullInt32, error) {
+	ids := make([]sql.NullInt32, len(names))
+	for i, name := range names {
+		id, err := optionalID(query, name)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s", name)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// Products exports products. Filters: category_id, supplier_id and
+// is_active.
+func (h *ExportHandler) Products(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	ids, err := optionalIDs(query, "category_id", "supplier_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var active sql.NullBool
+	if v := query.Get("is_active"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid is_active")
+			return
+		}
+		active = sql.NullBool{Bool: b, Valid: true}
+	}
+
+	ew, err := newExportWriter(w, exportFormat(query), "products")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ew.stream("products", db.ExportProductRow{}, func() error {
+		return h.queries.ExportProducts(ctx, db.ExportProductsParams{
+			CategoryID: ids[0],
+			SupplierID: ids[1],
+			IsActive:   active,
+		}, func(row db.ExportProductRow) error { return ew.write(row) })
+	})
+}
+
+// Inventory exports inventory rows. Filters: warehouse_id, product_id,
+// status and expiring_before (rows with an expiry date before it).
+func (h *ExportHandler) Inventory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	ids, err := optionalIDs(query, "warehouse_id", "product_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	status := query.Get("status")
+	if status != "" && !db.InventoryStatus(status).Valid() {
+		respondError(w, http.StatusBadRequest, "Invalid status")
+		return
+	}
+	expiring, err := optionalDate(query, "expiring_before", false)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ew, err := newExportWriter(w, exportFormat(query), "inventory")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ew.stream("inventory", db.ExportInventoryRow{}, func() error {
+		return h.queries.ExportInventory(ctx, db.ExportInventoryParams{
+			WarehouseID:    ids[0],
+			ProductID:      ids[1],
+			Status:         sql.NullString{String: status, Valid: status != ""},
+			ExpiringBefore: expiring,
+		}, func(row db.ExportInventoryRow) error { return ew.write(row) })
+	})
+}
+
+// StockMovements exports stock movements. Filters: product_id,
+// warehouse_id, movement_type and from/to (inclusive movement dates).
+func (h *ExportHandler) StockMovements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	ids, err := optionalIDs(query, "product_id", "warehouse_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	movementType := query.Get("movement_type")
+	if movementType != "" && !db.MovementType(movementType).Valid() {
+		respondError(w, http.StatusBadRequest, "Invalid movement_type")
+		return
+	}
+	from, err := optionalDate(query, "from", false)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	to, err := optionalDate(query, "to", true)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ew, err := newExportWriter(w, exportFormat(query), "stock_movements")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ew.stream("stock movements", db.ExportStockMovementRow{}, func() error {
+		return h.queries.ExportStockMovements(ctx, db.ExportStockMovementsParams{
+			ProductID:    ids[0],
+			WarehouseID:  ids[1],
+			MovementType: sql.NullString{String: movementType, Valid: movementType != ""},
+			From:         from,
+			To:           to,
+		}, func(row db.ExportStockMovementRow) error { return ew.write(row) })
+	})
+}
+
+// PurchaseOrders exports purchase orders. Filters: status, supplier_id and
+// from/to (inclusive order dates).
+func (h *ExportHandler) PurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	ids, err := optionalIDs(query, "supplier_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	status := query.Get("status")
+	if status != "" && !db.PurchaseOrderStatus(status).Valid() {
+		respondError(w, http.StatusBadRequest, "Invalid status")
+		return
+	}
+	from, err := optionalDate(query, "from", false)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	to, err := optionalDate(query, "to", true)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ew, err := newExportWriter(w, exportFormat(query), "purchase_orders")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ew.stream("purchase orders", db.ExportPurchaseOrderRow{}, func() error {
+		return h.queries.ExportPurchaseOrders(ctx, db.ExportPurchaseOrdersParams{
+			Status:     sql.NullString{String: status, Valid: status != ""},
+			SupplierID: ids[0],
+			From:       from,
+			To:         to,
+		}, func(row db.ExportPurchaseOrderRow) error { return ew.write(row) })
+	})
+}
+
+// Stocktakes exports stocktakes. Filters: warehouse_id and status.
+func (h *ExportHandler) Stocktakes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	ids, err := optionalIDs(query, "warehouse_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	status := query.Get("status")
+	if status != "" && !db.StocktakeStatus(status).Valid() {
+		respondError(w, http.StatusBadRequest, "Invalid status")
+		return
+	}
+
+	ew, err := newExportWriter(w, exportFormat(query), "stocktakes")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ew.stream("stocktakes", db.ExportStocktakeRow{}, func() error {
+		return h.queries.ExportStocktakes(ctx, db.ExportStocktakesParams{
+			WarehouseID: ids[0],
+			Status:      sql.NullString{String: status, Valid: status != ""},
+		}, func(row db.ExportStocktakeRow) error { return ew.write(row) })
+	})
+}
@@ -0,0 +1,513 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/putaway"
+)
+
+// FloorTaskHandler serves handheld devices working the warehouse floor and
+// the supervisors who hand out putaway and move tasks to them.
+type FloorTaskHandler struct {
+	queries db.SingleDb
+}
+
+func NewFloorTaskHandler(queries db.SingleDb) *FloorTaskHandler {
+	return &FloorTaskHandler{queries: queries}
+}
+
+const (
+	maxDeviceIDLength  = 100
+	maxRequestIDLength = 64
+)
+
+// DeviceTask is the compact form of a floor task sent to handhelds.
+type DeviceTask struct {
+	ID       int32  `json:"id"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	SKU      string `json:"sku"`
+	Name     string `json:"name"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+	Qty      int32  `json:"qty,omitempty"`
+	Done     *int32 `json:"done,omitempty"`
+	Batch    string `json:"batch,omitempty"`
+	Serial   string `json:"serial,omitempty"`
+	Replayed bool   `json:"replayed,omitempty"`
+}
+
+type NextTaskRequest struct {
+	WarehouseID int32  `json:"warehouse_id"`
+	UserID      *int64 `json:"user_id"`
+	Type        string `json:"type"`
+}
+
+type ConfirmTaskRequest struct {
+	RequestID  string `json:"request_id"`
+	Location   string `json:"location"`
+	Item       string `json:"item"`
+	ToLocation string `json:"to_location"`
+	Qty        *int32 `json:"qty"`
+	UserID     *int64 `json:"user_id"`
+}
+
+type TaskExceptionRequest struct {
+	RequestID string  `json:"request_id"`
+	Reason    string  `json:"reason"`
+	Qty       int32   `json:"qty"`
+	Notes     *string `json:"notes"`
+	UserID    *int64  `json:"user_id"`
+}
+
+type CreateFloorTaskRequest struct {
+	Type           string  `json:"type"`
+	ProductID      int32   `json:"product_id"`
+	FromLocationID int32   `json:"from_location_id"`
+	ToLocationID   *int64  `json:"to_location_id"`
+	Quantity       int32   `json:"quantity"`
+	BatchNumber    *string `json:"batch_number"`
+	SerialNumber   *string `json:"serial_number"`
+	Priority       *int32  `json:"priority"`
+	Notes          *string `json:"notes"`
+	CreatedBy      *int64  `json:"created_by"`
+}
+
+// respondFloorTaskError maps the errors of the floor task transactions to
+// status codes.
+func respondFloorTaskError(w http.ResponseWriter, err error, action string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		respondError(w, http.StatusNotFound, "Task not found")
+	case errors.Is(err, db.ErrWrongLocation), errors.Is(err, db.ErrWrongItem), errors.Is(err, db.ErrFloorTaskQuantity),
+		errors.Is(err, db.ErrCountQuantityRequired), errors.Is(err, db.ErrInvalidFloorException),
+		errors.Is(err, db.ErrLocationInactive):
+		respondError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, db.ErrFloorTaskNotAssigned), errors.Is(err, db.ErrFloorTaskClosed), errors.Is(err, db.ErrFloorTaskStale),
+		errors.Is(err, db.ErrInsufficientStock), errors.Is(err, putaway.ErrCapacityExceeded):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		log.Printf("Error trying to %s floor task: %v", action, err)
+		respondError(w, http.StatusInternalServerError, "Failed to "+action+" task")
+	}
+}
+
+// deviceTaskVars reads the device and, when the route has one, the task
+// from the path, answering 400 when either is invalid.
+func deviceTaskVars(w http.ResponseWriter, r *http.Request) (string, int32, bool) {
+	vars := mux.Vars(r)
+	device := strings.TrimSpace(vars["deviceId"])
+	if device == "" || len(device) > maxDeviceIDLength {
+		respondError(w, http.StatusBadRequest, "Invalid device ID")
+		return "", 0, false
+	}
+	if vars["taskId"] == "" {
+		return device, 0, true
+	}
+	id, err := strconv.ParseInt(vars["taskId"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid task ID")
+		return "", 0, false
+	}
+	return device, int32(id), true
+}
+
+func validRequestID(id string) bool {
+	return id != "" && len(id) <= maxRequestIDLength
+}
+
+// deviceTask loads a task in its compact form.
+func (h *FloorTaskHandler) deviceTask(ctx context.Context, taskID int32) (DeviceTask, error) {
+	t, err := h.queries.GetFloorTaskDetail(ctx, taskID)
+	if err != nil {
+		return DeviceTask{}, err
+	}
+	task := DeviceTask{
+		ID:     t.TaskID,
+		Type:   string(t.TaskType),
+		Status: string(t.Status),
+		SKU:    t.Sku,
+		Name:   t.ProductName,
+		From:   t.FromLocationCode.String,
+		To:     t.ToLocationCode.String,
+		Qty:    t.Quantity,
+		Batch:  t.BatchNumber.String,
+		Serial: t.SerialNumber.String,
+	}
+	if t.QuantityDone.Valid {
+		task.Done = &t.QuantityDone.Int32
+	}
+	return task, nil
+}
+
+func (h *FloorTaskHandler) respondDeviceTask(w http.ResponseWriter, r *http.Request, taskID int32, replayed bool) {
+	task, err := h.deviceTask(r.Context(), taskID)
+	if err != nil {
+		log.Printf("Error getting floor task: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch task")
+		return
+	}
+	task.Replayed = replayed
+	respondJSON(w, http.StatusOK, task)
+}
+
+// Next gives the device the task it holds or claims the next one, with 204
+// when there is nothing to do. Calling it again returns the same task until
+// the device confirms, excepts or releases it.
+func (h *FloorTaskHandler) Next(w http.ResponseWriter, r *http.Request) {
+	device, _, ok := deviceTaskVars(w, r)
+	if !ok {
+		return
+	}
+
+	var req NextTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.WarehouseID == 0 {
+		respondError(w, http.StatusBadRequest, "warehouse_id is required")
+		return
+	}
+
+	arg := db.NextFloorTaskTxParams{
+		WarehouseID: req.WarehouseID,
+		DeviceID:    device,
+		AssignedTo:  toNullInt32FromInt64(req.UserID),
+	}
+	if req.Type != "" {
+		arg.TaskType = db.NullFloorTaskType{FloorTaskType: db.FloorTaskType(req.Type), Valid: true}
+		if !arg.TaskType.FloorTaskType.Valid() {
+			respondError(w, http.StatusBadRequest, "Invalid type")
+			return
+		}
+	}
+
+	task, err := h.queries.NextFloorTaskTx(r.Context(), arg)
+	if err != nil {
+		if errors.Is(err, db.ErrNoFloorTask) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		respondFloorTaskError(w, err, "claim")
+		return
+	}
+
+	h.respondDeviceTask(w, r, task.TaskID, false)
+}
+
+// Confirm completes a task after the device scanned its location and item,
+// and for a putaway or move the destination. Retrying with the same
+// request_id returns the completed task without posting twice.
+func (h *FloorTaskHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	device, taskID, ok := deviceTaskVars(w, r)
+	if !ok {
+		return
+	}
+
+	var req ConfirmTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !validRequestID(req.RequestID) {
+		respondError(w, http.StatusBadRequest, "request_id is required, at most 64 characters")
+		return
+	}
+
+	arg := db.ConfirmFloorTaskTxParams{
+		TaskID:         taskID,
+		DeviceID:       device,
+		RequestID:      req.RequestID,
+		LocationCode:   req.Location,
+		ItemCode:       req.Item,
+		ToLocationCode: req.ToLocation,
+		UserID:         toNullInt32FromInt64(req.UserID),
+	}
+	if req.Qty != nil {
+		arg.Quantity = sql.NullInt32{Int32: *req.Qty, Valid: true}
+	}
+
+	result, err := h.queries.ConfirmFloorTaskTx(r.Context(), arg)
+	if err != nil {
+		respondFloorTaskError(w, err, "confirm")
+		return
+	}
+
+	h.respondDeviceTask(w, r, result.Task.TaskID, result.Replayed)
+}
+
+// Exception closes a task the device could not finish: short_pick (with the
+// qty found), damaged, not_found, wrong_item or location_blocked. Retrying
+// with the same request_id returns the closed task.
+func (h *FloorTaskHandler) Exception(w http.ResponseWriter, r *http.Request) {
+	device, taskID, ok := deviceTaskVars(w, r)
+	if !ok {
+		return
+	}
+
+	var req TaskExceptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !validRequestID(req.RequestID) {
+		respondError(w, http.StatusBadRequest, "request_id is required, at most 64 characters")
+		return
+	}
+	reason := db.FloorTaskException(req.Reason)
+	if !reason.Valid() {
+		respondError(w, http.StatusBadRequest, "Invalid reason")
+		return
+	}
+
+	result, err := h.queries.ReportFloorTaskExceptionTx(r.Context(), db.ReportFloorTaskExceptionTxParams{
+		TaskID:    taskID,
+		DeviceID:  device,
+		RequestID: req.RequestID,
+		Exception: reason,
+		Quantity:  req.Qty,
+		Notes:     toNullString(req.Notes),
+		UserID:    toNullInt32FromInt64(req.UserID),
+	})
+	if err != nil {
+		respondFloorTaskError(w, err, "report exception on")
+		return
+	}
+
+	h.respondDeviceTask(w, r, result.Task.TaskID, result.Replayed)
+}
+
+// Release hands a task the device holds back to the queue. Releasing a task
+// that is already open again succeeds, so the call can be retried.
+func (h *FloorTaskHandler) Release(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	device, taskID, ok := deviceTaskVars(w, r)
+	if !ok {
+		return
+	}
+
+	_, err := h.queries.ReleaseFloorTask(ctx, db.ReleaseFloorTaskParams{
+		TaskID:   taskID,
+		DeviceID: sql.NullString{String: device, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		task, getErr := h.queries.GetFloorTask(ctx, taskID)
+		switch {
+		case getErr != nil:
+			err = getErr
+		case task.Status == db.FloorTaskStatusOpen:
+			err = nil
+		default:
+			err = db.ErrFloorTaskNotAssigned
+		}
+	}
+	if err != nil {
+		respondFloorTaskError(w, err, "release")
+		return
+	}
+
+	h.respondDeviceTask(w, r, taskID, false)
+}
+
+func (h *FloorTaskHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	var arg db.ListFloorTasksParams
+	var err error
+	if arg.WarehouseID, err = optionalID(query, "warehouse_id"); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse_id")
+		return
+	}
+	if s := query.Get("status"); s != "" {
+		arg.Status = db.NullFloorTaskStatus{FloorTaskStatus: db.FloorTaskStatus(s), Valid: true}
+		if !arg.Status.FloorTaskStatus.Valid() {
+			respondError(w, http.StatusBadRequest, "Invalid status")
+			return
+		}
+	}
+	if t := query.Get("type"); t != "" {
+		arg.TaskType = db.NullFloorTaskType{FloorTaskType: db.FloorTaskType(t), Valid: true}
+		if !arg.TaskType.FloorTaskType.Valid() {
+			respondError(w, http.StatusBadRequest, "Invalid type")
+			return
+		}
+	}
+	if d := query.Get("device_id"); d != "" {
+		arg.DeviceID = sql.NullString{String: d, Valid: true}
+	}
+
+	arg.RowLimit = 50
+	if l := query.Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			arg.RowLimit = int32(val)
+		}
+	}
+	if o := query.Get("offset"); o != "" {
+		if val, err := strconv.ParseInt(o, 10, 32); err == nil {
+			arg.RowOffset = int32(val)
+		}
+	}
+
+	tasks, err := h.queries.ListFloorTasks(ctx, arg)
+	if err != nil {
+		log.Printf("Error listing floor tasks: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch tasks")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tasks)
+}
+
+// Create queues a putaway or move task. Pick and count tasks are not created
+// here; they come from picking waves and stocktakes.
+func (h *FloorTaskHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreateFloorTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	taskType := db.FloorTaskType(req.Type)
+	if taskType != db.FloorTaskTypePutaway && taskType != db.FloorTaskTypeMove {
+		respondError(w, http.StatusBadRequest, "type must be putaway or move")
+		return
+	}
+	if req.ProductID == 0 || req.FromLocationID == 0 {
+		respondError(w, http.StatusBadRequest, "product_id and from_location_id are required")
+		return
+	}
+	if taskType == db.FloorTaskTypeMove && req.ToLocationID == nil {
+		respondError(w, http.StatusBadRequest, "to_location_id is required for a move")
+		return
+	}
+	if req.Quantity <= 0 || (req.SerialNumber != nil && req.Quantity != 1) {
+		respondError(w, http.StatusBadRequest, db.ErrInvalidMoveQuantity.Error())
+		return
+	}
+
+	if _, err := h.queries.GetProduct(ctx, req.ProductID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Product not found")
+			return
+		}
+		log.Printf("Error getting product: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to create task")
+		return
+	}
+	from, err := h.queries.GetLocation(ctx, req.FromLocationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Location not found")
+			return
+		}
+		log.Printf("Error getting location: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to create task")
+		return
+	}
+	toLocation := toNullInt32FromInt64(req.ToLocationID)
+	if toLocation.Valid {
+		to, err := h.queries.GetLocation(ctx, toLocation.Int32)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				respondError(w, http.StatusNotFound, "Location not found")
+				return
+			}
+			log.Printf("Error getting location: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to create task")
+			return
+		}
+		if to.LocationID == from.LocationID {
+			respondError(w, http.StatusUnprocessableEntity, db.ErrSameLocation.Error())
+			return
+		}
+		if to.WarehouseID != from.WarehouseID {
+			respondError(w, http.StatusUnprocessableEntity, db.ErrWarehouseMismatch.Error())
+			return
+		}
+	}
+
+	priority := int32(5)
+	if req.Priority != nil {
+		priority = *req.Priority
+	}
+
+	task, err := h.queries.CreateFloorTask(ctx, db.CreateFloorTaskParams{
+		WarehouseID:    from.WarehouseID,
+		TaskType:       taskType,
+		Priority:       priority,
+		ProductID:      req.ProductID,
+		FromLocationID: sql.NullInt32{Int32: from.LocationID, Valid: true},
+		ToLocationID:   toLocation,
+		Quantity:       req.Quantity,
+		BatchNumber:    toNullString(req.BatchNumber),
+		SerialNumber:   toNullString(req.SerialNumber),
+		Notes:          toNullString(req.Notes),
+		CreatedBy:      toNullInt32FromInt64(req.CreatedBy),
+	})
+	if err != nil {
+		log.Printf("Error creating floor task: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to create task")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, task)
+}
+
+func (h *FloorTaskHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	task, err := h.queries.GetFloorTask(r.Context(), int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Task not found")
+			return
+		}
+		log.Printf("Error getting floor task: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch task")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, task)
+}
+
+// Cancel withdraws an open, assigned or excepted task. A pick or count task
+// is generated afresh for its line if the line is still open.
+func (h *FloorTaskHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	task, err := h.queries.CancelFloorTask(ctx, int32(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, getErr := h.queries.GetFloorTask(ctx, int32(id)); getErr == nil {
+			err = db.ErrFloorTaskClosed
+		}
+	}
+	if err != nil {
+		respondFloorTaskError(w, err, "cancel")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, task)
+}
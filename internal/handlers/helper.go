@@ -1,6 +1,11 @@
 package handlers
 
-import "database/sql"
+import (
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 func toNullString(s *string) sql.NullString {
 	if s == nil {
@@ -15,3 +20,17 @@ func toNullInt32FromInt64(v *int64) sql.NullInt32 {
 	}
 	return sql.NullInt32{Int32: int32(*v), Valid: true}
 }
+
+func toNullDecimal(d *decimal.Decimal) decimal.NullDecimal {
+	if d == nil {
+		return decimal.NullDecimal{Valid: false}
+	}
+	return decimal.NullDecimal{Decimal: *d, Valid: true}
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{Valid: false}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
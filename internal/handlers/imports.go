@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/importer"
+)
+
+// maxImportSize caps the size of an uploaded import file.
+const maxImportSize = 32 << 20
+
+type ImportHandler struct {
+	queries db.SingleDb
+}
+
+func NewImportHandler(queries db.SingleDb) *ImportHandler {
+	return &ImportHandler{queries: queries}
+}
+
+// importFormat picks the file format from the format query parameter, then
+// the file name's extension, then the content type; CSV otherwise.
+func importFormat(r *http.Request, fileName, contentType string) (importer.Format, error) {
+	if f := r.URL.Query().Get("format"); f != "" {
+		format := importer.Format(strings.ToLower(f))
+		if format != importer.CSV && format != importer.XLSX {
+			return "", importer.ErrUnknownFormat
+		}
+		return format, nil
+	}
+	switch {
+	case strings.EqualFold(filepath.Ext(fileName), ".xlsx"),
+		strings.Contains(contentType, "spreadsheetml"):
+		return importer.XLSX, nil
+	}
+	return importer.CSV, nil
+}
+
+// Import loads a CSV or XLSX file of categories, suppliers, products,
+// locations or opening stock. The file is the request body or the "file"
+// field of a multipart form. Query parameters: format (csv or xlsx),
+// dry_run=true to validate without saving, chunk_size to commit every N rows
+// and skip bad ones instead of importing all or nothing, and created_by.
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	kind := importer.Kind(vars["kind"])
+	if !kind.Valid() {
+		respondError(w, http.StatusBadRequest, "Kind must be categories, suppliers, products, locations or opening_stock")
+		return
+	}
+
+	query := r.URL.Query()
+	var dryRun bool
+	if v := query.Get("dry_run"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid dry_run")
+			return
+		}
+	}
+	var chunkSize int
+	if v := query.Get("chunk_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			respondError(w, http.StatusBadRequest, "Invalid chunk_size")
+			return
+		}
+		chunkSize = n
+	}
+	var createdBy sql.NullInt32
+	if v := query.Get("created_by"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid created_by")
+			return
+		}
+		createdBy = sql.NullInt32{Int32: int32(id), Valid: true}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	var body io.Reader = r.Body
+	var fileName, contentType string
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Multipart upload must have a file field")
+			return
+		}
+		defer file.Close()
+		body = file
+		fileName = header.Filename
+		contentType = header.Header.Get("Content-Type")
+	} else {
+		contentType = r.Header.Get("Content-Type")
+	}
+
+	format, err := importFormat(r, fileName, contentType)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	table, err := importer.Read(body, format)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Import files are limited to %d MB", maxImportSize>>20))
+			return
+		}
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Unreadable %s file: %v", format, err))
+		return
+	}
+	if len(table.Rows) == 0 {
+		respondError(w, http.StatusBadRequest, "File has no data rows")
+		return
+	}
+
+	records, invalid := importer.Validate(kind, table)
+	if len(invalid) > 0 && invalid[0].Line == 1 {
+		respondJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"error":  "File header does not match the " + string(kind) + " import",
+			"errors": invalid,
+		})
+		return
+	}
+
+	result, err := h.queries.ImportTx(ctx, db.ImportTxParams{
+		Kind:      kind,
+		Format:    format,
+		FileName:  sql.NullString{String: fileName, Valid: fileName != ""},
+		TotalRows: int32(len(table.Rows)),
+		Records:   records,
+		Invalid:   invalid,
+		DryRun:    dryRun,
+		ChunkSize: chunkSize,
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		log.Printf("Error importing %s: %v", kind, err)
+		respondError(w, http.StatusInternalServerError, "Failed to import file")
+		return
+	}
+
+	status := http.StatusOK
+	if !result.Committed && !result.DryRun {
+		status = http.StatusUnprocessableEntity
+	}
+	respondJSON(w, status, result)
+}
+
+// Template returns an empty CSV with the columns a kind of import accepts.
+func (h *ImportHandler) Template(w http.ResponseWriter, r *http.Request) {
+	kind := importer.Kind(mux.Vars(r)["kind"])
+	if !kind.Valid() {
+		respondError(w, http.StatusNotFound, "Unknown import kind")
+		return
+	}
+
+	columns := importer.Columns(kind)
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.Name
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, kind))
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		log.Printf("Error writing import template: %v", err)
+		return
+	}
+	cw.Flush()
+}
+
+func (h *ImportHandler) ListBatches(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit := int32(50)
+	offset := int32(0)
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			limit = int32(val)
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if val, err := strconv.ParseInt(o, 10, 32); err == nil {
+			offset = int32(val)
+		}
+	}
+
+	batches, err := h.queries.ListImportBatches(ctx, db.ListImportBatchesParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch import batches")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, batches)
+}
+
+func (h *ImportHandler) GetBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid batch ID")
+		return
+	}
+
+	batch, err := h.queries.GetImportBatch(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Import batch not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to fetch import batch")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, batch)
+}
+
+// ListOpeningBalances returns the opening balance lines an opening stock
+// import posted.
+func (h *ImportHandler) ListOpeningBalances(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid batch ID")
+		return
+	}
+
+	balances, err := h.queries.ListOpeningBalancesByBatch(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch opening balances")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, balances)
+}
@@ -1,12 +1,16 @@
 package handlers
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/putaway"
 )
 
 type InventoryHandler struct {
@@ -38,6 +42,7 @@ func (h *InventoryHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", inventory.ETag())
 	respondJSON(w, http.StatusOK, inventory)
 }
 
@@ -152,16 +157,34 @@ func (h *InventoryHandler) UpdateQuantity(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	inventory, err := h.queries.UpdateInventoryQuantity(ctx, db.UpdateInventoryQuantityParams{
+	inventory, err := h.queries.SetInventoryQuantityTx(ctx, db.SetInventoryQuantityTxParams{
 		InventoryID:      id,
 		Quantity:         req.Quantity,
 		ReservedQuantity: req.ReservedQuantity,
+		IfMatch:          r.Header.Get("If-Match"),
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Inventory not found")
+			return
+		}
+		if errors.Is(err, db.ErrPreconditionFailed) {
+			respondError(w, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+		if errors.Is(err, db.ErrPreconditionRequired) {
+			respondError(w, http.StatusPreconditionRequired, err.Error())
+			return
+		}
+		if errors.Is(err, putaway.ErrCapacityExceeded) {
+			respondError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to update inventory")
 		return
 	}
 
+	w.Header().Set("ETag", inventory.ETag())
 	respondJSON(w, http.StatusOK, inventory)
 }
 
@@ -248,14 +271,116 @@ func (h *InventoryHandler) UpdateStatus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	inventory, err := h.queries.UpdateInventoryStatus(ctx, db.UpdateInventoryStatusParams{
-		InventoryID: id,
-		Status:      db.InventoryStatus(req.Status),
+	status := db.InventoryStatus(req.Status)
+	if !status.Valid() {
+		respondError(w, http.StatusBadRequest, "Invalid status")
+		return
+	}
+
+	inventory, err := h.queries.UpdateInventoryStatusTx(ctx, db.UpdateInventoryStatusTxParams{
+		UpdateInventoryStatusParams: db.UpdateInventoryStatusParams{
+			InventoryID: id,
+			Status:      status,
+		},
+		IfMatch: r.Header.Get("If-Match"),
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Inventory not found")
+			return
+		}
+		if errors.Is(err, db.ErrPreconditionFailed) {
+			respondError(w, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+		if errors.Is(err, db.ErrPreconditionRequired) {
+			respondError(w, http.StatusPreconditionRequired, err.Error())
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to update inventory status")
 		return
 	}
 
+	w.Header().Set("ETag", inventory.ETag())
 	respondJSON(w, http.StatusOK, inventory)
-}
\ No newline at end of file
+}
+type MoveStockRequest struct {
+	ProductID      int64   `json:"product_id"`
+	FromLocationID int64   `json:"from_location_id"`
+	ToLocationID   int64   `json:"to_location_id"`
+	Quantity       int32   `json:"quantity"`
+	BatchNumber    *string `json:"batch_number"`
+	SerialNumber   *string `json:"serial_number"`
+	MovedBy        int64   `json:"moved_by"`
+	DeviceID       *string `json:"device_id"`
+	Notes          *string `json:"notes"`
+	Uom            *string `json:"uom"`
+}
+
+// Move relocates stock between two locations of the same warehouse.
+func (h *InventoryHandler) Move(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req MoveStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	quantity, err := toBaseQuantity(ctx, h.queries, int32(req.ProductID), req.Uom, req.Quantity)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
+	result, err := h.queries.MoveStockTx(ctx, db.MoveStockTxParams{
+		ProductID:      int32(req.ProductID),
+		FromLocationID: int32(req.FromLocationID),
+		ToLocationID:   int32(req.ToLocationID),
+		Quantity:       quantity,
+		BatchNumber:    toNullString(req.BatchNumber),
+		SerialNumber:   toNullString(req.SerialNumber),
+		MovedBy:        NullInt32(req.MovedBy),
+		DeviceID:       toNullString(req.DeviceID),
+		Notes:          toNullString(req.Notes),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "No matching stock at the source location")
+		case errors.Is(err, db.ErrSameLocation), errors.Is(err, db.ErrInvalidMoveQuantity), errors.Is(err, db.ErrWarehouseMismatch):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, db.ErrInsufficientStock), errors.Is(err, putaway.ErrCapacityExceeded):
+			respondError(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, db.ErrLocationInactive):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			log.Printf("Error moving product %d: %v", req.ProductID, err)
+			respondError(w, http.StatusInternalServerError, "Failed to move stock")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// IdentifierHistory lists the recorded location changes of a serial, batch
+// or lot identifier, newest first.
+func (h *InventoryHandler) IdentifierHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["identifierId"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid identifier ID")
+		return
+	}
+
+	history, err := h.queries.ListLocationHistoryByIdentifier(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch location history")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, history)
+}
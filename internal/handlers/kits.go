@@ -0,0 +1,353 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/kitting"
+	"github.com/molu/stock-management-system/internal/putaway"
+)
+
+type KitHandler struct {
+	queries db.SingleDb
+}
+
+func NewKitHandler(queries db.SingleDb) *KitHandler {
+	return &KitHandler{queries: queries}
+}
+
+type SetKitComponentRequest struct {
+	Quantity int32 `json:"quantity"`
+}
+
+type KitAssemblyRequest struct {
+	KitProductID int32   `json:"kit_product_id"`
+	LocationID   int32   `json:"location_id"`
+	Direction    string  `json:"direction"`
+	Quantity     int32   `json:"quantity"`
+	Notes        *string `json:"notes"`
+	CreatedBy    *int64  `json:"created_by"`
+}
+
+// ListComponents returns a kit's bill of materials.
+func (h *KitHandler) ListComponents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	components, err := h.queries.ListKitComponents(ctx, int32(id))
+	if err != nil {
+		log.Printf("Error listing kit components: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch kit components")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, components)
+}
+
+// SetComponent adds a component to a kit or changes how many go in one kit.
+func (h *KitHandler) SetComponent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+	componentID, err := strconv.ParseInt(vars["componentId"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid component ID")
+		return
+	}
+
+	var req SetKitComponentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	component, err := h.queries.SetKitComponentTx(ctx, db.SetKitComponentTxParams{
+		KitProductID:       int32(id),
+		ComponentProductID: int32(componentID),
+		Quantity:           req.Quantity,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "Product not found")
+		case errors.Is(err, db.ErrInvalidKitQuantity):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, db.ErrKitCycle):
+			respondError(w, http.StatusUnprocessableEntity, err.Error())
+		default:
+			log.Printf("Error setting kit component: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to set kit component")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, component)
+}
+
+func (h *KitHandler) DeleteComponent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+	componentID, err := strconv.ParseInt(vars["componentId"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid component ID")
+		return
+	}
+
+	n, err := h.queries.DeleteKitComponent(ctx, db.DeleteKitComponentParams{
+		KitProductID:       int32(id),
+		ComponentProductID: int32(componentID),
+	})
+	if err != nil {
+		log.Printf("Error deleting kit component: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to delete kit component")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusNotFound, "Product is not a component of this kit")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// WhereUsed lists the kits a product is a component of.
+func (h *KitHandler) WhereUsed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	kits, err := h.queries.ListKitsUsingComponent(ctx, int32(id))
+	if err != nil {
+		log.Printf("Error listing kits using component: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch kits")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, kits)
+}
+
+type KitAvailabilityComponent struct {
+	db.ListKitComponentAvailabilityRow
+	KitsPossible int32 `json:"kits_possible"`
+}
+
+type KitAvailabilityResponse struct {
+	KitProductID int32                      `json:"kit_product_id"`
+	WarehouseID  sql.NullInt32              `json:"warehouse_id"`
+	Buildable    int32                      `json:"buildable"`
+	Components   []KitAvailabilityComponent `json:"components"`
+	Requirements []kitting.Requirement      `json:"requirements,omitempty"`
+}
+
+// Availability returns how many kits the unreserved component stock can
+// build, in one warehouse or across all of them. With quantity it also
+// lists what building that many needs and what is short.
+func (h *KitHandler) Availability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+	warehouseID, err := optionalID(r.URL.Query(), "warehouse_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse_id")
+		return
+	}
+	var quantity int32
+	if v := r.URL.Query().Get("quantity"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n <= 0 {
+			respondError(w, http.StatusBadRequest, "Invalid quantity")
+			return
+		}
+		quantity = int32(n)
+	}
+
+	rows, err := h.queries.ListKitComponentAvailability(ctx, db.ListKitComponentAvailabilityParams{
+		WarehouseID:  warehouseID,
+		KitProductID: int32(id),
+	})
+	if err != nil {
+		log.Printf("Error getting kit availability: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch kit availability")
+		return
+	}
+	if len(rows) == 0 {
+		respondError(w, http.StatusNotFound, kitting.ErrNoComponents.Error())
+		return
+	}
+
+	resp := KitAvailabilityResponse{
+		KitProductID: int32(id),
+		WarehouseID:  warehouseID,
+		Components:   make([]KitAvailabilityComponent, len(rows)),
+	}
+	components := make([]kitting.Component, len(rows))
+	for i, row := range rows {
+		components[i] = kitting.Component{ProductID: row.ComponentProductID, Quantity: row.Quantity, Available: row.Available}
+		resp.Components[i] = KitAvailabilityComponent{
+			ListKitComponentAvailabilityRow: row,
+			KitsPossible:                    kitting.Buildable(components[i : i+1]),
+		}
+	}
+	resp.Buildable = kitting.Buildable(components)
+	if quantity > 0 {
+		resp.Requirements = kitting.Requirements(components, quantity)
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// CreateAssembly assembles kits from their components or disassembles kits
+// back into them.
+func (h *KitHandler) CreateAssembly(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req KitAssemblyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.KitProductID == 0 || req.LocationID == 0 {
+		respondError(w, http.StatusBadRequest, "kit_product_id and location_id are required")
+		return
+	}
+	direction := db.AssemblyDirection(req.Direction)
+	if req.Direction == "" {
+		direction = db.AssemblyDirectionAssemble
+	}
+
+	result, err := h.queries.KitAssemblyTx(ctx, db.KitAssemblyTxParams{
+		KitProductID: req.KitProductID,
+		LocationID:   req.LocationID,
+		Direction:    direction,
+		Quantity:     req.Quantity,
+		Notes:        toNullString(req.Notes),
+		CreatedBy:    toNullInt32FromInt64(req.CreatedBy),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrInvalidKitQuantity), errors.Is(err, db.ErrInvalidKitDirection):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "Kit or location not found")
+		case errors.Is(err, kitting.ErrNoComponents), errors.Is(err, db.ErrLocationInactive):
+			respondError(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, db.ErrInsufficientStock), errors.Is(err, putaway.ErrCapacityExceeded):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			log.Printf("Error recording kit assembly: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to record kit assembly")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, result)
+}
+
+type KitAssemblyResponse struct {
+	db.KitAssembly
+	Lines []db.KitAssemblyLine `json:"lines"`
+}
+
+func (h *KitHandler) GetAssembly(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid assembly ID")
+		return
+	}
+
+	assembly, err := h.queries.GetKitAssembly(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Kit assembly not found")
+			return
+		}
+		log.Printf("Error getting kit assembly: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch kit assembly")
+		return
+	}
+
+	lines, err := h.queries.ListKitAssemblyLines(ctx, assembly.AssemblyID)
+	if err != nil {
+		log.Printf("Error listing kit assembly lines: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch kit assembly")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, KitAssemblyResponse{KitAssembly: assembly, Lines: lines})
+}
+
+// ListAssemblies returns a kit's assemblies and disassemblies, newest first.
+func (h *KitHandler) ListAssemblies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	limit := int32(50)
+	offset := int32(0)
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			limit = int32(val)
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if val, err := strconv.ParseInt(o, 10, 32); err == nil {
+			offset = int32(val)
+		}
+	}
+
+	assemblies, err := h.queries.ListKitAssemblies(ctx, db.ListKitAssembliesParams{
+		KitProductID: int32(id),
+		Limit:        limit,
+		Offset:       offset,
+	})
+	if err != nil {
+		log.Printf("Error listing kit assemblies: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch kit assemblies")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, assemblies)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/molu/stock-management-system/internal/costing"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/shopspring/decimal"
+)
+
+type LandedCostHandler struct {
+	queries db.SingleDb
+}
+
+func NewLandedCostHandler(queries db.SingleDb) *LandedCostHandler {
+	return &LandedCostHandler{queries: queries}
+}
+
+type CreateLandedCostRequest struct {
+	ReferenceNumber *string         `json:"reference_number"`
+	CostType        string          `json:"cost_type"`
+	AllocationBasis string          `json:"allocation_basis"`
+	CurrencyCode    *string         `json:"currency_code"`
+	Amount          decimal.Decimal `json:"amount"`
+	// CostDate is YYYY-MM-DD and picks the exchange rate; empty means today.
+	CostDate  string  `json:"cost_date"`
+	Notes     *string `json:"notes"`
+	CreatedBy int64   `json:"created_by"`
+}
+
+// Create records a landed cost document (freight, duty, insurance, ...) on a
+// purchase order and allocates it over the lines received so far by value,
+// weight or quantity. The allocated amounts are added to the cost of the
+// received stock still on hand.
+func (h *LandedCostHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	poID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid PO ID")
+		return
+	}
+
+	var req CreateLandedCostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !req.Amount.IsPositive() {
+		respondError(w, http.StatusBadRequest, "amount must be positive")
+		return
+	}
+	if req.AllocationBasis == "" {
+		req.AllocationBasis = string(db.LandedCostBasisValue)
+	}
+
+	costDate := time.Now().Truncate(24 * time.Hour)
+	if req.CostDate != "" {
+		costDate, err = time.Parse("2006-01-02", req.CostDate)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cost_date")
+			return
+		}
+	}
+
+	if _, err := h.queries.GetPurchaseOrder(ctx, int32(poID)); err != nil {
+		respondError(w, http.StatusNotFound, "Purchase order not found")
+		return
+	}
+
+	currency, err := currencyCode(ctx, h.queries, req.CurrencyCode)
+	if err != nil {
+		respondCurrencyError(w, err)
+		return
+	}
+
+	result, err := h.queries.CreateLandedCostTx(ctx, db.CreateLandedCostTxParams{
+		PoID:            int32(poID),
+		ReferenceNumber: toNullString(req.ReferenceNumber),
+		CostType:        db.LandedCostType(strings.ToLower(req.CostType)),
+		AllocationBasis: db.LandedCostBasis(strings.ToLower(req.AllocationBasis)),
+		CurrencyCode:    currency.String,
+		Amount:          req.Amount,
+		CostDate:        costDate,
+		Notes:           toNullString(req.Notes),
+		CreatedBy:       toNullInt32FromValue(req.CreatedBy),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrLandedCostTypeInvalid),
+			errors.Is(err, db.ErrLandedCostBasisInvalid),
+			errors.Is(err, db.ErrNoExchangeRate),
+			errors.Is(err, costing.ErrNoAllocationBasis):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, db.ErrNothingReceived), errors.Is(err, db.ErrNoBaseCurrency):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			log.Printf("Error creating landed cost for PO %d: %v", poID, err)
+			respondError(w, http.StatusInternalServerError, "Failed to create landed cost")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, result)
+}
+
+func (h *LandedCostHandler) ListByPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	poID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid PO ID")
+		return
+	}
+
+	costs, err := h.queries.ListLandedCostsByPurchaseOrder(ctx, int32(poID))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch landed costs")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, costs)
+}
+
+type LandedCostResponse struct {
+	LandedCost  db.LandedCost                     `json:"landed_cost"`
+	Allocations []db.ListLandedCostAllocationsRow `json:"allocations"`
+}
+
+// Get returns a landed cost document with how it was allocated.
+func (h *LandedCostHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid landed cost ID")
+		return
+	}
+
+	cost, err := h.queries.GetLandedCost(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Landed cost not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to fetch landed cost")
+		return
+	}
+
+	allocations, err := h.queries.ListLandedCostAllocations(ctx, cost.LandedCostID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch allocations")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, LandedCostResponse{LandedCost: cost, Allocations: allocations})
+}
@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/margin"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	costBasisCosting   = "costing"
+	costBasisCostPrice = "cost_price"
+)
+
+type MarginHandler struct {
+	queries db.SingleDb
+}
+
+func NewMarginHandler(queries db.SingleDb) *MarginHandler {
+	return &MarginHandler{queries: queries}
+}
+
+// MarginLine is a report group. Product groups also carry the stored profit
+// ABC classification so it can be checked against the margin actually made.
+type MarginLine struct {
+	margin.Group
+	AbcCategory *db.AbcCategory `json:"abc_category,omitempty"`
+	AbcRanking  *int32          `json:"abc_ranking,omitempty"`
+	// AbcMatches reports whether the stored category equals MarginClass.
+	AbcMatches *bool `json:"abc_matches,omitempty"`
+}
+
+type MarginReport struct {
+	From      string       `json:"from"`
+	To        string       `json:"to"`
+	GroupBy   string       `json:"group_by"`
+	Period    string       `json:"period,omitempty"`
+	CostBasis string       `json:"cost_basis"`
+	Totals    margin.Group `json:"totals"`
+	Lines     []MarginLine `json:"lines"`
+}
+
+// Report computes revenue, cost of goods sold and gross margin of the sales
+// deliveries in a date range. Revenue uses the sale price recorded on the
+// movement, or the product's unit_price; cost is what the costing method
+// booked for the movement (cost_basis=costing) or the product's cost_price
+// (cost_basis=cost_price). Query parameters: from and to (YYYY-MM-DD, default
+// the last 30 days), group_by (product, category, warehouse or period),
+// period (day, week or month), warehouse_id, category_id, cost_basis and
+// format (json or csv).
+func (h *MarginHandler) Report(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	from, to, err := parseDateRange(query, 30)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	by := margin.Dimension(query.Get("group_by"))
+	if by == "" {
+		by = margin.ByProduct
+	}
+	period := margin.Period(query.Get("period"))
+	if by == margin.ByPeriod && period == "" {
+		period = margin.Month
+	}
+
+	costBasis := query.Get("cost_basis")
+	if costBasis == "" {
+		costBasis = costBasisCosting
+	}
+	if costBasis != costBasisCosting && costBasis != costBasisCostPrice {
+		respondError(w, http.StatusBadRequest, "cost_basis must be costing or cost_price")
+		return
+	}
+
+	format := query.Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		respondError(w, http.StatusBadRequest, "format must be json or csv")
+		return
+	}
+
+	warehouseID, err := optionalID(query, "warehouse_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	categoryID, err := optionalID(query, "category_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	rows, err := h.queries.ListSalesMarginLines(ctx, db.ListSalesMarginLinesParams{
+		FromDate:    from,
+		ToDate:      to,
+		WarehouseID: warehouseID,
+		CategoryID:  categoryID,
+	})
+	if err != nil {
+		log.Printf("Error listing sales deliveries for margin report: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to build margin report")
+		return
+	}
+
+	lines := make([]margin.Line, len(rows))
+	for i, row := range rows {
+		cost := row.BookedCost
+		if costBasis == costBasisCostPrice {
+			cost = row.CostPriceCost
+		}
+		lines[i] = margin.Line{
+			Date:          row.MovementDate,
+			ProductID:     row.ProductID,
+			SKU:           row.Sku,
+			ProductName:   row.ProductName,
+			CategoryID:    row.CategoryID.Int32,
+			CategoryName:  row.CategoryName.String,
+			WarehouseID:   row.WarehouseID,
+			WarehouseCode: row.WarehouseCode,
+			Quantity:      row.Quantity,
+			Revenue:       row.Revenue,
+			Cost:          cost,
+		}
+	}
+
+	groups, totals, err := margin.Summarize(lines, by, period)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report := MarginReport{
+		From:      from.Format("2006-01-02"),
+		To:        to.AddDate(0, 0, -1).Format("2006-01-02"),
+		GroupBy:   string(by),
+		Period:    string(period),
+		CostBasis: costBasis,
+		Totals:    totals,
+		Lines:     make([]MarginLine, len(groups)),
+	}
+
+	compare := by == margin.ByProduct && period == ""
+	if compare {
+		margin.Classify(groups)
+	}
+	for i, g := range groups {
+		report.Lines[i] = MarginLine{Group: g}
+	}
+
+	if compare {
+		classes, err := h.queries.ListProfitClassifications(ctx)
+		if err != nil {
+			log.Printf("Error listing profit classifications: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to build margin report")
+			return
+		}
+		byProduct := make(map[int32]db.AbcClassification, len(classes))
+		for _, c := range classes {
+			byProduct[c.ProductID] = c
+		}
+		for i := range report.Lines {
+			line := &report.Lines[i]
+			c, ok := byProduct[line.ID]
+			if !ok {
+				continue
+			}
+			category := c.Category
+			matches := string(c.Category) == line.MarginClass
+			line.AbcCategory = &category
+			line.AbcMatches = &matches
+			if c.Ranking.Valid {
+				ranking := c.Ranking.Int32
+				line.AbcRanking = &ranking
+			}
+		}
+	}
+
+	if format == "csv" {
+		writeMarginCSV(w, report, compare)
+		return
+	}
+	respondJSON(w, http.StatusOK, report)
+}
+
+// writeMarginCSV writes the report lines followed by a total row.
+func writeMarginCSV(w http.ResponseWriter, report MarginReport, compare bool) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="margin_%s_%s_%s.csv"`, report.GroupBy, report.From, report.To))
+	w.WriteHeader(http.StatusOK)
+
+	money := func(v decimal.Decimal) string { return v.StringFixed(2) }
+
+	header := []string{report.GroupBy, "name"}
+	if report.Period != "" && report.GroupBy != string(margin.ByPeriod) {
+		header = append(header, "period")
+	}
+	header = append(header, "quantity", "revenue", "cogs", "gross_margin", "margin_percent")
+	if compare {
+		header = append(header, "margin_rank", "margin_class", "abc_category", "abc_ranking", "abc_matches")
+	}
+
+	record := func(key string, l MarginLine) []string {
+		rec := []string{key, l.Label}
+		if report.Period != "" && report.GroupBy != string(margin.ByPeriod) {
+			rec = append(rec, l.Period)
+		}
+		rec = append(rec, strconv.FormatInt(l.Quantity, 10), money(l.Revenue), money(l.COGS),
+			money(l.GrossMargin), money(l.MarginPercent))
+		if compare {
+			var rank, category, ranking, matches string
+			if l.MarginRank > 0 {
+				rank = strconv.Itoa(l.MarginRank)
+			}
+			if l.AbcCategory != nil {
+				category = string(*l.AbcCategory)
+				matches = strconv.FormatBool(*l.AbcMatches)
+			}
+			if l.AbcRanking != nil {
+				ranking = strconv.Itoa(int(*l.AbcRanking))
+			}
+			rec = append(rec, rank, l.MarginClass, category, ranking, matches)
+		}
+		return rec
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+	for _, l := range report.Lines {
+		key := l.Key
+		if report.GroupBy == string(margin.ByPeriod) {
+			key = l.Label
+		}
+		cw.Write(record(key, l))
+	}
+	cw.Write(record("total", MarginLine{Group: report.Totals}))
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		log.Printf("Error writing margin report CSV: %v", err)
+	}
+}
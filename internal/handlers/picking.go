@@ -0,0 +1,601 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/routing"
+	"github.com/shopspring/decimal"
+)
+
+type PickingHandler struct {
+	queries db.SingleDb
+}
+
+func NewPickingHandler(queries db.SingleDb) *PickingHandler {
+	return &PickingHandler{queries: queries}
+}
+
+type CreatePickingRouteRequest struct {
+	WarehouseID          int64           `json:"warehouse_id"`
+	ZoneSequence         json.RawMessage `json:"zone_sequence"`
+	EstimatedTimeMinutes *int32          `json:"estimated_time_minutes"`
+}
+
+func (h *PickingHandler) CreateRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreatePickingRouteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	var zones []string
+	if err := json.Unmarshal(req.ZoneSequence, &zones); err != nil {
+		respondError(w, http.StatusBadRequest, "zone_sequence must be an array of zone codes")
+		return
+	}
+
+	route, err := h.queries.CreatePickingRoute(ctx, db.CreatePickingRouteParams{
+		WarehouseID:          int32(req.WarehouseID),
+		ZoneSequence:         req.ZoneSequence,
+		EstimatedTimeMinutes: toNullInt32FromInt32(req.EstimatedTimeMinutes),
+	})
+	if err != nil {
+		log.Printf("Error creating picking route: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to create picking route")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, route)
+}
+
+func (h *PickingHandler) GetRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid route ID")
+		return
+	}
+
+	route, err := h.queries.GetPickingRoute(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Picking route not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, route)
+}
+
+func (h *PickingHandler) ListRoutes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	warehouseID, err := strconv.ParseInt(vars["warehouseId"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	routes, err := h.queries.ListPickingRoutesByWarehouse(ctx, int32(warehouseID))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch picking routes")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, routes)
+}
+
+type CreatePickingWaveRequest struct {
+	WarehouseID int64   `json:"warehouse_id"`
+	WaveNumber  *string `json:"wave_number"`
+	Priority    *int32  `json:"priority"`
+	AssignedTo  *int64  `json:"assigned_to"`
+	RouteID     *int64  `json:"route_id"`
+	MaxLines    *int32  `json:"max_lines"`
+}
+
+// CreateWave batches every reservation in the warehouse that is not already on
+// an open wave into a new planned wave.
+func (h *PickingHandler) CreateWave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreatePickingWaveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.WarehouseID == 0 {
+		respondError(w, http.StatusBadRequest, "warehouse_id is required")
+		return
+	}
+
+	priority := int32(5)
+	if req.Priority != nil {
+		priority = *req.Priority
+	}
+
+	maxLines := int32(200)
+	if req.MaxLines != nil && *req.MaxLines > 0 {
+		maxLines = *req.MaxLines
+	}
+
+	result, err := h.queries.CreatePickingWaveTx(ctx, db.CreatePickingWaveTxParams{
+		WarehouseID: int32(req.WarehouseID),
+		WaveNumber:  toNullString(req.WaveNumber),
+		Priority:    priority,
+		AssignedTo:  toNullInt32FromInt64(req.AssignedTo),
+		RouteID:     toNullInt32FromInt64(req.RouteID),
+		MaxLines:    maxLines,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrNothingToPick) {
+			respondError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		log.Printf("Error creating picking wave: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to create picking wave")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, result)
+}
+
+func (h *PickingHandler) GetWave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid wave ID")
+		return
+	}
+
+	wave, err := h.queries.GetPickingWave(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Picking wave not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, wave)
+}
+
+// ListWaves lists the waves of a warehouse. With ?open=true only planned and
+// in-progress waves are returned, highest priority first.
+func (h *PickingHandler) ListWaves(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	warehouseID, err := strconv.ParseInt(vars["warehouseId"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	if r.URL.Query().Get("open") == "true" {
+		waves, err := h.queries.ListOpenPickingWaves(ctx, int32(warehouseID))
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch picking waves")
+			return
+		}
+		respondJSON(w, http.StatusOK, waves)
+		return
+	}
+
+	limit := int32(50)
+	offset := int32(0)
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			limit = int32(val)
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if val, err := strconv.ParseInt(o, 10, 32); err == nil {
+			offset = int32(val)
+		}
+	}
+
+	waves, err := h.queries.ListPickingWavesByWarehouse(ctx, db.ListPickingWavesByWarehouseParams{
+		WarehouseID: int32(warehouseID),
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch picking waves")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, waves)
+}
+
+// GetPickList returns the wave lines in walking order: by the optimised pick
+// sequence once the wave has been optimised, otherwise by the position of the
+// location's zone in the route's zone sequence, the location's own pick
+// sequence and finally its code.
+func (h *PickingHandler) GetPickList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid wave ID")
+		return
+	}
+
+	items, err := h.queries.GetPickList(ctx, int32(id))
+	if err != nil {
+		log.Printf("Error getting pick list for wave %d: %v", id, err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch pick list")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, items)
+}
+
+type OptimizePickPathResponse struct {
+	Wave     db.PickingWafe      `json:"wave"`
+	Route    db.PickingRoute     `json:"route"`
+	Method   routing.Method      `json:"method"`
+	Distance float64             `json:"distance"`
+	Items    []db.GetPickListRow `json:"items"`
+}
+
+// OptimizePickPath orders the open lines of a wave into a short walking path
+// over the location coordinates and stores the result on the wave. Query
+// parameters: method (s_shape, return, nearest_neighbour, sequence), depot_x,
+// depot_y, speed (metres per minute) and seconds_per_pick.
+func (h *PickingHandler) OptimizePickPath(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid wave ID")
+		return
+	}
+
+	method := routing.Method(query.Get("method"))
+	if method == "" {
+		method = routing.MethodNearestNeighbour
+	}
+
+	params := map[string]float64{
+		"depot_x":          0,
+		"depot_y":          0,
+		"speed":            routing.DefaultMetresPerMinute,
+		"seconds_per_pick": routing.DefaultSecondsPerPick,
+	}
+	for name := range params {
+		if v := query.Get(name); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil || f < 0 {
+				respondError(w, http.StatusBadRequest, "Invalid "+name)
+				return
+			}
+			params[name] = f
+		}
+	}
+
+	wave, err := h.queries.GetPickingWave(ctx, int32(id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusNotFound, "Picking wave not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to fetch picking wave")
+		return
+	}
+
+	depth, err := h.queries.GetWarehouseDepth(ctx, wave.WarehouseID)
+	if err != nil {
+		log.Printf("Error getting layout of warehouse %d: %v", wave.WarehouseID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch warehouse layout")
+		return
+	}
+
+	items, err := h.queries.GetPickList(ctx, wave.WaveID)
+	if err != nil {
+		log.Printf("Error getting pick list for wave %d: %v", id, err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch pick list")
+		return
+	}
+
+	// Only lines still to be picked are routed; closed lines keep their place
+	// at the front of the list.
+	var done []db.GetPickListRow
+	var stops []routing.Stop
+	byID := make(map[int32]db.GetPickListRow, len(items))
+	for _, item := range items {
+		if item.Status != db.PickStatusPending {
+			done = append(done, item)
+			continue
+		}
+		byID[item.WaveItemID] = item
+		stop := routing.Stop{
+			ID:       item.WaveItemID,
+			HasPoint: item.XCoord.Valid && item.YCoord.Valid,
+			Sequence: item.LocationSequence.Int32,
+		}
+		stop.X = item.XCoord.Decimal.InexactFloat64()
+		stop.Y = item.YCoord.Decimal.InexactFloat64()
+		stops = append(stops, stop)
+	}
+
+	layout := routing.Layout{
+		Depot: routing.Point{X: params["depot_x"], Y: params["depot_y"]},
+		BackY: depth,
+	}
+	route, err := routing.Plan(layout, stops, method)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "method must be one of s_shape, return, nearest_neighbour, sequence")
+		return
+	}
+
+	ordered := done
+	itemIDs := make([]int32, 0, len(items))
+	for _, item := range done {
+		itemIDs = append(itemIDs, item.WaveItemID)
+	}
+	zones := []string{}
+	seen := map[string]bool{}
+	for _, stop := range route.Stops {
+		item := byID[stop.ID]
+		ordered = append(ordered, item)
+		itemIDs = append(itemIDs, item.WaveItemID)
+		if item.ZoneCode.Valid && !seen[item.ZoneCode.String] {
+			seen[item.ZoneCode.String] = true
+			zones = append(zones, item.ZoneCode.String)
+		}
+	}
+	zoneSequence, _ := json.Marshal(zones)
+
+	result, err := h.queries.SavePickPathTx(ctx, db.SavePickPathTxParams{
+		WaveID:               wave.WaveID,
+		WaveItemIDs:          itemIDs,
+		ZoneSequence:         zoneSequence,
+		EstimatedTimeMinutes: routing.EstimateMinutes(route.Distance, len(route.Stops), params["speed"], params["seconds_per_pick"]),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrWaveNotOpen) {
+			respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		log.Printf("Error saving pick path for wave %d: %v", id, err)
+		respondError(w, http.StatusInternalServerError, "Failed to save pick path")
+		return
+	}
+
+	for i := range ordered {
+		ordered[i].PickSequence = sql.NullInt32{Int32: int32(i + 1), Valid: true}
+	}
+
+	respondJSON(w, http.StatusOK, OptimizePickPathResponse{
+		Wave:     result.Wave,
+		Route:    result.Route,
+		Method:   route.Method,
+		Distance: route.Distance,
+		Items:    ordered,
+	})
+}
+
+type AssignPickingWaveRequest struct {
+	AssignedTo int64 `json:"assigned_to"`
+}
+
+func (h *PickingHandler) AssignWave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid wave ID")
+		return
+	}
+
+	var req AssignPickingWaveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	wave, err := h.queries.AssignPickingWave(ctx, db.AssignPickingWaveParams{
+		WaveID:     int32(id),
+		AssignedTo: NullInt32(req.AssignedTo),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusConflict, "Picking wave not found or no longer open")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to assign picking wave")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, wave)
+}
+
+func (h *PickingHandler) StartWave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid wave ID")
+		return
+	}
+
+	wave, err := h.queries.StartPickingWave(ctx, int32(id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusConflict, "Picking wave not found or no longer open")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to start picking wave")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, wave)
+}
+
+func (h *PickingHandler) CancelWave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid wave ID")
+		return
+	}
+
+	wave, err := h.queries.CancelPickingWave(ctx, int32(id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusConflict, "Picking wave not found or no longer open")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to cancel picking wave")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, wave)
+}
+
+type ConfirmPickRequest struct {
+	Quantity  int32            `json:"quantity"`
+	PickedBy  int64            `json:"picked_by"`
+	Short     bool             `json:"short"`
+	SalePrice *decimal.Decimal `json:"sale_price"`
+}
+
+// ConfirmPick confirms a single pick line. Stock leaves the location through a
+// sales_delivery movement and the line records who picked it and how long it took.
+func (h *PickingHandler) ConfirmPick(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	itemID, err := strconv.ParseInt(vars["itemId"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid item ID")
+		return
+	}
+
+	var req ConfirmPickRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	result, err := h.queries.ConfirmPickTx(ctx, db.ConfirmPickTxParams{
+		WaveItemID: int32(itemID),
+		Quantity:   req.Quantity,
+		PickedBy:   NullInt32(req.PickedBy),
+		Short:      req.Short,
+		SalePrice:  toNullDecimal(req.SalePrice),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "Pick line not found")
+		case errors.Is(err, db.ErrInvalidPickQuantity):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, db.ErrPickLineClosed),
+			errors.Is(err, db.ErrWaveNotOpen),
+			errors.Is(err, db.ErrInsufficientStock):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			log.Printf("Error confirming pick line %d: %v", itemID, err)
+			respondError(w, http.StatusInternalServerError, "Failed to confirm pick")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+type PickerProductivity struct {
+	PickedBy     int32   `json:"picked_by"`
+	FullName     string  `json:"full_name"`
+	LinesPicked  int64   `json:"lines_picked"`
+	UnitsPicked  int64   `json:"units_picked"`
+	Waves        int64   `json:"waves"`
+	PickSeconds  int64   `json:"pick_seconds"`
+	LinesPerHour float64 `json:"lines_per_hour"`
+	UnitsPerHour float64 `json:"units_per_hour"`
+}
+
+// GetProductivity reports lines and units picked per picker over a period
+// (?from=YYYY-MM-DD&to=YYYY-MM-DD, defaulting to the last 7 days).
+func (h *PickingHandler) GetProductivity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	warehouseID, err := strconv.ParseInt(vars["warehouseId"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	to := time.Now().AddDate(0, 0, 1).Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, -7)
+
+	if f := r.URL.Query().Get("from"); f != "" {
+		if from, err = time.Parse("2006-01-02", f); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid from date")
+			return
+		}
+	}
+
+	if t := r.URL.Query().Get("to"); t != "" {
+		parsed, err := time.Parse("2006-01-02", t)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid to date")
+			return
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	rows, err := h.queries.GetPickerProductivity(ctx, db.GetPickerProductivityParams{
+		WarehouseID: int32(warehouseID),
+		FromTime:    from,
+		ToTime:      to,
+	})
+	if err != nil {
+		log.Printf("Error getting picker productivity: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch productivity")
+		return
+	}
+
+	report := make([]PickerProductivity, 0, len(rows))
+	for _, row := range rows {
+		p := PickerProductivity{
+			PickedBy:    row.PickedBy.Int32,
+			FullName:    row.FullName.String,
+			LinesPicked: row.LinesPicked,
+			UnitsPicked: row.UnitsPicked,
+			Waves:       row.Waves,
+			PickSeconds: row.PickSeconds,
+		}
+		if row.PickSeconds > 0 {
+			hours := float64(row.PickSeconds) / 3600
+			p.LinesPerHour = float64(row.LinesPicked) / hours
+			p.UnitsPerHour = float64(row.UnitsPicked) / hours
+		}
+		report = append(report, p)
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/middleware"
+	"github.com/molu/stock-management-system/internal/purchasing"
+	"github.com/shopspring/decimal"
+)
+
+type DecidePurchaseOrderRequest struct {
+	Comments *string `json:"comments"`
+}
+
+// Approve signs off the next outstanding approval level of a pending
+// purchase order on behalf of the authenticated user.
+func (h *PurchaseOrderHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, db.PoApprovalDecisionApproved)
+}
+
+// Reject turns down a pending purchase order at its next approval level and
+// sends it back to draft.
+func (h *PurchaseOrderHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, db.PoApprovalDecisionRejected)
+}
+
+func (h *PurchaseOrderHandler) decide(w http.ResponseWriter, r *http.Request, decision db.PoApprovalDecision) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid PO ID")
+		return
+	}
+
+	var req DecidePurchaseOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// The approver's role decides which levels they may sign, so it comes
+	// from the token and never from the request body.
+	approverID, ok := middleware.UserID(ctx)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	result, err := h.queries.DecidePurchaseOrderTx(ctx, db.DecidePurchaseOrderTxParams{
+		PoID:       int32(id),
+		ApproverID: int32(approverID),
+		Decision:   decision,
+		Comments:   toNullString(req.Comments),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "Purchase order not found")
+		case errors.Is(err, db.ErrApproverInactive):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, db.ErrApproverRole),
+			errors.Is(err, db.ErrSelfApproval),
+			errors.Is(err, db.ErrAlreadySignedOff):
+			respondError(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, db.ErrPurchaseOrderNotPending),
+			errors.Is(err, db.ErrCreatorUnknown):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			log.Printf("Error recording %s decision on PO %d: %v", decision, id, err)
+			respondError(w, http.StatusInternalServerError, "Failed to record decision")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+type PurchaseOrderApprovalsResponse struct {
+	Status    db.PurchaseOrderStatus             `json:"status"`
+	Approvals []db.ListPurchaseOrderApprovalsRow `json:"approvals"`
+	// Outstanding are the levels a pending order still needs.
+	Outstanding []purchasing.Level `json:"outstanding_levels"`
+}
+
+// ListApprovals returns every sign-off and rejection of a purchase order,
+// oldest first, and the levels it still needs while pending.
+func (h *PurchaseOrderHandler) ListApprovals(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid PO ID")
+		return
+	}
+
+	po, err := h.queries.GetPurchaseOrder(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Purchase order not found")
+		return
+	}
+
+	approvals, err := h.queries.ListPurchaseOrderApprovals(ctx, po.PoID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch approvals")
+		return
+	}
+
+	resp := PurchaseOrderApprovalsResponse{
+		Status:      po.Status,
+		Approvals:   approvals,
+		Outstanding: []purchasing.Level{},
+	}
+
+	if po.Status == db.PurchaseOrderStatusPending {
+		levels, err := h.queries.ListActivePoApprovalLevels(ctx)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch approval levels")
+			return
+		}
+		signed, err := h.queries.ListSubmissionApprovals(ctx, po.PoID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch approvals")
+			return
+		}
+
+		rules := make([]purchasing.Level, len(levels))
+		for i, l := range levels {
+			rules[i] = purchasing.Level{Level: l.Level, Role: purchasing.Role(l.ApproverRole), MinAmount: l.MinAmount}
+		}
+		done := make([]int32, len(signed))
+		for i, s := range signed {
+			done[i] = s.Level
+		}
+		resp.Outstanding = purchasing.Outstanding(purchasing.RequiredLevels(rules, po.TotalAmount), done)
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// requireAdmin answers 401 or 403 and reports false unless the user in the
+// bearer token is an active admin. The role is read from the users table, so
+// a demotion takes effect without waiting for the token to expire.
+func (h *PurchaseOrderHandler) requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return false
+	}
+
+	user, err := h.queries.GetUser(r.Context(), int32(userID))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Error getting user %d: %v", userID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to check permissions")
+		return false
+	}
+	if err != nil || !user.IsActive || user.Role != db.UserRoleAdmin {
+		respondError(w, http.StatusForbidden, "Only an admin may manage approval levels")
+		return false
+	}
+	return true
+}
+
+type PoApprovalLevelRequest struct {
+	Level        int32           `json:"level"`
+	ApproverRole string          `json:"approver_role"`
+	MinAmount    decimal.Decimal `json:"min_amount"`
+	Description  *string         `json:"description"`
+	IsActive     *bool           `json:"is_active"`
+}
+
+func (h *PurchaseOrderHandler) ListApprovalLevels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	levels, err := h.queries.ListPoApprovalLevels(ctx)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch approval levels")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, levels)
+}
+
+// CreateApprovalLevel adds a sign-off step: orders totalling min_amount or
+// more need a user with approver_role, or a higher role, to sign it. Only an
+// admin may add one.
+func (h *PurchaseOrderHandler) CreateApprovalLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !h.requireAdmin(w, r) {
+		return
+	}
+
+	var req PoApprovalLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	role := db.UserRole(req.ApproverRole)
+	if req.Level <= 0 || !role.Valid() || req.MinAmount.IsNegative() {
+		respondError(w, http.StatusBadRequest, "A positive level, a valid approver_role and a non-negative min_amount are required")
+		return
+	}
+
+	level, err := h.queries.CreatePoApprovalLevel(ctx, db.CreatePoApprovalLevelParams{
+		Level:        req.Level,
+		ApproverRole: role,
+		MinAmount:    req.MinAmount,
+		Description:  toNullString(req.Description),
+	})
+	if err != nil {
+		log.Printf("Error creating approval level %d: %v", req.Level, err)
+		respondError(w, http.StatusInternalServerError, "Failed to create approval level")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, level)
+}
+
+// UpdateApprovalLevel changes a level's role, threshold or description, or
+// deactivates it. Orders already pending are checked against the new rules
+// on their next sign-off. Only an admin may change one.
+func (h *PurchaseOrderHandler) UpdateApprovalLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !h.requireAdmin(w, r) {
+		return
+	}
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid approval level ID")
+		return
+	}
+
+	var req PoApprovalLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	role := db.UserRole(req.ApproverRole)
+	if !role.Valid() || req.MinAmount.IsNegative() {
+		respondError(w, http.StatusBadRequest, "A valid approver_role and a non-negative min_amount are required")
+		return
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	level, err := h.queries.UpdatePoApprovalLevel(ctx, db.UpdatePoApprovalLevelParams{
+		ApprovalLevelID: int32(id),
+		ApproverRole:    role,
+		MinAmount:       req.MinAmount,
+		Description:     toNullString(req.Description),
+		IsActive:        isActive,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Approval level not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to update approval level")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, level)
+}
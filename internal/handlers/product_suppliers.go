@@ -0,0 +1,462 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/shopspring/decimal"
+)
+
+type ProductSupplierHandler struct {
+	queries db.SingleDb
+}
+
+func NewProductSupplierHandler(queries db.SingleDb) *ProductSupplierHandler {
+	return &ProductSupplierHandler{queries: queries}
+}
+
+// effectiveDate parses a YYYY-MM-DD date; empty means today.
+func effectiveDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Now().Truncate(24 * time.Hour), nil
+	}
+	return time.Parse("2006-01-02", s)
+}
+
+type ProductSupplierRequest struct {
+	ProductID int64            `json:"product_id"`
+	Priority  int32            `json:"priority"`
+	UnitPrice *decimal.Decimal `json:"unit_price"`
+	// EffectiveFrom is YYYY-MM-DD and dates unit_price; empty means today.
+	EffectiveFrom    string `json:"effective_from"`
+	MinOrderQuantity *int64 `json:"min_order_quantity"`
+	LeadTimeDays     *int64 `json:"lead_time_days"`
+	IsActive         *bool  `json:"is_active"`
+	PurchaseUomID    *int64 `json:"purchase_uom_id"`
+	CreatedBy        *int64 `json:"created_by"`
+}
+
+// params validates the request and converts it for the store.
+func (req ProductSupplierRequest) params() (db.ProductSupplierTxParams, error) {
+	if req.Priority == 0 {
+		req.Priority = 1
+	}
+	if req.Priority < 1 {
+		return db.ProductSupplierTxParams{}, errors.New("priority must be 1 or more")
+	}
+	if req.UnitPrice != nil && req.UnitPrice.IsNegative() {
+		return db.ProductSupplierTxParams{}, errors.New("unit_price must not be negative")
+	}
+	if (req.MinOrderQuantity != nil && *req.MinOrderQuantity < 0) || (req.LeadTimeDays != nil && *req.LeadTimeDays < 0) {
+		return db.ProductSupplierTxParams{}, errors.New("min_order_quantity and lead_time_days must not be negative")
+	}
+	effective, err := effectiveDate(req.EffectiveFrom)
+	if err != nil {
+		return db.ProductSupplierTxParams{}, errors.New("Invalid effective_from date")
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	return db.ProductSupplierTxParams{
+		ProductID:        int32(req.ProductID),
+		Priority:         req.Priority,
+		LeadTimeDays:     toNullInt32FromInt64(req.LeadTimeDays),
+		MinOrderQuantity: toNullInt32FromInt64(req.MinOrderQuantity),
+		IsActive:         isActive,
+		PurchaseUomID:    toNullInt32FromInt64(req.PurchaseUomID),
+		UnitPrice:        toNullDecimal(req.UnitPrice),
+		EffectiveFrom:    effective,
+		CreatedBy:        toNullInt32FromInt64(req.CreatedBy),
+	}, nil
+}
+
+// ListCatalogue returns every product the supplier is linked to, active or
+// not, with its terms and current price.
+func (h *ProductSupplierHandler) ListCatalogue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid supplier ID")
+		return
+	}
+
+	limit := int32(50)
+	offset := int32(0)
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			limit = int32(val)
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if val, err := strconv.ParseInt(o, 10, 32); err == nil {
+			offset = int32(val)
+		}
+	}
+
+	links, err := h.queries.ListSupplierCatalogue(ctx, db.ListSupplierCatalogueParams{
+		SupplierID: int32(id),
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch supplier catalogue")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, links)
+}
+
+// ListByProduct returns a product's suppliers, active ones first in
+// priority order.
+func (h *ProductSupplierHandler) ListByProduct(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	links, err := h.queries.ListProductSuppliersByProduct(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch product suppliers")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, links)
+}
+
+// Create links a product to the supplier.
+func (h *ProductSupplierHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	supplierID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid supplier ID")
+		return
+	}
+
+	var req ProductSupplierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.ProductID == 0 {
+		respondError(w, http.StatusBadRequest, "product_id is required")
+		return
+	}
+
+	arg, err := req.params()
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	arg.SupplierID = int32(supplierID)
+
+	if _, err := h.queries.GetSupplier(ctx, arg.SupplierID); err != nil {
+		respondError(w, http.StatusNotFound, "Supplier not found")
+		return
+	}
+	if _, err := h.queries.GetProduct(ctx, arg.ProductID); err != nil {
+		respondError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	link, err := h.queries.CreateProductSupplierTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrProductSupplierExists) {
+			respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		log.Printf("Error linking supplier %d to product %d: %v", supplierID, req.ProductID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to create product supplier")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, link)
+}
+
+func (h *ProductSupplierHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product supplier ID")
+		return
+	}
+
+	link, err := h.queries.GetProductSupplier(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Product supplier not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to fetch product supplier")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, link)
+}
+
+// Update replaces a link's priority and terms. A unit_price is added to the
+// price history from effective_from rather than overwriting the current
+// price directly.
+func (h *ProductSupplierHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product supplier ID")
+		return
+	}
+
+	var req ProductSupplierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	arg, err := req.params()
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	arg.ProductSupplierID = int32(id)
+
+	link, err := h.queries.UpdateProductSupplierTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Product supplier not found")
+			return
+		}
+		log.Printf("Error updating product supplier %d: %v", id, err)
+		respondError(w, http.StatusInternalServerError, "Failed to update product supplier")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, link)
+}
+
+// Delete removes a link and its price history. Set is_active to false
+// instead to keep the history.
+func (h *ProductSupplierHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product supplier ID")
+		return
+	}
+
+	if err := h.queries.DeleteProductSupplierTx(ctx, int32(id)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Product supplier not found")
+			return
+		}
+		log.Printf("Error deleting product supplier %d: %v", id, err)
+		respondError(w, http.StatusInternalServerError, "Failed to delete product supplier")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListPrices returns a link's price history, newest first, each price with
+// the one before it.
+func (h *ProductSupplierHandler) ListPrices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product supplier ID")
+		return
+	}
+
+	if _, err := h.queries.GetProductSupplier(ctx, int32(id)); err != nil {
+		respondError(w, http.StatusNotFound, "Product supplier not found")
+		return
+	}
+
+	prices, err := h.queries.ListProductSupplierPrices(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch price history")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, prices)
+}
+
+type PriceListLineRequest struct {
+	Sku       string          `json:"sku"`
+	UnitPrice decimal.Decimal `json:"unit_price"`
+	// EffectiveFrom overrides the price list's date for this line.
+	EffectiveFrom    string `json:"effective_from"`
+	MinOrderQuantity *int64 `json:"min_order_quantity"`
+	LeadTimeDays     *int64 `json:"lead_time_days"`
+}
+
+type ImportPriceListRequest struct {
+	// EffectiveFrom is YYYY-MM-DD; empty means today.
+	EffectiveFrom string                 `json:"effective_from"`
+	AddMissing    bool                   `json:"add_missing"`
+	CreatedBy     *int64                 `json:"created_by"`
+	Lines         []PriceListLineRequest `json:"lines"`
+}
+
+type PriceListLineError struct {
+	Line  int    `json:"line"`
+	Sku   string `json:"sku"`
+	Error string `json:"error"`
+}
+
+// ImportPriceList records a supplier's price list. Every line is checked
+// first and nothing is saved when any line is wrong; the response then lists
+// the problems by line number.
+func (h *ProductSupplierHandler) ImportPriceList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	supplierID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid supplier ID")
+		return
+	}
+
+	var req ImportPriceListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if len(req.Lines) == 0 {
+		respondError(w, http.StatusBadRequest, db.ErrPriceListEmpty.Error())
+		return
+	}
+
+	defaultDate, err := effectiveDate(req.EffectiveFrom)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid effective_from date")
+		return
+	}
+
+	if _, err := h.queries.GetSupplier(ctx, int32(supplierID)); err != nil {
+		respondError(w, http.StatusNotFound, "Supplier not found")
+		return
+	}
+
+	lines := make([]db.PriceListLine, 0, len(req.Lines))
+	lineErrors := []PriceListLineError{}
+	seen := make(map[string]int)
+	for i, l := range req.Lines {
+		fail := func(format string, args ...interface{}) {
+			lineErrors = append(lineErrors, PriceListLineError{Line: i + 1, Sku: l.Sku, Error: fmt.Sprintf(format, args...)})
+		}
+
+		effective := defaultDate
+		if l.EffectiveFrom != "" {
+			effective, err = time.Parse("2006-01-02", l.EffectiveFrom)
+			if err != nil {
+				fail("invalid effective_from date")
+				continue
+			}
+		}
+		switch {
+		case l.Sku == "":
+			fail("sku is required")
+			continue
+		case !l.UnitPrice.IsPositive():
+			fail("unit_price must be positive")
+			continue
+		case (l.MinOrderQuantity != nil && *l.MinOrderQuantity < 0) || (l.LeadTimeDays != nil && *l.LeadTimeDays < 0):
+			fail("min_order_quantity and lead_time_days must not be negative")
+			continue
+		}
+
+		key := l.Sku + "|" + effective.Format("2006-01-02")
+		if first, ok := seen[key]; ok {
+			fail("same sku and effective_from as line %d", first)
+			continue
+		}
+		seen[key] = i + 1
+
+		product, err := h.queries.GetProductBySKU(ctx, l.Sku)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				fail("unknown sku")
+				continue
+			}
+			respondError(w, http.StatusInternalServerError, "Failed to check price list")
+			return
+		}
+		if !req.AddMissing {
+			_, err := h.queries.GetProductSupplierByProduct(ctx, db.GetProductSupplierByProductParams{
+				SupplierID: int32(supplierID),
+				ProductID:  product.ProductID,
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				fail("%s (set add_missing to link it)", db.ErrProductSupplierNotFound)
+				continue
+			}
+			if err != nil {
+				respondError(w, http.StatusInternalServerError, "Failed to check price list")
+				return
+			}
+		}
+
+		lines = append(lines, db.PriceListLine{
+			ProductID:        product.ProductID,
+			UnitPrice:        l.UnitPrice,
+			EffectiveFrom:    effective,
+			MinOrderQuantity: toNullInt32FromInt64(l.MinOrderQuantity),
+			LeadTimeDays:     toNullInt32FromInt64(l.LeadTimeDays),
+		})
+	}
+
+	if len(lineErrors) > 0 {
+		respondJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"error":  "Price list has invalid lines; nothing was imported",
+			"errors": lineErrors,
+		})
+		return
+	}
+
+	result, err := h.queries.ImportSupplierPriceListTx(ctx, db.ImportSupplierPriceListTxParams{
+		SupplierID: int32(supplierID),
+		Lines:      lines,
+		AddMissing: req.AddMissing,
+		CreatedBy:  toNullInt32FromInt64(req.CreatedBy),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrProductSupplierNotFound) {
+			respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		log.Printf("Error importing price list of supplier %d: %v", supplierID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to import price list")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
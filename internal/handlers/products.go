@@ -3,12 +3,15 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/molu/stock-management-system/internal/barcode"
 	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/search"
 	"github.com/shopspring/decimal"
 )
 
@@ -38,10 +41,20 @@ func (h *ProductHandler) List(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	products, err := h.queries.ListProducts(ctx, db.ListProductsParams{
-		Limit:  limit,
-		Offset: offset,
-	})
+	var products []db.Product
+	var err error
+	if filter, ok := attributeFilter(r); ok {
+		products, err = h.queries.ListProductsByAttributes(ctx, db.ListProductsByAttributesParams{
+			Filter:    filter,
+			RowLimit:  limit,
+			RowOffset: offset,
+		})
+	} else {
+		products, err = h.queries.ListProducts(ctx, db.ListProductsParams{
+			Limit:  limit,
+			Offset: offset,
+		})
+	}
 	if err != nil {
 		log.Printf("Error listing products: %v", err)
 		respondError(w, http.StatusInternalServerError, "Failed to fetch products")
@@ -73,6 +86,7 @@ func (h *ProductHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", product.ETag())
 	respondJSON(w, http.StatusOK, product)
 }
 
@@ -94,6 +108,15 @@ func (h *ProductHandler) GetBySKU(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, product)
 }
 
+// validateBarcode checks the check digit of a product barcode; a missing or
+// empty barcode is fine.
+func validateBarcode(code *string) error {
+	if code == nil || *code == "" {
+		return nil
+	}
+	return barcode.Validate(*code)
+}
+
 type CreateProductRequest struct {
 	SKU           string           `json:"sku"`
 	Name          string           `json:"name"`
@@ -112,6 +135,7 @@ type CreateProductRequest struct {
 	LeadTimeDays  *int32           `json:"lead_time_days"`
 	AutoReorder   bool             `json:"auto_reorder"`
 	IsActive      bool             `json:"is_active"`
+	BaseUomID     *int64           `json:"base_uom_id"`
 }
 
 func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -133,6 +157,10 @@ func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, "Name is required")
 		return
 	}
+	if err := validateBarcode(req.Barcode); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	// Convert nullable string fields
 	var description sql.NullString
@@ -212,6 +240,7 @@ func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
 		LeadTimeDays:  leadTimeDays,
 		AutoReorder:   req.AutoReorder,
 		IsActive:      req.IsActive,
+		BaseUomID:     toNullInt32FromInt64(req.BaseUomID),
 	})
 	if err != nil {
 		log.Printf("Error creating product: %v", err)
@@ -250,6 +279,10 @@ func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, "Name is required")
 		return
 	}
+	if err := validateBarcode(req.Barcode); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	// Convert nullable string fields
 	var description sql.NullString
@@ -311,35 +344,48 @@ func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 		leadTimeDays = sql.NullInt32{Int32: *req.LeadTimeDays, Valid: true}
 	}
 
-	product, err := h.queries.UpdateProduct(ctx, db.UpdateProductParams{
-		ProductID:     id,
-		Name:          req.Name,
-		Description:   description,
-		CategoryID:    categoryID,
-		UnitPrice:     req.UnitPrice,
-		CostPrice:     req.CostPrice,
-		Barcode:       barcode,
-		Weight:        weight,
-		Dimensions:    dimensions,
-		SupplierID:    supplierID,
-		MinStockLevel: req.MinStockLevel,
-		MaxStockLevel: maxStockLevel,
-		ReorderPoint:  reorderPoint,
-		SafetyStock:   safetyStock,
-		LeadTimeDays:  leadTimeDays,
-		AutoReorder:   req.AutoReorder,
-		IsActive:      req.IsActive,
+	product, err := h.queries.UpdateProductTx(ctx, db.UpdateProductTxParams{
+		UpdateProductParams: db.UpdateProductParams{
+			ProductID:     id,
+			Name:          req.Name,
+			Description:   description,
+			CategoryID:    categoryID,
+			UnitPrice:     req.UnitPrice,
+			CostPrice:     req.CostPrice,
+			Barcode:       barcode,
+			Weight:        weight,
+			Dimensions:    dimensions,
+			SupplierID:    supplierID,
+			MinStockLevel: req.MinStockLevel,
+			MaxStockLevel: maxStockLevel,
+			ReorderPoint:  reorderPoint,
+			SafetyStock:   safetyStock,
+			LeadTimeDays:  leadTimeDays,
+			AutoReorder:   req.AutoReorder,
+			IsActive:      req.IsActive,
+			BaseUomID:     toNullInt32FromInt64(req.BaseUomID),
+		},
+		IfMatch: r.Header.Get("If-Match"),
 	})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			respondError(w, http.StatusNotFound, "Product not found")
 			return
 		}
+		if errors.Is(err, db.ErrPreconditionFailed) {
+			respondError(w, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+		if errors.Is(err, db.ErrPreconditionRequired) {
+			respondError(w, http.StatusPreconditionRequired, err.Error())
+			return
+		}
 		log.Printf("Error updating product %d: %v", id, err)
 		respondError(w, http.StatusInternalServerError, "Failed to update product: "+err.Error())
 		return
 	}
 
+	w.Header().Set("ETag", product.ETag())
 	respondJSON(w, http.StatusOK, product)
 }
 
@@ -354,11 +400,23 @@ func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	id := int32(id64)
 
-	if err := h.queries.SoftDeleteProduct(ctx, id); err != nil {
+	err = h.queries.DeleteProductTx(ctx, db.DeleteProductTxParams{
+		ProductID: id,
+		IfMatch:   r.Header.Get("If-Match"),
+	})
+	if err != nil {
 		if err == sql.ErrNoRows {
 			respondError(w, http.StatusNotFound, "Product not found")
 			return
 		}
+		if errors.Is(err, db.ErrPreconditionFailed) {
+			respondError(w, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+		if errors.Is(err, db.ErrPreconditionRequired) {
+			respondError(w, http.StatusPreconditionRequired, err.Error())
+			return
+		}
 		log.Printf("Error deleting product %d: %v", id, err)
 		respondError(w, http.StatusInternalServerError, "Failed to delete product: "+err.Error())
 		return
@@ -393,11 +451,36 @@ func (h *ProductHandler) ListByCategory(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	products, err := h.queries.ListProductsByCategory(ctx, db.ListProductsByCategoryParams{
-		CategoryID: sql.NullInt32{Int32: categoryID, Valid: true},
-		Limit:      limit,
-		Offset:     offset,
-	})
+	var includeDescendants bool
+	if v := r.URL.Query().Get("include_descendants"); v != "" {
+		if includeDescendants, err = strconv.ParseBool(v); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid include_descendants")
+			return
+		}
+	}
+
+	var products []db.Product
+	if filter, ok := attributeFilter(r); ok {
+		products, err = h.queries.ListProductsByAttributes(ctx, db.ListProductsByAttributesParams{
+			CategoryID:         sql.NullInt32{Int32: categoryID, Valid: true},
+			IncludeDescendants: includeDescendants,
+			Filter:             filter,
+			RowLimit:           limit,
+			RowOffset:          offset,
+		})
+	} else if includeDescendants {
+		products, err = h.queries.ListProductsInCategoryTree(ctx, db.ListProductsInCategoryTreeParams{
+			CategoryID: categoryID,
+			Limit:      limit,
+			Offset:     offset,
+		})
+	} else {
+		products, err = h.queries.ListProductsByCategory(ctx, db.ListProductsByCategoryParams{
+			CategoryID: sql.NullInt32{Int32: categoryID, Valid: true},
+			Limit:      limit,
+			Offset:     offset,
+		})
+	}
 	if err != nil {
 		log.Printf("Error listing products by category %d: %v", categoryID, err)
 		respondError(w, http.StatusInternalServerError, "Failed to fetch products")
@@ -419,3 +502,85 @@ func (h *ProductHandler) ListBelowReorderPoint(w http.ResponseWriter, r *http.Re
 
 	respondJSON(w, http.StatusOK, products)
 }
+
+// Search finds products by name, description, SKU or barcode, ranking exact
+// SKU and barcode hits first, then full-text and prefix matches, then
+// misspellings close enough by trigram similarity. Query parameters: q,
+// category_id (its whole subtree), supplier_id, active, min_price,
+// max_price, stock_status (in_stock, low_stock or out_of_stock), limit and
+// offset.
+func (h *ProductHandler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	term := search.Normalize(query.Get("q"))
+	arg := db.SearchProductsParams{
+		Term:     term,
+		TsQuery:  search.TSQuery(term),
+		RowLimit: 50,
+	}
+
+	var err error
+	if arg.CategoryID, err = optionalID(query, "category_id"); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category_id")
+		return
+	}
+	if arg.SupplierID, err = optionalID(query, "supplier_id"); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid supplier_id")
+		return
+	}
+	if v := query.Get("active"); v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid active")
+			return
+		}
+		arg.IsActive = sql.NullBool{Bool: active, Valid: true}
+	}
+	if v := query.Get("min_price"); v != "" {
+		d, err := decimal.NewFromString(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid min_price")
+			return
+		}
+		arg.MinPrice = decimal.NullDecimal{Decimal: d, Valid: true}
+	}
+	if v := query.Get("max_price"); v != "" {
+		d, err := decimal.NewFromString(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid max_price")
+			return
+		}
+		arg.MaxPrice = decimal.NullDecimal{Decimal: d, Valid: true}
+	}
+	if v := query.Get("stock_status"); v != "" {
+		if !search.ValidStockStatus(v) {
+			respondError(w, http.StatusBadRequest, "stock_status must be in_stock, low_stock or out_of_stock")
+			return
+		}
+		arg.StockStatus = sql.NullString{String: v, Valid: true}
+	}
+
+	if l := query.Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			arg.RowLimit = int32(val)
+		}
+	}
+	if o := query.Get("offset"); o != "" {
+		if val, err := strconv.ParseInt(o, 10, 32); err == nil {
+			arg.RowOffset = int32(val)
+		}
+	}
+
+	products, err := h.queries.SearchProductsTx(ctx, db.SearchProductsTxParams{
+		SearchProductsParams: arg,
+		MinSimilarity:        search.DefaultSimilarity,
+	})
+	if err != nil {
+		log.Printf("Error searching products: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to search products")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, products)
+}
@@ -4,12 +4,16 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
 	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/middleware"
+	"github.com/molu/stock-management-system/internal/purchasing"
 	"github.com/shopspring/decimal"
 )
 
@@ -22,32 +26,54 @@ func NewPurchaseOrderHandler(queries db.SingleDb) *PurchaseOrderHandler {
 }
 
 type CreatePurchaseOrderRequest struct {
-	PONumber             string          `json:"po_number"`
-	SupplierID           int64           `json:"supplier_id"`
-	OrderDate            time.Time       `json:"order_date"`
-	ExpectedDeliveryDate time.Time       `json:"expected_delivery_date"`
-	Status               string          `json:"status"`
-	TotalAmount          decimal.Decimal `json:"total_amount"`
-	Notes                *string         `json:"notes"`
-	CreatedBy            int64           `json:"created_by"`
+	PONumber             string    `json:"po_number"`
+	SupplierID           int64     `json:"supplier_id"`
+	OrderDate            time.Time `json:"order_date"`
+	ExpectedDeliveryDate time.Time `json:"expected_delivery_date"`
+	Notes                *string   `json:"notes"`
+	// CurrencyCode is the currency of the order prices; empty means the
+	// supplier's currency.
+	CurrencyCode *string `json:"currency_code"`
+	// WarehouseID is where the goods are delivered to.
+	WarehouseID *int64 `json:"warehouse_id"`
 }
 
+// Create opens a purchase order as a draft. Its total_amount is kept as the
+// sum of the items added to it. The creator is the user in the bearer token,
+// so that they cannot approve it themselves.
 func (h *PurchaseOrderHandler) Create(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	creatorID, ok := middleware.UserID(ctx)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
 	var req CreatePurchaseOrderRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
+	currency, err := currencyCode(ctx, h.queries, req.CurrencyCode)
+	if err != nil {
+		respondCurrencyError(w, err)
+		return
+	}
+	if !currency.Valid {
+		if supplier, err := h.queries.GetSupplier(ctx, int32(req.SupplierID)); err == nil {
+			currency = supplier.CurrencyCode
+		}
+	}
+
 	po, err := h.queries.CreatePurchaseOrder(ctx, db.CreatePurchaseOrderParams{
 		PoNumber:             req.PONumber,
 		SupplierID:           int32(req.SupplierID),
 		OrderDate:            req.OrderDate,
 		ExpectedDeliveryDate: req.ExpectedDeliveryDate,
-		Status:               db.PurchaseOrderStatus(req.Status),
-		TotalAmount:          req.TotalAmount,
+		Status:               db.PurchaseOrderStatusDraft,
+		TotalAmount:          decimal.Zero,
 
 		Notes: sql.NullString{
 			String: func() string {
@@ -59,10 +85,10 @@ func (h *PurchaseOrderHandler) Create(w http.ResponseWriter, r *http.Request) {
 			Valid: req.Notes != nil,
 		},
 
-		CreatedBy: sql.NullInt32{
-			Int32: int32(req.CreatedBy),
-			Valid: req.CreatedBy != 0,
-		},
+		CreatedBy: NullInt32(creatorID),
+
+		CurrencyCode: currency,
+		WarehouseID:  toNullInt32FromInt64(req.WarehouseID),
 	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to create purchase order")
@@ -130,10 +156,14 @@ func (h *PurchaseOrderHandler) ListByStatus(w http.ResponseWriter, r *http.Reque
 }
 
 type UpdatePurchaseOrderStatusRequest struct {
-	Status      string          `json:"status"`
-	TotalAmount decimal.Decimal `json:"total_amount"`
+	Status string `json:"status"`
 }
 
+// UpdateStatus moves a purchase order through its workflow: draft orders
+// are submitted (pending) or cancelled, pending orders recalled to draft or
+// cancelled, approved orders cancelled and partially received orders closed
+// (completed). Approval goes through sign-off and receiving statuses are set
+// by receipts.
 func (h *PurchaseOrderHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
@@ -150,13 +180,36 @@ func (h *PurchaseOrderHandler) UpdateStatus(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	po, err := h.queries.UpdatePurchaseOrderStatus(ctx, db.UpdatePurchaseOrderStatusParams{
-		PoID:        int32(id),
-		Status:      db.PurchaseOrderStatus(req.Status),
-		TotalAmount: req.TotalAmount,
+	status := db.PurchaseOrderStatus(req.Status)
+	if !status.Valid() {
+		respondError(w, http.StatusBadRequest, "Invalid status")
+		return
+	}
+
+	userID, ok := middleware.UserID(ctx)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	po, err := h.queries.ChangePurchaseOrderStatusTx(ctx, db.ChangePurchaseOrderStatusTxParams{
+		PoID:      int32(id),
+		Status:    status,
+		ChangedBy: int32(userID),
 	})
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to update purchase order")
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "Purchase order not found")
+		case errors.Is(err, purchasing.ErrInvalidTransition),
+			errors.Is(err, purchasing.ErrApprovalRequired),
+			errors.Is(err, purchasing.ErrReceiptRequired),
+			errors.Is(err, db.ErrPurchaseOrderEmpty):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			log.Printf("Error changing status of PO %d: %v", id, err)
+			respondError(w, http.StatusInternalServerError, "Failed to update purchase order")
+		}
 		return
 	}
 
@@ -187,9 +240,14 @@ type CreatePurchaseOrderItemRequest struct {
 	QuantityOrdered  int32           `json:"quantity_ordered"`
 	QuantityReceived int32           `json:"quantity_received"`
 	UnitPrice        decimal.Decimal `json:"unit_price"`
-	TotalPrice       decimal.Decimal `json:"total_price"`
+	// Uom is the unit the quantities and unit price are given in; empty
+	// means the product's base unit.
+	Uom *string `json:"uom"`
 }
 
+// CreateItem adds a line to a draft purchase order. The line total is
+// quantity_ordered * unit_price and the order total is recalculated in the
+// same transaction.
 func (h *PurchaseOrderHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
@@ -206,16 +264,54 @@ func (h *PurchaseOrderHandler) CreateItem(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	item, err := h.queries.CreatePurchaseOrderItem(ctx, db.CreatePurchaseOrderItemParams{
+	if req.QuantityOrdered <= 0 || req.QuantityReceived < 0 || req.UnitPrice.IsNegative() {
+		respondError(w, http.StatusBadRequest, "quantity_ordered must be positive and quantity_received and unit_price must not be negative")
+		return
+	}
+
+	factor, err := uomFactor(ctx, h.queries, int32(req.ProductID), req.Uom)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
+	quantityOrdered, err := scaleQuantity(req.QuantityOrdered, factor.ConversionFactor)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
+	quantityReceived, err := scaleQuantity(req.QuantityReceived, factor.ConversionFactor)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
+	// Lines are stored per base unit, so a case price becomes a unit price.
+	unitPrice := req.UnitPrice
+	if factor.ConversionFactor > 1 {
+		unitPrice = unitPrice.Div(decimal.NewFromInt32(factor.ConversionFactor)).Round(4)
+	}
+
+	item, err := h.queries.CreatePurchaseOrderItemTx(ctx, db.CreatePurchaseOrderItemParams{
 		PoID:             int32(poID),
 		ProductID:        int32(req.ProductID),
-		QuantityOrdered:  req.QuantityOrdered,
-		QuantityReceived: req.QuantityReceived,
-		UnitPrice:        req.UnitPrice,
-		TotalPrice:       req.TotalPrice,
+		QuantityOrdered:  quantityOrdered,
+		QuantityReceived: quantityReceived,
+		UnitPrice:        unitPrice,
+		TotalPrice:       unitPrice.Mul(decimal.NewFromInt32(quantityOrdered)).Round(2),
+		UomID:            sql.NullInt32{Int32: factor.UomID, Valid: factor.UomID != 0},
 	})
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to create item")
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "Purchase order not found")
+		case errors.Is(err, db.ErrPurchaseOrderNotDraft):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			log.Printf("Error adding item to PO %d: %v", poID, err)
+			respondError(w, http.StatusInternalServerError, "Failed to create item")
+		}
 		return
 	}
 
@@ -223,9 +319,14 @@ func (h *PurchaseOrderHandler) CreateItem(w http.ResponseWriter, r *http.Request
 }
 
 type ReceiveItemRequest struct {
-	Quantity int32 `json:"quantity"`
+	Quantity   int32   `json:"quantity"`
+	Uom        *string `json:"uom"`
+	ReceivedBy *int64  `json:"received_by"`
 }
 
+// ReceiveItem records a delivery against an approved purchase order and
+// moves the order to partially_received or, once every line is in full,
+// completed. Each delivery is dated for the supplier scorecard.
 func (h *PurchaseOrderHandler) ReceiveItem(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
@@ -242,11 +343,34 @@ func (h *PurchaseOrderHandler) ReceiveItem(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	item, err := h.queries.UpdatePurchaseOrderItemReceivedQty(ctx, db.UpdatePurchaseOrderItemReceivedQtyParams{
-		PoItemID:         int32(itemID),
-		QuantityReceived: req.Quantity,
+	if req.Quantity <= 0 {
+		respondError(w, http.StatusBadRequest, "quantity must be positive")
+		return
+	}
+
+	poItem, err := h.queries.GetPurchaseOrderItem(ctx, int32(itemID))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	quantity, err := toBaseQuantity(ctx, h.queries, poItem.ProductID, req.Uom, req.Quantity)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
+	item, err := h.queries.ReceivePurchaseOrderItemTx(ctx, db.ReceivePurchaseOrderItemTxParams{
+		PoItemID:   poItem.PoItemID,
+		Quantity:   quantity,
+		ReceivedBy: toNullInt32FromInt64(req.ReceivedBy),
 	})
 	if err != nil {
+		if errors.Is(err, db.ErrPurchaseOrderNotOpen) {
+			respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		log.Printf("Error receiving PO item %d: %v", itemID, err)
 		respondError(w, http.StatusInternalServerError, "Failed to receive item")
 		return
 	}
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/putaway"
+)
+
+type PutawayHandler struct {
+	queries db.SingleDb
+}
+
+func NewPutawayHandler(queries db.SingleDb) *PutawayHandler {
+	return &PutawayHandler{queries: queries}
+}
+
+type PutawaySuggestionResponse struct {
+	ProductID        int32                `json:"product_id"`
+	WarehouseID      int32                `json:"warehouse_id"`
+	Quantity         int32                `json:"quantity"`
+	Suggestions      []putaway.Suggestion `json:"suggestions"`
+	UnplacedQuantity int32                `json:"unplaced_quantity"`
+}
+
+// Suggest proposes locations for received goods. Query parameters:
+// warehouse_id, product_id, quantity and optionally batch_number.
+func (h *PutawayHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	warehouseID, err := strconv.ParseInt(query.Get("warehouse_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	productID, err := strconv.ParseInt(query.Get("product_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	quantity, err := strconv.ParseInt(query.Get("quantity"), 10, 32)
+	if err != nil || quantity <= 0 {
+		respondError(w, http.StatusBadRequest, "quantity must be a positive number")
+		return
+	}
+
+	product, err := h.queries.GetProduct(ctx, int32(productID))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	locations, err := h.queries.ListLocationsByWarehouse(ctx, int32(warehouseID))
+	if err != nil {
+		log.Printf("Error listing locations of warehouse %d: %v", warehouseID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch locations")
+		return
+	}
+
+	contents, err := h.queries.ListLocationContents(ctx, int32(warehouseID))
+	if err != nil {
+		log.Printf("Error listing location contents of warehouse %d: %v", warehouseID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch location contents")
+		return
+	}
+
+	zones, err := h.queries.ListPutawayZonesForCategory(ctx, db.ListPutawayZonesForCategoryParams{
+		WarehouseID: int32(warehouseID),
+		CategoryID:  product.CategoryID,
+	})
+	if err != nil {
+		log.Printf("Error listing putaway rules of warehouse %d: %v", warehouseID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch putaway rules")
+		return
+	}
+
+	byLocation := make(map[int32][]db.ListLocationContentsRow)
+	for _, row := range contents {
+		byLocation[row.LocationID.Int32] = append(byLocation[row.LocationID.Int32], row)
+	}
+
+	candidates := make([]putaway.Location, 0, len(locations))
+	for _, loc := range locations {
+		usage, held := db.PutawayOccupancy(byLocation[loc.LocationID])
+		candidates = append(candidates, putaway.Location{
+			ID:       loc.LocationID,
+			Code:     loc.LocationCode,
+			ZoneID:   loc.ZoneID.Int32,
+			Sequence: loc.PickSequence.Int32,
+			Limits:   loc.PutawayLimits(),
+			Usage:    usage,
+			Contents: held,
+		})
+	}
+
+	zoneRank := make(map[int32]int, len(zones))
+	for i, zone := range zones {
+		if _, ok := zoneRank[zone.ZoneID]; !ok {
+			zoneRank[zone.ZoneID] = i
+		}
+	}
+
+	suggestions, unplaced := putaway.Suggest(candidates, product.PutawayItem(query.Get("batch_number")), int32(quantity), zoneRank)
+	if suggestions == nil {
+		suggestions = []putaway.Suggestion{}
+	}
+
+	respondJSON(w, http.StatusOK, PutawaySuggestionResponse{
+		ProductID:        product.ProductID,
+		WarehouseID:      int32(warehouseID),
+		Quantity:         int32(quantity),
+		Suggestions:      suggestions,
+		UnplacedQuantity: unplaced,
+	})
+}
+
+type ConfirmPutawayRequest struct {
+	ProductID         int64      `json:"product_id"`
+	LocationID        int64      `json:"location_id"`
+	Quantity          int32      `json:"quantity"`
+	BatchNumber       *string    `json:"batch_number"`
+	SerialNumber      *string    `json:"serial_number"`
+	ExpiryDate        *time.Time `json:"expiry_date"`
+	ManufacturingDate *time.Time `json:"manufacturing_date"`
+	ReferenceID       *int64     `json:"reference_id"`
+	ReferenceTable    *string    `json:"reference_table"`
+	Notes             *string    `json:"notes"`
+	CreatedBy         int64      `json:"created_by"`
+	Uom               *string    `json:"uom"`
+}
+
+// Confirm stores received goods in a location, rejecting the move when it
+// would exceed the location's capacity.
+func (h *PutawayHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req ConfirmPutawayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.ProductID == 0 || req.LocationID == 0 || req.Quantity <= 0 {
+		respondError(w, http.StatusBadRequest, "product_id, location_id and a positive quantity are required")
+		return
+	}
+
+	quantity, err := toBaseQuantity(ctx, h.queries, int32(req.ProductID), req.Uom, req.Quantity)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
+	result, err := h.queries.PutawayTx(ctx, db.PutawayTxParams{
+		ProductID:         int32(req.ProductID),
+		LocationID:        int32(req.LocationID),
+		Quantity:          quantity,
+		BatchNumber:       toNullString(req.BatchNumber),
+		SerialNumber:      toNullString(req.SerialNumber),
+		ExpiryDate:        toNullTime(req.ExpiryDate),
+		ManufacturingDate: toNullTime(req.ManufacturingDate),
+		ReferenceID:       toNullInt32FromInt64(req.ReferenceID),
+		ReferenceTable:    toNullString(req.ReferenceTable),
+		Notes:             toNullString(req.Notes),
+		CreatedBy:         NullInt32(req.CreatedBy),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "Product, location or purchase order line not found")
+		case errors.Is(err, putaway.ErrCapacityExceeded),
+			errors.Is(err, db.ErrPutawayReference),
+			errors.Is(err, db.ErrPutawayWrongProduct):
+			respondError(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, db.ErrLocationInactive),
+			errors.Is(err, db.ErrPurchaseOrderNotOpen):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			log.Printf("Error putting away product %d: %v", req.ProductID, err)
+			respondError(w, http.StatusInternalServerError, "Failed to put away stock")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, result)
+}
+
+type CreatePutawayRuleRequest struct {
+	WarehouseID int64  `json:"warehouse_id"`
+	CategoryID  *int64 `json:"category_id"`
+	ZoneID      int64  `json:"zone_id"`
+	Priority    *int32 `json:"priority"`
+}
+
+func (h *PutawayHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreatePutawayRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	zone, err := h.queries.GetWarehouseZone(ctx, int32(req.ZoneID))
+	if err != nil || zone.WarehouseID != int32(req.WarehouseID) {
+		respondError(w, http.StatusBadRequest, "Zone not found in this warehouse")
+		return
+	}
+
+	priority := int32(1)
+	if req.Priority != nil {
+		priority = *req.Priority
+	}
+
+	rule, err := h.queries.CreatePutawayRule(ctx, db.CreatePutawayRuleParams{
+		WarehouseID: int32(req.WarehouseID),
+		CategoryID:  toNullInt32FromInt64(req.CategoryID),
+		ZoneID:      zone.ZoneID,
+		Priority:    priority,
+	})
+	if err != nil {
+		log.Printf("Error creating putaway rule: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to create putaway rule")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, rule)
+}
+
+func (h *PutawayHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	warehouseID, err := strconv.ParseInt(vars["warehouseId"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	rules, err := h.queries.ListPutawayRulesByWarehouse(ctx, int32(warehouseID))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch putaway rules")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rules)
+}
+
+func (h *PutawayHandler) DeactivateRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid rule ID")
+		return
+	}
+
+	if err := h.queries.DeactivatePutawayRule(ctx, int32(id)); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to deactivate putaway rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
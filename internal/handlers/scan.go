@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/molu/stock-management-system/internal/barcode"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+)
+
+type ScanHandler struct {
+	queries db.SingleDb
+}
+
+func NewScanHandler(queries db.SingleDb) *ScanHandler {
+	return &ScanHandler{queries: queries}
+}
+
+// Scan formats, telling what a scanned code was read as.
+const (
+	ScanFormatGTIN       = "gtin"
+	ScanFormatGS1        = "gs1"
+	ScanFormatSKU        = "sku"
+	ScanFormatIdentifier = "identifier"
+)
+
+type ScanResponse struct {
+	Code        string                 `json:"code"`
+	Format      string                 `json:"format"`
+	GS1         *barcode.GS1           `json:"gs1,omitempty"`
+	Product     *db.Product            `json:"product"`
+	ProductUom  *db.ProductUom         `json:"product_uom,omitempty"`
+	Inventory   []db.Inventory         `json:"inventory,omitempty"`
+	Identifiers []db.ProductIdentifier `json:"identifiers,omitempty"`
+}
+
+// Scan resolves a scanned code to a product. EAN/UPC barcodes are matched
+// against product and unit of measure barcodes in any of their equivalent
+// lengths. GS1-128 and DataMatrix data is read into its GTIN, batch, expiry
+// and serial, and the batch and serial are matched against inventory and
+// product identifiers. Anything else is tried as a SKU and then as an
+// identifier value. The code is taken from the path or, for data holding a
+// slash, the code query parameter.
+func (h *ScanHandler) Scan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	code := mux.Vars(r)["code"]
+	if code == "" {
+		code = r.URL.Query().Get("code")
+	}
+	code = strings.TrimSpace(code)
+	if code == "" {
+		respondError(w, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	resp := ScanResponse{Code: code}
+	var err error
+	switch {
+	case barcode.ValidGTIN(code):
+		resp.Format = ScanFormatGTIN
+		err = h.resolveGTIN(ctx, code, &resp)
+	case looksLikeGS1(code):
+		g, perr := barcode.ParseGS1(code)
+		if perr != nil {
+			respondError(w, http.StatusBadRequest, perr.Error())
+			return
+		}
+		resp.Format = ScanFormatGS1
+		resp.GS1 = &g
+		err = h.resolveGS1(ctx, g, &resp)
+	default:
+		err = h.resolveText(ctx, code, &resp)
+	}
+	if err != nil {
+		log.Printf("Error resolving scanned code %q: %v", code, err)
+		respondError(w, http.StatusInternalServerError, "Failed to resolve code")
+		return
+	}
+	if resp.Product == nil {
+		respondError(w, http.StatusNotFound, "No product matches this code")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// looksLikeGS1 reports whether a code that is not a plain GTIN is meant as
+// GS1 element string data: printed with bracketed identifiers, scanned with
+// a symbology identifier or group separators, or all digits starting with
+// a GTIN or SSCC identifier.
+func looksLikeGS1(code string) bool {
+	if strings.HasPrefix(code, "(") || strings.HasPrefix(code, "]") || strings.ContainsRune(code, barcode.GroupSeparator) {
+		return true
+	}
+	return len(code) > 16 && (strings.HasPrefix(code, "01") || strings.HasPrefix(code, "00")) &&
+		strings.Trim(code[:16], "0123456789") == ""
+}
+
+// resolveGTIN finds the product, or the product unit of measure, whose
+// barcode is the GTIN.
+func (h *ScanHandler) resolveGTIN(ctx context.Context, gtin string, resp *ScanResponse) error {
+	full := barcode.Equivalents(gtin)[0]
+
+	product, err := h.queries.GetProductByGTIN(ctx, full)
+	if err == nil {
+		resp.Product = &product
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	uom, err := h.queries.GetProductUomByGTIN(ctx, full)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	product, err = h.queries.GetProduct(ctx, uom.ProductID)
+	if err != nil {
+		return err
+	}
+	resp.Product = &product
+	resp.ProductUom = &uom
+	return nil
+}
+
+// resolveGS1 finds the product of the GTIN, the inventory of its batch and
+// the identifiers recorded for its batch and serial. Without a GTIN, or with
+// one no product has, the product is taken from a serial identifier.
+func (h *ScanHandler) resolveGS1(ctx context.Context, g barcode.GS1, resp *ScanResponse) error {
+	if g.GTIN != "" {
+		if err := h.resolveGTIN(ctx, g.GTIN, resp); err != nil {
+			return err
+		}
+	}
+
+	var productID sql.NullInt32
+	if resp.Product != nil {
+		productID = sql.NullInt32{Int32: resp.Product.ProductID, Valid: true}
+	}
+	for _, value := range []string{g.Serial, g.Batch} {
+		if value == "" {
+			continue
+		}
+		identifiers, err := h.queries.ListProductIdentifiersByValue(ctx, db.ListProductIdentifiersByValueParams{
+			IdentifierValue: value,
+			ProductID:       productID,
+		})
+		if err != nil {
+			return err
+		}
+		resp.Identifiers = append(resp.Identifiers, identifiers...)
+	}
+
+	if resp.Product == nil && len(resp.Identifiers) > 0 {
+		product, err := h.queries.GetProduct(ctx, resp.Identifiers[0].ProductID)
+		if err != nil {
+			return err
+		}
+		resp.Product = &product
+	}
+	if resp.Product == nil || g.Batch == "" {
+		return nil
+	}
+
+	inventory, err := h.queries.ListInventoryByProductBatch(ctx, db.ListInventoryByProductBatchParams{
+		ProductID:   resp.Product.ProductID,
+		BatchNumber: sql.NullString{String: g.Batch, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	resp.Inventory = inventory
+	return nil
+}
+
+// resolveText tries a code that is no barcode as a SKU, then as a serial,
+// RFID or other identifier value.
+func (h *ScanHandler) resolveText(ctx context.Context, code string, resp *ScanResponse) error {
+	product, err := h.queries.GetProductBySKU(ctx, code)
+	if err == nil {
+		resp.Format = ScanFormatSKU
+		resp.Product = &product
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	identifiers, err := h.queries.ListProductIdentifiersByValue(ctx, db.ListProductIdentifiersByValueParams{
+		IdentifierValue: code,
+	})
+	if err != nil || len(identifiers) == 0 {
+		return err
+	}
+	product, err = h.queries.GetProduct(ctx, identifiers[0].ProductID)
+	if err != nil {
+		return err
+	}
+	resp.Format = ScanFormatIdentifier
+	resp.Product = &product
+	resp.Identifiers = identifiers
+	return nil
+}
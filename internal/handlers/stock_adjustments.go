@@ -107,6 +107,9 @@ type CreateAdjustmentItemRequest struct {
 	QuantityAdjusted int32           `json:"quantity_adjusted"`
 	CostPrice        decimal.Decimal `json:"cost_price"`
 	Reason           string          `json:"reason"`
+	// Uom applies to quantity_adjusted; quantity_before is always in base
+	// units.
+	Uom *string `json:"uom"`
 }
 
 func (h *StockAdjustmentHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
@@ -125,11 +128,17 @@ func (h *StockAdjustmentHandler) CreateItem(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	quantityAdjusted, err := toBaseQuantity(ctx, h.queries, int32(req.ProductID), req.Uom, req.QuantityAdjusted)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
 	item, err := h.queries.CreateStockAdjustmentItem(ctx, db.CreateStockAdjustmentItemParams{
 		AdjustmentID:     int32(adjustmentID),
 		ProductID:        int32(req.ProductID),
 		QuantityBefore:   req.QuantityBefore,
-		QuantityAdjusted: req.QuantityAdjusted,
+		QuantityAdjusted: quantityAdjusted,
 		CostPrice:        req.CostPrice,
 		Reason:           sql.NullString{String: req.Reason, Valid: true},
 	})
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gorilla/mux"
 	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/shopspring/decimal"
 )
 
 func toNullStringFromValue(s string) sql.NullString {
@@ -61,6 +62,9 @@ type CreateStockMovementRequest struct {
 	ReferenceTable  *string `json:"reference_table"`
 	Notes           *string `json:"notes"`
 	CreatedBy       int64   `json:"created_by"`
+	// SalePrice is the unit price of a sales delivery; without it the
+	// product's unit_price is used for margin reporting.
+	SalePrice *decimal.Decimal `json:"sale_price"`
 }
 
 func (h *StockMovementHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -72,7 +76,7 @@ func (h *StockMovementHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	movement, err := h.queries.CreateStockMovement(ctx, db.CreateStockMovementParams{
+	result, err := h.queries.CreateStockMovementTx(ctx, db.CreateStockMovementParams{
 		ReferenceNumber: toNullStringFromValue(req.ReferenceNumber), // string → sql.NullString
 		ProductID:       int32(req.ProductID),
 		WarehouseID:     int32(req.WarehouseID),
@@ -96,13 +100,14 @@ func (h *StockMovementHandler) Create(w http.ResponseWriter, r *http.Request) {
 		ReferenceTable: toNullString(req.ReferenceTable),      // *string → sql.NullString
 		Notes:          toNullString(req.Notes),               // *string → sql.NullString
 		CreatedBy:      toNullInt32FromValue(req.CreatedBy),   // int64 → sql.NullInt32
+		SalePrice:      toNullDecimal(req.SalePrice),
 	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to create stock movement")
 		return
 	}
 
-	respondJSON(w, http.StatusCreated, movement)
+	respondJSON(w, http.StatusCreated, result.Movement)
 }
 
 func (h *StockMovementHandler) Get(w http.ResponseWriter, r *http.Request) {
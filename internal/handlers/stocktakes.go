@@ -159,6 +159,9 @@ type CreateStocktakeItemRequest struct {
 	CountedBy       *int64     `json:"counted_by"`
 	CountedAt       *time.Time `json:"counted_at"`
 	Notes           *string    `json:"notes"`
+	// Uom applies to counted_quantity; system_quantity is always in base
+	// units.
+	Uom *string `json:"uom"`
 }
 
 func (h *StocktakeHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
@@ -177,6 +180,15 @@ func (h *StocktakeHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.CountedQuantity != nil {
+		counted, err := toBaseQuantity(ctx, h.queries, int32(req.ProductID), req.Uom, *req.CountedQuantity)
+		if err != nil {
+			respondUomError(w, err)
+			return
+		}
+		req.CountedQuantity = &counted
+	}
+
 	item, err := h.queries.CreateStocktakeItem(ctx, db.CreateStocktakeItemParams{
 	StocktakeID:     int32(stocktakeID),
 	ProductID:       int32(req.ProductID),
@@ -217,8 +229,9 @@ func (h *StocktakeHandler) GetItems(w http.ResponseWriter, r *http.Request) {
 }
 
 type UpdateStocktakeItemCountRequest struct {
-	CountedQuantity int32 `json:"counted_quantity"`
-	CountedBy       int64 `json:"counted_by"`
+	CountedQuantity int32   `json:"counted_quantity"`
+	CountedBy       int64   `json:"counted_by"`
+	Uom             *string `json:"uom"`
 }
 
 func (h *StocktakeHandler) UpdateItemCount(w http.ResponseWriter, r *http.Request) {
@@ -237,9 +250,21 @@ func (h *StocktakeHandler) UpdateItemCount(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	stocktakeItem, err := h.queries.GetStocktakeItem(ctx, int32(itemID))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	counted, err := toBaseQuantity(ctx, h.queries, stocktakeItem.ProductID, req.Uom, req.CountedQuantity)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
 	item, err := h.queries.UpdateStocktakeItemCount(ctx, db.UpdateStocktakeItemCountParams{
 		StocktakeItemID: int32(itemID),
-		CountedQuantity: toNullInt32FromInt32(&req.CountedQuantity),
+		CountedQuantity: toNullInt32FromInt32(&counted),
 		CountedBy:       toNullInt32FromValue(req.CountedBy),
 	})
 	if err != nil {
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/molu/stock-management-system/internal/stream"
+)
+
+const (
+	// streamHeartbeat is how often an idle stream gets a comment line, so
+	// proxies keep it open and a dead client is noticed.
+	streamHeartbeat = 15 * time.Second
+	// streamWriteTimeout replaces the server's write timeout for streams:
+	// each write must reach the client within it.
+	streamWriteTimeout = time.Minute
+	// streamRetry is the reconnect delay suggested to EventSource clients.
+	streamRetry = 3 * time.Second
+)
+
+// StreamHandler pushes stock events to clients as server-sent events.
+type StreamHandler struct {
+	broker *stream.Broker
+}
+
+func NewStreamHandler(broker *stream.Broker) *StreamHandler {
+	return &StreamHandler{broker: broker}
+}
+
+// Stock streams inventory.changed, movement.created and stock.below_reorder
+// events as they are committed. Filters: warehouse_id, product_id and
+// events, a comma-separated list of event types. Each event's data is the
+// JSON of the change, inventory.changed and stock.below_reorder carrying
+// the same fields as their webhooks. Nothing is replayed: a client should
+// load its view first and reload it whenever the stream ends, which the
+// server does when the client falls behind or events may have been missed.
+func (h *StreamHandler) Stock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	ids, err := optionalIDs(query, "warehouse_id", "product_id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter := stream.Filter{WarehouseID: ids[0].Int32, ProductID: ids[1].Int32}
+	if v := query.Get("events"); v != "" {
+		for _, e := range strings.Split(v, ",") {
+			e = strings.TrimSpace(e)
+			if !stream.ValidEvent(e) {
+				respondError(w, http.StatusBadRequest, "events must be inventory.changed, movement.created or stock.below_reorder")
+				return
+			}
+			filter.Types = append(filter.Types, e)
+		}
+	}
+
+	rc := http.NewResponseController(w)
+	flush := func() error {
+		if err := rc.Flush(); err != nil {
+			return err
+		}
+		err := rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if errors.Is(err, http.ErrNotSupported) {
+			return nil
+		}
+		return err
+	}
+
+	events, cancel := h.broker.Subscribe(filter)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+	if err := flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+		}
+		if err := flush(); err != nil {
+			return
+		}
+	}
+}
@@ -3,11 +3,15 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/scorecard"
 	"github.com/shopspring/decimal"
 )
 
@@ -32,6 +36,9 @@ type CreateSupplierRequest struct {
 	LeadTimeDays  *int32           `json:"lead_time_days"`
 	Rating        *decimal.Decimal `json:"rating"`
 	IsActive      bool             `json:"is_active"`
+	// CurrencyCode is the ISO 4217 code the supplier invoices in; empty
+	// means the base currency.
+	CurrencyCode *string `json:"currency_code"`
 }
 
 func (h *SupplierHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -50,6 +57,12 @@ func (h *SupplierHandler) Create(w http.ResponseWriter, r *http.Request) {
 		rating = decimal.Zero
 	}
 
+	currency, err := currencyCode(ctx, h.queries, req.CurrencyCode)
+	if err != nil {
+		respondCurrencyError(w, err)
+		return
+	}
+
 	supplier, err := h.queries.CreateSupplier(ctx, db.CreateSupplierParams{
 		Code:          req.Code,
 		Name:          req.Name,
@@ -61,7 +74,7 @@ func (h *SupplierHandler) Create(w http.ResponseWriter, r *http.Request) {
 		PaymentTerms:  toNullString(req.PaymentTerms),
 		LeadTimeDays:  toNullInt32FromInt32(req.LeadTimeDays),
 		Rating: rating,
-
+		CurrencyCode:  currency,
 	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to create supplier")
@@ -92,6 +105,7 @@ func (h *SupplierHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", supplier.ETag())
 	respondJSON(w, http.StatusOK, supplier)
 }
 
@@ -180,27 +194,48 @@ func (h *SupplierHandler) Update(w http.ResponseWriter, r *http.Request) {
 	} else {
 		rating = decimal.Zero
 	}
-	
 
+	currency, err := currencyCode(ctx, h.queries, req.CurrencyCode)
+	if err != nil {
+		respondCurrencyError(w, err)
+		return
+	}
 	
 	searchTerm := req.Name
-	supplier, err := h.queries.UpdateSupplier(ctx, db.UpdateSupplierParams{
-		SupplierID:    id,
-		Name:          searchTerm,
-		ContactPerson: toNullString(req.ContactPerson),
-		Email:         toNullString(req.Email),
-		Phone:         toNullString(req.Phone),
-		Address:       toNullString(req.Address),
-		TaxID:         toNullString(req.TaxID),
-		PaymentTerms:  toNullString(req.PaymentTerms),
-		LeadTimeDays:  toNullInt32FromInt32(req.LeadTimeDays),
-		Rating:rating,
+	supplier, err := h.queries.UpdateSupplierTx(ctx, db.UpdateSupplierTxParams{
+		UpdateSupplierParams: db.UpdateSupplierParams{
+			SupplierID:    id,
+			Name:          searchTerm,
+			ContactPerson: toNullString(req.ContactPerson),
+			Email:         toNullString(req.Email),
+			Phone:         toNullString(req.Phone),
+			Address:       toNullString(req.Address),
+			TaxID:         toNullString(req.TaxID),
+			PaymentTerms:  toNullString(req.PaymentTerms),
+			LeadTimeDays:  toNullInt32FromInt32(req.LeadTimeDays),
+			Rating:        rating,
+			CurrencyCode:  currency,
+		},
+		IfMatch: r.Header.Get("If-Match"),
 	})
 	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusNotFound, "Supplier not found")
+			return
+		}
+		if errors.Is(err, db.ErrPreconditionFailed) {
+			respondError(w, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+		if errors.Is(err, db.ErrPreconditionRequired) {
+			respondError(w, http.StatusPreconditionRequired, err.Error())
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to update supplier")
 		return
 	}
 
+	w.Header().Set("ETag", supplier.ETag())
 	respondJSON(w, http.StatusOK, supplier)
 }
 
@@ -336,7 +371,89 @@ func (h *SupplierHandler) GetProducts(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, products)
 }
 
+type SetPurchaseUomRequest struct {
+	UomID *int64 `json:"uom_id"`
+}
+
+// SetPurchaseUom records the unit the supplier sells a product in. The unit
+// must be the product's base unit or one of its active alternate units; a
+// null uom_id clears it.
+func (h *SupplierHandler) SetPurchaseUom(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	supplierID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid supplier ID")
+		return
+	}
+
+	productID, err := strconv.ParseInt(vars["productId"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req SetPurchaseUomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.UomID != nil {
+		uom, err := h.queries.GetUnitOfMeasure(ctx, int32(*req.UomID))
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Unit of measure not found")
+			return
+		}
+		if _, err := uomFactor(ctx, h.queries, int32(productID), &uom.Code); err != nil {
+			respondUomError(w, err)
+			return
+		}
+	}
+
+	id, err := h.queries.SetProductSupplierPurchaseUom(ctx, db.SetProductSupplierPurchaseUomParams{
+		SupplierID:    int32(supplierID),
+		ProductID:     int32(productID),
+		PurchaseUomID: toNullInt32FromInt64(req.UomID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusNotFound, "Supplier does not supply this product")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to set purchase unit")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"product_supplier_id": id,
+		"purchase_uom_id":     req.UomID,
+	})
+}
+
+type SupplierPerformanceResponse struct {
+	db.GetSupplierPerformanceRow
+	Since     time.Time             `json:"since"`
+	Scorecard scorecard.Scorecard   `json:"scorecard"`
+	Products  []db.ProductScorecard `json:"products"`
+}
+
+// GetPerformance returns the supplier's order totals and its scorecard for
+// orders placed since the since query parameter (YYYY-MM-DD, default one
+// year ago). The stored ratings are not changed.
 func (h *SupplierHandler) GetPerformance(w http.ResponseWriter, r *http.Request) {
+	h.score(w, r, false)
+}
+
+// RefreshRating recalculates the supplier's scorecard like GetPerformance
+// and saves the ratings into suppliers.rating and
+// product_suppliers.performance_rating.
+func (h *SupplierHandler) RefreshRating(w http.ResponseWriter, r *http.Request) {
+	h.score(w, r, true)
+}
+
+func (h *SupplierHandler) score(w http.ResponseWriter, r *http.Request, save bool) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
 
@@ -347,11 +464,44 @@ func (h *SupplierHandler) GetPerformance(w http.ResponseWriter, r *http.Request)
 	}
 	id := int32(id64)
 
+	now := time.Now()
+	since := scorecard.DefaultSince(now)
+	if s := r.URL.Query().Get("since"); s != "" {
+		since, err = time.Parse("2006-01-02", s)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid since date")
+			return
+		}
+	}
+
+	if _, err := h.queries.GetSupplier(ctx, id); err != nil {
+		respondError(w, http.StatusNotFound, "Supplier not found")
+		return
+	}
+
 	performance, err := h.queries.GetSupplierPerformance(ctx, id)
 	if err != nil {
+		log.Printf("Error fetching performance of supplier %d: %v", id, err)
 		respondError(w, http.StatusInternalServerError, "Failed to fetch supplier performance")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, performance)
+	result, err := h.queries.ScoreSupplierTx(ctx, db.ScoreSupplierTxParams{
+		SupplierID:  id,
+		Since:       since,
+		AsOf:        now,
+		SaveRatings: save,
+	})
+	if err != nil {
+		log.Printf("Error scoring supplier %d: %v", id, err)
+		respondError(w, http.StatusInternalServerError, "Failed to build supplier scorecard")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, SupplierPerformanceResponse{
+		GetSupplierPerformanceRow: performance,
+		Since:                     result.Since,
+		Scorecard:                 result.Scorecard,
+		Products:                  result.Products,
+	})
 }
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -82,11 +84,15 @@ func (h *TransferHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	transfer, err := h.queries.UpdateStockTransferStatus(ctx, db.UpdateStockTransferStatusParams{
+	transfer, err := h.queries.UpdateStockTransferStatusTx(ctx, db.UpdateStockTransferStatusParams{
 		TransferID: id,
 		Status:     db.TransferStatus(req.Status),
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Transfer not found")
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to update transfer")
 		return
 	}
@@ -95,10 +101,11 @@ func (h *TransferHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 }
 
 type CreateTransferItemRequest struct {
-	ProductID      int64  `json:"product_id"`
-	Quantity       int32  `json:"quantity"`
-	FromLocationID *int64 `json:"from_location_id"`
-	ToLocationID   *int64 `json:"to_location_id"`
+	ProductID      int64   `json:"product_id"`
+	Quantity       int32   `json:"quantity"`
+	FromLocationID *int64  `json:"from_location_id"`
+	ToLocationID   *int64  `json:"to_location_id"`
+	Uom            *string `json:"uom"`
 }
 
 func (h *TransferHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
@@ -118,10 +125,16 @@ func (h *TransferHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	quantity, err := toBaseQuantity(ctx, h.queries, int32(req.ProductID), req.Uom, req.Quantity)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
 	item, err := h.queries.CreateStockTransferItem(ctx, db.CreateStockTransferItemParams{
 		TransferID:     transferID,
 		ProductID:      int32(req.ProductID),
-		Quantity:       req.Quantity,
+		Quantity:       quantity,
 		FromLocationID: toNullInt32FromInt64(req.FromLocationID),
 		ToLocationID:   toNullInt32FromInt64(req.ToLocationID),
 	})
@@ -134,8 +147,9 @@ func (h *TransferHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 }
 
 type UpdateTransferQuantitiesRequest struct {
-	QuantitySent     int32 `json:"quantity_sent"`
-	QuantityReceived int32 `json:"quantity_received"`
+	QuantitySent     int32   `json:"quantity_sent"`
+	QuantityReceived int32   `json:"quantity_received"`
+	Uom              *string `json:"uom"`
 }
 
 func (h *TransferHandler) UpdateItemQuantities(w http.ResponseWriter, r *http.Request) {
@@ -155,10 +169,34 @@ func (h *TransferHandler) UpdateItemQuantities(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	transferItem, err := h.queries.GetStockTransferItem(ctx, itemID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	factor, err := uomFactor(ctx, h.queries, transferItem.ProductID, req.Uom)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
+	quantitySent, err := scaleQuantity(req.QuantitySent, factor.ConversionFactor)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
+	quantityReceived, err := scaleQuantity(req.QuantityReceived, factor.ConversionFactor)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
 	item, err := h.queries.UpdateStockTransferItemQuantities(ctx, db.UpdateStockTransferItemQuantitiesParams{
 		TransferItemID:   itemID,
-		QuantitySent:     req.QuantitySent,
-		QuantityReceived: req.QuantityReceived,
+		QuantitySent:     quantitySent,
+		QuantityReceived: quantityReceived,
 	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to update quantities")
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+)
+
+var (
+	errUnknownUom       = errors.New("unit of measure is not set up for this product")
+	errQuantityOverflow = errors.New("quantity is too large once converted to base units")
+)
+
+type UomHandler struct {
+	queries db.SingleDb
+}
+
+func NewUomHandler(queries db.SingleDb) *UomHandler {
+	return &UomHandler{queries: queries}
+}
+
+// uomFactor looks up how many base units of the product one of the named unit
+// holds. An empty unit is the base unit itself.
+func uomFactor(ctx context.Context, queries db.SingleDb, productID int32, uom *string) (db.GetProductUomFactorRow, error) {
+	if uom == nil || *uom == "" {
+		return db.GetProductUomFactorRow{ConversionFactor: 1}, nil
+	}
+
+	factor, err := queries.GetProductUomFactor(ctx, db.GetProductUomFactorParams{
+		ProductID: productID,
+		Code:      *uom,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return factor, fmt.Errorf("%w: %s", errUnknownUom, *uom)
+	}
+	return factor, err
+}
+
+// toBaseQuantity converts a quantity counted in the named unit into base units
+// of the product.
+func toBaseQuantity(ctx context.Context, queries db.SingleDb, productID int32, uom *string, quantity int32) (int32, error) {
+	factor, err := uomFactor(ctx, queries, productID, uom)
+	if err != nil {
+		return 0, err
+	}
+	return scaleQuantity(quantity, factor.ConversionFactor)
+}
+
+func scaleQuantity(quantity, factor int32) (int32, error) {
+	base := int64(quantity) * int64(factor)
+	if base > math.MaxInt32 || base < math.MinInt32 {
+		return 0, errQuantityOverflow
+	}
+	return int32(base), nil
+}
+
+// respondUomError reports a failed unit conversion, treating unknown units
+// and overflows as client errors.
+func respondUomError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errUnknownUom) || errors.Is(err, errQuantityOverflow) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	log.Printf("Error converting unit of measure: %v", err)
+	respondError(w, http.StatusInternalServerError, "Failed to convert unit of measure")
+}
+
+type CreateUnitOfMeasureRequest struct {
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+func (h *UomHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreateUnitOfMeasureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Code == "" || req.Name == "" {
+		respondError(w, http.StatusBadRequest, "code and name are required")
+		return
+	}
+
+	uom, err := h.queries.CreateUnitOfMeasure(ctx, db.CreateUnitOfMeasureParams{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: toNullString(req.Description),
+	})
+	if err != nil {
+		log.Printf("Error creating unit of measure %s: %v", req.Code, err)
+		respondError(w, http.StatusInternalServerError, "Failed to create unit of measure")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, uom)
+}
+
+func (h *UomHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uoms, err := h.queries.ListUnitsOfMeasure(ctx)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch units of measure")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, uoms)
+}
+
+func (h *UomHandler) ListProductUoms(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	uoms, err := h.queries.ListProductUoms(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch product units of measure")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, uoms)
+}
+
+type CreateProductUomRequest struct {
+	UomID            int64   `json:"uom_id"`
+	ConversionFactor int32   `json:"conversion_factor"`
+	Barcode          *string `json:"barcode"`
+}
+
+// CreateProductUom adds an alternate unit to a product, e.g. a box holding
+// 12 of the product's base unit.
+func (h *UomHandler) CreateProductUom(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req CreateProductUomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.ConversionFactor <= 0 {
+		respondError(w, http.StatusBadRequest, "conversion_factor must be positive")
+		return
+	}
+
+	product, err := h.queries.GetProduct(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+	if !product.BaseUomID.Valid {
+		respondError(w, http.StatusConflict, "Product has no base unit of measure")
+		return
+	}
+	if product.BaseUomID.Int32 == int32(req.UomID) {
+		respondError(w, http.StatusConflict, "Unit is already the product's base unit")
+		return
+	}
+
+	if _, err := h.queries.GetUnitOfMeasure(ctx, int32(req.UomID)); err != nil {
+		respondError(w, http.StatusBadRequest, "Unit of measure not found")
+		return
+	}
+
+	uom, err := h.queries.CreateProductUom(ctx, db.CreateProductUomParams{
+		ProductID:        product.ProductID,
+		UomID:            int32(req.UomID),
+		ConversionFactor: req.ConversionFactor,
+		Barcode:          toNullString(req.Barcode),
+	})
+	if err != nil {
+		log.Printf("Error adding unit %d to product %d: %v", req.UomID, product.ProductID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to add unit of measure")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, uom)
+}
+
+func (h *UomHandler) DeactivateProductUom(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["productUomId"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product unit ID")
+		return
+	}
+
+	if err := h.queries.DeactivateProductUom(ctx, int32(id)); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to deactivate unit of measure")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type ConvertQuantityResponse struct {
+	ProductID        int32  `json:"product_id"`
+	Uom              string `json:"uom"`
+	Quantity         int32  `json:"quantity"`
+	ConversionFactor int32  `json:"conversion_factor"`
+	BaseQuantity     int32  `json:"base_quantity"`
+}
+
+// Convert shows how a quantity in one of the product's units translates to
+// base units. Query parameters: uom and quantity.
+func (h *UomHandler) Convert(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	quantity, err := strconv.ParseInt(query.Get("quantity"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid quantity")
+		return
+	}
+
+	uom := query.Get("uom")
+	factor, err := uomFactor(ctx, h.queries, int32(id), &uom)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
+	base, err := scaleQuantity(int32(quantity), factor.ConversionFactor)
+	if err != nil {
+		respondUomError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ConvertQuantityResponse{
+		ProductID:        int32(id),
+		Uom:              uom,
+		Quantity:         int32(quantity),
+		ConversionFactor: factor.ConversionFactor,
+		BaseQuantity:     base,
+	})
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/variants"
+)
+
+type VariantHandler struct {
+	queries db.SingleDb
+}
+
+func NewVariantHandler(queries db.SingleDb) *VariantHandler {
+	return &VariantHandler{queries: queries}
+}
+
+type GenerateVariantsRequest struct {
+	Axes []variants.Axis `json:"axes"`
+}
+
+type ProductVariantsResponse struct {
+	ProductID int32                       `json:"product_id"`
+	VariantOf *db.ProductVariant          `json:"variant_of"`
+	Axes      []db.ProductVariantAxis     `json:"axes"`
+	Variants  []db.ListProductVariantsRow `json:"variants"`
+}
+
+// List returns a parent product's option axes and variants. For a variant
+// it also names the parent and the options the variant takes.
+func (h *VariantHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	product, err := h.queries.GetProduct(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Product not found")
+			return
+		}
+		log.Printf("Error getting product: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch variants")
+		return
+	}
+
+	resp := ProductVariantsResponse{ProductID: product.ProductID}
+	variant, err := h.queries.GetProductVariant(ctx, product.ProductID)
+	switch {
+	case err == nil:
+		resp.VariantOf = &variant
+	case !errors.Is(err, sql.ErrNoRows):
+		log.Printf("Error getting product variant: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch variants")
+		return
+	}
+
+	if resp.Axes, err = h.queries.ListProductVariantAxes(ctx, product.ProductID); err != nil {
+		log.Printf("Error listing variant axes: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch variants")
+		return
+	}
+	if resp.Variants, err = h.queries.ListProductVariants(ctx, product.ProductID); err != nil {
+		log.Printf("Error listing variants: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch variants")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// Generate sets a product's option axes and creates the variants missing
+// from the size/colour style matrix they span. Running it again with more
+// values only adds the new combinations.
+func (h *VariantHandler) Generate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req GenerateVariantsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := variants.Check(req.Axes); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.queries.GenerateVariantsTx(ctx, db.GenerateVariantsTxParams{
+		ParentProductID: int32(id),
+		Axes:            req.Axes,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "Product not found")
+		case errors.Is(err, db.ErrVariantOfVariant):
+			respondError(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, db.ErrVariantSKUTaken), errors.Is(err, db.ErrVariantSKULength):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			log.Printf("Error generating variants: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to generate variants")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, result)
+}
@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/shopspring/decimal"
 )
 
 func NullInt32(i int64) sql.NullInt32 {
@@ -81,6 +85,7 @@ func (h *WarehouseHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", warehouse.ETag())
 	respondJSON(w, http.StatusOK, warehouse)
 }
 
@@ -125,19 +130,35 @@ func (h *WarehouseHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	warehouse, err := h.queries.UpdateWarehouse(ctx, db.UpdateWarehouseParams{
-		WarehouseID:   int32(id),
-		Name:          req.Name,
-		Address:       toNullString(req.Address),
-		ContactPerson: toNullString(req.ContactPerson),
-		ContactPhone:  toNullString(req.ContactPhone),
-		ContactEmail:  toNullString(req.ContactEmail),
+	warehouse, err := h.queries.UpdateWarehouseTx(ctx, db.UpdateWarehouseTxParams{
+		UpdateWarehouseParams: db.UpdateWarehouseParams{
+			WarehouseID:   int32(id),
+			Name:          req.Name,
+			Address:       toNullString(req.Address),
+			ContactPerson: toNullString(req.ContactPerson),
+			ContactPhone:  toNullString(req.ContactPhone),
+			ContactEmail:  toNullString(req.ContactEmail),
+		},
+		IfMatch: r.Header.Get("If-Match"),
 	})
 	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusNotFound, "Warehouse not found")
+			return
+		}
+		if errors.Is(err, db.ErrPreconditionFailed) {
+			respondError(w, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+		if errors.Is(err, db.ErrPreconditionRequired) {
+			respondError(w, http.StatusPreconditionRequired, err.Error())
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to update warehouse")
 		return
 	}
 
+	w.Header().Set("ETag", warehouse.ETag())
 	respondJSON(w, http.StatusOK, warehouse)
 }
 
@@ -151,7 +172,23 @@ func (h *WarehouseHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.queries.DeactivateWarehouse(ctx, int32(id)); err != nil {
+	err = h.queries.DeactivateWarehouseTx(ctx, db.DeactivateWarehouseTxParams{
+		WarehouseID: int32(id),
+		IfMatch:     r.Header.Get("If-Match"),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusNotFound, "Warehouse not found")
+			return
+		}
+		if errors.Is(err, db.ErrPreconditionFailed) {
+			respondError(w, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+		if errors.Is(err, db.ErrPreconditionRequired) {
+			respondError(w, http.StatusPreconditionRequired, err.Error())
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to deactivate warehouse")
 		return
 	}
@@ -160,11 +197,26 @@ func (h *WarehouseHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
 }
 
 type CreateLocationRequest struct {
-	LocationCode string  `json:"location_code"`
-	Aisle        *string `json:"aisle"`
-	Shelf        *string `json:"shelf"`
-	Bin          *string `json:"bin"`
-	MaxCapacity  *int32  `json:"max_capacity"`
+	LocationCode string           `json:"location_code"`
+	Aisle        *string          `json:"aisle"`
+	Shelf        *string          `json:"shelf"`
+	Bin          *string          `json:"bin"`
+	MaxCapacity  *int32           `json:"max_capacity"`
+	ZoneID       *int32           `json:"zone_id"`
+	XCoord       *decimal.Decimal `json:"x_coord"`
+	YCoord       *decimal.Decimal `json:"y_coord"`
+	PickSequence *int32           `json:"pick_sequence"`
+	MaxWeight    *decimal.Decimal `json:"max_weight"`
+	MaxVolume    *decimal.Decimal `json:"max_volume"`
+}
+
+// checkZone reports whether the optional zone belongs to the warehouse.
+func (h *WarehouseHandler) checkZone(ctx context.Context, warehouseID int32, zoneID *int32) bool {
+	if zoneID == nil {
+		return true
+	}
+	zone, err := h.queries.GetWarehouseZone(ctx, *zoneID)
+	return err == nil && zone.WarehouseID == warehouseID
 }
 
 func (h *WarehouseHandler) CreateLocation(w http.ResponseWriter, r *http.Request) {
@@ -183,6 +235,11 @@ func (h *WarehouseHandler) CreateLocation(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if !h.checkZone(ctx, int32(warehouseID), req.ZoneID) {
+		respondError(w, http.StatusBadRequest, "Zone not found in this warehouse")
+		return
+	}
+
 	location, err := h.queries.CreateLocation(ctx, db.CreateLocationParams{
 		WarehouseID:  int32(warehouseID),
 		LocationCode: req.LocationCode,
@@ -190,6 +247,12 @@ func (h *WarehouseHandler) CreateLocation(w http.ResponseWriter, r *http.Request
 		Shelf:        toNullString(req.Shelf),
 		Bin:          toNullString(req.Bin),
 		MaxCapacity:  toNullInt32FromInt32(req.MaxCapacity),
+		ZoneID:       toNullInt32FromInt32(req.ZoneID),
+		XCoord:       toNullDecimal(req.XCoord),
+		YCoord:       toNullDecimal(req.YCoord),
+		PickSequence: toNullInt32FromInt32(req.PickSequence),
+		MaxWeight:    toNullDecimal(req.MaxWeight),
+		MaxVolume:    toNullDecimal(req.MaxVolume),
 	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to create location")
@@ -253,12 +316,29 @@ func (h *WarehouseHandler) UpdateLocation(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	current, err := h.queries.GetLocation(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Location not found")
+		return
+	}
+
+	if !h.checkZone(ctx, current.WarehouseID, req.ZoneID) {
+		respondError(w, http.StatusBadRequest, "Zone not found in this warehouse")
+		return
+	}
+
 	location, err := h.queries.UpdateLocation(ctx, db.UpdateLocationParams{
-		LocationID:  int32(id),
-		Aisle:       toNullString(req.Aisle),
-		Shelf:       toNullString(req.Shelf),
-		Bin:         toNullString(req.Bin),
-		MaxCapacity: toNullInt32FromInt32(req.MaxCapacity),
+		LocationID:   int32(id),
+		Aisle:        toNullString(req.Aisle),
+		Shelf:        toNullString(req.Shelf),
+		Bin:          toNullString(req.Bin),
+		MaxCapacity:  toNullInt32FromInt32(req.MaxCapacity),
+		ZoneID:       toNullInt32FromInt32(req.ZoneID),
+		XCoord:       toNullDecimal(req.XCoord),
+		YCoord:       toNullDecimal(req.YCoord),
+		PickSequence: toNullInt32FromInt32(req.PickSequence),
+		MaxWeight:    toNullDecimal(req.MaxWeight),
+		MaxVolume:    toNullDecimal(req.MaxVolume),
 	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to update location")
@@ -286,6 +366,145 @@ func (h *WarehouseHandler) DeactivateLocation(w http.ResponseWriter, r *http.Req
 	w.WriteHeader(http.StatusNoContent)
 }
 
+type CreateZoneRequest struct {
+	ZoneCode    string  `json:"zone_code"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+func (h *WarehouseHandler) CreateZone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	warehouseID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	var req CreateZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.ZoneCode == "" || req.Name == "" {
+		respondError(w, http.StatusBadRequest, "zone_code and name are required")
+		return
+	}
+
+	zone, err := h.queries.CreateWarehouseZone(ctx, db.CreateWarehouseZoneParams{
+		WarehouseID: int32(warehouseID),
+		ZoneCode:    req.ZoneCode,
+		Name:        req.Name,
+		Description: toNullString(req.Description),
+	})
+	if err != nil {
+		log.Printf("Error creating zone: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to create zone")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, zone)
+}
+
+func (h *WarehouseHandler) ListZones(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	warehouseID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	zones, err := h.queries.ListWarehouseZones(ctx, int32(warehouseID))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch zones")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, zones)
+}
+
+func (h *WarehouseHandler) GetZone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid zone ID")
+		return
+	}
+
+	zone, err := h.queries.GetWarehouseZone(ctx, int32(id))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Zone not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, zone)
+}
+
+type UpdateZoneRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	IsActive    bool    `json:"is_active"`
+}
+
+func (h *WarehouseHandler) UpdateZone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid zone ID")
+		return
+	}
+
+	var req UpdateZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	zone, err := h.queries.UpdateWarehouseZone(ctx, db.UpdateWarehouseZoneParams{
+		ZoneID:      int32(id),
+		Name:        req.Name,
+		Description: toNullString(req.Description),
+		IsActive:    req.IsActive,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusNotFound, "Zone not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to update zone")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, zone)
+}
+
+func (h *WarehouseHandler) ListZoneLocations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid zone ID")
+		return
+	}
+
+	locations, err := h.queries.ListLocationsByZone(ctx, NullInt32(id))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch locations")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, locations)
+}
+
 func (h *WarehouseHandler) GetInventorySummary(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -0,0 +1,422 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/middleware"
+	"github.com/molu/stock-management-system/internal/webhook"
+)
+
+// WebhookHandler manages webhook subscriptions and their delivery log.
+type WebhookHandler struct {
+	queries      db.SingleDb
+	client       *http.Client
+	allowPrivate bool
+}
+
+// NewWebhookHandler returns a handler whose subscriptions may point at
+// loopback and private hosts only when allowPrivate is set.
+func NewWebhookHandler(queries db.SingleDb, allowPrivate bool) *WebhookHandler {
+	return &WebhookHandler{queries: queries, client: webhook.NewClient(allowPrivate), allowPrivate: allowPrivate}
+}
+
+const maxWebhookURLLength = 2000
+
+type WebhookSubscriptionRequest struct {
+	URL         string   `json:"url"`
+	Secret      *string  `json:"secret"`
+	Description *string  `json:"description"`
+	EventTypes  []string `json:"event_types"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+// WebhookSubscriptionResponse shows the secret only when it was just
+// generated or changed.
+type WebhookSubscriptionResponse struct {
+	db.WebhookSubscription
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types"`
+}
+
+// WebhookPingResponse is the subscriber's status code, 0 when it could not be
+// reached, and how long the attempt took.
+type WebhookPingResponse struct {
+	StatusCode int   `json:"status_code"`
+	DurationMs int64 `json:"duration_ms"`
+}
+
+type WebhookDeliveryResponse struct {
+	db.WebhookDelivery
+	Event db.WebhookEvent             `json:"event"`
+	Log   []db.WebhookDeliveryAttempt `json:"log"`
+}
+
+// validWebhookURL reports whether the URL is an absolute http or https URL.
+// Plain http is allowed so a local stub can subscribe, but localhost and
+// literal non-public addresses only when allowPrivate is set; names that
+// resolve to them are refused by the client when it connects.
+func (h *WebhookHandler) validWebhookURL(raw string) bool {
+	if raw == "" || len(raw) > maxWebhookURLLength {
+		return false
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return false
+	}
+	if h.allowPrivate {
+		return true
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return webhook.PublicIP(ip)
+	}
+	return true
+}
+
+// newWebhookSecret returns 32 random bytes, hex encoded.
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func respondWebhookError(w http.ResponseWriter, err error, action string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		respondError(w, http.StatusNotFound, "Webhook subscription not found")
+	case errors.Is(err, db.ErrWebhookEventInvalid), errors.Is(err, db.ErrWebhookEventRequired):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		log.Printf("Error trying to %s webhook subscription: %v", action, err)
+		respondError(w, http.StatusInternalServerError, "Failed to "+action+" webhook subscription")
+	}
+}
+
+func webhookID(w http.ResponseWriter, r *http.Request, message string) (int32, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, message)
+		return 0, false
+	}
+	return int32(id), true
+}
+
+func (h *WebhookHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	subscriptions, err := h.queries.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		log.Printf("Error listing webhook subscriptions: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch webhook subscriptions")
+		return
+	}
+
+	resp := make([]WebhookSubscriptionResponse, len(subscriptions))
+	for i, s := range subscriptions {
+		events, err := h.queries.ListWebhookSubscriptionEvents(ctx, s.SubscriptionID)
+		if err != nil {
+			log.Printf("Error listing webhook subscription events: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to fetch webhook subscriptions")
+			return
+		}
+		resp[i] = WebhookSubscriptionResponse{WebhookSubscription: s, EventTypes: events}
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// CreateSubscription subscribes a URL to event types on behalf of the user
+// in the bearer token. Without a secret one is generated; either way it is
+// returned only in this response.
+func (h *WebhookHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	var req WebhookSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !h.validWebhookURL(req.URL) {
+		respondError(w, http.StatusBadRequest, "url must be an absolute http or https URL of a public host")
+		return
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		log.Printf("Error generating webhook secret: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to create webhook subscription")
+		return
+	}
+	if req.Secret != nil {
+		secret = *req.Secret
+	}
+	if secret == "" {
+		respondError(w, http.StatusBadRequest, "secret must not be empty")
+		return
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	result, err := h.queries.CreateWebhookSubscriptionTx(r.Context(), db.CreateWebhookSubscriptionTxParams{
+		CreateWebhookSubscriptionParams: db.CreateWebhookSubscriptionParams{
+			Url:         req.URL,
+			Secret:      secret,
+			Description: toNullString(req.Description),
+			IsActive:    isActive,
+			CreatedBy:   NullInt32(userID),
+		},
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		respondWebhookError(w, err, "create")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, WebhookSubscriptionResponse{
+		WebhookSubscription: result.Subscription,
+		Secret:              result.Subscription.Secret,
+		EventTypes:          result.EventTypes,
+	})
+}
+
+func (h *WebhookHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, ok := webhookID(w, r, "Invalid subscription ID")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.queries.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		respondWebhookError(w, err, "fetch")
+		return
+	}
+	events, err := h.queries.ListWebhookSubscriptionEvents(ctx, id)
+	if err != nil {
+		respondWebhookError(w, err, "fetch")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, WebhookSubscriptionResponse{WebhookSubscription: subscription, EventTypes: events})
+}
+
+// UpdateSubscription replaces the URL, description, active flag and event
+// types of a subscription, and its secret when one is given.
+func (h *WebhookHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookID(w, r, "Invalid subscription ID")
+	if !ok {
+		return
+	}
+
+	var req WebhookSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !h.validWebhookURL(req.URL) {
+		respondError(w, http.StatusBadRequest, "url must be an absolute http or https URL of a public host")
+		return
+	}
+	if req.Secret != nil && *req.Secret == "" {
+		respondError(w, http.StatusBadRequest, "secret must not be empty")
+		return
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	result, err := h.queries.UpdateWebhookSubscriptionTx(r.Context(), db.UpdateWebhookSubscriptionTxParams{
+		UpdateWebhookSubscriptionParams: db.UpdateWebhookSubscriptionParams{
+			Url:            req.URL,
+			Secret:         toNullString(req.Secret),
+			Description:    toNullString(req.Description),
+			IsActive:       isActive,
+			SubscriptionID: id,
+		},
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		respondWebhookError(w, err, "update")
+		return
+	}
+
+	resp := WebhookSubscriptionResponse{WebhookSubscription: result.Subscription, EventTypes: result.EventTypes}
+	if req.Secret != nil {
+		resp.Secret = result.Subscription.Secret
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// DeactivateSubscription stops new events for a subscription and holds its
+// pending deliveries; the delivery log is kept.
+func (h *WebhookHandler) DeactivateSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, ok := webhookID(w, r, "Invalid subscription ID")
+	if !ok {
+		return
+	}
+
+	if _, err := h.queries.GetWebhookSubscription(ctx, id); err != nil {
+		respondWebhookError(w, err, "deactivate")
+		return
+	}
+	if err := h.queries.DeactivateWebhookSubscription(ctx, id); err != nil {
+		respondWebhookError(w, err, "deactivate")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Ping sends a signed ping event to the subscription straight away and
+// reports the subscriber's status code and the time taken, to test an
+// endpoint or a local stub. Nothing is stored.
+func (h *WebhookHandler) Ping(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, ok := webhookID(w, r, "Invalid subscription ID")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.queries.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		respondWebhookError(w, err, "ping")
+		return
+	}
+
+	data, _ := json.Marshal(map[string]int32{"subscription_id": id})
+	now := time.Now()
+	result := webhook.Send(ctx, h.client, webhook.Delivery{
+		URL:    subscription.Url,
+		Secret: subscription.Secret,
+		Envelope: webhook.Envelope{
+			Type:      webhook.EventPing,
+			CreatedAt: now,
+			Data:      data,
+		},
+	}, now)
+
+	respondJSON(w, http.StatusOK, WebhookPingResponse{
+		StatusCode: result.StatusCode,
+		DurationMs: result.Duration.Milliseconds(),
+	})
+}
+
+// ListDeliveries pages through the delivery log of a subscription, newest
+// first, optionally by status.
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, ok := webhookID(w, r, "Invalid subscription ID")
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+
+	arg := db.ListWebhookDeliveriesParams{SubscriptionID: id, RowLimit: 50}
+	if s := query.Get("status"); s != "" {
+		arg.Status = db.NullWebhookDeliveryStatus{WebhookDeliveryStatus: db.WebhookDeliveryStatus(s), Valid: true}
+		if !arg.Status.WebhookDeliveryStatus.Valid() {
+			respondError(w, http.StatusBadRequest, "Invalid status")
+			return
+		}
+	}
+	if l := query.Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			arg.RowLimit = int32(val)
+		}
+	}
+	if o := query.Get("offset"); o != "" {
+		if val, err := strconv.ParseInt(o, 10, 32); err == nil {
+			arg.RowOffset = int32(val)
+		}
+	}
+
+	deliveries, err := h.queries.ListWebhookDeliveries(ctx, arg)
+	if err != nil {
+		log.Printf("Error listing webhook deliveries: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch webhook deliveries")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, deliveries)
+}
+
+// GetDelivery returns a delivery with its event and every attempt made.
+func (h *WebhookHandler) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, ok := webhookID(w, r, "Invalid delivery ID")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.queries.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Webhook delivery not found")
+			return
+		}
+		log.Printf("Error getting webhook delivery %d: %v", id, err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch webhook delivery")
+		return
+	}
+	resp := WebhookDeliveryResponse{WebhookDelivery: delivery}
+	if resp.Event, err = h.queries.GetWebhookEvent(ctx, delivery.EventID); err == nil {
+		resp.Log, err = h.queries.ListWebhookDeliveryAttempts(ctx, id)
+	}
+	if err != nil {
+		log.Printf("Error getting webhook delivery %d: %v", id, err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch webhook delivery")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// Redeliver queues a delivery to be sent again with a fresh set of attempts,
+// whether it failed or was already delivered.
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookID(w, r, "Invalid delivery ID")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.queries.RedeliverWebhookDelivery(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Webhook delivery not found")
+			return
+		}
+		log.Printf("Error redelivering webhook delivery %d: %v", id, err)
+		respondError(w, http.StatusInternalServerError, "Failed to redeliver webhook delivery")
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, delivery)
+}
@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/kitting"
+	"github.com/molu/stock-management-system/internal/manufacturing"
+	"github.com/molu/stock-management-system/internal/putaway"
+	"github.com/shopspring/decimal"
+)
+
+type WorkOrderHandler struct {
+	queries db.SingleDb
+}
+
+func NewWorkOrderHandler(queries db.SingleDb) *WorkOrderHandler {
+	return &WorkOrderHandler{queries: queries}
+}
+
+type CreateWorkOrderRequest struct {
+	ProductID        int32      `json:"product_id"`
+	OutputLocationID int32      `json:"output_location_id"`
+	PlannedQuantity  int32      `json:"planned_quantity"`
+	BatchNumber      *string    `json:"batch_number"`
+	ExpiryDate       *time.Time `json:"expiry_date"`
+	Notes            *string    `json:"notes"`
+	CreatedBy        *int64     `json:"created_by"`
+}
+
+type WorkOrderConsumptionRequest struct {
+	ComponentProductID int32   `json:"component_product_id"`
+	Quantity           int32   `json:"quantity"`
+	ScrapQuantity      int32   `json:"scrap_quantity"`
+	BatchNumber        *string `json:"batch_number"`
+}
+
+type ConsumeWorkOrderRequest struct {
+	Lines     []WorkOrderConsumptionRequest `json:"lines"`
+	CreatedBy *int64                        `json:"created_by"`
+}
+
+type CompleteWorkOrderRequest struct {
+	ProducedQuantity int32      `json:"produced_quantity"`
+	ScrapQuantity    int32      `json:"scrap_quantity"`
+	BatchNumber      *string    `json:"batch_number"`
+	ExpiryDate       *time.Time `json:"expiry_date"`
+	Backflush        bool       `json:"backflush"`
+	CreatedBy        *int64     `json:"created_by"`
+}
+
+type WorkOrderResponse struct {
+	db.WorkOrder
+	YieldPercent decimal.Decimal           `json:"yield_percent"`
+	Components   []db.WorkOrderComponent   `json:"components"`
+	Reservations []db.WorkOrderReservation `json:"reservations"`
+	Consumptions []db.WorkOrderConsumption `json:"consumptions"`
+}
+
+// respondWorkOrderError maps the errors of the work order transactions to
+// status codes.
+func respondWorkOrderError(w http.ResponseWriter, err error, action string) {
+	var transition *manufacturing.TransitionError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		respondError(w, http.StatusNotFound, "Work order, product or location not found")
+	case errors.Is(err, db.ErrInvalidWorkOrderQuantity), errors.Is(err, manufacturing.ErrNothingProduced):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.As(err, &transition):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, kitting.ErrNoComponents), errors.Is(err, db.ErrNotWorkOrderComponent),
+		errors.Is(err, db.ErrNothingConsumed), errors.Is(err, db.ErrLocationInactive):
+		respondError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, db.ErrInsufficientStock), errors.Is(err, putaway.ErrCapacityExceeded):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		log.Printf("Error trying to %s work order: %v", action, err)
+		respondError(w, http.StatusInternalServerError, "Failed to "+action+" work order")
+	}
+}
+
+func workOrderID(r *http.Request) (int32, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 32)
+	return int32(id), err
+}
+
+func (h *WorkOrderHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	var arg db.ListWorkOrdersParams
+	if s := query.Get("status"); s != "" {
+		arg.Status = db.NullWorkOrderStatus{WorkOrderStatus: db.WorkOrderStatus(s), Valid: true}
+		if !arg.Status.WorkOrderStatus.Valid() {
+			respondError(w, http.StatusBadRequest, "Invalid status")
+			return
+		}
+	}
+	var err error
+	if arg.ProductID, err = optionalID(query, "product_id"); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product_id")
+		return
+	}
+	if arg.WarehouseID, err = optionalID(query, "warehouse_id"); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid warehouse_id")
+		return
+	}
+
+	arg.RowLimit = 50
+	if l := query.Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			arg.RowLimit = int32(val)
+		}
+	}
+	if o := query.Get("offset"); o != "" {
+		if val, err := strconv.ParseInt(o, 10, 32); err == nil {
+			arg.RowOffset = int32(val)
+		}
+	}
+
+	workOrders, err := h.queries.ListWorkOrders(ctx, arg)
+	if err != nil {
+		log.Printf("Error listing work orders: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch work orders")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, workOrders)
+}
+
+// Create opens a draft work order from the product's bill of materials.
+func (h *WorkOrderHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreateWorkOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.ProductID == 0 || req.OutputLocationID == 0 {
+		respondError(w, http.StatusBadRequest, "product_id and output_location_id are required")
+		return
+	}
+
+	result, err := h.queries.CreateWorkOrderTx(ctx, db.CreateWorkOrderTxParams{
+		ProductID:        req.ProductID,
+		OutputLocationID: req.OutputLocationID,
+		PlannedQuantity:  req.PlannedQuantity,
+		BatchNumber:      toNullString(req.BatchNumber),
+		ExpiryDate:       toNullTime(req.ExpiryDate),
+		Notes:            toNullString(req.Notes),
+		CreatedBy:        toNullInt32FromInt64(req.CreatedBy),
+	})
+	if err != nil {
+		respondWorkOrderError(w, err, "create")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, result)
+}
+
+// Get returns a work order with its components, reservations and the lots
+// consumed on it.
+func (h *WorkOrderHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := workOrderID(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid work order ID")
+		return
+	}
+
+	wo, err := h.queries.GetWorkOrder(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Work order not found")
+			return
+		}
+		log.Printf("Error getting work order: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch work order")
+		return
+	}
+
+	resp := WorkOrderResponse{
+		WorkOrder:    wo,
+		YieldPercent: manufacturing.Yield(wo.ProducedQuantity, wo.ScrapQuantity),
+	}
+	if resp.Components, err = h.queries.ListWorkOrderComponents(ctx, id); err != nil {
+		log.Printf("Error listing work order components: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch work order")
+		return
+	}
+	if resp.Reservations, err = h.queries.ListWorkOrderReservations(ctx, id); err != nil {
+		log.Printf("Error listing work order reservations: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch work order")
+		return
+	}
+	if resp.Consumptions, err = h.queries.ListWorkOrderConsumptions(ctx, id); err != nil {
+		log.Printf("Error listing work order consumptions: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch work order")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// Release reserves a draft work order's components.
+func (h *WorkOrderHandler) Release(w http.ResponseWriter, r *http.Request) {
+	id, err := workOrderID(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid work order ID")
+		return
+	}
+
+	result, err := h.queries.ReleaseWorkOrderTx(r.Context(), id)
+	if err != nil {
+		respondWorkOrderError(w, err, "release")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// Consume records the components actually used, including scrap.
+func (h *WorkOrderHandler) Consume(w http.ResponseWriter, r *http.Request) {
+	id, err := workOrderID(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid work order ID")
+		return
+	}
+
+	var req ConsumeWorkOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.Lines) == 0 {
+		respondError(w, http.StatusBadRequest, "At least one line is required")
+		return
+	}
+
+	lines := make([]db.WorkOrderConsumptionLine, len(req.Lines))
+	for i, l := range req.Lines {
+		lines[i] = db.WorkOrderConsumptionLine{
+			ComponentProductID: l.ComponentProductID,
+			Quantity:           l.Quantity,
+			ScrapQuantity:      l.ScrapQuantity,
+			BatchNumber:        toNullString(l.BatchNumber),
+		}
+	}
+
+	result, err := h.queries.ConsumeWorkOrderTx(r.Context(), db.ConsumeWorkOrderTxParams{
+		WorkOrderID: id,
+		Lines:       lines,
+		CreatedBy:   toNullInt32FromInt64(req.CreatedBy),
+	})
+	if err != nil {
+		respondWorkOrderError(w, err, "consume")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// Complete records the yield and receives the good output as one lot.
+func (h *WorkOrderHandler) Complete(w http.ResponseWriter, r *http.Request) {
+	id, err := workOrderID(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid work order ID")
+		return
+	}
+
+	var req CompleteWorkOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	result, err := h.queries.CompleteWorkOrderTx(r.Context(), db.CompleteWorkOrderTxParams{
+		WorkOrderID:      id,
+		ProducedQuantity: req.ProducedQuantity,
+		ScrapQuantity:    req.ScrapQuantity,
+		BatchNumber:      toNullString(req.BatchNumber),
+		ExpiryDate:       toNullTime(req.ExpiryDate),
+		Backflush:        req.Backflush,
+		CreatedBy:        toNullInt32FromInt64(req.CreatedBy),
+	})
+	if err != nil {
+		respondWorkOrderError(w, err, "complete")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+func (h *WorkOrderHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, err := workOrderID(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid work order ID")
+		return
+	}
+
+	wo, err := h.queries.CancelWorkOrderTx(r.Context(), id)
+	if err != nil {
+		respondWorkOrderError(w, err, "cancel")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, wo)
+}
+
+// Trace follows a lot through production. Backward (the default) lists the
+// component lots a product's output lot was made from; forward lists the
+// output lots a component lot went into. Query parameters: product_id,
+// batch_number and direction.
+func (h *WorkOrderHandler) Trace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	productID, err := strconv.ParseInt(query.Get("product_id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product_id")
+		return
+	}
+	batch := query.Get("batch_number")
+	if batch == "" {
+		respondError(w, http.StatusBadRequest, "batch_number is required")
+		return
+	}
+
+	switch query.Get("direction") {
+	case "", "backward":
+		rows, err := h.queries.TraceWorkOrderLot(ctx, db.TraceWorkOrderLotParams{
+			ProductID:         int32(productID),
+			OutputBatchNumber: sql.NullString{String: batch, Valid: true},
+		})
+		if err != nil {
+			log.Printf("Error tracing work order lot: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to trace lot")
+			return
+		}
+		respondJSON(w, http.StatusOK, rows)
+	case "forward":
+		rows, err := h.queries.TraceComponentLot(ctx, db.TraceComponentLotParams{
+			ComponentProductID: int32(productID),
+			BatchNumber:        sql.NullString{String: batch, Valid: true},
+		})
+		if err != nil {
+			log.Printf("Error tracing component lot: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to trace lot")
+			return
+		}
+		respondJSON(w, http.StatusOK, rows)
+	default:
+		respondError(w, http.StatusBadRequest, "direction must be backward or forward")
+	}
+}
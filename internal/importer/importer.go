@@ -0,0 +1,432 @@
+// Package importer reads bulk import files (CSV or XLSX) and validates their
+// rows against the columns each kind of import expects. Checks that need the
+// database, such as whether a referenced category exists, are left to the
+// caller. It has no database dependencies.
+package importer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/molu/stock-management-system/internal/barcode"
+	"github.com/shopspring/decimal"
+)
+
+// Kind is what an import file holds.
+type Kind string
+
+const (
+	KindCategories   Kind = "categories"
+	KindSuppliers    Kind = "suppliers"
+	KindProducts     Kind = "products"
+	KindLocations    Kind = "locations"
+	KindOpeningStock Kind = "opening_stock"
+)
+
+// Kinds lists the kinds in the order a new system is best loaded: products
+// refer to categories and suppliers, opening stock to products and
+// locations.
+func Kinds() []Kind {
+	return []Kind{KindCategories, KindSuppliers, KindProducts, KindLocations, KindOpeningStock}
+}
+
+// Valid reports whether k is a known kind.
+func (k Kind) Valid() bool {
+	_, ok := schemas[k]
+	return ok
+}
+
+// Type is the format a column's values must have.
+type Type int
+
+const (
+	Text    Type = iota
+	Integer      // whole number, not negative
+	Decimal      // decimal number with a point, not negative
+	Bool         // true/false, yes/no, y/n or 1/0
+	Date         // YYYY-MM-DD or an Excel serial date
+	Barcode      // EAN-8, UPC-A, EAN-13 or GTIN-14 with a valid check digit
+)
+
+// Column describes one column of an import file.
+type Column struct {
+	Name     string
+	Type     Type
+	Required bool
+	MaxLen   int  // Text: longest value allowed; 0 for no limit
+	Places   int  // Decimal: most digits allowed after the point
+	Positive bool // Integer and Decimal: zero is not allowed either
+}
+
+var schemas = map[Kind][]Column{
+	KindCategories: {
+		{Name: "category_code", Required: true, MaxLen: 50},
+		{Name: "name", Required: true, MaxLen: 100},
+		{Name: "parent_code", MaxLen: 50},
+		{Name: "description"},
+	},
+	KindSuppliers: {
+		{Name: "code", Required: true, MaxLen: 50},
+		{Name: "name", Required: true, MaxLen: 255},
+		{Name: "contact_person", MaxLen: 100},
+		{Name: "email", MaxLen: 100},
+		{Name: "phone", MaxLen: 20},
+		{Name: "address"},
+		{Name: "tax_id", MaxLen: 50},
+		{Name: "payment_terms", MaxLen: 100},
+		{Name: "lead_time_days", Type: Integer},
+		{Name: "currency_code", MaxLen: 3},
+		{Name: "is_active", Type: Bool},
+	},
+	KindProducts: {
+		{Name: "sku", Required: true, MaxLen: 50},
+		{Name: "name", Required: true, MaxLen: 255},
+		{Name: "description"},
+		{Name: "category_code", MaxLen: 50},
+		{Name: "unit_price", Type: Decimal, Required: true, Places: 2},
+		{Name: "cost_price", Type: Decimal, Places: 2},
+		{Name: "barcode", Type: Barcode},
+		{Name: "weight", Type: Decimal, Places: 3},
+		{Name: "dimensions", MaxLen: 100},
+		{Name: "supplier_code", MaxLen: 50},
+		{Name: "min_stock_level", Type: Integer},
+		{Name: "max_stock_level", Type: Integer},
+		{Name: "reorder_point", Type: Integer},
+		{Name: "safety_stock", Type: Integer},
+		{Name: "lead_time_days", Type: Integer},
+		{Name: "auto_reorder", Type: Bool},
+		{Name: "is_active", Type: Bool},
+	},
+	KindLocations: {
+		{Name: "warehouse_code", Required: true, MaxLen: 20},
+		{Name: "location_code", Required: true, MaxLen: 50},
+		{Name: "zone_code", MaxLen: 20},
+		{Name: "aisle", MaxLen: 20},
+		{Name: "shelf", MaxLen: 20},
+		{Name: "bin", MaxLen: 20},
+		{Name: "max_capacity", Type: Integer},
+		{Name: "x_coord", Type: Decimal, Places: 2},
+		{Name: "y_coord", Type: Decimal, Places: 2},
+		{Name: "pick_sequence", Type: Integer},
+		{Name: "max_weight", Type: Decimal, Places: 3},
+		{Name: "max_volume", Type: Decimal, Places: 3},
+	},
+	KindOpeningStock: {
+		{Name: "sku", Required: true, MaxLen: 50},
+		{Name: "warehouse_code", Required: true, MaxLen: 20},
+		{Name: "location_code", Required: true, MaxLen: 50},
+		{Name: "quantity", Type: Integer, Required: true, Positive: true},
+		{Name: "unit_cost", Type: Decimal, Places: 4},
+		{Name: "batch_number", MaxLen: 100},
+		{Name: "expiry_date", Type: Date},
+	},
+}
+
+// keys are the columns that must be unique together within a file.
+var keys = map[Kind][]string{
+	KindCategories:   {"category_code"},
+	KindSuppliers:    {"code"},
+	KindProducts:     {"sku"},
+	KindLocations:    {"warehouse_code", "location_code"},
+	KindOpeningStock: {"sku", "warehouse_code", "location_code", "batch_number"},
+}
+
+// Columns returns the columns a kind of import accepts.
+func Columns(kind Kind) []Column {
+	return schemas[kind]
+}
+
+// RowError is a problem with one row, or with the header when Line is 1.
+type RowError struct {
+	Line    int    `json:"line"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"error"`
+}
+
+func (e RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("line %d, %s: %s", e.Line, e.Column, e.Message)
+}
+
+// Record is a row that passed validation, so its typed accessors cannot fail.
+type Record struct {
+	Row
+}
+
+// Text returns the value of a text column.
+func (r Record) Text(column string) string {
+	return r.Get(column)
+}
+
+// Int returns the value of an integer column, or nil when it is blank.
+func (r Record) Int(column string) *int32 {
+	v := r.Get(column)
+	if v == "" {
+		return nil
+	}
+	n, _ := parseInt(v)
+	return &n
+}
+
+// Decimal returns the value of a decimal column, or nil when it is blank.
+func (r Record) Decimal(column string) *decimal.Decimal {
+	v := r.Get(column)
+	if v == "" {
+		return nil
+	}
+	d, _ := decimal.NewFromString(v)
+	return &d
+}
+
+// Bool returns the value of a boolean column, or def when it is blank.
+func (r Record) Bool(column string, def bool) bool {
+	v := r.Get(column)
+	if v == "" {
+		return def
+	}
+	b, _ := parseBool(v)
+	return b
+}
+
+// Date returns the value of a date column, or nil when it is blank.
+func (r Record) Date(column string) *time.Time {
+	v := r.Get(column)
+	if v == "" {
+		return nil
+	}
+	t, _ := parseDate(v)
+	return &t
+}
+
+// Validate checks the header and every row of t for a kind of import. Rows
+// with any error are left out of the records; all errors are reported, in
+// line order. A header without a required column fails every row, so no
+// records are returned. Categories are ordered so a parent defined in the
+// file comes before its children.
+func Validate(kind Kind, t *Table) ([]Record, []RowError) {
+	columns := schemas[kind]
+	var errs []RowError
+
+	known := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		known[c.Name] = true
+	}
+	present := make(map[string]bool, len(t.Columns))
+	for _, name := range t.Columns {
+		if name == "" {
+			continue
+		}
+		if present[name] {
+			errs = append(errs, RowError{Line: 1, Column: name, Message: "column appears more than once"})
+		}
+		present[name] = true
+		if !known[name] {
+			errs = append(errs, RowError{Line: 1, Column: name, Message: "unknown column"})
+		}
+	}
+	for _, c := range columns {
+		if c.Required && !present[c.Name] {
+			errs = append(errs, RowError{Line: 1, Column: c.Name, Message: "required column is missing"})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	var records []Record
+	seen := make(map[string]int)
+	for _, row := range t.Rows {
+		rowErrs := validateRow(columns, row)
+		if len(rowErrs) == 0 {
+			key := rowKey(kind, row)
+			if first, dup := seen[key]; dup {
+				rowErrs = append(rowErrs, RowError{
+					Line:    row.Line,
+					Column:  keys[kind][0],
+					Message: fmt.Sprintf("duplicate of line %d", first),
+				})
+			} else {
+				seen[key] = row.Line
+			}
+		}
+		if len(rowErrs) > 0 {
+			errs = append(errs, rowErrs...)
+			continue
+		}
+		records = append(records, Record{row})
+	}
+
+	if kind == KindCategories {
+		var cycles []RowError
+		records, cycles = parentsFirst(records)
+		errs = append(errs, cycles...)
+		sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+	}
+	return records, errs
+}
+
+func validateRow(columns []Column, row Row) []RowError {
+	var errs []RowError
+	fail := func(c Column, format string, args ...interface{}) {
+		errs = append(errs, RowError{Line: row.Line, Column: c.Name, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, c := range columns {
+		v := row.Get(c.Name)
+		if v == "" {
+			if c.Required {
+				fail(c, "is required")
+			}
+			continue
+		}
+
+		switch c.Type {
+		case Text:
+			if c.MaxLen > 0 && len([]rune(v)) > c.MaxLen {
+				fail(c, "is longer than %d characters", c.MaxLen)
+			}
+		case Integer:
+			n, err := parseInt(v)
+			switch {
+			case err != nil:
+				fail(c, "must be a whole number")
+			case n < 0 || (c.Positive && n == 0):
+				fail(c, "must be %s", sign(c))
+			}
+		case Decimal:
+			d, err := decimal.NewFromString(v)
+			switch {
+			case err != nil || strings.ContainsAny(v, "eE"):
+				fail(c, "must be a decimal number such as 12.50")
+			case d.IsNegative() || (c.Positive && d.IsZero()):
+				fail(c, "must be %s", sign(c))
+			case -d.Exponent() > int32(c.Places) && !d.Equal(d.Truncate(int32(c.Places))):
+				fail(c, "has more than %d decimal places", c.Places)
+			}
+		case Bool:
+			if _, err := parseBool(v); err != nil {
+				fail(c, "must be true or false")
+			}
+		case Date:
+			if _, err := parseDate(v); err != nil {
+				fail(c, "must be a date in YYYY-MM-DD form")
+			}
+		case Barcode:
+			if err := barcode.CheckGTIN(v); err != nil {
+				fail(c, "%v", err)
+			}
+		}
+	}
+	return errs
+}
+
+func sign(c Column) string {
+	if c.Positive {
+		return "greater than zero"
+	}
+	return "zero or more"
+}
+
+func rowKey(kind Kind, row Row) string {
+	parts := make([]string, len(keys[kind]))
+	for i, k := range keys[kind] {
+		parts[i] = strings.ToUpper(row.Get(k))
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// parentsFirst orders category records so that each parent defined in the
+// file comes before its children, keeping file order otherwise. Categories
+// that are their own ancestor are rejected.
+func parentsFirst(records []Record) ([]Record, []RowError) {
+	byCode := make(map[string]int, len(records))
+	for i, r := range records {
+		byCode[strings.ToUpper(r.Text("category_code"))] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(records))
+	cyclic := make([]bool, len(records))
+	out := make([]Record, 0, len(records))
+
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		switch state[i] {
+		case visiting:
+			return false
+		case done:
+			return !cyclic[i]
+		}
+		state[i] = visiting
+		ok := true
+		if parent, found := byCode[strings.ToUpper(records[i].Text("parent_code"))]; found {
+			ok = visit(parent)
+		}
+		state[i] = done
+		if ok {
+			out = append(out, records[i])
+		} else {
+			cyclic[i] = true
+		}
+		return ok
+	}
+
+	var errs []RowError
+	for i := range records {
+		visit(i)
+	}
+	for i, r := range records {
+		if cyclic[i] {
+			errs = append(errs, RowError{Line: r.Line, Column: "parent_code", Message: "category would be its own ancestor"})
+		}
+	}
+	return out, errs
+}
+
+func parseInt(s string) (int32, error) {
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		// Spreadsheets often store whole numbers as "12.0".
+		f, ferr := strconv.ParseFloat(s, 64)
+		if ferr != nil || f != math.Trunc(f) || math.Abs(f) > math.MaxInt32 {
+			return 0, err
+		}
+		n = int64(f)
+	}
+	return int32(n), nil
+}
+
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "true", "yes", "y", "1":
+		return true, nil
+	case "false", "no", "n", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("not a boolean: %q", s)
+}
+
+// excelEpoch is day 0 of Excel's serial dates, allowing for its phantom
+// 29 February 1900.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	serial, err := strconv.ParseFloat(s, 64)
+	if err != nil || serial < 1 || serial > 2958465 {
+		return time.Time{}, fmt.Errorf("not a date: %q", s)
+	}
+	return excelEpoch.AddDate(0, 0, int(serial)), nil
+}
@@ -0,0 +1,207 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func readCSV(t *testing.T, data string) *Table {
+	t.Helper()
+	table, err := ReadCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func TestReadCSV(t *testing.T) {
+	table := readCSV(t, "\ufeffSKU, Warehouse Code ,\nA-1,MAIN,ignored\n,,\nB-2,MAIN\n")
+	if len(table.Columns) != 3 || table.Columns[0] != "sku" || table.Columns[1] != "warehouse_code" || table.Columns[2] != "" {
+		t.Fatalf("columns = %q, want [sku warehouse_code \"\"]", table.Columns)
+	}
+	if len(table.Rows) != 2 {
+		t.Fatalf("rows = %v, want 2 rows", table.Rows)
+	}
+	if table.Rows[0].Line != 2 || table.Rows[1].Line != 4 {
+		t.Errorf("lines = %d, %d, want 2, 4", table.Rows[0].Line, table.Rows[1].Line)
+	}
+	if got := table.Rows[1].Get("sku"); got != "B-2" {
+		t.Errorf("sku = %q, want B-2", got)
+	}
+}
+
+func TestReadCSVEmpty(t *testing.T) {
+	if _, err := ReadCSV(strings.NewReader("")); err != ErrNoHeader {
+		t.Errorf("err = %v, want ErrNoHeader", err)
+	}
+}
+
+const openingStockHeader = "sku,warehouse_code,location_code,quantity,unit_cost,batch_number,expiry_date\n"
+
+func TestValidateOpeningStockRow(t *testing.T) {
+	tests := []struct {
+		name    string
+		row     string
+		column  string
+		message string
+	}{
+		{"valid", "A-1,MAIN,A-01,10,2.5,LOT-1,2026-12-31", "", ""},
+		{"optional columns blank", "A-1,MAIN,A-01,10,,,", "", ""},
+		{"whole number stored as decimal", "A-1,MAIN,A-01,10.0,,,", "", ""},
+		{"excel serial date", "A-1,MAIN,A-01,10,,,45292", "", ""},
+		{"trailing zeros past the places", "A-1,MAIN,A-01,10,2.50000,,", "", ""},
+		{"batch number at the limit", "A-1,MAIN,A-01,10,," + strings.Repeat("B", 100) + ",", "", ""},
+		{"required value missing", "A-1,,A-01,10,,,", "warehouse_code", "is required"},
+		{"quantity zero", "A-1,MAIN,A-01,0,,,", "quantity", "must be greater than zero"},
+		{"quantity negative", "A-1,MAIN,A-01,-3,,,", "quantity", "must be greater than zero"},
+		{"quantity fractional", "A-1,MAIN,A-01,1.5,,,", "quantity", "must be a whole number"},
+		{"cost not a number", "A-1,MAIN,A-01,10,abc,,", "unit_cost", "must be a decimal number such as 12.50"},
+		{"cost in exponent form", "A-1,MAIN,A-01,10,1e2,,", "unit_cost", "must be a decimal number such as 12.50"},
+		{"cost negative", "A-1,MAIN,A-01,10,-1,,", "unit_cost", "must be zero or more"},
+		{"cost with too many places", "A-1,MAIN,A-01,10,1.23456,,", "unit_cost", "has more than 4 decimal places"},
+		{"batch number too long", "A-1,MAIN,A-01,10,," + strings.Repeat("B", 101) + ",", "batch_number", "is longer than 100 characters"},
+		{"date in another form", "A-1,MAIN,A-01,10,,,31/12/2026", "expiry_date", "must be a date in YYYY-MM-DD form"},
+	}
+	for _, tt := range tests {
+		records, errs := Validate(KindOpeningStock, readCSV(t, openingStockHeader+tt.row+"\n"))
+		if tt.message == "" {
+			if len(errs) > 0 || len(records) != 1 {
+				t.Errorf("%s: records = %d, errors = %v, want 1 record", tt.name, len(records), errs)
+			}
+			continue
+		}
+		if len(records) != 0 || len(errs) != 1 {
+			t.Errorf("%s: records = %d, errors = %v, want one error", tt.name, len(records), errs)
+			continue
+		}
+		if errs[0].Line != 2 || errs[0].Column != tt.column || errs[0].Message != tt.message {
+			t.Errorf("%s: error = %v, want line 2, %s: %s", tt.name, errs[0], tt.column, tt.message)
+		}
+	}
+}
+
+func TestValidateProductRow(t *testing.T) {
+	tests := []struct {
+		name    string
+		row     string
+		column  string
+		message string
+	}{
+		{"valid", "P-1,Widget,12.50,4006381333931,yes", "", ""},
+		{"bool as digit", "P-1,Widget,12.50,,0", "", ""},
+		{"bad barcode", "P-1,Widget,12.50,4006381333932,", "barcode", "barcode check digit is wrong"},
+		{"bad bool", "P-1,Widget,12.50,,maybe", "is_active", "must be true or false"},
+		{"price with three places", "P-1,Widget,12.505,,", "unit_price", "has more than 2 decimal places"},
+	}
+	for _, tt := range tests {
+		records, errs := Validate(KindProducts, readCSV(t, "sku,name,unit_price,barcode,is_active\n"+tt.row+"\n"))
+		if tt.message == "" {
+			if len(errs) > 0 || len(records) != 1 {
+				t.Errorf("%s: records = %d, errors = %v, want 1 record", tt.name, len(records), errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || errs[0].Column != tt.column || errs[0].Message != tt.message {
+			t.Errorf("%s: errors = %v, want %s: %s", tt.name, errs, tt.column, tt.message)
+		}
+	}
+}
+
+func TestValidateHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		column string
+		want   string
+	}{
+		{"required column missing", "sku,warehouse_code,quantity", "location_code", "required column is missing"},
+		{"unknown column", "sku,warehouse_code,location_code,quantity,colour", "colour", "unknown column"},
+		{"column twice", "sku,warehouse_code,location_code,quantity,sku", "sku", "column appears more than once"},
+	}
+	for _, tt := range tests {
+		records, errs := Validate(KindOpeningStock, readCSV(t, tt.header+"\nA-1,MAIN,A-01,10,x\n"))
+		if len(records) != 0 || len(errs) != 1 {
+			t.Errorf("%s: records = %d, errors = %v, want one header error", tt.name, len(records), errs)
+			continue
+		}
+		if errs[0].Line != 1 || errs[0].Column != tt.column || errs[0].Message != tt.want {
+			t.Errorf("%s: error = %v, want line 1, %s: %s", tt.name, errs[0], tt.column, tt.want)
+		}
+	}
+}
+
+func TestValidateDuplicates(t *testing.T) {
+	table := readCSV(t, openingStockHeader+
+		"A-1,MAIN,A-01,10,,LOT-1,\n"+
+		"a-1,main,a-01,5,,lot-1,\n"+
+		"A-1,MAIN,A-01,5,,LOT-2,\n")
+	records, errs := Validate(KindOpeningStock, table)
+	if len(records) != 2 {
+		t.Errorf("records = %d, want 2", len(records))
+	}
+	if len(errs) != 1 || errs[0].Line != 3 || errs[0].Column != "sku" || errs[0].Message != "duplicate of line 2" {
+		t.Errorf("errors = %v, want line 3, sku: duplicate of line 2", errs)
+	}
+}
+
+func TestValidateCategoriesParentsFirst(t *testing.T) {
+	table := readCSV(t, "category_code,name,parent_code\n"+
+		"PHONES,Phones,ELEC\n"+
+		"ELEC,Electronics,\n"+
+		"TOOLS,Tools,EXTERNAL\n")
+	records, errs := Validate(KindCategories, table)
+	if len(errs) > 0 {
+		t.Fatalf("errors = %v, want none", errs)
+	}
+	var got []string
+	for _, r := range records {
+		got = append(got, r.Text("category_code"))
+	}
+	if strings.Join(got, ",") != "ELEC,PHONES,TOOLS" {
+		t.Errorf("order = %v, want [ELEC PHONES TOOLS]", got)
+	}
+}
+
+func TestValidateCategoryCycle(t *testing.T) {
+	table := readCSV(t, "category_code,name,parent_code\n"+
+		"A,A,B\n"+
+		"B,B,a\n"+
+		"C,C,\n"+
+		"D,D,D\n")
+	records, errs := Validate(KindCategories, table)
+	if len(records) != 1 || records[0].Text("category_code") != "C" {
+		t.Errorf("records = %v, want only C", records)
+	}
+	if len(errs) != 3 {
+		t.Fatalf("errors = %v, want 3", errs)
+	}
+	for i, line := range []int{2, 3, 5} {
+		if errs[i].Line != line || errs[i].Column != "parent_code" || errs[i].Message != "category would be its own ancestor" {
+			t.Errorf("error %d = %v, want line %d, parent_code: category would be its own ancestor", i, errs[i], line)
+		}
+	}
+}
+
+func TestRecordAccessors(t *testing.T) {
+	records, errs := Validate(KindOpeningStock, readCSV(t, openingStockHeader+"A-1,MAIN,A-01,12.0,2.5,,45292\n"))
+	if len(errs) > 0 || len(records) != 1 {
+		t.Fatalf("records = %d, errors = %v, want 1 record", len(records), errs)
+	}
+	r := records[0]
+	if q := r.Int("quantity"); q == nil || *q != 12 {
+		t.Errorf("quantity = %v, want 12", q)
+	}
+	if c := r.Decimal("unit_cost"); c == nil || c.String() != "2.5" {
+		t.Errorf("unit_cost = %v, want 2.5", c)
+	}
+	if e := r.Date("expiry_date"); e == nil || !e.Equal(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expiry_date = %v, want 2024-01-01", e)
+	}
+	if b := r.Date("batch_number"); b != nil {
+		t.Errorf("blank date = %v, want nil", b)
+	}
+	if !r.Bool("is_active", true) {
+		t.Error("blank bool = false, want the default")
+	}
+}
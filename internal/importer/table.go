@@ -0,0 +1,293 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownFormat = errors.New("format must be csv or xlsx")
+	ErrNoHeader      = errors.New("file has no header row")
+	ErrNoSheet       = errors.New("workbook has no worksheet")
+)
+
+// Format is the file type of an import.
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+// Table is an import file: a header row naming the columns and the data
+// rows below it. Column names are lower case with spaces as underscores.
+type Table struct {
+	Columns []string
+	Rows    []Row
+}
+
+// Row is one data row. Line is its line in the file (the header is line 1),
+// so errors can point at it.
+type Row struct {
+	Line   int
+	Values map[string]string
+}
+
+// Get returns the trimmed value of a column, or "" when the row has none.
+func (r Row) Get(column string) string {
+	return strings.TrimSpace(r.Values[column])
+}
+
+// Read parses an import file.
+func Read(r io.Reader, format Format) (*Table, error) {
+	switch format {
+	case CSV:
+		return ReadCSV(r)
+	case XLSX:
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return ReadXLSX(data)
+	}
+	return nil, ErrUnknownFormat
+}
+
+// ReadCSV parses a comma-separated file with a header row. A UTF-8 byte order
+// mark, as spreadsheet programs write, is skipped.
+func ReadCSV(r io.Reader) (*Table, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 && len(records[0]) > 0 {
+		records[0][0] = strings.TrimPrefix(records[0][0], "\ufeff")
+	}
+	return table(records)
+}
+
+// table turns raw records, header first, into a Table. Blank rows are
+// skipped but still counted for line numbers.
+func table(records [][]string) (*Table, error) {
+	if len(records) == 0 {
+		return nil, ErrNoHeader
+	}
+	t := &Table{Columns: make([]string, len(records[0]))}
+	for i, c := range records[0] {
+		t.Columns[i] = columnName(c)
+	}
+
+	for i, rec := range records[1:] {
+		blank := true
+		values := make(map[string]string, len(t.Columns))
+		for j, v := range rec {
+			if j >= len(t.Columns) || t.Columns[j] == "" {
+				continue
+			}
+			values[t.Columns[j]] = v
+			if strings.TrimSpace(v) != "" {
+				blank = false
+			}
+		}
+		if !blank {
+			t.Rows = append(t.Rows, Row{Line: i + 2, Values: values})
+		}
+	}
+	return t, nil
+}
+
+func columnName(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Join(strings.Fields(s), "_")
+}
+
+// XLSX parts, as far as reading cell values goes.
+type xlsxWorkbook struct {
+	Sheets []struct {
+		ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string   `xml:"r,attr"`
+			T      string   `xml:"t,attr"`
+			V      string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSX parses the first worksheet of an Excel workbook. Only cell values
+// are read: formulas give their cached result and numbers are returned as
+// Excel stores them, so dates arrive as serial day numbers.
+func ReadXLSX(data []byte) (*Table, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not an xlsx file: %w", err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var shared xlsxSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXML(f, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetPath, err := firstSheet(files)
+	if err != nil {
+		return nil, err
+	}
+	var sheet xlsxSheet
+	if err := decodeXML(files[sheetPath], &sheet); err != nil {
+		return nil, err
+	}
+
+	var records [][]string
+	for _, row := range sheet.Rows {
+		line := row.R
+		if line == 0 {
+			line = len(records) + 1
+		}
+		for len(records) < line {
+			records = append(records, nil)
+		}
+		var rec []string
+		for i, c := range row.Cells {
+			col := i
+			if c.R != "" {
+				col = columnIndex(c.R)
+			}
+			for len(rec) <= col {
+				rec = append(rec, "")
+			}
+			switch c.T {
+			case "s":
+				n, err := strconv.Atoi(c.V)
+				if err != nil || n < 0 || n >= len(shared.Items) {
+					return nil, fmt.Errorf("cell %s: bad shared string", c.R)
+				}
+				rec[col] = shared.Items[n].String()
+			case "inlineStr":
+				rec[col] = c.Inline.String()
+			case "b":
+				rec[col] = map[string]string{"1": "true", "0": "false"}[c.V]
+			default:
+				rec[col] = c.V
+			}
+		}
+		records[line-1] = rec
+	}
+	return table(records)
+}
+
+func firstSheet(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	wb, ok := files["xl/workbook.xml"]
+	rels, relsOK := files["xl/_rels/workbook.xml.rels"]
+	if !ok || !relsOK {
+		if _, ok := files[fallback]; ok {
+			return fallback, nil
+		}
+		return "", ErrNoSheet
+	}
+
+	var workbook xlsxWorkbook
+	if err := decodeXML(wb, &workbook); err != nil {
+		return "", err
+	}
+	var relationships xlsxRelationships
+	if err := decodeXML(rels, &relationships); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", ErrNoSheet
+	}
+	for _, rel := range relationships.Relationships {
+		if rel.ID != workbook.Sheets[0].ID {
+			continue
+		}
+		target := strings.TrimPrefix(rel.Target, "/")
+		if !strings.HasPrefix(target, "xl/") {
+			target = path.Join("xl", target)
+		}
+		if _, ok := files[target]; ok {
+			return target, nil
+		}
+	}
+	if _, ok := files[fallback]; ok {
+		return fallback, nil
+	}
+	return "", ErrNoSheet
+}
+
+func decodeXML(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("%s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// columnIndex converts the letters of a cell reference such as "AB12" to a
+// zero-based column number.
+func columnIndex(ref string) int {
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		n = n*26 + int(r-'A'+1)
+	}
+	return n - 1
+}
@@ -0,0 +1,122 @@
+// Package kitting holds the bill of materials arithmetic of kits: how many
+// can be built from the components on hand, what building some needs, and
+// how a kit's cost is shared out when it is taken apart.
+package kitting
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+// UnitCostPlaces matches the precision unit costs are stored at.
+const UnitCostPlaces = 4
+
+var ErrNoComponents = errors.New("kit has no components")
+
+// Component is one line of a kit's bill of materials.
+type Component struct {
+	ProductID int32
+	// Quantity of the component in one kit.
+	Quantity int32
+	// Available is the component's unreserved stock.
+	Available int32
+}
+
+// Buildable returns how many whole kits the available components make; the
+// scarcest component decides. A kit without components cannot be built.
+func Buildable(components []Component) int32 {
+	if len(components) == 0 {
+		return 0
+	}
+	var kits int32 = -1
+	for _, c := range components {
+		if c.Quantity <= 0 {
+			continue
+		}
+		n := c.Available / c.Quantity
+		if n < 0 {
+			n = 0
+		}
+		if kits < 0 || n < kits {
+			kits = n
+		}
+	}
+	if kits < 0 {
+		return 0
+	}
+	return kits
+}
+
+// Requirement is what building a number of kits takes of one component.
+type Requirement struct {
+	ProductID int32 `json:"product_id"`
+	Quantity  int32 `json:"quantity"`
+	Shortage  int32 `json:"shortage"`
+}
+
+// Requirements lists the quantity of each component that kits kits need
+// and how much of it is missing.
+func Requirements(components []Component, kits int32) []Requirement {
+	reqs := make([]Requirement, len(components))
+	for i, c := range components {
+		need := c.Quantity * kits
+		reqs[i] = Requirement{ProductID: c.ProductID, Quantity: need}
+		if need > c.Available {
+			reqs[i].Shortage = need - c.Available
+		}
+	}
+	return reqs
+}
+
+// Share is one component line a kit's cost is split over, weighted by the
+// component's own unit cost.
+type Share struct {
+	Quantity int32
+	UnitCost decimal.Decimal
+}
+
+// Split shares the total cost of taken-apart kits over the component lines
+// in proportion to what each line is worth at its own unit cost, and
+// returns the unit cost of each line. When no line has a cost the total is
+// split by quantity.
+func Split(total decimal.Decimal, shares []Share) []decimal.Decimal {
+	costs := make([]decimal.Decimal, len(shares))
+	if len(shares) == 0 {
+		return costs
+	}
+
+	weights := make([]decimal.Decimal, len(shares))
+	sum := decimal.Zero
+	for i, s := range shares {
+		weights[i] = s.UnitCost.Mul(decimal.NewFromInt32(s.Quantity))
+		sum = sum.Add(weights[i])
+	}
+	if !sum.IsPositive() {
+		sum = decimal.Zero
+		for i, s := range shares {
+			weights[i] = decimal.NewFromInt32(s.Quantity)
+			sum = sum.Add(weights[i])
+		}
+	}
+	if !sum.IsPositive() {
+		return costs
+	}
+
+	for i, s := range shares {
+		if s.Quantity <= 0 {
+			continue
+		}
+		line := total.Mul(weights[i]).Div(sum)
+		costs[i] = line.Div(decimal.NewFromInt32(s.Quantity)).Round(UnitCostPlaces)
+	}
+	return costs
+}
+
+// UnitCost is the cost of one kit built from components costing total.
+func UnitCost(total decimal.Decimal, kits int32) decimal.Decimal {
+	if kits <= 0 {
+		return decimal.Zero
+	}
+	return total.Div(decimal.NewFromInt32(kits)).Round(UnitCostPlaces)
+}
@@ -0,0 +1,97 @@
+// Package manufacturing holds the rules of work orders: which status changes
+// are allowed, how much of a component a run should have used, and the
+// yield and unit cost of what it produced.
+package manufacturing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// UnitCostPlaces matches the precision unit costs are stored at.
+const UnitCostPlaces = 4
+
+var ErrNothingProduced = errors.New("a completed work order must produce or scrap at least one unit")
+
+// Status is the state of a work order.
+type Status string
+
+const (
+	Draft      Status = "draft"
+	Released   Status = "released"
+	InProgress Status = "in_progress"
+	Completed  Status = "completed"
+	Cancelled  Status = "cancelled"
+)
+
+// transitions lists the statuses a work order can move to from each status.
+// Released work orders have their components reserved; once components have
+// been consumed a work order can only be completed.
+var transitions = map[Status][]Status{
+	Draft:      {Released, InProgress, Completed, Cancelled},
+	Released:   {InProgress, Completed, Cancelled},
+	InProgress: {Completed},
+}
+
+// CanMove reports whether a work order may go from one status to another.
+func CanMove(from, to Status) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionError is returned for a status change CanMove refuses.
+type TransitionError struct {
+	From, To Status
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("work order cannot go from %s to %s", e.From, e.To)
+}
+
+// Check returns a *TransitionError unless the change is allowed.
+func Check(from, to Status) error {
+	if !CanMove(from, to) {
+		return &TransitionError{From: from, To: to}
+	}
+	return nil
+}
+
+// Standard is how much of a component a run producing and scrapping the
+// given quantities of output should use.
+func Standard(quantityPer, produced, scrapped int32) int32 {
+	return quantityPer * (produced + scrapped)
+}
+
+// Backflush is the quantity still to consume to bring a component up to its
+// standard usage; zero when at least that much has been consumed already.
+func Backflush(quantityPer, produced, scrapped, consumed int32) int32 {
+	if n := Standard(quantityPer, produced, scrapped) - consumed; n > 0 {
+		return n
+	}
+	return 0
+}
+
+// Yield is the share of the output that was good, as a percentage with two
+// decimals; zero when nothing was made.
+func Yield(produced, scrapped int32) decimal.Decimal {
+	total := produced + scrapped
+	if total <= 0 {
+		return decimal.Zero
+	}
+	return decimal.NewFromInt32(produced * 100).Div(decimal.NewFromInt32(total)).Round(2)
+}
+
+// UnitCost spreads the cost of everything consumed, scrap included, over the
+// good output.
+func UnitCost(consumed decimal.Decimal, produced int32) decimal.Decimal {
+	if produced <= 0 {
+		return decimal.Zero
+	}
+	return consumed.Div(decimal.NewFromInt32(produced)).Round(UnitCostPlaces)
+}
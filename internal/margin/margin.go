@@ -0,0 +1,251 @@
+// Package margin rolls sales deliveries up into revenue, cost of goods sold
+// and gross margin, and ranks products by the profit they make. It has no
+// database dependencies.
+package margin
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Dimension is what a report is grouped by.
+type Dimension string
+
+const (
+	ByProduct   Dimension = "product"
+	ByCategory  Dimension = "category"
+	ByWarehouse Dimension = "warehouse"
+	ByPeriod    Dimension = "period"
+)
+
+// Period is the length of the time buckets a report is split into.
+type Period string
+
+const (
+	Day   Period = "day"
+	Week  Period = "week"
+	Month Period = "month"
+)
+
+var (
+	ErrUnknownDimension = errors.New("group_by must be product, category, warehouse or period")
+	ErrUnknownPeriod    = errors.New("period must be day, week or month")
+)
+
+// Valid reports whether d is a known dimension.
+func (d Dimension) Valid() bool {
+	switch d {
+	case ByProduct, ByCategory, ByWarehouse, ByPeriod:
+		return true
+	}
+	return false
+}
+
+// Valid reports whether p is a known period.
+func (p Period) Valid() bool {
+	switch p {
+	case Day, Week, Month:
+		return true
+	}
+	return false
+}
+
+// Start is the beginning of the period t falls in. Weeks start on Monday.
+func (p Period) Start(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch p {
+	case Week:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case Month:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	return day
+}
+
+// Label names the period starting at start, e.g. 2024-03 for a month or
+// 2024-W09 for an ISO week.
+func (p Period) Label(start time.Time) string {
+	switch p {
+	case Week:
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case Month:
+		return start.Format("2006-01")
+	}
+	return start.Format("2006-01-02")
+}
+
+// Line is one sales delivery with its revenue and cost.
+type Line struct {
+	Date          time.Time
+	ProductID     int32
+	SKU           string
+	ProductName   string
+	CategoryID    int32
+	CategoryName  string
+	WarehouseID   int32
+	WarehouseCode string
+	Quantity      int32
+	Revenue       decimal.Decimal
+	Cost          decimal.Decimal
+}
+
+// Group is the total of the lines sharing a key.
+type Group struct {
+	Key string `json:"key"`
+	// ID is the product, category or warehouse ID; it is 0 for periods and
+	// uncategorized products.
+	ID     int32  `json:"id,omitempty"`
+	Label  string `json:"label"`
+	Period string `json:"period,omitempty"`
+
+	Quantity      int64           `json:"quantity"`
+	Revenue       decimal.Decimal `json:"revenue"`
+	COGS          decimal.Decimal `json:"cogs"`
+	GrossMargin   decimal.Decimal `json:"gross_margin"`
+	MarginPercent decimal.Decimal `json:"margin_percent"`
+
+	// MarginRank and MarginClass are set by Classify.
+	MarginRank  int    `json:"margin_rank,omitempty"`
+	MarginClass string `json:"margin_class,omitempty"`
+
+	start time.Time
+}
+
+func (g *Group) add(l Line) {
+	g.Quantity += int64(l.Quantity)
+	g.Revenue = g.Revenue.Add(l.Revenue)
+	g.COGS = g.COGS.Add(l.Cost)
+}
+
+// finish rounds the totals to cents and works out the margin.
+func (g *Group) finish() {
+	g.Revenue = g.Revenue.Round(2)
+	g.COGS = g.COGS.Round(2)
+	g.GrossMargin = g.Revenue.Sub(g.COGS)
+	if !g.Revenue.IsZero() {
+		g.MarginPercent = g.GrossMargin.Div(g.Revenue).Mul(decimal.NewFromInt(100)).Round(2)
+	}
+}
+
+// key places a line in its group under by. Products without a category are
+// grouped together under an empty key.
+func key(l Line, by Dimension) (string, int32, string) {
+	switch by {
+	case ByProduct:
+		return l.SKU, l.ProductID, l.ProductName
+	case ByCategory:
+		if l.CategoryID == 0 {
+			return "", 0, "Uncategorized"
+		}
+		return strconv.Itoa(int(l.CategoryID)), l.CategoryID, l.CategoryName
+	case ByWarehouse:
+		return l.WarehouseCode, l.WarehouseID, l.WarehouseCode
+	}
+	return "", 0, ""
+}
+
+// Summarize totals the lines by dimension. With a period, every group is
+// further split into periods; grouping by period needs one. Groups come out
+// by period, then by gross margin, highest first. The second result is the
+// grand total.
+func Summarize(lines []Line, by Dimension, period Period) ([]Group, Group, error) {
+	if !by.Valid() {
+		return nil, Group{}, ErrUnknownDimension
+	}
+	if (period != "" && !period.Valid()) || (by == ByPeriod && period == "") {
+		return nil, Group{}, ErrUnknownPeriod
+	}
+
+	type groupKey struct {
+		key   string
+		start time.Time
+	}
+	index := make(map[groupKey]int)
+	groups := []Group{}
+	total := Group{Key: "total", Label: "Total"}
+
+	for _, l := range lines {
+		k, id, label := key(l, by)
+		var start time.Time
+		if period != "" {
+			start = period.Start(l.Date)
+		}
+		if by == ByPeriod {
+			k, label = start.Format("2006-01-02"), period.Label(start)
+		}
+
+		gk := groupKey{key: k, start: start}
+		i, ok := index[gk]
+		if !ok {
+			i = len(groups)
+			index[gk] = i
+			g := Group{Key: k, ID: id, Label: label, start: start}
+			if period != "" && by != ByPeriod {
+				g.Period = period.Label(start)
+			}
+			groups = append(groups, g)
+		}
+		groups[i].add(l)
+		total.add(l)
+	}
+
+	for i := range groups {
+		groups[i].finish()
+	}
+	total.finish()
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if !groups[i].start.Equal(groups[j].start) {
+			return groups[i].start.Before(groups[j].start)
+		}
+		if !groups[i].GrossMargin.Equal(groups[j].GrossMargin) {
+			return groups[i].GrossMargin.GreaterThan(groups[j].GrossMargin)
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups, total, nil
+}
+
+// Classify ranks groups by gross margin, highest first, and gives each a
+// profit class the way an ABC analysis does: A for the groups making the
+// first 80% of the positive margin, B for the next 15% and C for the rest,
+// including everything sold at a loss. Groups must not be split by period.
+func Classify(groups []Group) {
+	order := make([]int, len(groups))
+	var positive decimal.Decimal
+	for i := range groups {
+		order[i] = i
+		if groups[i].GrossMargin.IsPositive() {
+			positive = positive.Add(groups[i].GrossMargin)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return groups[order[a]].GrossMargin.GreaterThan(groups[order[b]].GrossMargin)
+	})
+
+	var cumulative decimal.Decimal
+	for rank, i := range order {
+		g := &groups[i]
+		g.MarginRank = rank + 1
+		g.MarginClass = "C"
+		if !g.GrossMargin.IsPositive() || positive.IsZero() {
+			continue
+		}
+		// The share before this group decides its class, so the group that
+		// crosses the 80% line is still an A.
+		share := cumulative.Div(positive).InexactFloat64()
+		cumulative = cumulative.Add(g.GrossMargin)
+		switch {
+		case share < 0.80:
+			g.MarginClass = "A"
+		case share < 0.95:
+			g.MarginClass = "B"
+		}
+	}
+}
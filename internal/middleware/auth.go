@@ -29,7 +29,7 @@ func Auth(jwtSecret string) func(http.Handler) http.Handler {
 
 			token, err := jwt.Parse(parts[1], func(token *jwt.Token) (interface{}, error) {
 				return []byte(jwtSecret), nil
-			})
+			}, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
 
 			if err != nil || !token.Valid {
 				http.Error(w, "Invalid token", http.StatusUnauthorized)
@@ -42,10 +42,20 @@ func Auth(jwtSecret string) func(http.Handler) http.Handler {
 				return
 			}
 
-			userID := int64(claims["user_id"].(float64))
-			ctx := context.WithValue(r.Context(), UserIDKey, userID)
+			id, ok := claims["user_id"].(float64)
+			if !ok {
+				http.Error(w, "Invalid token claims", http.StatusUnauthorized)
+				return
+			}
+			ctx := context.WithValue(r.Context(), UserIDKey, int64(id))
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
-}
\ No newline at end of file
+}
+
+// UserID returns the ID of the user authenticated by Auth.
+func UserID(ctx context.Context) (int64, bool) {
+	id, ok := ctx.Value(UserIDKey).(int64)
+	return id, ok
+}
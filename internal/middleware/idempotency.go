@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header clients put a unique key
+	// in to make a POST or PUT safe to retry.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from a stored
+	// key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyStore is the part of the store the middleware needs.
+type IdempotencyStore interface {
+	ClaimIdempotencyKey(ctx context.Context, arg db.ClaimIdempotencyKeyParams) (db.IdempotencyKey, error)
+	GetIdempotencyKey(ctx context.Context, arg db.GetIdempotencyKeyParams) (db.IdempotencyKey, error)
+	SaveIdempotencyResponse(ctx context.Context, arg db.SaveIdempotencyResponseParams) error
+	DeleteIdempotencyKey(ctx context.Context, arg db.DeleteIdempotencyKeyParams) error
+}
+
+// recorder passes a response through while keeping a copy of it.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *recorder) WriteHeader(code int) {
+	rec.status = code
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+func (rec *recorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// callerHash fingerprints the credentials of a request, so that a key only
+// replays to the caller that sent it.
+func callerHash(r *http.Request) string {
+	sum := sha256.Sum256([]byte(r.Header.Get("Authorization")))
+	return hex.EncodeToString(sum[:])
+}
+
+// setHeaders returns the response headers the handler added or changed,
+// leaving out those the server fills in for every response.
+func setHeaders(before, after http.Header) http.Header {
+	set := http.Header{}
+	for name, values := range after {
+		switch name {
+		case "Content-Length", "Date", IdempotentReplayedHeader:
+			continue
+		}
+		if !slices.Equal(before[name], values) {
+			set[name] = values
+		}
+	}
+	return set
+}
+
+// requestHash fingerprints a request by its method, path with query and
+// body.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+"\n"+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Idempotency honours the Idempotency-Key header on POST and PUT requests.
+// Keys belong to the caller, told apart by the Authorization header. The
+// first request with a key is processed and its status, headers and body
+// stored for ttl; a repeat with the same method, path and body gets the
+// stored response back without being processed again. A key reused for a
+// different request, or repeated while the first is still running, is
+// rejected with 409. Server errors are not stored, since their transaction
+// was rolled back, and neither is a 401, since nothing was done, so the
+// request can be retried with the same key.
+func Idempotency(store IdempotencyStore, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || (r.Method != http.MethodPost && r.Method != http.MethodPut) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				http.Error(w, "Idempotency-Key must be at most 255 characters", http.StatusBadRequest)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, "Failed to read request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			hash := requestHash(r, body)
+			caller := callerHash(r)
+
+			// The outcome is saved even if the client has gone away, as the
+			// retry it will send is what the key is for.
+			ctx := context.WithoutCancel(r.Context())
+
+			_, err = store.ClaimIdempotencyKey(ctx, db.ClaimIdempotencyKeyParams{
+				IdempotencyKey: key,
+				CallerHash:     caller,
+				RequestMethod:  r.Method,
+				RequestPath:    r.URL.RequestURI(),
+				RequestHash:    hash,
+				TtlSeconds:     int32(ttl.Seconds()),
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				replayIdempotent(ctx, w, store, caller, key, hash)
+				return
+			}
+			if err != nil {
+				log.Printf("Error claiming idempotency key: %v", err)
+				http.Error(w, "Failed to check Idempotency-Key", http.StatusInternalServerError)
+				return
+			}
+
+			rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+			before := w.Header().Clone()
+			saved := false
+			defer func() {
+				// A panicking handler frees the key for a retry.
+				if !saved {
+					if err := store.DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{
+						CallerHash:     caller,
+						IdempotencyKey: key,
+					}); err != nil {
+						log.Printf("Error releasing idempotency key: %v", err)
+					}
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= http.StatusInternalServerError || rec.status == http.StatusUnauthorized {
+				return
+			}
+			headers, err := json.Marshal(setHeaders(before, rec.Header()))
+			if err != nil {
+				log.Printf("Error saving idempotent response: %v", err)
+				return
+			}
+			err = store.SaveIdempotencyResponse(ctx, db.SaveIdempotencyResponseParams{
+				CallerHash:      caller,
+				IdempotencyKey:  key,
+				StatusCode:      sql.NullInt32{Int32: int32(rec.status), Valid: true},
+				ResponseHeaders: headers,
+				ResponseBody:    rec.body.Bytes(),
+			})
+			if err != nil {
+				log.Printf("Error saving idempotent response: %v", err)
+				return
+			}
+			saved = true
+		})
+	}
+}
+
+// replayIdempotent answers a request whose key is already taken.
+func replayIdempotent(ctx context.Context, w http.ResponseWriter, store IdempotencyStore, caller, key, hash string) {
+	stored, err := store.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{CallerHash: caller, IdempotencyKey: key})
+	if errors.Is(err, sql.ErrNoRows) {
+		// The first request failed and freed the key in the meantime.
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "A request with this Idempotency-Key is still being processed", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		log.Printf("Error getting idempotency key: %v", err)
+		http.Error(w, "Failed to check Idempotency-Key", http.StatusInternalServerError)
+		return
+	}
+
+	switch {
+	case stored.RequestHash != hash:
+		http.Error(w, "Idempotency-Key was already used for a different request", http.StatusConflict)
+	case !stored.StatusCode.Valid:
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "A request with this Idempotency-Key is still being processed", http.StatusConflict)
+	default:
+		var headers http.Header
+		if err := json.Unmarshal(stored.ResponseHeaders, &headers); err != nil {
+			log.Printf("Error reading idempotent response headers: %v", err)
+			http.Error(w, "Failed to check Idempotency-Key", http.StatusInternalServerError)
+			return
+		}
+		for name, values := range headers {
+			w.Header()[name] = values
+		}
+		w.Header().Set(IdempotentReplayedHeader, "true")
+		w.WriteHeader(int(stored.StatusCode.Int32))
+		w.Write(stored.ResponseBody)
+	}
+}
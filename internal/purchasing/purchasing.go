@@ -0,0 +1,134 @@
+// Package purchasing holds the purchase order workflow: which status changes
+// are allowed and which approval levels an order needs before it can be
+// sent to the supplier. It also keeps each product's supplier links to a
+// single primary supplier. It has no database dependencies.
+package purchasing
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+type Status string
+
+const (
+	StatusDraft             Status = "draft"
+	StatusPending           Status = "pending"
+	StatusApproved          Status = "approved"
+	StatusPartiallyReceived Status = "partially_received"
+	StatusCompleted         Status = "completed"
+	StatusCancelled         Status = "cancelled"
+)
+
+var (
+	ErrInvalidTransition = errors.New("status change not allowed")
+	ErrApprovalRequired  = errors.New("purchase orders are approved by signing off every approval level")
+	ErrReceiptRequired   = errors.New("receiving status is set by receiving items")
+)
+
+// transitions are the status changes that can be requested directly. An
+// order reaches approved through sign-off and partially_received or
+// completed through receipts; completing by hand closes an order that will
+// not be delivered in full.
+var transitions = map[Status][]Status{
+	StatusDraft:             {StatusPending, StatusCancelled},
+	StatusPending:           {StatusDraft, StatusCancelled},
+	StatusApproved:          {StatusCancelled},
+	StatusPartiallyReceived: {StatusCompleted},
+}
+
+// CheckTransition reports whether an order in status from may be moved to
+// status to on request.
+func CheckTransition(from, to Status) error {
+	for _, next := range transitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	switch {
+	case to == StatusApproved && from == StatusPending:
+		return ErrApprovalRequired
+	case to == StatusPartiallyReceived, to == StatusCompleted && from == StatusApproved:
+		return ErrReceiptRequired
+	}
+	return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+}
+
+// Editable reports whether items may still be added to an order.
+func Editable(s Status) bool {
+	return s == StatusDraft
+}
+
+// Receivable reports whether goods may be received against an order.
+func Receivable(s Status) bool {
+	return s == StatusApproved || s == StatusPartiallyReceived
+}
+
+// Role is a user role; roles are ranked admin, manager, staff, viewer.
+type Role string
+
+const (
+	RoleAdmin   Role = "admin"
+	RoleManager Role = "manager"
+	RoleStaff   Role = "staff"
+	RoleViewer  Role = "viewer"
+)
+
+func (r Role) rank() int {
+	switch r {
+	case RoleAdmin:
+		return 4
+	case RoleManager:
+		return 3
+	case RoleStaff:
+		return 2
+	case RoleViewer:
+		return 1
+	}
+	return 0
+}
+
+// CanSign reports whether a user with role may sign off a level that
+// requires required; higher roles may sign for lower ones.
+func CanSign(role, required Role) bool {
+	return role.rank() > 0 && role.rank() >= required.rank()
+}
+
+// Level is one approval step: orders totalling MinAmount or more need a
+// sign-off by a user with at least Role.
+type Level struct {
+	Level     int32           `json:"level"`
+	Role      Role            `json:"approver_role"`
+	MinAmount decimal.Decimal `json:"min_amount"`
+}
+
+// RequiredLevels returns the levels an order of total needs, lowest first.
+func RequiredLevels(levels []Level, total decimal.Decimal) []Level {
+	required := make([]Level, 0, len(levels))
+	for _, l := range levels {
+		if total.GreaterThanOrEqual(l.MinAmount) {
+			required = append(required, l)
+		}
+	}
+	sort.Slice(required, func(i, j int) bool { return required[i].Level < required[j].Level })
+	return required
+}
+
+// Outstanding returns the required levels not yet signed off, in the order
+// they must be signed.
+func Outstanding(required []Level, signed []int32) []Level {
+	done := make(map[int32]bool, len(signed))
+	for _, s := range signed {
+		done[s] = true
+	}
+	outstanding := make([]Level, 0, len(required))
+	for _, l := range required {
+		if !done[l.Level] {
+			outstanding = append(outstanding, l)
+		}
+	}
+	return outstanding
+}
@@ -0,0 +1,52 @@
+package purchasing
+
+// PrimaryPriority is the priority of a product's primary supplier.
+const PrimaryPriority = 1
+
+// SupplierLink is one supplier of a product, as far as choosing the primary
+// supplier is concerned.
+type SupplierLink struct {
+	ID       int32
+	Priority int32
+	Active   bool
+}
+
+// Primary works out which of a product's supplier links is its primary
+// supplier, so that exactly one active link has priority 1. prefer is the
+// link just made primary or changed, and keeps priority 1 if it asked for
+// it; otherwise an existing primary keeps its place, and when there is none
+// the active link with the best priority is promoted, prefer last among
+// equals. Demote lists the other
+// active links that hold priority 1 and must give it up. ok is false when
+// the product has no active supplier.
+func Primary(links []SupplierLink, prefer int32) (primary int32, demote []int32, ok bool) {
+	var best *SupplierLink
+	for i := range links {
+		l := &links[i]
+		if !l.Active {
+			continue
+		}
+		switch {
+		case best == nil:
+			best = l
+		case l.ID == prefer && l.Priority == PrimaryPriority:
+			best = l
+		case best.ID == prefer && best.Priority == PrimaryPriority:
+		case l.Priority < best.Priority:
+			best = l
+		case l.Priority == best.Priority && best.ID == prefer:
+			// prefer asked to step down; let another link take over.
+			best = l
+		}
+	}
+	if best == nil {
+		return 0, nil, false
+	}
+
+	for _, l := range links {
+		if l.Active && l.ID != best.ID && l.Priority == PrimaryPriority {
+			demote = append(demote, l.ID)
+		}
+	}
+	return best.ID, demote, true
+}
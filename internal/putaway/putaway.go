@@ -0,0 +1,247 @@
+// Package putaway decides where received goods should be stored. It checks
+// location limits (units, weight and volume) against current occupancy and
+// ranks candidate locations, preferring consolidation with existing stock of
+// the same SKU and batch and the zones configured for the product category.
+// It has no database dependencies.
+package putaway
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrCapacityExceeded = errors.New("location capacity exceeded")
+
+// Limits are the configured maxima of a location. Zero means no limit.
+type Limits struct {
+	MaxUnits  int32
+	MaxWeight float64
+	MaxVolume float64
+}
+
+// Usage is what a location currently holds.
+type Usage struct {
+	Units  int32
+	Weight float64
+	Volume float64
+}
+
+// Item describes the goods being put away, per unit.
+type Item struct {
+	ProductID   int32
+	BatchNumber string
+	UnitWeight  float64
+	UnitVolume  float64
+}
+
+// Fit returns how many units of the item still fit in the location, or -1
+// when the location has no limits at all.
+func Fit(limits Limits, usage Usage, item Item) int32 {
+	fit := int64(-1)
+	bound := func(n int64) {
+		if n < 0 {
+			n = 0
+		}
+		if fit < 0 || n < fit {
+			fit = n
+		}
+	}
+
+	if limits.MaxUnits > 0 {
+		bound(int64(limits.MaxUnits - usage.Units))
+	}
+	if limits.MaxWeight > 0 && item.UnitWeight > 0 {
+		bound(int64(math.Floor((limits.MaxWeight - usage.Weight) / item.UnitWeight)))
+	}
+	if limits.MaxVolume > 0 && item.UnitVolume > 0 {
+		bound(int64(math.Floor((limits.MaxVolume - usage.Volume) / item.UnitVolume)))
+	}
+
+	if fit > math.MaxInt32 {
+		fit = math.MaxInt32
+	}
+	return int32(fit)
+}
+
+// Check rejects adding quantity units of the item to a location when that
+// would break any of its limits.
+func Check(limits Limits, usage Usage, item Item, quantity int32) error {
+	units := usage.Units + quantity
+	weight := usage.Weight + float64(quantity)*item.UnitWeight
+	volume := usage.Volume + float64(quantity)*item.UnitVolume
+
+	switch {
+	case limits.MaxUnits > 0 && units > limits.MaxUnits:
+		return fmt.Errorf("%w: %d units over a maximum of %d", ErrCapacityExceeded, units, limits.MaxUnits)
+	case limits.MaxWeight > 0 && weight > limits.MaxWeight:
+		return fmt.Errorf("%w: %.3f kg over a maximum of %.3f kg", ErrCapacityExceeded, weight, limits.MaxWeight)
+	case limits.MaxVolume > 0 && volume > limits.MaxVolume:
+		return fmt.Errorf("%w: %.3f l over a maximum of %.3f l", ErrCapacityExceeded, volume, limits.MaxVolume)
+	}
+	return nil
+}
+
+var dimensionsPattern = regexp.MustCompile(`^\s*([0-9]+(?:\.[0-9]+)?)\s*[x×*]\s*([0-9]+(?:\.[0-9]+)?)\s*[x×*]\s*([0-9]+(?:\.[0-9]+)?)\s*(mm|cm|m)?\s*$`)
+
+// ParseDimensions reads a product's free-text dimensions as "LxWxH" in
+// centimetres (a trailing mm, cm or m overrides the unit) and returns the
+// volume in litres.
+func ParseDimensions(s string) (float64, bool) {
+	m := dimensionsPattern.FindStringSubmatch(strings.ToLower(s))
+	if m == nil {
+		return 0, false
+	}
+
+	scale := 1.0
+	switch m[4] {
+	case "mm":
+		scale = 0.1
+	case "m":
+		scale = 100
+	}
+
+	volume := 1.0
+	for _, v := range m[1:4] {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		volume *= f * scale
+	}
+	return volume / 1000, true
+}
+
+// Content is one product/batch held in a location.
+type Content struct {
+	ProductID   int32
+	BatchNumber string
+	Quantity    int32
+}
+
+// Location is a candidate location with what it holds today.
+type Location struct {
+	ID       int32
+	Code     string
+	ZoneID   int32
+	Sequence int32
+	Limits   Limits
+	Usage    Usage
+	Contents []Content
+}
+
+type Reason string
+
+const (
+	ReasonSameBatch   Reason = "same_batch"
+	ReasonSameProduct Reason = "same_product"
+	ReasonEmpty       Reason = "empty"
+	ReasonMixed       Reason = "mixed"
+)
+
+type Suggestion struct {
+	LocationID   int32  `json:"location_id"`
+	LocationCode string `json:"location_code"`
+	ZoneID       int32  `json:"zone_id,omitempty"`
+	Quantity     int32  `json:"quantity"`
+	Reason       Reason `json:"reason"`
+	// Fit is how many units the location could take in total; -1 means
+	// unlimited.
+	Fit int32 `json:"fit"`
+}
+
+// Suggest spreads quantity units of the item over the locations. When
+// zoneRank is not empty only locations in those zones are used, lower ranks
+// first. Locations already holding the same batch come first, then those
+// with the same product, then empty ones and finally mixed ones; ties go to
+// the lower pick sequence and location code. It returns the suggestions and
+// the quantity that could not be placed.
+func Suggest(locations []Location, item Item, quantity int32, zoneRank map[int32]int) ([]Suggestion, int32) {
+	type candidate struct {
+		loc    Location
+		reason Reason
+		class  int
+		rank   int
+		fit    int32
+	}
+
+	var candidates []candidate
+	for _, loc := range locations {
+		rank := 0
+		if len(zoneRank) > 0 {
+			r, ok := zoneRank[loc.ZoneID]
+			if !ok {
+				continue
+			}
+			rank = r
+		}
+
+		fit := Fit(loc.Limits, loc.Usage, item)
+		if fit == 0 {
+			continue
+		}
+
+		reason, class := ReasonEmpty, 2
+		for _, c := range loc.Contents {
+			if c.Quantity <= 0 {
+				continue
+			}
+			switch {
+			case c.ProductID == item.ProductID && c.BatchNumber == item.BatchNumber && class > 0:
+				reason, class = ReasonSameBatch, 0
+			case c.ProductID == item.ProductID && class > 1:
+				reason, class = ReasonSameProduct, 1
+			case c.ProductID != item.ProductID && class == 2:
+				reason, class = ReasonMixed, 3
+			}
+		}
+
+		candidates = append(candidates, candidate{loc: loc, reason: reason, class: class, rank: rank, fit: fit})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.class != b.class {
+			return a.class < b.class
+		}
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if (a.loc.Sequence == 0) != (b.loc.Sequence == 0) {
+			return b.loc.Sequence == 0
+		}
+		if a.loc.Sequence != b.loc.Sequence {
+			return a.loc.Sequence < b.loc.Sequence
+		}
+		if a.loc.Code != b.loc.Code {
+			return a.loc.Code < b.loc.Code
+		}
+		return a.loc.ID < b.loc.ID
+	})
+
+	var out []Suggestion
+	left := quantity
+	for _, c := range candidates {
+		if left <= 0 {
+			break
+		}
+		qty := left
+		if c.fit >= 0 && c.fit < qty {
+			qty = c.fit
+		}
+		out = append(out, Suggestion{
+			LocationID:   c.loc.ID,
+			LocationCode: c.loc.Code,
+			ZoneID:       c.loc.ZoneID,
+			Quantity:     qty,
+			Reason:       c.reason,
+			Fit:          c.fit,
+		})
+		left -= qty
+	}
+	return out, left
+}
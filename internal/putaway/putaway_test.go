@@ -0,0 +1,81 @@
+package putaway
+
+import (
+	"errors"
+	"testing"
+)
+
+// crate weighs 2 kg and takes 5 l.
+var crate = Item{ProductID: 1, UnitWeight: 2, UnitVolume: 5}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   Limits
+		usage    Usage
+		quantity int32
+		want     error
+	}{
+		{"no limits", Limits{}, Usage{Units: 1000}, 1000, nil},
+		{"units up to the limit", Limits{MaxUnits: 10}, Usage{Units: 6}, 4, nil},
+		{"units over the limit", Limits{MaxUnits: 10}, Usage{Units: 6}, 5, ErrCapacityExceeded},
+		{"weight up to the limit", Limits{MaxWeight: 20}, Usage{Weight: 10}, 5, nil},
+		{"weight over the limit", Limits{MaxWeight: 20}, Usage{Weight: 10.5}, 5, ErrCapacityExceeded},
+		{"volume over the limit", Limits{MaxVolume: 40}, Usage{Volume: 20}, 5, ErrCapacityExceeded},
+		{"already overfilled", Limits{MaxUnits: 10}, Usage{Units: 12}, 1, ErrCapacityExceeded},
+		{"units fit but weight does not", Limits{MaxUnits: 100, MaxWeight: 10}, Usage{}, 6, ErrCapacityExceeded},
+	}
+	for _, tt := range tests {
+		if err := Check(tt.limits, tt.usage, crate, tt.quantity); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Check = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestCheckWeightlessItem(t *testing.T) {
+	item := Item{ProductID: 2}
+	if err := Check(Limits{MaxWeight: 1, MaxVolume: 1}, Usage{}, item, 1000); err != nil {
+		t.Errorf("Check = %v, want nil", err)
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits Limits
+		usage  Usage
+		want   int32
+	}{
+		{"no limits", Limits{}, Usage{}, -1},
+		{"units", Limits{MaxUnits: 10}, Usage{Units: 4}, 6},
+		{"weight rounds down", Limits{MaxWeight: 15}, Usage{Weight: 4}, 5},
+		{"tightest limit wins", Limits{MaxUnits: 10, MaxWeight: 100, MaxVolume: 30}, Usage{}, 6},
+		{"full", Limits{MaxUnits: 10}, Usage{Units: 12}, 0},
+	}
+	for _, tt := range tests {
+		if got := Fit(tt.limits, tt.usage, crate); got != tt.want {
+			t.Errorf("%s: Fit = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseDimensions(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   float64
+		wantOK bool
+	}{
+		{"10x20x30", 6, true},
+		{"10 x 20 x 30 cm", 6, true},
+		{"100×200×300mm", 6, true},
+		{"0.1*0.2*0.3 m", 6, true},
+		{"10x20", 0, false},
+		{"large", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseDimensions(tt.in)
+		if ok != tt.wantOK || (ok && (got < tt.want-1e-9 || got > tt.want+1e-9)) {
+			t.Errorf("%q: ParseDimensions = %v, %v, want %v, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
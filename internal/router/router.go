@@ -2,27 +2,47 @@ package router
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	db "github.com/molu/stock-management-system/internal/db/sqlc"
 	"github.com/molu/stock-management-system/internal/handlers"
 	"github.com/molu/stock-management-system/internal/middleware"
+	"github.com/molu/stock-management-system/internal/stream"
 )
 
-func New(queries *db.Queries, jwtSecret string) http.Handler {
+func New(store db.SingleDb, broker *stream.Broker, jwtSecret string, idempotencyKeyTTL time.Duration, webhookAllowPrivateHosts bool) http.Handler {
 	r := mux.NewRouter()
 
 	// Initialize handlers
-	productHandler := handlers.NewProductHandler(queries)
-	inventoryHandler := handlers.NewInventoryHandler(queries)
-	stockMovementHandler := handlers.NewStockMovementHandler(queries)
-	purchaseOrderHandler := handlers.NewPurchaseOrderHandler(queries)
-	stockAdjustmentHandler := handlers.NewStockAdjustmentHandler(queries)
-	transferHandler := handlers.NewTransferHandler(queries)
-	stocktakeHandler := handlers.NewStocktakeHandler(queries)
-	warehouseHandler := handlers.NewWarehouseHandler(queries)
-	supplierHandler := handlers.NewSupplierHandler(queries) // Add this line
-	categoryHandler := handlers.NewCategoryHandler(queries)
+	productHandler := handlers.NewProductHandler(store)
+	inventoryHandler := handlers.NewInventoryHandler(store)
+	stockMovementHandler := handlers.NewStockMovementHandler(store)
+	purchaseOrderHandler := handlers.NewPurchaseOrderHandler(store)
+	stockAdjustmentHandler := handlers.NewStockAdjustmentHandler(store)
+	transferHandler := handlers.NewTransferHandler(store)
+	stocktakeHandler := handlers.NewStocktakeHandler(store)
+	warehouseHandler := handlers.NewWarehouseHandler(store)
+	supplierHandler := handlers.NewSupplierHandler(store) // Add this line
+	categoryHandler := handlers.NewCategoryHandler(store)
+	pickingHandler := handlers.NewPickingHandler(store)
+	putawayHandler := handlers.NewPutawayHandler(store)
+	uomHandler := handlers.NewUomHandler(store)
+	costingHandler := handlers.NewCostingHandler(store)
+	marginHandler := handlers.NewMarginHandler(store)
+	currencyHandler := handlers.NewCurrencyHandler(store)
+	landedCostHandler := handlers.NewLandedCostHandler(store)
+	productSupplierHandler := handlers.NewProductSupplierHandler(store)
+	importHandler := handlers.NewImportHandler(store)
+	exportHandler := handlers.NewExportHandler(store)
+	attributeHandler := handlers.NewAttributeHandler(store)
+	variantHandler := handlers.NewVariantHandler(store)
+	kitHandler := handlers.NewKitHandler(store)
+	workOrderHandler := handlers.NewWorkOrderHandler(store)
+	scanHandler := handlers.NewScanHandler(store)
+	floorTaskHandler := handlers.NewFloorTaskHandler(store)
+	webhookHandler := handlers.NewWebhookHandler(store, webhookAllowPrivateHosts)
+	streamHandler := handlers.NewStreamHandler(broker)
 
 	// Global middleware
 	r.Use(middleware.Logger)
@@ -36,21 +56,51 @@ func New(queries *db.Queries, jwtSecret string) http.Handler {
 
 	// API routes
 	api := r.PathPrefix("/api/v1").Subrouter()
+	api.Use(middleware.Idempotency(store, idempotencyKeyTTL))
+
+	// Purchase order creation, status changes and sign-offs, approval level
+	// and webhook management act on behalf of the user in the bearer token
+	auth := middleware.Auth(jwtSecret)
 
 	// Products
 	products := api.PathPrefix("/products").Subrouter()
 	products.HandleFunc("", productHandler.List).Methods("GET")
 	products.HandleFunc("", productHandler.Create).Methods("POST")
+	products.HandleFunc("/search", productHandler.Search).Methods("GET")
 	products.HandleFunc("/{id}", productHandler.Get).Methods("GET")
 	products.HandleFunc("/{id}", productHandler.Update).Methods("PUT")
 	products.HandleFunc("/{id}", productHandler.Delete).Methods("DELETE")
 	products.HandleFunc("/sku/{sku}", productHandler.GetBySKU).Methods("GET")
 	products.HandleFunc("/category/{categoryId}", productHandler.ListByCategory).Methods("GET")
 	products.HandleFunc("/reorder/below-point", productHandler.ListBelowReorderPoint).Methods("GET")
+	products.HandleFunc("/{id}/suppliers", productSupplierHandler.ListByProduct).Methods("GET")
+	products.HandleFunc("/{id}/attributes", attributeHandler.GetForProduct).Methods("GET")
+	products.HandleFunc("/{id}/attributes", attributeHandler.SetForProduct).Methods("PUT")
+	products.HandleFunc("/{id}/variants", variantHandler.List).Methods("GET")
+	products.HandleFunc("/{id}/variants", variantHandler.Generate).Methods("POST")
+	products.HandleFunc("/{id}/components", kitHandler.ListComponents).Methods("GET")
+	products.HandleFunc("/{id}/components/{componentId}", kitHandler.SetComponent).Methods("PUT")
+	products.HandleFunc("/{id}/components/{componentId}", kitHandler.DeleteComponent).Methods("DELETE")
+	products.HandleFunc("/{id}/where-used", kitHandler.WhereUsed).Methods("GET")
+	products.HandleFunc("/{id}/kit-availability", kitHandler.Availability).Methods("GET")
+	products.HandleFunc("/{id}/assemblies", kitHandler.ListAssemblies).Methods("GET")
+	products.HandleFunc("/{id}/uoms", uomHandler.ListProductUoms).Methods("GET")
+	products.HandleFunc("/{id}/uoms", uomHandler.CreateProductUom).Methods("POST")
+	products.HandleFunc("/{id}/uoms/convert", uomHandler.Convert).Methods("GET")
+	products.HandleFunc("/uoms/{productUomId}", uomHandler.DeactivateProductUom).Methods("DELETE")
+	products.HandleFunc("/{id}/costing", costingHandler.SetCosting).Methods("PUT")
+	products.HandleFunc("/{id}/cost-layers", costingHandler.ListCostLayers).Methods("GET")
+
+	// Units of Measure
+	uoms := api.PathPrefix("/uoms").Subrouter()
+	uoms.HandleFunc("", uomHandler.List).Methods("GET")
+	uoms.HandleFunc("", uomHandler.Create).Methods("POST")
 
 	// Inventory
 	inventory := api.PathPrefix("/inventory").Subrouter()
 	inventory.HandleFunc("", inventoryHandler.List).Methods("GET")
+	inventory.HandleFunc("/move", inventoryHandler.Move).Methods("POST")
+	inventory.HandleFunc("/identifiers/{identifierId}/history", inventoryHandler.IdentifierHistory).Methods("GET")
 	inventory.HandleFunc("/{id}", inventoryHandler.Get).Methods("GET")
 	inventory.HandleFunc("/product/{productId}/warehouse/{warehouseId}", inventoryHandler.GetByProductWarehouse).Methods("GET")
 	inventory.HandleFunc("/warehouse/{warehouseId}", inventoryHandler.ListByWarehouse).Methods("GET")
@@ -65,6 +115,7 @@ func New(queries *db.Queries, jwtSecret string) http.Handler {
 	movements := api.PathPrefix("/stock-movements").Subrouter()
 	movements.HandleFunc("", stockMovementHandler.Create).Methods("POST")
 	movements.HandleFunc("/{id}", stockMovementHandler.Get).Methods("GET")
+	movements.HandleFunc("/{id}/cost", costingHandler.MovementCost).Methods("GET")
 	movements.HandleFunc("/product/{productId}", stockMovementHandler.ListByProduct).Methods("GET")
 	movements.HandleFunc("/warehouse/{warehouseId}", stockMovementHandler.ListByWarehouse).Methods("GET")
 	movements.HandleFunc("/type/{type}", stockMovementHandler.ListByType).Methods("GET")
@@ -73,13 +124,36 @@ func New(queries *db.Queries, jwtSecret string) http.Handler {
 	// Purchase Orders
 	purchaseOrders := api.PathPrefix("/purchase-orders").Subrouter()
 	purchaseOrders.HandleFunc("", purchaseOrderHandler.List).Methods("GET")
-	purchaseOrders.HandleFunc("", purchaseOrderHandler.Create).Methods("POST")
+	purchaseOrders.Handle("", auth(http.HandlerFunc(purchaseOrderHandler.Create))).Methods("POST")
 	purchaseOrders.HandleFunc("/{id}", purchaseOrderHandler.Get).Methods("GET")
-	purchaseOrders.HandleFunc("/{id}/status", purchaseOrderHandler.UpdateStatus).Methods("PUT")
+	purchaseOrders.Handle("/{id}/status", auth(http.HandlerFunc(purchaseOrderHandler.UpdateStatus))).Methods("PUT")
 	purchaseOrders.HandleFunc("/status/{status}", purchaseOrderHandler.ListByStatus).Methods("GET")
 	purchaseOrders.HandleFunc("/{id}/items", purchaseOrderHandler.GetItems).Methods("GET")
 	purchaseOrders.HandleFunc("/{id}/items", purchaseOrderHandler.CreateItem).Methods("POST")
 	purchaseOrders.HandleFunc("/items/{itemId}/receive", purchaseOrderHandler.ReceiveItem).Methods("POST")
+	purchaseOrders.HandleFunc("/{id}/document", purchaseOrderHandler.Document).Methods("GET")
+	purchaseOrders.Handle("/{id}/approve", auth(http.HandlerFunc(purchaseOrderHandler.Approve))).Methods("POST")
+	purchaseOrders.Handle("/{id}/reject", auth(http.HandlerFunc(purchaseOrderHandler.Reject))).Methods("POST")
+	purchaseOrders.HandleFunc("/{id}/approvals", purchaseOrderHandler.ListApprovals).Methods("GET")
+	purchaseOrders.HandleFunc("/{id}/landed-costs", landedCostHandler.ListByPurchaseOrder).Methods("GET")
+	purchaseOrders.HandleFunc("/{id}/landed-costs", landedCostHandler.Create).Methods("POST")
+
+	// Purchase Order Approval Levels
+	approvalLevels := api.PathPrefix("/po-approval-levels").Subrouter()
+	approvalLevels.HandleFunc("", purchaseOrderHandler.ListApprovalLevels).Methods("GET")
+	approvalLevels.Handle("", auth(http.HandlerFunc(purchaseOrderHandler.CreateApprovalLevel))).Methods("POST")
+	approvalLevels.Handle("/{id}", auth(http.HandlerFunc(purchaseOrderHandler.UpdateApprovalLevel))).Methods("PUT")
+
+	// Landed Costs
+	landedCosts := api.PathPrefix("/landed-costs").Subrouter()
+	landedCosts.HandleFunc("/{id}", landedCostHandler.Get).Methods("GET")
+
+	// Currencies
+	currencies := api.PathPrefix("/currencies").Subrouter()
+	currencies.HandleFunc("", currencyHandler.List).Methods("GET")
+	currencies.HandleFunc("", currencyHandler.Create).Methods("POST")
+	currencies.HandleFunc("/{code}/rates", currencyHandler.ListRates).Methods("GET")
+	currencies.HandleFunc("/{code}/rates", currencyHandler.SetRate).Methods("POST")
 
 	// Stock Adjustments
 	adjustments := api.PathPrefix("/stock-adjustments").Subrouter()
@@ -117,6 +191,10 @@ func New(queries *db.Queries, jwtSecret string) http.Handler {
 	warehouses.HandleFunc("/code/{code}", warehouseHandler.GetByCode).Methods("GET")
 	warehouses.HandleFunc("/{id}/locations", warehouseHandler.ListLocations).Methods("GET")
 	warehouses.HandleFunc("/{id}/locations", warehouseHandler.CreateLocation).Methods("POST")
+	warehouses.HandleFunc("/{id}/zones", warehouseHandler.ListZones).Methods("GET")
+	warehouses.HandleFunc("/{id}/zones", warehouseHandler.CreateZone).Methods("POST")
+	warehouses.HandleFunc("/{id}/letterhead", warehouseHandler.GetLetterhead).Methods("GET")
+	warehouses.HandleFunc("/{id}/letterhead", warehouseHandler.SetLetterhead).Methods("PUT")
 	warehouses.HandleFunc("/summary", warehouseHandler.GetInventorySummary).Methods("GET")
 
 	// Locations
@@ -125,6 +203,12 @@ func New(queries *db.Queries, jwtSecret string) http.Handler {
 	locations.HandleFunc("/{id}", warehouseHandler.UpdateLocation).Methods("PUT")
 	locations.HandleFunc("/{id}", warehouseHandler.DeactivateLocation).Methods("DELETE")
 
+	// Zones
+	zones := api.PathPrefix("/zones").Subrouter()
+	zones.HandleFunc("/{id}", warehouseHandler.GetZone).Methods("GET")
+	zones.HandleFunc("/{id}", warehouseHandler.UpdateZone).Methods("PUT")
+	zones.HandleFunc("/{id}/locations", warehouseHandler.ListZoneLocations).Methods("GET")
+
 	suppliers := api.PathPrefix("/suppliers").Subrouter()
 	suppliers.HandleFunc("", supplierHandler.List).Methods("GET")
 	suppliers.HandleFunc("", supplierHandler.Create).Methods("POST")
@@ -136,18 +220,144 @@ func New(queries *db.Queries, jwtSecret string) http.Handler {
 	suppliers.HandleFunc("/{id}/deactivate", supplierHandler.Deactivate).Methods("POST")
 	suppliers.HandleFunc("/{id}/activate", supplierHandler.Activate).Methods("POST")
 	suppliers.HandleFunc("/{id}/products", supplierHandler.GetProducts).Methods("GET")
+	suppliers.HandleFunc("/{id}/products/{productId}/purchase-uom", supplierHandler.SetPurchaseUom).Methods("PUT")
+	suppliers.HandleFunc("/{id}/catalogue", productSupplierHandler.ListCatalogue).Methods("GET")
+	suppliers.HandleFunc("/{id}/catalogue", productSupplierHandler.Create).Methods("POST")
+	suppliers.HandleFunc("/{id}/price-list", productSupplierHandler.ImportPriceList).Methods("POST")
 	suppliers.HandleFunc("/{id}/performance", supplierHandler.GetPerformance).Methods("GET")
+	suppliers.HandleFunc("/{id}/performance/refresh", supplierHandler.RefreshRating).Methods("POST")
+
+	// Product-supplier links
+	productSuppliers := api.PathPrefix("/product-suppliers").Subrouter()
+	productSuppliers.HandleFunc("/{id}", productSupplierHandler.Get).Methods("GET")
+	productSuppliers.HandleFunc("/{id}", productSupplierHandler.Update).Methods("PUT")
+	productSuppliers.HandleFunc("/{id}", productSupplierHandler.Delete).Methods("DELETE")
+	productSuppliers.HandleFunc("/{id}/prices", productSupplierHandler.ListPrices).Methods("GET")
+
+	// Kit assemblies
+	kitAssemblies := api.PathPrefix("/kit-assemblies").Subrouter()
+	kitAssemblies.HandleFunc("", kitHandler.CreateAssembly).Methods("POST")
+	kitAssemblies.HandleFunc("/{id}", kitHandler.GetAssembly).Methods("GET")
+
+	// Barcode scanning
+	api.HandleFunc("/scan", scanHandler.Scan).Methods("GET")
+	api.HandleFunc("/scan/{code}", scanHandler.Scan).Methods("GET")
+
+	// Work orders
+	workOrders := api.PathPrefix("/work-orders").Subrouter()
+	workOrders.HandleFunc("", workOrderHandler.List).Methods("GET")
+	workOrders.HandleFunc("", workOrderHandler.Create).Methods("POST")
+	workOrders.HandleFunc("/trace", workOrderHandler.Trace).Methods("GET")
+	workOrders.HandleFunc("/{id}", workOrderHandler.Get).Methods("GET")
+	workOrders.HandleFunc("/{id}/release", workOrderHandler.Release).Methods("POST")
+	workOrders.HandleFunc("/{id}/consume", workOrderHandler.Consume).Methods("POST")
+	workOrders.HandleFunc("/{id}/complete", workOrderHandler.Complete).Methods("POST")
+	workOrders.HandleFunc("/{id}/cancel", workOrderHandler.Cancel).Methods("POST")
+
+	// Floor tasks for handheld devices
+	devices := api.PathPrefix("/devices/{deviceId}/tasks").Subrouter()
+	devices.HandleFunc("/next", floorTaskHandler.Next).Methods("POST")
+	devices.HandleFunc("/{taskId}/confirm", floorTaskHandler.Confirm).Methods("POST")
+	devices.HandleFunc("/{taskId}/exception", floorTaskHandler.Exception).Methods("POST")
+	devices.HandleFunc("/{taskId}/release", floorTaskHandler.Release).Methods("POST")
+
+	floorTasks := api.PathPrefix("/floor-tasks").Subrouter()
+	floorTasks.HandleFunc("", floorTaskHandler.List).Methods("GET")
+	floorTasks.HandleFunc("", floorTaskHandler.Create).Methods("POST")
+	floorTasks.HandleFunc("/{id}", floorTaskHandler.Get).Methods("GET")
+	floorTasks.HandleFunc("/{id}/cancel", floorTaskHandler.Cancel).Methods("POST")
+
+	// Webhooks
+	webhooks := api.PathPrefix("/webhooks").Subrouter()
+	webhooks.Use(auth)
+	webhooks.HandleFunc("/subscriptions", webhookHandler.ListSubscriptions).Methods("GET")
+	webhooks.HandleFunc("/subscriptions", webhookHandler.CreateSubscription).Methods("POST")
+	webhooks.HandleFunc("/subscriptions/{id}", webhookHandler.GetSubscription).Methods("GET")
+	webhooks.HandleFunc("/subscriptions/{id}", webhookHandler.UpdateSubscription).Methods("PUT")
+	webhooks.HandleFunc("/subscriptions/{id}", webhookHandler.DeactivateSubscription).Methods("DELETE")
+	webhooks.HandleFunc("/subscriptions/{id}/ping", webhookHandler.Ping).Methods("POST")
+	webhooks.HandleFunc("/subscriptions/{id}/deliveries", webhookHandler.ListDeliveries).Methods("GET")
+	webhooks.HandleFunc("/deliveries/{id}", webhookHandler.GetDelivery).Methods("GET")
+	webhooks.HandleFunc("/deliveries/{id}/redeliver", webhookHandler.Redeliver).Methods("POST")
+
+	// Live stock events
+	api.HandleFunc("/stream/stock", streamHandler.Stock).Methods("GET")
+
+	// Bulk imports
+	imports := api.PathPrefix("/imports").Subrouter()
+	imports.HandleFunc("/batches", importHandler.ListBatches).Methods("GET")
+	imports.HandleFunc("/batches/{id}", importHandler.GetBatch).Methods("GET")
+	imports.HandleFunc("/batches/{id}/opening-balances", importHandler.ListOpeningBalances).Methods("GET")
+	imports.HandleFunc("/{kind}", importHandler.Import).Methods("POST")
+	imports.HandleFunc("/{kind}/template", importHandler.Template).Methods("GET")
+
+	// Bulk exports
+	exports := api.PathPrefix("/exports").Subrouter()
+	exports.HandleFunc("/products", exportHandler.Products).Methods("GET")
+	exports.HandleFunc("/inventory", exportHandler.Inventory).Methods("GET")
+	exports.HandleFunc("/stock-movements", exportHandler.StockMovements).Methods("GET")
+	exports.HandleFunc("/purchase-orders", exportHandler.PurchaseOrders).Methods("GET")
+	exports.HandleFunc("/stocktakes", exportHandler.Stocktakes).Methods("GET")
 
 	// Categories
 	categories := api.PathPrefix("/categories").Subrouter()
 	categories.HandleFunc("", categoryHandler.List).Methods("GET")
 	categories.HandleFunc("", categoryHandler.Create).Methods("POST")
 	categories.HandleFunc("/root", categoryHandler.ListRoot).Methods("GET")
+	categories.HandleFunc("/tree", categoryHandler.Tree).Methods("GET")
 	categories.HandleFunc("/{id}", categoryHandler.Get).Methods("GET")
 	categories.HandleFunc("/{id}", categoryHandler.Update).Methods("PUT")
 	categories.HandleFunc("/{id}", categoryHandler.Delete).Methods("DELETE")
 	categories.HandleFunc("/code/{code}", categoryHandler.GetByCode).Methods("GET")
 	categories.HandleFunc("/{id}/subcategories", categoryHandler.ListSubCategories).Methods("GET")
+	categories.HandleFunc("/{id}/tree", categoryHandler.Tree).Methods("GET")
+	categories.HandleFunc("/{id}/path", categoryHandler.Path).Methods("GET")
+	categories.HandleFunc("/{id}/parent", categoryHandler.Move).Methods("PUT")
+	categories.HandleFunc("/{id}/attributes", attributeHandler.ListForCategory).Methods("GET")
+	categories.HandleFunc("/{id}/attributes", attributeHandler.Create).Methods("POST")
+
+	// Category attributes
+	categoryAttributes := api.PathPrefix("/category-attributes").Subrouter()
+	categoryAttributes.HandleFunc("/{id}", attributeHandler.Get).Methods("GET")
+	categoryAttributes.HandleFunc("/{id}", attributeHandler.Update).Methods("PUT")
+	categoryAttributes.HandleFunc("/{id}", attributeHandler.Delete).Methods("DELETE")
+
+	// Valuation
+	valuation := api.PathPrefix("/valuation").Subrouter()
+	valuation.HandleFunc("/warehouses", costingHandler.ValuationByWarehouse).Methods("GET")
+	valuation.HandleFunc("/categories", costingHandler.ValuationByCategory).Methods("GET")
+	valuation.HandleFunc("/report", costingHandler.ValuationReport).Methods("GET")
+	valuation.HandleFunc("/variances", costingHandler.ListVariances).Methods("GET")
+
+	// Reports
+	reports := api.PathPrefix("/reports").Subrouter()
+	reports.HandleFunc("/margin", marginHandler.Report).Methods("GET")
+
+	// Putaway
+	putawayRoutes := api.PathPrefix("/putaway").Subrouter()
+	putawayRoutes.HandleFunc("", putawayHandler.Confirm).Methods("POST")
+	putawayRoutes.HandleFunc("/suggestions", putawayHandler.Suggest).Methods("GET")
+	putawayRoutes.HandleFunc("/rules", putawayHandler.CreateRule).Methods("POST")
+	putawayRoutes.HandleFunc("/rules/{id}", putawayHandler.DeactivateRule).Methods("DELETE")
+	putawayRoutes.HandleFunc("/rules/warehouse/{warehouseId}", putawayHandler.ListRules).Methods("GET")
+
+	// Picking
+	pickingRoutes := api.PathPrefix("/picking-routes").Subrouter()
+	pickingRoutes.HandleFunc("", pickingHandler.CreateRoute).Methods("POST")
+	pickingRoutes.HandleFunc("/{id}", pickingHandler.GetRoute).Methods("GET")
+	pickingRoutes.HandleFunc("/warehouse/{warehouseId}", pickingHandler.ListRoutes).Methods("GET")
+
+	pickingWaves := api.PathPrefix("/picking-waves").Subrouter()
+	pickingWaves.HandleFunc("", pickingHandler.CreateWave).Methods("POST")
+	pickingWaves.HandleFunc("/{id}", pickingHandler.GetWave).Methods("GET")
+	pickingWaves.HandleFunc("/{id}/pick-list", pickingHandler.GetPickList).Methods("GET")
+	pickingWaves.HandleFunc("/{id}/optimize", pickingHandler.OptimizePickPath).Methods("POST")
+	pickingWaves.HandleFunc("/{id}/assign", pickingHandler.AssignWave).Methods("PUT")
+	pickingWaves.HandleFunc("/{id}/start", pickingHandler.StartWave).Methods("POST")
+	pickingWaves.HandleFunc("/{id}/cancel", pickingHandler.CancelWave).Methods("POST")
+	pickingWaves.HandleFunc("/items/{itemId}/confirm", pickingHandler.ConfirmPick).Methods("POST")
+	pickingWaves.HandleFunc("/warehouse/{warehouseId}", pickingHandler.ListWaves).Methods("GET")
+	pickingWaves.HandleFunc("/warehouse/{warehouseId}/productivity", pickingHandler.GetProductivity).Methods("GET")
 
 	return r
 }
@@ -0,0 +1,266 @@
+// Package routing orders pick lists into short walking paths over a
+// rectangular warehouse layout of parallel aisles joined by a front and a
+// back cross-aisle. It has no database dependencies so layouts can be built
+// by hand.
+package routing
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+type Method string
+
+const (
+	// MethodSShape walks every aisle that holds a pick end to end, alternating
+	// direction.
+	MethodSShape Method = "s_shape"
+	// MethodReturn enters every aisle from the front and walks back out.
+	MethodReturn Method = "return"
+	// MethodNearestNeighbour builds a greedy tour and improves it with 2-opt.
+	MethodNearestNeighbour Method = "nearest_neighbour"
+	// MethodSequence follows the locations' configured pick sequence.
+	MethodSequence Method = "sequence"
+)
+
+const (
+	// DefaultMetresPerMinute is an average walking speed with a cart.
+	DefaultMetresPerMinute = 60.0
+	// DefaultSecondsPerPick covers scanning and handling at the location.
+	DefaultSecondsPerPick = 15.0
+)
+
+var ErrUnknownMethod = errors.New("unknown routing method")
+
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Stop is one pick on the list. Stops without coordinates are kept at the
+// end of the path in the order they were given.
+type Stop struct {
+	ID int32
+	Point
+	HasPoint bool
+	// Sequence is the location's configured walk order, 0 when unset.
+	Sequence int32
+}
+
+// Layout describes the warehouse: aisles run along y and share an x value,
+// the front cross-aisle is at FrontY and the back cross-aisle at BackY. A
+// BackY that is not beyond FrontY means there is no back cross-aisle.
+type Layout struct {
+	Depot  Point
+	FrontY float64
+	BackY  float64
+}
+
+type Route struct {
+	Method   Method  `json:"method"`
+	Stops    []Stop  `json:"-"`
+	Distance float64 `json:"distance"`
+}
+
+// Distance is the walking distance between two points. Within an aisle it is
+// the distance along the aisle; between aisles the picker has to leave
+// through whichever cross-aisle is shorter.
+func (l Layout) Distance(a, b Point) float64 {
+	if a.X == b.X {
+		return math.Abs(a.Y - b.Y)
+	}
+	dx := math.Abs(a.X - b.X)
+	front := math.Abs(a.Y-l.FrontY) + math.Abs(b.Y-l.FrontY)
+	if l.BackY <= l.FrontY {
+		return dx + front
+	}
+	back := math.Abs(l.BackY-a.Y) + math.Abs(l.BackY-b.Y)
+	return dx + math.Min(front, back)
+}
+
+// PathLength is the length of a tour that starts and ends at the depot and
+// visits the stops in order. Stops without coordinates add nothing.
+func (l Layout) PathLength(stops []Stop) float64 {
+	var total float64
+	at := l.Depot
+	for _, s := range stops {
+		if !s.HasPoint {
+			continue
+		}
+		total += l.Distance(at, s.Point)
+		at = s.Point
+	}
+	return total + l.Distance(at, l.Depot)
+}
+
+// Plan orders the stops with the given method. The result only depends on
+// the input, with ties broken by stop ID.
+func Plan(layout Layout, stops []Stop, method Method) (Route, error) {
+	var placed, unplaced []Stop
+	for _, s := range stops {
+		if s.HasPoint {
+			placed = append(placed, s)
+		} else {
+			unplaced = append(unplaced, s)
+		}
+	}
+
+	var ordered []Stop
+	switch method {
+	case MethodSShape:
+		ordered = sShape(layout, placed)
+	case MethodReturn:
+		ordered = returnPath(placed)
+	case MethodNearestNeighbour:
+		ordered = twoOpt(layout, nearestNeighbour(layout, placed))
+	case MethodSequence:
+		ordered = bySequence(stops)
+		unplaced = nil
+	default:
+		return Route{}, ErrUnknownMethod
+	}
+
+	ordered = append(ordered, unplaced...)
+	return Route{
+		Method:   method,
+		Stops:    ordered,
+		Distance: layout.PathLength(ordered),
+	}, nil
+}
+
+// EstimateMinutes converts a travel distance and the number of picks into
+// whole minutes, rounding up.
+func EstimateMinutes(distance float64, picks int, metresPerMinute, secondsPerPick float64) int32 {
+	if metresPerMinute <= 0 {
+		metresPerMinute = DefaultMetresPerMinute
+	}
+	if secondsPerPick < 0 {
+		secondsPerPick = DefaultSecondsPerPick
+	}
+	minutes := distance/metresPerMinute + float64(picks)*secondsPerPick/60
+	return int32(math.Ceil(minutes))
+}
+
+// aisles groups stops by x, with the aisles in ascending x and the stops in
+// each aisle in ascending y.
+func aisles(stops []Stop) [][]Stop {
+	sorted := append([]Stop(nil), stops...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.ID < b.ID
+	})
+
+	var out [][]Stop
+	for i, s := range sorted {
+		if i == 0 || s.X != sorted[i-1].X {
+			out = append(out, nil)
+		}
+		out[len(out)-1] = append(out[len(out)-1], s)
+	}
+	return out
+}
+
+func sShape(layout Layout, stops []Stop) []Stop {
+	groups := aisles(stops)
+	// Start from the end of the warehouse nearest the depot.
+	if len(groups) > 1 && math.Abs(groups[len(groups)-1][0].X-layout.Depot.X) < math.Abs(groups[0][0].X-layout.Depot.X) {
+		for i, j := 0, len(groups)-1; i < j; i, j = i+1, j-1 {
+			groups[i], groups[j] = groups[j], groups[i]
+		}
+	}
+
+	var out []Stop
+	for i, aisle := range groups {
+		if i%2 == 1 {
+			for j := len(aisle) - 1; j >= 0; j-- {
+				out = append(out, aisle[j])
+			}
+			continue
+		}
+		out = append(out, aisle...)
+	}
+	return out
+}
+
+func returnPath(stops []Stop) []Stop {
+	var out []Stop
+	for _, aisle := range aisles(stops) {
+		out = append(out, aisle...)
+	}
+	return out
+}
+
+func nearestNeighbour(layout Layout, stops []Stop) []Stop {
+	left := append([]Stop(nil), stops...)
+	sort.Slice(left, func(i, j int) bool { return left[i].ID < left[j].ID })
+
+	out := make([]Stop, 0, len(left))
+	at := layout.Depot
+	for len(left) > 0 {
+		best := 0
+		bestDist := layout.Distance(at, left[0].Point)
+		for i := 1; i < len(left); i++ {
+			if d := layout.Distance(at, left[i].Point); d < bestDist {
+				best, bestDist = i, d
+			}
+		}
+		out = append(out, left[best])
+		at = left[best].Point
+		left = append(left[:best], left[best+1:]...)
+	}
+	return out
+}
+
+// twoOpt reverses segments of the tour while that makes it shorter.
+func twoOpt(layout Layout, stops []Stop) []Stop {
+	const eps = 1e-9
+	tour := append([]Stop(nil), stops...)
+	point := func(i int) Point {
+		if i < 0 || i >= len(tour) {
+			return layout.Depot
+		}
+		return tour[i].Point
+	}
+
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < len(tour)-1; i++ {
+			for j := i + 1; j < len(tour); j++ {
+				before := layout.Distance(point(i-1), point(i)) + layout.Distance(point(j), point(j+1))
+				after := layout.Distance(point(i-1), point(j)) + layout.Distance(point(i), point(j+1))
+				if after < before-eps {
+					for a, b := i, j; a < b; a, b = a+1, b-1 {
+						tour[a], tour[b] = tour[b], tour[a]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+	return tour
+}
+
+func bySequence(stops []Stop) []Stop {
+	out := append([]Stop(nil), stops...)
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if (a.Sequence == 0) != (b.Sequence == 0) {
+			return b.Sequence == 0
+		}
+		if a.Sequence != b.Sequence {
+			return a.Sequence < b.Sequence
+		}
+		if a.Sequence == 0 {
+			return false
+		}
+		return a.ID < b.ID
+	})
+	return out
+}
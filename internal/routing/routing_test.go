@@ -0,0 +1,154 @@
+package routing
+
+import (
+	"reflect"
+	"testing"
+)
+
+// grid is three aisles at x = 0, 5 and 10 with cross-aisles at y = 0 and
+// y = 10 and the depot at the front of the first aisle.
+var grid = Layout{Depot: Point{X: 0, Y: 0}, FrontY: 0, BackY: 10}
+
+func gridStops() []Stop {
+	return []Stop{
+		{ID: 1, Point: Point{X: 0, Y: 2}, HasPoint: true},
+		{ID: 2, Point: Point{X: 0, Y: 8}, HasPoint: true},
+		{ID: 3, Point: Point{X: 5, Y: 5}, HasPoint: true},
+		{ID: 4, Point: Point{X: 10, Y: 3}, HasPoint: true},
+		{ID: 5, Point: Point{X: 10, Y: 7}, HasPoint: true},
+		{ID: 6, Point: Point{X: 5, Y: 9}, HasPoint: true},
+	}
+}
+
+func ids(stops []Stop) []int32 {
+	out := make([]int32, 0, len(stops))
+	for _, s := range stops {
+		out = append(out, s.ID)
+	}
+	return out
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+		a, b   Point
+		want   float64
+	}{
+		{"same aisle", grid, Point{X: 5, Y: 2}, Point{X: 5, Y: 9}, 7},
+		{"via front", grid, Point{X: 0, Y: 2}, Point{X: 5, Y: 3}, 10},
+		{"via back", grid, Point{X: 0, Y: 8}, Point{X: 5, Y: 9}, 8},
+		{"no back cross-aisle", Layout{}, Point{X: 0, Y: 8}, Point{X: 5, Y: 9}, 22},
+	}
+	for _, tt := range tests {
+		if got := tt.layout.Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: Distance = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	farDepot := Layout{Depot: Point{X: 10, Y: 0}, BackY: 10}
+
+	tests := []struct {
+		name     string
+		layout   Layout
+		method   Method
+		want     []int32
+		distance float64
+	}{
+		{"s-shape", grid, MethodSShape, []int32{1, 2, 6, 3, 4, 5}, 54},
+		{"s-shape from far end", farDepot, MethodSShape, []int32{4, 5, 6, 3, 1, 2}, 56},
+		{"return", grid, MethodReturn, []int32{1, 2, 3, 6, 4, 5}, 58},
+		{"nearest neighbour", grid, MethodNearestNeighbour, []int32{1, 2, 6, 5, 4, 3}, 52},
+		{"nearest neighbour from far end", farDepot, MethodNearestNeighbour, []int32{4, 5, 6, 2, 1, 3}, 52},
+	}
+	for _, tt := range tests {
+		route, err := Plan(tt.layout, gridStops(), tt.method)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := ids(route.Stops); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: order = %v, want %v", tt.name, got, tt.want)
+		}
+		if route.Distance != tt.distance {
+			t.Errorf("%s: distance = %v, want %v", tt.name, route.Distance, tt.distance)
+		}
+	}
+}
+
+func TestPlanSequence(t *testing.T) {
+	stops := []Stop{
+		{ID: 1, Point: Point{X: 0, Y: 2}, HasPoint: true, Sequence: 3},
+		{ID: 2, Point: Point{X: 5, Y: 5}, HasPoint: true},
+		{ID: 4, Point: Point{X: 10, Y: 3}, HasPoint: true, Sequence: 1},
+		{ID: 3, Point: Point{X: 0, Y: 8}, HasPoint: true, Sequence: 1},
+		{ID: 5, Sequence: 2},
+		{ID: 6},
+	}
+	route, err := Plan(grid, stops, MethodSequence)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int32{3, 4, 5, 1, 2, 6}
+	if got := ids(route.Stops); !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestPlanKeepsUnplacedStopsLast(t *testing.T) {
+	stops := append([]Stop{{ID: 9}}, gridStops()...)
+	stops = append(stops, Stop{ID: 7})
+
+	route, err := Plan(grid, stops, MethodSShape)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int32{1, 2, 6, 3, 4, 5, 9, 7}
+	if got := ids(route.Stops); !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	if route.Distance != 54 {
+		t.Errorf("distance = %v, want 54", route.Distance)
+	}
+}
+
+func TestPlanIsDeterministic(t *testing.T) {
+	reversed := gridStops()
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+
+	for _, method := range []Method{MethodSShape, MethodReturn, MethodNearestNeighbour, MethodSequence} {
+		first, err := Plan(grid, gridStops(), method)
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		for run := 0; run < 10; run++ {
+			again, _ := Plan(grid, gridStops(), method)
+			if !reflect.DeepEqual(again, first) {
+				t.Fatalf("%s: run %d gave %v, want %v", method, run, ids(again.Stops), ids(first.Stops))
+			}
+		}
+		if method == MethodSequence {
+			// Unsequenced stops keep their input order.
+			continue
+		}
+		if got, _ := Plan(grid, reversed, method); !reflect.DeepEqual(ids(got.Stops), ids(first.Stops)) {
+			t.Errorf("%s: reversed input gave %v, want %v", method, ids(got.Stops), ids(first.Stops))
+		}
+	}
+}
+
+func TestPlanUnknownMethod(t *testing.T) {
+	if _, err := Plan(grid, gridStops(), "zigzag"); err != ErrUnknownMethod {
+		t.Errorf("err = %v, want ErrUnknownMethod", err)
+	}
+}
+
+func TestEstimateMinutes(t *testing.T) {
+	// 54 m at 60 m/min plus 6 picks of 15 s is 2.4 minutes.
+	if got := EstimateMinutes(54, 6, DefaultMetresPerMinute, DefaultSecondsPerPick); got != 3 {
+		t.Errorf("EstimateMinutes = %d, want 3", got)
+	}
+}
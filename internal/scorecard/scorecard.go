@@ -0,0 +1,251 @@
+// Package scorecard rates suppliers on how they deliver purchase orders:
+// on time, in full, within their promised lead time, without returns and at
+// stable prices. It has no database dependencies.
+package scorecard
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxRating is the rating of a supplier that is perfect on every metric.
+const MaxRating = 5
+
+// Weights of the metrics in the rating. A metric without data is left out
+// and the others are scaled up to fill its share.
+const (
+	onTimeWeight  = 0.35
+	fillWeight    = 0.30
+	leadWeight    = 0.10
+	returnWeight  = 0.15
+	priceWeight   = 0.10
+	priceCeiling  = 0.20 // price rises of 20% or more score zero
+	leadTolerance = 1.0  // late by this share of the promised lead time scores zero
+)
+
+// Line is one purchase order line with what was promised and what happened.
+// Quantities are in base units.
+type Line struct {
+	ProductID        int32
+	OrderDate        time.Time
+	PromisedDate     time.Time // zero when the order has no expected delivery date
+	PromisedLeadDays int       // supplier's quoted lead time; 0 when not known
+	QuantityOrdered  int32
+	QuantityReceived int32
+	QuantityReturned int32
+	UnitPrice        decimal.Decimal
+	LastReceivedAt   time.Time // zero until something is received
+}
+
+// Complete reports whether the line has been received in full.
+func (l Line) Complete() bool {
+	return l.QuantityReceived >= l.QuantityOrdered
+}
+
+// due is when the line should have arrived: the order's expected delivery
+// date, or the order date plus the quoted lead time.
+func (l Line) due() (time.Time, bool) {
+	if !l.PromisedDate.IsZero() {
+		return day(l.PromisedDate), true
+	}
+	if l.PromisedLeadDays > 0 {
+		return day(l.OrderDate).AddDate(0, 0, l.PromisedLeadDays), true
+	}
+	return time.Time{}, false
+}
+
+// Scorecard is a supplier's (or one product's) delivery record. Rates are
+// fractions between 0 and 1; a nil metric had nothing to measure.
+type Scorecard struct {
+	Lines int `json:"lines"`
+	// Share of lines due so far that arrived in full by their due date.
+	OnTimeRate *float64 `json:"on_time_rate"`
+	// Units received against units ordered on lines that are complete or
+	// overdue.
+	FillRate *float64 `json:"fill_rate"`
+	// Average days from order to full receipt, and the average promised.
+	ActualLeadDays   *float64 `json:"actual_lead_days"`
+	PromisedLeadDays *float64 `json:"promised_lead_days"`
+	// Units sent back to the supplier against units received.
+	ReturnRate *float64 `json:"return_rate"`
+	// Quantity-weighted change in unit price from the first order of each
+	// product in the period to its latest; positive means prices rose.
+	PriceTrend *float64 `json:"price_trend"`
+	// Rating from 0 to MaxRating; not valid when no metric had data.
+	Rating decimal.NullDecimal `json:"rating"`
+}
+
+// Score builds the scorecard of lines as of asOf. Lines should be in order
+// date order.
+func Score(lines []Line, asOf time.Time) Scorecard {
+	sc := Scorecard{Lines: len(lines)}
+	today := day(asOf)
+
+	var due, onTime int
+	var settledOrdered, settledReceived int64
+	var actualDays, promisedDays float64
+	var leadLines int
+	var received, returned int64
+
+	for _, l := range lines {
+		received += int64(l.QuantityReceived)
+		returned += int64(l.QuantityReturned)
+
+		dueDate, hasDue := l.due()
+		complete := l.Complete() && !l.LastReceivedAt.IsZero()
+		overdue := hasDue && !complete && today.After(dueDate)
+
+		if hasDue && (complete || overdue) {
+			due++
+			if complete && !day(l.LastReceivedAt).After(dueDate) {
+				onTime++
+			}
+		}
+
+		if complete || overdue {
+			settledOrdered += int64(l.QuantityOrdered)
+			settledReceived += int64(min(l.QuantityReceived, l.QuantityOrdered))
+		}
+
+		if complete && hasDue {
+			leadLines++
+			actualDays += days(day(l.OrderDate), day(l.LastReceivedAt))
+			promisedDays += days(day(l.OrderDate), dueDate)
+		}
+	}
+
+	if due > 0 {
+		sc.OnTimeRate = ratio(float64(onTime), float64(due))
+	}
+	if settledOrdered > 0 {
+		sc.FillRate = ratio(float64(settledReceived), float64(settledOrdered))
+	}
+	if leadLines > 0 {
+		sc.ActualLeadDays = ptr(round(actualDays/float64(leadLines), 1))
+		sc.PromisedLeadDays = ptr(round(promisedDays/float64(leadLines), 1))
+	}
+	if received > 0 {
+		sc.ReturnRate = ratio(float64(returned), float64(received))
+	}
+	sc.PriceTrend = priceTrend(lines)
+	sc.Rating = sc.rate()
+	return sc
+}
+
+// ByProduct scores each product's lines separately.
+func ByProduct(lines []Line, asOf time.Time) map[int32]Scorecard {
+	grouped := make(map[int32][]Line)
+	for _, l := range lines {
+		grouped[l.ProductID] = append(grouped[l.ProductID], l)
+	}
+	out := make(map[int32]Scorecard, len(grouped))
+	for id, ls := range grouped {
+		out[id] = Score(ls, asOf)
+	}
+	return out
+}
+
+func (sc Scorecard) rate() decimal.NullDecimal {
+	var score, weight float64
+	add := func(w, s float64) {
+		score += w * math.Max(0, math.Min(1, s))
+		weight += w
+	}
+	if sc.OnTimeRate != nil {
+		add(onTimeWeight, *sc.OnTimeRate)
+	}
+	if sc.FillRate != nil {
+		add(fillWeight, *sc.FillRate)
+	}
+	if sc.ActualLeadDays != nil && *sc.PromisedLeadDays > 0 {
+		late := (*sc.ActualLeadDays - *sc.PromisedLeadDays) / *sc.PromisedLeadDays
+		add(leadWeight, 1-math.Max(0, late)/leadTolerance)
+	}
+	if sc.ReturnRate != nil {
+		add(returnWeight, 1-*sc.ReturnRate)
+	}
+	if sc.PriceTrend != nil {
+		add(priceWeight, 1-math.Max(0, *sc.PriceTrend)/priceCeiling)
+	}
+	if weight == 0 {
+		return decimal.NullDecimal{}
+	}
+	rating := decimal.NewFromFloat(score / weight * MaxRating).Round(2)
+	return decimal.NullDecimal{Decimal: rating, Valid: true}
+}
+
+// priceTrend compares each product's first and latest unit price and weights
+// the relative changes by the quantity ordered of the product.
+func priceTrend(lines []Line) *float64 {
+	type span struct {
+		first, last decimal.Decimal
+		firstDate   time.Time
+		lastDate    time.Time
+		quantity    int64
+		n           int
+	}
+	spans := make(map[int32]*span)
+	var ids []int32
+	for _, l := range lines {
+		s, ok := spans[l.ProductID]
+		if !ok {
+			s = &span{first: l.UnitPrice, firstDate: l.OrderDate}
+			spans[l.ProductID] = s
+			ids = append(ids, l.ProductID)
+		}
+		if l.OrderDate.Before(s.firstDate) {
+			s.first, s.firstDate = l.UnitPrice, l.OrderDate
+		}
+		if !l.OrderDate.Before(s.lastDate) {
+			s.last, s.lastDate = l.UnitPrice, l.OrderDate
+		}
+		s.quantity += int64(l.QuantityOrdered)
+		s.n++
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var change, weight float64
+	for _, id := range ids {
+		s := spans[id]
+		if s.n < 2 || !s.first.IsPositive() || !s.lastDate.After(s.firstDate) {
+			continue
+		}
+		rel, _ := s.last.Sub(s.first).Div(s.first).Float64()
+		change += rel * float64(s.quantity)
+		weight += float64(s.quantity)
+	}
+	if weight == 0 {
+		return nil
+	}
+	return ptr(round(change/weight, 4))
+}
+
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func days(from, to time.Time) float64 {
+	return to.Sub(from).Hours() / 24
+}
+
+func ratio(n, d float64) *float64 {
+	return ptr(round(n/d, 4))
+}
+
+func round(f float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(f*p) / p
+}
+
+func ptr(f float64) *float64 {
+	return &f
+}
+
+// DefaultSince is the start of the default scoring period: orders placed in
+// the year up to asOf.
+func DefaultSince(asOf time.Time) time.Time {
+	return day(asOf).AddDate(-1, 0, 0)
+}
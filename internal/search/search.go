@@ -0,0 +1,72 @@
+// Package search turns what a user types into the product search box into
+// the terms the database matches on.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxTermLength caps the search text; anything longer is cut.
+	MaxTermLength = 100
+	// MaxWords caps how many words go into the full-text query.
+	MaxWords = 8
+	// DefaultSimilarity is the trigram word similarity, between 0 and 1, a
+	// name or SKU needs to match a misspelt search.
+	DefaultSimilarity = 0.3
+)
+
+// Stock status filters.
+const (
+	InStock    = "in_stock"
+	LowStock   = "low_stock"
+	OutOfStock = "out_of_stock"
+)
+
+// ValidStockStatus reports whether s is one of the stock status filters.
+func ValidStockStatus(s string) bool {
+	return s == InStock || s == LowStock || s == OutOfStock
+}
+
+// Normalize trims the search text, collapses runs of white space and cuts
+// it to MaxTermLength characters.
+func Normalize(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if r := []rune(text); len(r) > MaxTermLength {
+		text = strings.TrimSpace(string(r[:MaxTermLength]))
+	}
+	return text
+}
+
+// Words splits the search text into lower-case words of letters and
+// digits, dropping duplicates and keeping at most MaxWords.
+func Words(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	seen := make(map[string]bool, len(fields))
+	var words []string
+	for _, f := range fields {
+		if seen[f] {
+			continue
+		}
+		seen[f] = true
+		words = append(words, f)
+		if len(words) == MaxWords {
+			break
+		}
+	}
+	return words
+}
+
+// TSQuery is a to_tsquery expression matching documents that contain every
+// word of the search text as a word or the start of one, so partial names
+// match while they are being typed. It is empty when the text has no words.
+func TSQuery(text string) string {
+	words := Words(text)
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
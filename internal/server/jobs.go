@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	db "github.com/molu/stock-management-system/internal/db/sqlc"
+	"github.com/molu/stock-management-system/internal/scorecard"
+	"github.com/molu/stock-management-system/internal/webhook"
+)
+
+// priceInterval is how often supplier prices that have come into effect
+// are applied.
+const priceInterval = time.Hour
+
+// idempotencyPurgeInterval is how often expired idempotency keys are
+// deleted.
+const idempotencyPurgeInterval = time.Hour
+
+const (
+	// webhookInterval is how often due webhook deliveries are sent.
+	webhookInterval = 5 * time.Second
+	// webhookBatch is how many deliveries are sent at once.
+	webhookBatch = 20
+	// webhookLease keeps claimed deliveries from other workers; it must
+	// outlast an attempt.
+	webhookLease = 2 * time.Minute
+	// webhookRetention is how long sent webhook events and their delivery
+	// log are kept, and webhookPurgeInterval how often older ones are
+	// deleted.
+	webhookRetention     = 30 * 24 * time.Hour
+	webhookPurgeInterval = time.Hour
+)
+
+// every calls job once per interval until ctx is cancelled.
+func every(ctx context.Context, interval time.Duration, job func(context.Context, time.Time)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			job(ctx, now)
+		}
+	}
+}
+
+// rateSuppliers recalculates the scorecard of every active supplier and
+// saves the ratings.
+func (s *Server) rateSuppliers(ctx context.Context, now time.Time) {
+	suppliers, err := s.store.ListActiveSuppliers(ctx)
+	if err != nil {
+		log.Printf("Error listing suppliers to rate: %v", err)
+		return
+	}
+
+	rated := 0
+	for _, supplier := range suppliers {
+		result, err := s.store.ScoreSupplierTx(ctx, db.ScoreSupplierTxParams{
+			SupplierID:  supplier.SupplierID,
+			Since:       scorecard.DefaultSince(now),
+			AsOf:        now,
+			SaveRatings: true,
+		})
+		if err != nil {
+			log.Printf("Error rating supplier %d: %v", supplier.SupplierID, err)
+			continue
+		}
+		if result.Scorecard.Rating.Valid {
+			rated++
+		}
+	}
+	log.Printf("Rated %d of %d active suppliers", rated, len(suppliers))
+}
+
+// applySupplierPrices makes scheduled supplier prices whose effective date
+// has arrived the current price of their product-supplier link.
+func (s *Server) applySupplierPrices(ctx context.Context, now time.Time) {
+	applied, err := s.store.ApplyDueProductSupplierPrices(ctx)
+	if err != nil {
+		log.Printf("Error applying supplier prices: %v", err)
+		return
+	}
+	if applied > 0 {
+		log.Printf("Applied %d supplier prices that came into effect", applied)
+	}
+}
+
+// purgeIdempotencyKeys deletes idempotency keys whose responses are past
+// their TTL.
+func (s *Server) purgeIdempotencyKeys(ctx context.Context, now time.Time) {
+	purged, err := s.store.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		log.Printf("Error purging idempotency keys: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("Purged %d expired idempotency keys", purged)
+	}
+}
+
+// deliverWebhooks sends the webhook deliveries that are due, in parallel,
+// and logs each attempt.
+func (s *Server) deliverWebhooks(ctx context.Context, now time.Time) {
+	due, err := s.store.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{
+		LeaseSeconds: int32(webhookLease.Seconds()),
+		RowLimit:     webhookBatch,
+	})
+	if err != nil {
+		log.Printf("Error claiming webhook deliveries: %v", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, d := range due {
+		wg.Add(1)
+		go func(d db.ClaimDueWebhookDeliveriesRow) {
+			defer wg.Done()
+			result := webhook.Send(ctx, s.webhookClient, webhook.Delivery{
+				ID:     d.DeliveryID,
+				URL:    d.Url,
+				Secret: d.Secret,
+				Envelope: webhook.Envelope{
+					ID:        d.EventID,
+					Type:      d.EventType,
+					CreatedAt: d.EventCreatedAt,
+					Data:      d.Payload,
+				},
+			}, time.Now())
+
+			// The attempt is logged even if the server is shutting down.
+			if _, err := s.store.RecordWebhookAttemptTx(context.WithoutCancel(ctx), db.RecordWebhookAttemptTxParams{
+				DeliveryID: d.DeliveryID,
+				Attempts:   d.Attempts,
+				Result:     result,
+			}); err != nil {
+				log.Printf("Error recording webhook delivery %d: %v", d.DeliveryID, err)
+			}
+		}(d)
+	}
+	wg.Wait()
+}
+
+// purgeWebhookEvents deletes webhook events past their retention that have
+// nothing left to deliver.
+func (s *Server) purgeWebhookEvents(ctx context.Context, now time.Time) {
+	purged, err := s.store.DeleteOldWebhookEvents(ctx, now.Add(-webhookRetention))
+	if err != nil {
+		log.Printf("Error purging webhook events: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("Purged %d old webhook events", purged)
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/molu/stock-management-system/internal/config"
 	db "github.com/molu/stock-management-system/internal/db/sqlc"
 	"github.com/molu/stock-management-system/internal/router"
+	"github.com/molu/stock-management-system/internal/stream"
+	"github.com/molu/stock-management-system/internal/webhook"
 )
 
 type Server struct {
@@ -20,14 +22,26 @@ type Server struct {
 	httpSrv *http.Server
 	db      *sql.DB
 	queries *db.Queries
+	store   db.SingleDb
+	cancel  context.CancelFunc
+
+	webhookClient *http.Client
+	broker        *stream.Broker
+	listener      *stream.Listener
 }
 
 type Config struct {
 	Address   string
 	DB        *sql.DB
 	Queries   *db.Queries
+	Store     db.SingleDb
 	Env       string
 	JWTSecret string
+
+	SupplierRatingInterval time.Duration
+	IdempotencyKeyTTL      time.Duration
+
+	WebhookAllowPrivateHosts bool
 }
 
 func New(cfg *config.Config) (*Server, error) {
@@ -52,17 +66,29 @@ func New(cfg *config.Config) (*Server, error) {
 	// Create sqlc queries instance
 	queries := db.New(dbConn)
 
+	// Create transactional store for multi-step operations
+	store := db.NewStore(dbConn)
+
 	// Create server config
 	srvCfg := &Config{
 		Address:   cfg.ServerAddress,
 		DB:        dbConn,
 		Queries:   queries,
+		Store:     store,
 		Env:       cfg.Environment,
 		JWTSecret: cfg.JWTSecret,
+
+		SupplierRatingInterval: cfg.SupplierRatingInterval,
+		IdempotencyKeyTTL:      cfg.IdempotencyKeyTTL,
+
+		WebhookAllowPrivateHosts: cfg.WebhookAllowPrivateHosts,
 	}
 
+	// Live stock events are fed by a listener on a connection of its own
+	broker := stream.NewBroker()
+
 	// Create router
-	r := router.New(srvCfg.Queries, srvCfg.JWTSecret)
+	r := router.New(srvCfg.Store, broker, srvCfg.JWTSecret, srvCfg.IdempotencyKeyTTL, srvCfg.WebhookAllowPrivateHosts)
 
 	// Create HTTP server
 	srv := &Server{
@@ -70,6 +96,7 @@ func New(cfg *config.Config) (*Server, error) {
 		router:  r,
 		db:      dbConn,
 		queries: queries,
+		store:   store,
 		httpSrv: &http.Server{
 			Addr:         cfg.ServerAddress,
 			Handler:      r,
@@ -77,16 +104,36 @@ func New(cfg *config.Config) (*Server, error) {
 			WriteTimeout: 15 * time.Second,
 			IdleTimeout:  60 * time.Second,
 		},
+		webhookClient: webhook.NewClient(cfg.WebhookAllowPrivateHosts),
+		broker:        broker,
+		listener:      stream.NewListener(cfg.DatabaseURL, broker),
 	}
 
 	return srv, nil
 }
 
 func (s *Server) Start() error {
+	// Background jobs stop when the server shuts down
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	if s.config.SupplierRatingInterval > 0 {
+		go every(ctx, s.config.SupplierRatingInterval, s.rateSuppliers)
+	}
+	go every(ctx, priceInterval, s.applySupplierPrices)
+	go every(ctx, idempotencyPurgeInterval, s.purgeIdempotencyKeys)
+	go every(ctx, webhookInterval, s.deliverWebhooks)
+	go every(ctx, webhookPurgeInterval, s.purgeWebhookEvents)
+	go s.listener.Run(ctx)
+
 	return s.httpSrv.ListenAndServe()
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	// End open event streams, which would otherwise hold up the shutdown
+	s.broker.Reset()
 	if s.db != nil {
 		_ = s.db.Close()
 	}
@@ -0,0 +1,76 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// reconnectDelay is the wait before listening again after the connection
+// was lost.
+const reconnectDelay = 5 * time.Second
+
+// Listener publishes the stock events notified on Channel to a broker. It
+// holds a connection of its own, outside the pool, for as long as it runs.
+type Listener struct {
+	databaseURL string
+	broker      *Broker
+}
+
+func NewListener(databaseURL string, broker *Broker) *Listener {
+	return &Listener{databaseURL: databaseURL, broker: broker}
+}
+
+// Run listens until ctx is cancelled, reconnecting when the connection is
+// lost. Notifications sent while it was down are gone, so once it listens
+// again every subscription is reset.
+func (l *Listener) Run(ctx context.Context) {
+	lost := false
+	for {
+		err := l.listen(ctx, func() {
+			if lost {
+				l.broker.Reset()
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		lost = true
+		log.Printf("Stock event listener stopped, reconnecting in %s: %v", reconnectDelay, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (l *Listener) listen(ctx context.Context, listening func()) error {
+	conn, err := pgx.Connect(ctx, l.databaseURL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{Channel}.Sanitize()); err != nil {
+		return err
+	}
+	listening()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var e Event
+		if err := json.Unmarshal([]byte(n.Payload), &e); err != nil {
+			log.Printf("Ignoring malformed stock event: %v", err)
+			continue
+		}
+		l.broker.Publish(e)
+	}
+}
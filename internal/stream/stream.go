@@ -0,0 +1,152 @@
+// Package stream fans stock events out to live subscribers, such as
+// dashboards on the server-sent events endpoint. Events are published with
+// PostgreSQL NOTIFY in the transaction that posts the change, so they are
+// only seen once it commits, and reach every server through its Listener.
+package stream
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Channel is the PostgreSQL notification channel stock events are sent on.
+const Channel = "stock_events"
+
+// Events subscribers can receive.
+const (
+	EventInventoryChanged  = "inventory.changed"
+	EventMovementCreated   = "movement.created"
+	EventStockBelowReorder = "stock.below_reorder"
+)
+
+// Events lists the event types a subscriber can filter on.
+var Events = []string{
+	EventInventoryChanged,
+	EventMovementCreated,
+	EventStockBelowReorder,
+}
+
+// ValidEvent reports whether a subscriber can filter on the event type.
+func ValidEvent(event string) bool {
+	for _, e := range Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// it is dropped.
+const subscriberBuffer = 256
+
+// Event is one stock event. ID is assigned by the broker and only orders
+// the events of one server.
+type Event struct {
+	ID          uint64          `json:"-"`
+	Type        string          `json:"type"`
+	WarehouseID int32           `json:"warehouse_id"`
+	ProductID   int32           `json:"product_id"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Filter selects the events a subscriber receives. Zero fields match
+// everything.
+type Filter struct {
+	WarehouseID int32
+	ProductID   int32
+	Types       []string
+}
+
+// Match reports whether the event passes the filter.
+func (f Filter) Match(e Event) bool {
+	if f.WarehouseID != 0 && e.WarehouseID != f.WarehouseID {
+		return false
+	}
+	if f.ProductID != 0 && e.ProductID != f.ProductID {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if t == e.Type {
+			return true
+		}
+	}
+	return false
+}
+
+type subscriber struct {
+	filter Filter
+	events chan Event
+}
+
+// Broker hands published events to the subscribers whose filter they
+// match. A subscriber that falls behind is dropped rather than slowing the
+// others: its channel is closed, as it is when events may have been missed,
+// and it should reload what it shows before subscribing again.
+type Broker struct {
+	mu   sync.Mutex
+	seq  uint64
+	subs map[*subscriber]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[*subscriber]struct{})}
+}
+
+// Subscribe returns a channel of the events matching the filter and a
+// function that ends the subscription. The channel is closed when the
+// subscription ends.
+func (b *Broker) Subscribe(f Filter) (<-chan Event, func()) {
+	s := &subscriber{filter: f, events: make(chan Event, subscriberBuffer)}
+
+	b.mu.Lock()
+	b.subs[s] = struct{}{}
+	b.mu.Unlock()
+
+	return s.events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.drop(s)
+	}
+}
+
+// Publish sends an event to the matching subscribers without blocking.
+func (b *Broker) Publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	e.ID = b.seq
+	for s := range b.subs {
+		if !s.filter.Match(e) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			b.drop(s)
+		}
+	}
+}
+
+// Reset ends every subscription, telling subscribers that events may have
+// been missed.
+func (b *Broker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.subs {
+		b.drop(s)
+	}
+}
+
+// drop ends a subscription; b.mu must be held.
+func (b *Broker) drop(s *subscriber) {
+	if _, ok := b.subs[s]; ok {
+		delete(b.subs, s)
+		close(s.events)
+	}
+}
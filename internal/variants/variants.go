@@ -0,0 +1,126 @@
+// Package variants expands a parent product's option axes, such as size and
+// colour, into the matrix of variant SKUs.
+package variants
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MaxVariants caps how many variants one parent can generate.
+const MaxVariants = 500
+
+var (
+	ErrNoAxes        = errors.New("at least one axis with values is needed")
+	ErrTooManyValues = fmt.Errorf("axes would generate more than %d variants", MaxVariants)
+)
+
+// Axis is one dimension of the variant matrix and the values it takes,
+// in display order.
+type Axis struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// Option is the value a variant takes on one axis.
+type Option struct {
+	Axis  string
+	Value string
+}
+
+// Check reports whether the axes are usable: named, with distinct
+// non-blank values, and not generating more than MaxVariants combinations.
+func Check(axes []Axis) error {
+	if len(axes) == 0 {
+		return ErrNoAxes
+	}
+	names := make(map[string]bool, len(axes))
+	total := 1
+	for _, a := range axes {
+		name := strings.TrimSpace(a.Name)
+		if name == "" {
+			return errors.New("axis names cannot be blank")
+		}
+		if names[strings.ToLower(name)] {
+			return fmt.Errorf("axis %q is listed twice", name)
+		}
+		names[strings.ToLower(name)] = true
+		if len(a.Values) == 0 {
+			return fmt.Errorf("axis %q has no values", name)
+		}
+		seen := make(map[string]bool, len(a.Values))
+		for _, v := range a.Values {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				return fmt.Errorf("axis %q has a blank value", name)
+			}
+			if seen[strings.ToLower(v)] {
+				return fmt.Errorf("axis %q lists %q twice", name, v)
+			}
+			seen[strings.ToLower(v)] = true
+		}
+		total *= len(a.Values)
+		if total > MaxVariants {
+			return ErrTooManyValues
+		}
+	}
+	return nil
+}
+
+// Combinations returns every combination of one value per axis, varying the
+// last axis fastest.
+func Combinations(axes []Axis) [][]Option {
+	combos := [][]Option{{}}
+	for _, a := range axes {
+		next := make([][]Option, 0, len(combos)*len(a.Values))
+		for _, c := range combos {
+			for _, v := range a.Values {
+				combo := make([]Option, len(c), len(c)+1)
+				copy(combo, c)
+				next = append(next, append(combo, Option{Axis: strings.TrimSpace(a.Name), Value: strings.TrimSpace(v)}))
+			}
+		}
+		combos = next
+	}
+	return combos
+}
+
+// Map returns the options as axis name to value, the form variants are
+// stored and matched in.
+func Map(options []Option) map[string]string {
+	m := make(map[string]string, len(options))
+	for _, o := range options {
+		m[o.Axis] = o.Value
+	}
+	return m
+}
+
+// SKU derives a variant's SKU from its parent's: each value upper-cased with
+// anything but letters and digits dropped, joined by hyphens, as in
+// TSHIRT-M-RED.
+func SKU(parent string, options []Option) string {
+	parts := []string{parent}
+	for _, o := range options {
+		var b strings.Builder
+		for _, r := range strings.ToUpper(o.Value) {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				b.WriteRune(r)
+			}
+		}
+		if b.Len() > 0 {
+			parts = append(parts, b.String())
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// Name derives a variant's name from its parent's, as in "T-shirt (M, Red)".
+func Name(parent string, options []Option) string {
+	values := make([]string, len(options))
+	for i, o := range options {
+		values[i] = o.Value
+	}
+	return fmt.Sprintf("%s (%s)", parent, strings.Join(values, ", "))
+}
@@ -0,0 +1,94 @@
+package webhook
+
+// Events subscribers can receive.
+const (
+	EventInventoryChanged  = "inventory.changed"
+	EventPOReceived        = "po.received"
+	EventTransferCompleted = "transfer.completed"
+	EventStockBelowReorder = "stock.below_reorder"
+
+	// EventPing is sent by the ping endpoint to test a subscriber; it is
+	// not stored and cannot be subscribed to.
+	EventPing = "ping"
+)
+
+// Events lists the event types a subscription can ask for.
+var Events = []string{
+	EventInventoryChanged,
+	EventPOReceived,
+	EventTransferCompleted,
+	EventStockBelowReorder,
+}
+
+// ValidEvent reports whether a subscription can ask for the event type.
+func ValidEvent(event string) bool {
+	for _, e := range Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// InventoryChanged is sent when the quantity of an inventory row changes,
+// through a stock movement or a direct correction. Available is the
+// product's available quantity across all warehouses after the change.
+type InventoryChanged struct {
+	ProductID      int32  `json:"product_id"`
+	Sku            string `json:"sku"`
+	WarehouseID    int32  `json:"warehouse_id"`
+	LocationID     int32  `json:"location_id,omitempty"`
+	InventoryID    int32  `json:"inventory_id,omitempty"`
+	MovementID     int32  `json:"movement_id,omitempty"`
+	MovementType   string `json:"movement_type,omitempty"`
+	QuantityChange int32  `json:"quantity_change"`
+	QuantityAfter  *int32 `json:"quantity_after,omitempty"`
+	Available      int32  `json:"available_quantity"`
+	ReferenceTable string `json:"reference_table,omitempty"`
+	ReferenceID    int32  `json:"reference_id,omitempty"`
+}
+
+// POReceived is sent for each receipt booked against a purchase order line.
+type POReceived struct {
+	PoID             int32  `json:"po_id"`
+	PoNumber         string `json:"po_number"`
+	PoStatus         string `json:"po_status"`
+	PoItemID         int32  `json:"po_item_id"`
+	ProductID        int32  `json:"product_id"`
+	Quantity         int32  `json:"quantity"`
+	QuantityReceived int32  `json:"quantity_received"`
+	QuantityOrdered  int32  `json:"quantity_ordered"`
+}
+
+// TransferItem is one line of a completed transfer.
+type TransferItem struct {
+	ProductID        int32 `json:"product_id"`
+	Quantity         int32 `json:"quantity"`
+	QuantitySent     int32 `json:"quantity_sent"`
+	QuantityReceived int32 `json:"quantity_received"`
+}
+
+// TransferCompleted is sent when a stock transfer is marked completed.
+type TransferCompleted struct {
+	TransferID      int32          `json:"transfer_id"`
+	TransferNumber  string         `json:"transfer_number"`
+	FromWarehouseID int32          `json:"from_warehouse_id"`
+	ToWarehouseID   int32          `json:"to_warehouse_id"`
+	Items           []TransferItem `json:"items"`
+}
+
+// StockBelowReorder is sent when a change takes a product's available
+// quantity from above its reorder point to at or below it.
+type StockBelowReorder struct {
+	ProductID    int32  `json:"product_id"`
+	Sku          string `json:"sku"`
+	WarehouseID  int32  `json:"warehouse_id"`
+	ReorderPoint int32  `json:"reorder_point"`
+	Available    int32  `json:"available_quantity"`
+}
+
+// BelowReorder reports whether the available quantity going from before to
+// after crosses the reorder point downwards.
+func BelowReorder(before, after, reorderPoint int32) bool {
+	return before > reorderPoint && after <= reorderPoint
+}
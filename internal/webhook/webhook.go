@@ -0,0 +1,224 @@
+// Package webhook signs and sends webhook deliveries and holds the events
+// subscribers can receive, free of database code.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Headers sent with every delivery.
+const (
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+const (
+	// MaxAttempts is how often a delivery is tried before it is marked
+	// failed.
+	MaxAttempts = 10
+	// Timeout bounds one attempt, including reading the response.
+	Timeout = 10 * time.Second
+
+	baseBackoff = time.Minute
+	maxBackoff  = 6 * time.Hour
+	// maxLogLength is how much of an error is kept in the delivery log.
+	maxLogLength = 1000
+)
+
+var (
+	ErrSignature        = errors.New("webhook signature does not match")
+	ErrSignatureExpired = errors.New("webhook signature is too old")
+	ErrPrivateHost      = errors.New("webhook host is not a public address")
+)
+
+// Envelope is the JSON body of a delivery.
+type Envelope struct {
+	ID        int32           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Delivery is one envelope to send to one subscriber.
+type Delivery struct {
+	ID       int32
+	URL      string
+	Secret   string
+	Envelope Envelope
+}
+
+// Result is the outcome of one attempt. The response body is not kept, so
+// nothing a subscriber URL answers is shown back to whoever set it.
+type Result struct {
+	StatusCode int           `json:"status_code,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// OK reports whether the subscriber accepted the delivery with a 2xx status.
+func (r Result) OK() bool {
+	return r.Error == "" && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// NewClient returns a client for sending deliveries. Redirects are not
+// followed, so a subscriber URL that moved fails until it is updated.
+// Unless allowPrivate is set, connections to loopback, private, link-local
+// and other non-public addresses are refused after the host is resolved, so
+// a subscription cannot reach services inside the network.
+func NewClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: Timeout}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !PublicIP(ip) {
+				return ErrPrivateHost
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   Timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// PublicIP reports whether ip is a globally routable unicast address.
+func PublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which
+// net.IP.IsPrivate does not cover.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// Sign returns the signature header value of a body sent at t:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">". The
+// timestamp is signed too, so a captured delivery cannot be replayed later.
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + mac(secret, ts, body)
+}
+
+// Verify checks a signature header against the body, as a receiver should,
+// rejecting signatures older than tolerance; a zero tolerance skips the age
+// check.
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sig = v
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || sig == "" {
+		return ErrSignature
+	}
+	if !hmac.Equal([]byte(sig), []byte(mac(secret, ts, body))) {
+		return ErrSignature
+	}
+	if tolerance > 0 && now.Sub(time.Unix(unix, 0)) > tolerance {
+		return ErrSignatureExpired
+	}
+	return nil
+}
+
+func mac(secret, ts string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Backoff is the wait before the attempt after the given number of failed
+// ones: a minute after the first, doubling each time up to six hours.
+func Backoff(failed int) time.Duration {
+	if failed < 1 {
+		return 0
+	}
+	d := baseBackoff
+	for i := 1; i < failed; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
+
+// Send posts a delivery, signed at now, and reports what came back. A
+// transport error or non-2xx status is a failed attempt.
+func Send(ctx context.Context, client *http.Client, d Delivery, now time.Time) Result {
+	var result Result
+	body, err := json.Marshal(d.Envelope)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		result.Error = truncate(err.Error())
+		return result
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "stock-management-system-webhooks")
+	req.Header.Set(EventHeader, d.Envelope.Type)
+	req.Header.Set(DeliveryHeader, strconv.Itoa(int(d.ID)))
+	req.Header.Set(SignatureHeader, Sign(d.Secret, now, body))
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Duration = time.Since(start)
+		result.Error = truncate(err.Error())
+		return result
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	result.Duration = time.Since(start)
+	result.StatusCode = resp.StatusCode
+	if !result.OK() {
+		result.Error = fmt.Sprintf("subscriber answered %d", resp.StatusCode)
+	}
+	return result
+}
+
+// truncate cuts s to what the delivery log keeps, as valid UTF-8 text
+// without NUL bytes, which PostgreSQL text cannot hold.
+func truncate(s string) string {
+	if len(s) > maxLogLength {
+		s = s[:maxLogLength]
+	}
+	return strings.ReplaceAll(strings.ToValidUTF8(s, ""), "\x00", "")
+}